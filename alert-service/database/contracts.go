@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var contractCollectionsByTechnology = map[string]string{
+	model.TechnologyOracleDatabase:           "oracle_database_contracts",
+	model.TechnologyMicrosoftSQLServer:       "ms_sqlserver_database_contracts",
+	model.TechnologyOracleMySQL:              "mysql_contracts",
+	model.TechnologyPostgreSQLPostgreSQL:     "postgresql_contracts",
+	model.TechnologyMongoDBMongoDB:           "mongodb_contracts",
+	model.TechnologyMariaDBFoundationMariaDB: "mariadb_contracts",
+}
+
+// FindContractsSupportExpirations return the support expiration of every contract that has one
+func (md *MongoDatabase) FindContractsSupportExpirations() ([]model.ContractSupportExpiration, error) {
+	ctx := context.TODO()
+	res := make([]model.ContractSupportExpiration, 0)
+
+	for technology, collection := range contractCollectionsByTechnology {
+		cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).Find(ctx,
+			bson.M{"supportExpiration": bson.M{"$type": "date"}},
+			options.Find().SetProjection(bson.M{
+				"contractID":        1,
+				"licenseTypeID":     1,
+				"location":          1,
+				"supportExpiration": 1,
+			}))
+		if err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+
+		contracts := make([]model.ContractSupportExpiration, 0)
+		if err := cur.All(ctx, &contracts); err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+
+		for i := range contracts {
+			contracts[i].Technology = technology
+		}
+
+		res = append(res, contracts...)
+	}
+
+	return res, nil
+}
+
+// ExistContractExpirationAlert return true if an alert with the code has already been thrown
+// for the contract, its current support expiration and the lead days
+func (md *MongoDatabase) ExistContractExpirationAlert(code string, contract model.ContractSupportExpiration, leadDays int) (bool, error) {
+	val, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("alerts").CountDocuments(context.TODO(), bson.M{
+		"alertCode":                   code,
+		"otherInfo.technology":        contract.Technology,
+		"otherInfo.id":                contract.ID,
+		"otherInfo.supportExpiration": contract.SupportExpiration.Truncate(time.Millisecond),
+		"otherInfo.leadDays":          leadDays,
+	}, &options.CountOptions{
+		Limit: utils.Intptr(1),
+	})
+	if err != nil {
+		return false, utils.NewError(err, "DB ERROR")
+	}
+
+	return val > 0, nil
+}
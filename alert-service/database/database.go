@@ -47,6 +47,10 @@ type MongoDatabaseInterface interface {
 	AckOldAlerts(dueDays int) (*mongo.UpdateResult, error)
 	RemoveOldAlerts(dueDays int) (*mongo.DeleteResult, error)
 	FindAlertsByDate(startDate, endDate time.Time) ([]model.Alert, error) 
+	// FindContractsSupportExpirations return the support expiration of every contract that has one
+	FindContractsSupportExpirations() ([]model.ContractSupportExpiration, error)
+	// ExistContractExpirationAlert return true if an alert with the code has already been thrown for the contract and the lead days
+	ExistContractExpirationAlert(code string, contract model.ContractSupportExpiration, leadDays int) (bool, error)
 }
 
 // MongoDatabase is a implementation
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"github.com/ercole-io/ercole/v2/alert-service/service"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+// ContractExpirationJob is the job used to throw the alerts about the support expiration of the contracts
+type ContractExpirationJob struct {
+	Service service.AlertServiceInterface
+	Config  config.Configuration
+	Log     logger.Logger
+}
+
+// Run throws the alerts about the support expiration of the contracts
+func (j *ContractExpirationJob) Run() {
+	if err := j.Service.ThrowContractExpirationAlerts(j.Config.AlertService.ContractExpirationJob.LeadDays); err != nil {
+		j.Log.Errorf("contract expiration job: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/bamzi/jobrunner"
 	"github.com/ercole-io/ercole/v2/alert-service/database"
 	"github.com/ercole-io/ercole/v2/alert-service/emailer"
+	"github.com/ercole-io/ercole/v2/alert-service/service"
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/logger"
 )
@@ -17,6 +18,7 @@ type Job struct {
 	Database database.MongoDatabaseInterface
 	Log      logger.Logger
 	Emailer  emailer.Emailer
+	Service  service.AlertServiceInterface
 }
 
 func (j *Job) Init() {
@@ -50,4 +52,13 @@ func (j *Job) Init() {
 	if j.Config.AlertService.ReportAlertJob.RunAtStartup {
 		jobrunner.Now(&reportAlertJob)
 	}
+
+	contractExpirationJob := ContractExpirationJob{Service: j.Service, Config: j.Config, Log: j.Log}
+	if err := jobrunner.Schedule(j.Config.AlertService.ContractExpirationJob.Crontab, &contractExpirationJob); err != nil {
+		j.Log.Errorf("something went wrong scheduling contractExpirationJob: %v", err)
+	}
+
+	if j.Config.AlertService.ContractExpirationJob.RunAtStartup {
+		jobrunner.Now(&contractExpirationJob)
+	}
 }
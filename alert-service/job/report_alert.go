@@ -199,6 +199,9 @@ func (r *ReportAlertJob) getAlertMails(alerts []model.Alert) AlertMails {
 		model.AlertCodeMissingDatabase:         {r.Config.AlertService.Emailer.AlertType.MissingDatabase.Enable, r.Config.AlertService.Emailer.AlertType.MissingDatabase.To},
 		model.AlertCodeAgentError:              {r.Config.AlertService.Emailer.AlertType.AgentError.Enable, r.Config.AlertService.Emailer.AlertType.AgentError.To},
 		model.AlertCodeNoData:                  {r.Config.AlertService.Emailer.AlertType.NoData.Enable, r.Config.AlertService.Emailer.AlertType.NoData.To},
+		model.AlertCodeContractExpiring:        {r.Config.AlertService.Emailer.AlertType.ContractExpiring.Enable, r.Config.AlertService.Emailer.AlertType.ContractExpiring.To},
+		model.AlertCodeContractExpired:         {r.Config.AlertService.Emailer.AlertType.ContractExpired.Enable, r.Config.AlertService.Emailer.AlertType.ContractExpired.To},
+		model.AlertCodeLicenseNonCompliant:     {r.Config.AlertService.Emailer.AlertType.LicenseNonCompliant.Enable, r.Config.AlertService.Emailer.AlertType.LicenseNonCompliant.To},
 	}
 
 	for _, alert := range alerts {
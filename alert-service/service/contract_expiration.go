@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// ThrowContractExpirationAlerts create and insert in the database a new CONTRACT_EXPIRED alert for every contract
+// whose support is expired and a new CONTRACT_EXPIRING alert for every contract whose support expires within one of the leadDays
+func (as *AlertService) ThrowContractExpirationAlerts(leadDays []int) error {
+	contracts, err := as.Database.FindContractsSupportExpirations()
+	if err != nil {
+		return err
+	}
+
+	leads := make([]int, len(leadDays))
+	copy(leads, leadDays)
+	sort.Ints(leads)
+
+	now := as.TimeNow()
+
+	for _, contract := range contracts {
+		daysLeft := int(math.Ceil(contract.SupportExpiration.Sub(now).Hours() / 24))
+
+		var alr model.Alert
+
+		var lead int
+
+		if !contract.SupportExpiration.After(now) {
+			alr = model.Alert{
+				AlertCategory: model.AlertCategoryLicense,
+				AlertCode:     model.AlertCodeContractExpired,
+				AlertSeverity: model.AlertSeverityCritical,
+				Description: fmt.Sprintf("The support of the %s contract %s (%s) expired on %s",
+					contract.Technology, contract.ContractID, contract.LicenseTypeID, contract.SupportExpiration.Format("2006-01-02")),
+			}
+		} else {
+			i := sort.SearchInts(leads, daysLeft)
+			if i == len(leads) {
+				continue
+			}
+
+			lead = leads[i]
+			alr = model.Alert{
+				AlertCategory: model.AlertCategoryLicense,
+				AlertCode:     model.AlertCodeContractExpiring,
+				AlertSeverity: model.AlertSeverityWarning,
+				Description: fmt.Sprintf("The support of the %s contract %s (%s) expires in %d day(s) on %s",
+					contract.Technology, contract.ContractID, contract.LicenseTypeID, daysLeft, contract.SupportExpiration.Format("2006-01-02")),
+			}
+		}
+
+		exist, err := as.Database.ExistContractExpirationAlert(alr.AlertCode, contract, lead)
+		if err != nil {
+			return err
+		}
+
+		if exist {
+			continue
+		}
+
+		technology := contract.Technology
+		alr.AlertAffectedTechnology = &technology
+		alr.Date = now
+		alr.OtherInfo = map[string]interface{}{
+			"technology":        contract.Technology,
+			"id":                contract.ID,
+			"contractID":        contract.ContractID,
+			"licenseTypeID":     contract.LicenseTypeID,
+			"location":          contract.Location,
+			"supportExpiration": contract.SupportExpiration,
+			"leadDays":          lead,
+			"daysLeft":          daysLeft,
+		}
+
+		if err := as.ThrowNewAlert(alr); err != nil {
+			return err
+		}
+
+		if as.Config.AlertService.LogAlertThrows {
+			as.Log.Warnf("Alert %s of %s contract %s was thrown\n", alr.AlertCode, contract.Technology, contract.ContractID)
+		}
+	}
+
+	return nil
+}
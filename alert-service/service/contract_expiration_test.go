@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestThrowContractExpirationAlerts_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := AlertService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Queue:    hub.New(),
+		Log:      logger.NewLogger("TEST"),
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				LogAlertThrows: true},
+		},
+	}
+
+	expired := model.ContractSupportExpiration{
+		ID:                utils.Str2oid("5dc3f534db7e81a98b726a51"),
+		Technology:        model.TechnologyOracleDatabase,
+		ContractID:        "AID001",
+		LicenseTypeID:     "A90611",
+		SupportExpiration: utils.P("2019-11-01T00:00:00Z"),
+	}
+	expiring := model.ContractSupportExpiration{
+		ID:                utils.Str2oid("5dc3f534db7e81a98b726a52"),
+		Technology:        model.TechnologyMicrosoftSQLServer,
+		ContractID:        "AID002",
+		LicenseTypeID:     "DG7GMGF0FKZV-0001",
+		Location:          "Italy",
+		SupportExpiration: utils.P("2019-11-25T14:02:03Z"),
+	}
+	alreadyThrown := model.ContractSupportExpiration{
+		ID:                utils.Str2oid("5dc3f534db7e81a98b726a53"),
+		Technology:        model.TechnologyOracleMySQL,
+		ContractID:        "AID003",
+		LicenseTypeID:     "mysql-ent",
+		SupportExpiration: utils.P("2019-11-08T14:02:03Z"),
+	}
+	farAway := model.ContractSupportExpiration{
+		ID:                utils.Str2oid("5dc3f534db7e81a98b726a54"),
+		Technology:        model.TechnologyOracleDatabase,
+		ContractID:        "AID004",
+		LicenseTypeID:     "A90611",
+		SupportExpiration: utils.P("2020-11-05T14:02:03Z"),
+	}
+
+	gomock.InOrder(
+		db.EXPECT().FindContractsSupportExpirations().
+			Return([]model.ContractSupportExpiration{expired, expiring, alreadyThrown, farAway}, nil),
+		db.EXPECT().ExistContractExpirationAlert(model.AlertCodeContractExpired, expired, 0).Return(false, nil),
+		db.EXPECT().InsertAlert(gomock.Any()).Return(nil, nil).Do(func(alert model.Alert) {
+			assert.Equal(t, model.AlertCategoryLicense, alert.AlertCategory)
+			assert.Equal(t, model.TechnologyOracleDatabase, *alert.AlertAffectedTechnology)
+			assert.Equal(t, model.AlertCodeContractExpired, alert.AlertCode)
+			assert.Equal(t, model.AlertSeverityCritical, alert.AlertSeverity)
+			assert.Equal(t, model.AlertStatusNew, alert.AlertStatus)
+			assert.Equal(t, "The support of the Oracle/Database contract AID001 (A90611) expired on 2019-11-01", alert.Description)
+			assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), alert.Date)
+		}),
+		db.EXPECT().ExistContractExpirationAlert(model.AlertCodeContractExpiring, expiring, 30).Return(false, nil),
+		db.EXPECT().InsertAlert(gomock.Any()).Return(nil, nil).Do(func(alert model.Alert) {
+			assert.Equal(t, model.TechnologyMicrosoftSQLServer, *alert.AlertAffectedTechnology)
+			assert.Equal(t, model.AlertCodeContractExpiring, alert.AlertCode)
+			assert.Equal(t, model.AlertSeverityWarning, alert.AlertSeverity)
+			assert.Equal(t, "The support of the Microsoft/SQLServer contract AID002 (DG7GMGF0FKZV-0001) expires in 20 day(s) on 2019-11-25", alert.Description)
+			assert.Equal(t, map[string]interface{}{
+				"technology":        model.TechnologyMicrosoftSQLServer,
+				"id":                expiring.ID,
+				"contractID":        "AID002",
+				"licenseTypeID":     "DG7GMGF0FKZV-0001",
+				"location":          "Italy",
+				"supportExpiration": utils.P("2019-11-25T14:02:03Z"),
+				"leadDays":          30,
+				"daysLeft":          20,
+			}, alert.OtherInfo)
+		}),
+		db.EXPECT().ExistContractExpirationAlert(model.AlertCodeContractExpiring, alreadyThrown, 7).Return(true, nil),
+	)
+
+	require.NoError(t, as.ThrowContractExpirationAlerts([]int{90, 7, 30}))
+}
+
+func TestThrowContractExpirationAlerts_DatabaseError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := AlertService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	db.EXPECT().FindContractsSupportExpirations().Return(nil, aerrMock)
+
+	assert.Equal(t, aerrMock, as.ThrowContractExpirationAlerts([]int{90, 30, 7}))
+}
@@ -50,6 +50,8 @@ type AlertServiceInterface interface {
 	ThrowActivatedFeaturesAlert(dbname string, hostname string, activatedFeatures []string) error
 	// ThrowNoDataAlert create and insert in the database a new NO_DATA alert
 	ThrowNoDataAlert(hostname string, freshnessThreshold int) error
+	// ThrowContractExpirationAlerts create and insert in the database the CONTRACT_EXPIRING and CONTRACT_EXPIRED alerts
+	ThrowContractExpirationAlerts(leadDays []int) error
 }
 
 // AlertService is the concrete implementation of HostDataServiceInterface. It saves data to a MongoDB database
@@ -194,6 +196,24 @@ func (as *AlertService) ProcessAlertInsertion(params hub.Fields) {
 
 	to = append(to, as.Config.AlertService.Emailer.AlertType.NoData.To...)
 
+	if alert.IsCode(model.AlertCodeContractExpiring) && !as.Config.AlertService.Emailer.AlertType.ContractExpiring.Enable {
+		return
+	}
+
+	to = append(to, as.Config.AlertService.Emailer.AlertType.ContractExpiring.To...)
+
+	if alert.IsCode(model.AlertCodeContractExpired) && !as.Config.AlertService.Emailer.AlertType.ContractExpired.Enable {
+		return
+	}
+
+	to = append(to, as.Config.AlertService.Emailer.AlertType.ContractExpired.To...)
+
+	if alert.IsCode(model.AlertCodeLicenseNonCompliant) && !as.Config.AlertService.Emailer.AlertType.LicenseNonCompliant.Enable {
+		return
+	}
+
+	to = append(to, as.Config.AlertService.Emailer.AlertType.LicenseNonCompliant.To...)
+
 	//Create the subject and message
 	var subject, message string
 
@@ -38,6 +38,9 @@ type ApiSvcClientInterface interface {
 	GetOracleDatabaseLicenseTypes() ([]model.OracleDatabaseLicenseType, error)
 	GetSQLServerDatabaseLicenseTypes() ([]model.SqlServerDatabaseLicenseType, error)
 	GetMySqlDatabaseLicenseTypes() ([]model.MySqlLicenseType, error)
+	GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error)
+	GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error)
+	GetMariaDBLicenseTypes() ([]model.MariaDBLicenseType, error)
 	GetOracleDatabases() ([]model.OracleDatabase, error)
 }
 
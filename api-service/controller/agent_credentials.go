@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) ListAgentCredentials(w http.ResponseWriter, r *http.Request) {
+	credentials, err := ctrl.Service.ListAgentCredentials()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, credentials)
+}
+
+func (ctrl *APIController) AddAgentCredential(w http.ResponseWriter, r *http.Request) {
+	var req dto.AgentCredentialRequest
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	credential, err := ctrl.Service.AddAgentCredential(req)
+	if errors.Is(err, utils.ErrInvalidAgentCredential) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	} else if errors.Is(err, utils.ErrAgentCredentialAlreadyExists) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, credential)
+}
+
+func (ctrl *APIController) RotateAgentCredentialKey(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	credential, err := ctrl.Service.RotateAgentCredentialKey(id)
+	if errors.Is(err, utils.ErrAgentCredentialNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if errors.Is(err, utils.ErrInvalidAgentCredential) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, credential)
+}
+
+func (ctrl *APIController) RevokeAgentCredential(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = ctrl.Service.RevokeAgentCredential(id)
+	if errors.Is(err, utils.ErrAgentCredentialNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddAgentCredential_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.AgentCredentialRequest{Name: "web", HostnamePatterns: []string{"web-*"}}
+	expected := &dto.AgentCredentialKey{
+		Credential: model.AgentCredential{Name: "web", HostnamePatterns: []string{"web-*"}},
+		Key:        "s3cr3tKey",
+	}
+
+	as.EXPECT().AddAgentCredential(request).Return(expected, nil)
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddAgentCredential).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+	assert.NotContains(t, rr.Body.String(), "keyHash")
+}
+
+func TestAddAgentCredential_Conflict(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.AgentCredentialRequest{Name: "web", HostnamePatterns: []string{"web-*"}}
+	as.EXPECT().AddAgentCredential(request).Return(nil, utils.ErrAgentCredentialAlreadyExists)
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddAgentCredential).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestRevokeAgentCredential_NotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := primitive.NewObjectID()
+	as.EXPECT().RevokeAgentCredential(id).Return(utils.ErrAgentCredentialNotFound)
+
+	req, err := http.NewRequest("DELETE", "/", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.RevokeAgentCredential).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ListContractChanges return the changes of the contracts, filtered by technology, contract and date
+func (ctrl *APIController) ListContractChanges(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	filter := dto.ContractChangesFilter{
+		Technology: r.URL.Query().Get("technology"),
+	}
+
+	if contract := r.URL.Query().Get("contract"); contract != "" {
+		id, err := primitive.ObjectIDFromHex(contract)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+			return
+		}
+
+		filter.ContractObjectID = &id
+	}
+
+	if filter.From, err = utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if filter.To, err = utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	changes, err := ctrl.Service.ListContractChanges(filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, changes)
+}
+
+// GetContractsAsOf return the contracts as they were on the date in the request
+func (ctrl *APIController) GetContractsAsOf(w http.ResponseWriter, r *http.Request) {
+	date, err := utils.Str2time(r.URL.Query().Get("date"), ctrl.TimeNow())
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	contracts, err := ctrl.Service.GetContractsAsOf(date)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, contracts)
+}
+
+// GetDatabaseLicensesComplianceAsOf return the compliance of the licenses against the contracts as they were on the date in the request
+func (ctrl *APIController) GetDatabaseLicensesComplianceAsOf(w http.ResponseWriter, r *http.Request) {
+	date, err := utils.Str2time(r.URL.Query().Get("date"), ctrl.TimeNow())
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	locations := strings.Split(r.URL.Query().Get("location"), ",")
+
+	licenses, err := ctrl.Service.GetDatabaseLicensesComplianceAsOf(date, locations)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licensesCompliance": licenses,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
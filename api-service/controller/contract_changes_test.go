@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestListContractChanges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+		filter := dto.ContractChangesFilter{
+			Technology:       model.TechnologyOracleDatabase,
+			ContractObjectID: &id,
+			From:             utils.P("2019-01-01T00:00:00Z"),
+			To:               utils.MAX_TIME,
+		}
+
+		as.EXPECT().ListContractChanges(filter).Return([]model.ContractChange{}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/changes?technology=Oracle/Database&contract=aaaaaaaaaaaaaaaaaaaaaaaa&from=2019-01-01T00:00:00Z", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ListContractChanges).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid contract", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/contracts/changes?contract=foobar", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ListContractChanges).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		as.EXPECT().ListContractChanges(gomock.Any()).Return(nil, errMock)
+
+		req, err := http.NewRequest("GET", "/contracts/changes", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ListContractChanges).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestGetContractsAsOf(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		date := utils.P("2019-06-01T00:00:00Z")
+		as.EXPECT().GetContractsAsOf(date).Return(&dto.ContractsAsOf{Date: date}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/as-of?date=2019-06-01T00:00:00Z", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetContractsAsOf).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Default is now", func(t *testing.T) {
+		as.EXPECT().GetContractsAsOf(utils.P("2019-11-05T14:02:03Z")).Return(&dto.ContractsAsOf{}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/as-of", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetContractsAsOf).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/contracts/as-of?date=yesterday", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetContractsAsOf).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestGetDatabaseLicensesComplianceAsOf(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().GetDatabaseLicensesComplianceAsOf(utils.P("2019-06-01T00:00:00Z"), []string{"Italy"}).
+		Return([]dto.LicenseCompliance{}, nil)
+
+	req, err := http.NewRequest("GET", "/contracts/as-of/licenses-compliance?date=2019-06-01T00:00:00Z&location=Italy", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.GetDatabaseLicensesComplianceAsOf).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/golang/gddo/httputil"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// contractHandlers contains what is needed to handle the requests on the contracts of a technology
+type contractHandlers[T interface{ IsValid() bool }] struct {
+	id func(contract T) primitive.ObjectID
+	// errLicenseTypeIDNotFound is returned by add and update when the license type of the contract doesn't exist
+	errLicenseTypeIDNotFound error
+	add                      func(contract T, user string) (*T, error)
+	update                   func(contract T, user string) (*T, error)
+	remove                   func(id primitive.ObjectID, user string) error
+	list                     func(locations []string) ([]T, error)
+	listAsXLSX               func(locations []string) (*excelize.File, error)
+}
+
+func addContract[T interface{ IsValid() bool }](ctrl *APIController, handlers contractHandlers[T], w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var req T
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	if handlers.id(req) != primitive.NilObjectID {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(errors.New("ID must be empty to add a new contract"), http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	if !req.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contract, err := handlers.add(req, requestUsername(r))
+	if errors.Is(err, handlers.errLicenseTypeIDNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, contract)
+}
+
+func updateContract[T interface{ IsValid() bool }](ctrl *APIController, handlers contractHandlers[T], w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var req T
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	if !req.IsValid() {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("Contract isn't valid"))
+		return
+	}
+
+	contract, err := handlers.update(req, requestUsername(r))
+	if errors.Is(err, utils.ErrContractNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if errors.Is(err, handlers.errLicenseTypeIDNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, contract)
+}
+
+func deleteContract[T interface{ IsValid() bool }](ctrl *APIController, handlers contractHandlers[T], w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
+		return
+	}
+
+	if err = handlers.remove(id, requestUsername(r)); errors.Is(err, utils.ErrContractNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, nil)
+}
+
+func getContracts[T interface{ IsValid() bool }](ctrl *APIController, handlers contractHandlers[T], w http.ResponseWriter, r *http.Request) {
+	filter, err := dto.GetGlobalFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	locations := strings.Split(filter.Location, ",")
+
+	choice := httputil.NegotiateContentType(r, []string{"application/json", xlsxContentType}, "application/json")
+
+	switch choice {
+	case "application/json":
+		contracts, err := handlers.list(locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		response := map[string]interface{}{
+			"contracts": contracts,
+		}
+
+		utils.WriteJSONResponse(w, http.StatusOK, response)
+	case xlsxContentType:
+		xlsx, err := handlers.listAsXLSX(locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, xlsx)
+	}
+}
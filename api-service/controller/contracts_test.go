@@ -0,0 +1,335 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddPostgreSQLContract_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.PostgreSQLContract{
+		Type:                model.PostgreSQLContractTypeHost,
+		ContractID:          "EDB-001",
+		LicenseTypeID:       "EDB-ENT-CORE",
+		SubscriptionsNumber: 8,
+		Hosts:               []string{"pg1"},
+		Clusters:            []string{},
+	}
+
+	returnContract := contract
+	returnContract.ID = utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+
+	as.EXPECT().AddPostgreSQLContract(contract, "").
+		Return(&returnContract, nil)
+
+	body, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddPostgreSQLContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(returnContract), rr.Body.String())
+}
+
+func TestAddPostgreSQLContract_BadRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contracts := []model.PostgreSQLContract{
+		{
+			ID:                  utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			Type:                model.PostgreSQLContractTypeHost,
+			ContractID:          "EDB-001",
+			LicenseTypeID:       "EDB-ENT-CORE",
+			SubscriptionsNumber: 8,
+		},
+		{
+			Type:                "SOCKET",
+			ContractID:          "EDB-001",
+			LicenseTypeID:       "EDB-ENT-CORE",
+			SubscriptionsNumber: 8,
+		},
+		{
+			Type:          model.PostgreSQLContractTypeHost,
+			ContractID:    "EDB-001",
+			LicenseTypeID: "EDB-ENT-CORE",
+		},
+	}
+
+	for _, contract := range contracts {
+		body, err := json.Marshal(contract)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.AddPostgreSQLContract).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestAddPostgreSQLContract_LicenseTypeNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.PostgreSQLContract{
+		Type:                model.PostgreSQLContractTypeHost,
+		ContractID:          "EDB-001",
+		LicenseTypeID:       "NOT-EXISTING",
+		SubscriptionsNumber: 8,
+	}
+
+	as.EXPECT().AddPostgreSQLContract(contract, "").
+		Return(nil, utils.ErrPostgreSQLLicenseTypeIDNotFound)
+
+	body, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddPostgreSQLContract).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestDeletePostgreSQLContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		as.EXPECT().DeletePostgreSQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), "").Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "aaaaaaaaaaaaaaaaaaaaaaaa"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeletePostgreSQLContract).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		as.EXPECT().DeletePostgreSQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), "").Return(utils.ErrContractNotFound)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "aaaaaaaaaaaaaaaaaaaaaaaa"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeletePostgreSQLContract).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "pippo"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeletePostgreSQLContract).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("Read only", func(t *testing.T) {
+		ac.Config.APIService.ReadOnly = true
+		defer func() { ac.Config.APIService.ReadOnly = false }()
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": primitive.NewObjectID().Hex()})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeletePostgreSQLContract).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestAddContract_Technologies(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	mariaDBContract := model.MariaDBContract{
+		ContractID:    "MARIADB-001",
+		LicenseTypeID: "MARIADB-ES-SERVER",
+		ServersNumber: 4,
+		Hosts:         []string{"maria1"},
+	}
+	mongoDBContract := model.MongoDBContract{
+		ContractID:    "MDB-001",
+		LicenseTypeID: "MDB-EA-SERVER",
+		ServersNumber: 4,
+		Hosts:         []string{"mongo1"},
+		ReplicaSets:   []string{"rs0"},
+	}
+
+	testCases := []struct {
+		name                     string
+		contract                 interface{}
+		handler                  http.HandlerFunc
+		errLicenseTypeIDNotFound error
+		expect                   func(err error)
+	}{
+		{
+			name:                     "MariaDB",
+			contract:                 mariaDBContract,
+			handler:                  ac.AddMariaDBContract,
+			errLicenseTypeIDNotFound: utils.ErrMariaDBLicenseTypeIDNotFound,
+			expect: func(err error) {
+				as.EXPECT().AddMariaDBContract(mariaDBContract, "").Return(&mariaDBContract, err)
+			},
+		},
+		{
+			name:                     "MongoDB",
+			contract:                 mongoDBContract,
+			handler:                  ac.AddMongoDBContract,
+			errLicenseTypeIDNotFound: utils.ErrMongoDBLicenseTypeIDNotFound,
+			expect: func(err error) {
+				as.EXPECT().AddMongoDBContract(mongoDBContract, "").Return(&mongoDBContract, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := json.Marshal(tc.contract)
+			require.NoError(t, err)
+
+			tc.expect(nil)
+
+			rr := httptest.NewRecorder()
+			tc.handler.ServeHTTP(rr, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, utils.ToJSON(tc.contract), rr.Body.String())
+
+			tc.expect(tc.errLicenseTypeIDNotFound)
+
+			rr = httptest.NewRecorder()
+			tc.handler.ServeHTTP(rr, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+
+			require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		})
+	}
+}
+
+func TestUpdateMariaDBContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	contract := model.MariaDBContract{
+		ID:            utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		ContractID:    "MARIADB-001",
+		LicenseTypeID: "MARIADB-ES-SERVER",
+		ServersNumber: 4,
+		Hosts:         []string{},
+	}
+
+	body, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Success", expected: http.StatusOK},
+		{name: "Not found", err: utils.ErrContractNotFound, expected: http.StatusNotFound},
+		{name: "License type not found", err: utils.ErrMariaDBLicenseTypeIDNotFound, expected: http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as.EXPECT().UpdateMariaDBContract(contract, "").Return(&contract, tc.err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.UpdateMariaDBContract).ServeHTTP(rr, httptest.NewRequest("PUT", "/", bytes.NewReader(body)))
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
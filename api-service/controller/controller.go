@@ -50,6 +50,8 @@ type APIControllerInterface interface {
 	SearchOracleDatabasePatchAdvisors(w http.ResponseWriter, r *http.Request)
 	// GetHost return all informations about the host requested in the id path variable
 	GetHost(w http.ResponseWriter, r *http.Request)
+	// GetHostChanges return the changes of the host requested in the hostname path variable
+	GetHostChanges(w http.ResponseWriter, r *http.Request)
 	// SearchAlerts search alerts using the filters in the request
 	SearchAlerts(w http.ResponseWriter, r *http.Request)
 	// SearchOracleDatabaseUsedLicenses search licenses consumed by the hosts using the filters in the request
@@ -105,6 +107,8 @@ type APIControllerInterface interface {
 	GetOracleDatabasesStatistics(w http.ResponseWriter, r *http.Request)
 	// GetOracleDatabaseLicensesCompliance return licenses usage status and compliance
 	GetOracleDatabaseLicensesCompliance(w http.ResponseWriter, r *http.Request)
+	// GetOracleDatabaseContractsLicensesCompliance return usage status and compliance of the licenses of each contract
+	GetOracleDatabaseContractsLicensesCompliance(w http.ResponseWriter, r *http.Request)
 
 	// GetDefaultDatabaseTags return the default list of database tags from configuration
 	GetDefaultDatabaseTags(w http.ResponseWriter, r *http.Request)
@@ -122,6 +126,9 @@ type APIControllerInterface interface {
 	DismissHost(w http.ResponseWriter, r *http.Request)
 
 	CreateDr(w http.ResponseWriter, r *http.Request)
+	ListDisasterRecoveryPairs(w http.ResponseWriter, r *http.Request)
+	AddDisasterRecoveryPair(w http.ResponseWriter, r *http.Request)
+	DeleteDisasterRecoveryPair(w http.ResponseWriter, r *http.Request)
 
 	GetMissingDatabases(w http.ResponseWriter, r *http.Request)
 	GetMissingDatabasesByHostname(w http.ResponseWriter, r *http.Request)
@@ -158,11 +165,15 @@ type APIControllerInterface interface {
 	UpdateOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 	GetOracleDatabaseContracts(w http.ResponseWriter, r *http.Request)
 	DeleteOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
+	// GetOracleDatabaseContractsAssignmentTrace return the trace of the assignment of the contracts to the hosts
+	GetOracleDatabaseContractsAssignmentTrace(w http.ResponseWriter, r *http.Request)
 
 	AddHostToOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 	DeleteHostFromOracleDatabaseContract(w http.ResponseWriter, r *http.Request)
 
 	ImportContractFromCSV(w http.ResponseWriter, r *http.Request)
+	PreviewContractsImportFromCSV(w http.ResponseWriter, r *http.Request)
+	CommitContractsImportFromCSV(w http.ResponseWriter, r *http.Request)
 	GetContractSampleCSV(w http.ResponseWriter, r *http.Request)
 
 	// ORACLE DATABASE LICENSE TYPES
@@ -201,10 +212,44 @@ type APIControllerInterface interface {
 	// POSTGRESQL
 	// SearchPostgreSqlInstances search instances data using the filters in the request
 	SearchPostgreSqlInstances(w http.ResponseWriter, r *http.Request)
+	// GetPostgreSQLLicensesCompliance return the list of PostgreSQL subscriptions with usage and compliance
+	GetPostgreSQLLicensesCompliance(w http.ResponseWriter, r *http.Request)
+	GetPostgreSQLLicenseTypes(w http.ResponseWriter, r *http.Request)
+	AddPostgreSQLLicenseType(w http.ResponseWriter, r *http.Request)
+	UpdatePostgreSQLLicenseType(w http.ResponseWriter, r *http.Request)
+	DeletePostgreSQLLicenseType(w http.ResponseWriter, r *http.Request)
+	AddPostgreSQLContract(w http.ResponseWriter, r *http.Request)
+	UpdatePostgreSQLContract(w http.ResponseWriter, r *http.Request)
+	GetPostgreSQLContracts(w http.ResponseWriter, r *http.Request)
+	DeletePostgreSQLContract(w http.ResponseWriter, r *http.Request)
 
 	// MONGODB
 	// SearchMongoDBInstances search instances data using the filters in the request
 	SearchMongoDBInstances(w http.ResponseWriter, r *http.Request)
+	// GetMongoDBLicensesCompliance return the list of MongoDB subscriptions with usage and compliance
+	GetMongoDBLicensesCompliance(w http.ResponseWriter, r *http.Request)
+	GetMongoDBLicenseTypes(w http.ResponseWriter, r *http.Request)
+	AddMongoDBLicenseType(w http.ResponseWriter, r *http.Request)
+	UpdateMongoDBLicenseType(w http.ResponseWriter, r *http.Request)
+	DeleteMongoDBLicenseType(w http.ResponseWriter, r *http.Request)
+	AddMongoDBContract(w http.ResponseWriter, r *http.Request)
+	UpdateMongoDBContract(w http.ResponseWriter, r *http.Request)
+	GetMongoDBContracts(w http.ResponseWriter, r *http.Request)
+	DeleteMongoDBContract(w http.ResponseWriter, r *http.Request)
+
+	// MARIADB
+	// SearchMariaDBInstances search instances data using the filters in the request
+	SearchMariaDBInstances(w http.ResponseWriter, r *http.Request)
+	// GetMariaDBLicensesCompliance return the list of MariaDB subscriptions with usage and compliance
+	GetMariaDBLicensesCompliance(w http.ResponseWriter, r *http.Request)
+	GetMariaDBLicenseTypes(w http.ResponseWriter, r *http.Request)
+	AddMariaDBLicenseType(w http.ResponseWriter, r *http.Request)
+	UpdateMariaDBLicenseType(w http.ResponseWriter, r *http.Request)
+	DeleteMariaDBLicenseType(w http.ResponseWriter, r *http.Request)
+	AddMariaDBContract(w http.ResponseWriter, r *http.Request)
+	UpdateMariaDBContract(w http.ResponseWriter, r *http.Request)
+	GetMariaDBContracts(w http.ResponseWriter, r *http.Request)
+	DeleteMariaDBContract(w http.ResponseWriter, r *http.Request)
 
 	// MYSQL CONTRACTS
 	AddMySQLContract(w http.ResponseWriter, r *http.Request)
@@ -212,6 +257,20 @@ type APIControllerInterface interface {
 	GetMySQLContracts(w http.ResponseWriter, r *http.Request)
 	DeleteMySQLContract(w http.ResponseWriter, r *http.Request)
 
+	// ORACLE ULA
+	// GetOracleULACertification return the Oracle licenses deployed on a date for the ULA certification
+	GetOracleULACertification(w http.ResponseWriter, r *http.Request)
+	// GetOracleULAUsageHistory return the usage of the licenses covered by the ULA over its term
+	GetOracleULAUsageHistory(w http.ResponseWriter, r *http.Request)
+
+	// CONTRACT CHANGES
+	// ListContractChanges return the changes of the contracts
+	ListContractChanges(w http.ResponseWriter, r *http.Request)
+	// GetContractsAsOf return the contracts as they were on a date
+	GetContractsAsOf(w http.ResponseWriter, r *http.Request)
+	// GetDatabaseLicensesComplianceAsOf return the compliance of the licenses against the contracts as they were on a date
+	GetDatabaseLicensesComplianceAsOf(w http.ResponseWriter, r *http.Request)
+
 	// ROLES
 	GetRole(w http.ResponseWriter, r *http.Request)
 	GetRoles(w http.ResponseWriter, r *http.Request)
@@ -236,6 +295,11 @@ type APIControllerInterface interface {
 	RemoveUser(w http.ResponseWriter, r *http.Request)
 	GetInfo(w http.ResponseWriter, r *http.Request)
 
+	ListAgentCredentials(w http.ResponseWriter, r *http.Request)
+	AddAgentCredential(w http.ResponseWriter, r *http.Request)
+	RotateAgentCredentialKey(w http.ResponseWriter, r *http.Request)
+	RevokeAgentCredential(w http.ResponseWriter, r *http.Request)
+
 	GetNodes(w http.ResponseWriter, r *http.Request)
 	GetNode(w http.ResponseWriter, r *http.Request)
 	AddNode(w http.ResponseWriter, r *http.Request)
@@ -277,3 +341,12 @@ func (ctrl *APIController) userHasAccessToLocation(r *http.Request, location str
 
 	return utils.ContainsSomeI(locations, location, model.AllLocation)
 }
+
+// requestUsername return the username of the user that made the request
+func requestUsername(r *http.Request) string {
+	if user, ok := context.Get(r, "user").(model.User); ok {
+		return user.Username
+	}
+
+	return ""
+}
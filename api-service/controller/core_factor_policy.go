@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) ListCoreFactorPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := ctrl.Service.ListCoreFactorPolicies()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, policies)
+}
+
+func (ctrl *APIController) GetActiveCoreFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := ctrl.Service.GetActiveCoreFactorPolicy()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, policy)
+}
+
+func (ctrl *APIController) GetCoreFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	policy, err := ctrl.Service.GetCoreFactorPolicy(version)
+	if errors.Is(err, utils.ErrCoreFactorPolicyNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, policy)
+}
+
+func (ctrl *APIController) AddCoreFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	var req dto.CoreFactorPolicyRequest
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	policy, err := ctrl.Service.AddCoreFactorPolicy(req)
+	if errors.Is(err, utils.ErrInvalidCoreFactorPolicy) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, policy)
+}
+
+func (ctrl *APIController) ActivateCoreFactorPolicy(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = ctrl.Service.ActivateCoreFactorPolicy(version)
+	if errors.Is(err, utils.ErrCoreFactorPolicyNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
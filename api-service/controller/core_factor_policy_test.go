@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddCoreFactorPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.CoreFactorPolicyRequest{
+		Description:       "IBM POWER",
+		DefaultCoreFactor: 0.5,
+		CoreFactorRules:   []model.CoreFactorRule{{CPUModel: "power", CoreFactor: 1}},
+	}
+
+	testCases := []struct {
+		name     string
+		policy   *model.CoreFactorPolicy
+		err      error
+		expected int
+	}{
+		{"Success", &model.CoreFactorPolicy{Version: 2, Active: true}, nil, http.StatusCreated},
+		{"Invalid", nil, utils.ErrInvalidCoreFactorPolicy, http.StatusUnprocessableEntity},
+		{"Internal error", nil, errMock, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as.EXPECT().AddCoreFactorPolicy(request).Return(tc.policy, tc.err)
+
+			body, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "/oracle/core-factor-policies", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.AddCoreFactorPolicy).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestActivateCoreFactorPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	testCases := []struct {
+		name     string
+		version  string
+		err      error
+		expected int
+	}{
+		{"Success", "2", nil, http.StatusNoContent},
+		{"Not found", "5", utils.ErrCoreFactorPolicyNotFound, http.StatusNotFound},
+		{"Invalid version", "foobar", nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expected != http.StatusUnprocessableEntity {
+				as.EXPECT().ActivateCoreFactorPolicy(gomock.Any()).Return(tc.err)
+			}
+
+			req, err := http.NewRequest("POST", "/", nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{"version": tc.version})
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.ActivateCoreFactorPolicy).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const dataServiceAdminPath = "/admin"
+
+// ForwardToDataServiceAdmin forwards the request to the same admin route of the data-service,
+// authenticated with the service credential of the api-service
+func (ctrl *APIController) ForwardToDataServiceAdmin(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly && r.Method != http.MethodGet {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, errors.New("The API is disabled because the service is put in read-only mode"))
+		return
+	}
+
+	i := strings.Index(r.URL.Path, dataServiceAdminPath+"/")
+	if i < 0 {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, utils.ErrNotFound)
+		return
+	}
+
+	target, err := url.Parse(strings.TrimSuffix(ctrl.Config.DataService.RemoteEndpoint, "/"))
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	path := r.URL.Path[i:]
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path = target.Path + path
+			req.URL.RawPath = ""
+			req.Host = target.Host
+
+			req.SetBasicAuth(ctrl.Config.APIService.AuthenticationProvider.Username,
+				ctrl.Config.APIService.AuthenticationProvider.Password)
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusBadGateway, err)
+		},
+	}
+
+	proxy.ServeHTTP(w, r)
+}
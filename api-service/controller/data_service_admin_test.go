@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestForwardToDataServiceAdmin(t *testing.T) {
+	dataService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "ercole", username)
+		assert.Equal(t, "s3rv1ce", password)
+
+		assert.Equal(t, "/admin/hostdata-quarantine/5fcb9a000000000000000001/replay", r.URL.Path)
+		assert.Equal(t, "force=true", r.URL.RawQuery)
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"hostname":"foobar"}`, string(body))
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer dataService.Close()
+
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Config: config.Configuration{
+			DataService: config.DataService{
+				RemoteEndpoint: dataService.URL,
+			},
+			APIService: config.APIService{
+				AuthenticationProvider: config.AuthenticationProviderConfig{
+					Username: "ercole",
+					Password: "s3rv1ce",
+				},
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	t.Run("Forwarded", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/ldap/admin/hostdata-quarantine/5fcb9a000000000000000001/replay?force=true",
+			strings.NewReader(`{"hostname":"foobar"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer userToken")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ForwardToDataServiceAdmin).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("Read-only", func(t *testing.T) {
+		ac := ac
+		ac.Config.APIService.ReadOnly = true
+
+		req, err := http.NewRequest("POST", "/admin/oracle/license-reprocessing", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ForwardToDataServiceAdmin).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
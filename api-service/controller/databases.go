@@ -192,6 +192,32 @@ func (ctrl *APIController) GetDatabaseLicensesComplianceXLSX(w http.ResponseWrit
 	utils.WriteXLSXResponse(w, xlsx)
 }
 
+// GetLicensesCost return the current spend on the licenses and the cost of the consumption not covered by them
+func (ctrl *APIController) GetLicensesCost(w http.ResponseWriter, r *http.Request) {
+	locations := strings.Split(r.URL.Query().Get("location"), ",")
+
+	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
+
+	switch choice {
+	case "application/json":
+		cost, err := ctrl.Service.GetLicensesCost(locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteJSONResponse(w, http.StatusOK, cost)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		xlsx, err := ctrl.Service.GetLicensesCostAsXLSX(locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, xlsx)
+	}
+}
+
 func (ctrl *APIController) GetUsedLicensesPerHost(w http.ResponseWriter, r *http.Request) {
 	filter, err := dto.GetGlobalFilter(r)
 	if err != nil {
@@ -600,3 +600,65 @@ func TestGetUsedLicensesPerClusterAsXLSX_InternalServerError1(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestGetLicensesCost(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cost := &dto.LicensesCost{
+			Spend:    4600,
+			Exposure: 2300,
+			Technologies: []dto.LicensesCostGroup{
+				{Group: "Oracle/Database", Spend: 4600, Exposure: 2300},
+			},
+		}
+		as.EXPECT().GetLicensesCost([]string{"Italy", "Germany"}).Return(cost, nil)
+
+		req, err := http.NewRequest("GET", "/hosts/technologies/all/databases/licenses-cost?location=Italy,Germany", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetLicensesCost).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(cost), rr.Body.String())
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		xlsx := excelize.File{}
+		as.EXPECT().GetLicensesCostAsXLSX([]string{""}).Return(&xlsx, nil)
+
+		req, err := http.NewRequest("GET", "/hosts/technologies/all/databases/licenses-cost", nil)
+		require.NoError(t, err)
+
+		req.Header.Add("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetLicensesCost).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		_, err = excelize.OpenReader(rr.Body)
+		require.NoError(t, err)
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		as.EXPECT().GetLicensesCost(gomock.Any()).Return(nil, errMock)
+
+		req, err := http.NewRequest("GET", "/hosts/technologies/all/databases/licenses-cost", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetLicensesCost).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -17,10 +17,13 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (ctrl *APIController) CreateDr(w http.ResponseWriter, r *http.Request) {
@@ -36,3 +39,55 @@ func (ctrl *APIController) CreateDr(w http.ResponseWriter, r *http.Request) {
 		"hostname": drName,
 	})
 }
+
+func (ctrl *APIController) ListDisasterRecoveryPairs(w http.ResponseWriter, r *http.Request) {
+	pairs, err := ctrl.Service.ListDisasterRecoveryPairs()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, pairs)
+}
+
+func (ctrl *APIController) AddDisasterRecoveryPair(w http.ResponseWriter, r *http.Request) {
+	var req dto.DisasterRecoveryPairRequest
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	pair, err := ctrl.Service.AddDisasterRecoveryPair(req)
+	if errors.Is(err, utils.ErrInvalidDisasterRecoveryPair) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if errors.Is(err, utils.ErrDisasterRecoveryPairAlreadyExists) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, pair)
+}
+
+func (ctrl *APIController) DeleteDisasterRecoveryPair(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = ctrl.Service.DeleteDisasterRecoveryPair(id)
+	if errors.Is(err, utils.ErrDisasterRecoveryPairNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
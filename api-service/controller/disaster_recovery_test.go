@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestListDisasterRecoveryPairs_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	expected := []dto.DisasterRecoveryPairStatus{
+		{
+			Pair: model.DisasterRecoveryPair{Primary: "db01", DR: "standby01"},
+			Hosts: []dto.DisasterRecoveryPairHost{
+				{Primary: "db01", DR: "standby01", PrimaryExists: true, DRExists: false},
+			},
+		},
+	}
+
+	as.EXPECT().ListDisasterRecoveryPairs().Return(expected, nil)
+
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.ListDisasterRecoveryPairs).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestAddDisasterRecoveryPair_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.DisasterRecoveryPairRequest{Primary: "db01", DR: "standby01"}
+	expected := &model.DisasterRecoveryPair{Primary: "db01", DR: "standby01"}
+
+	as.EXPECT().AddDisasterRecoveryPair(request).Return(expected, nil)
+
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.AddDisasterRecoveryPair).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestAddDisasterRecoveryPair_Errors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	testCases := []struct {
+		err    error
+		status int
+	}{
+		{utils.ErrInvalidDisasterRecoveryPair, http.StatusUnprocessableEntity},
+		{utils.ErrDisasterRecoveryPairAlreadyExists, http.StatusConflict},
+		{aerrMock, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		request := dto.DisasterRecoveryPairRequest{Primary: "db01", DR: "standby01"}
+		as.EXPECT().AddDisasterRecoveryPair(request).Return(nil, tc.err)
+
+		req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte(utils.ToJSON(request))))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.AddDisasterRecoveryPair).ServeHTTP(rr, req)
+
+		require.Equal(t, tc.status, rr.Code)
+	}
+}
+
+func TestDeleteDisasterRecoveryPair(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := primitive.NewObjectID()
+
+	t.Run("Success", func(t *testing.T) {
+		as.EXPECT().DeleteDisasterRecoveryPair(id).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteDisasterRecoveryPair).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		as.EXPECT().DeleteDisasterRecoveryPair(id).Return(utils.ErrDisasterRecoveryPairNotFound)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteDisasterRecoveryPair).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "foobar"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteDisasterRecoveryPair).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/context"
+	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetHostChanges return the changes of the host requested in the hostname path variable
+func (ctrl *APIController) GetHostChanges(w http.ResponseWriter, r *http.Request) {
+	hostname := mux.Vars(r)["hostname"]
+
+	var kinds []string
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		kinds = strings.Split(kind, ",")
+	}
+
+	from, err := utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	to, err := utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	changes, err := ctrl.Service.GetHostChanges(hostname, kinds, from, to)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	locations, err := ctrl.Service.ListLocations(context.Get(r, "user"))
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	allowed := make([]model.HostChange, 0, len(changes))
+
+	for _, change := range changes {
+		if utils.ContainsSomeI(locations, change.Location, model.AllLocation) {
+			allowed = append(allowed, change)
+		}
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, allowed)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetHostChanges_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	changes := []model.HostChange{
+		{Hostname: "foobar", Location: "Italy", Kind: model.HostChangeKindDatabaseAdded, Database: "ERCOLE"},
+		{Hostname: "foobar", Location: "Germany", Kind: model.HostChangeKindPatchApplied, Database: "ERCOLE"},
+	}
+
+	var user interface{}
+
+	as.EXPECT().
+		GetHostChanges("foobar", []string{model.HostChangeKindDatabaseAdded, model.HostChangeKindPatchApplied},
+			utils.P("2020-06-10T11:54:59Z"), utils.MAX_TIME).
+		Return(changes, nil)
+	as.EXPECT().ListLocations(user).Return([]string{"Italy"}, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetHostChanges)
+	req, err := http.NewRequest("GET", "/hosts/foobar/changes?kind=DATABASE_ADDED,PATCH_APPLIED&from=2020-06-10T11%3A54%3A59Z", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"hostname": "foobar"})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(changes[:1]), rr.Body.String())
+}
+
+func TestGetHostChanges_UnprocessableEntity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetHostChanges)
+	req, err := http.NewRequest("GET", "/hosts/foobar/changes?to=yesterday", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"hostname": "foobar"})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
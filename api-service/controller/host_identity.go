@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) ResolveHostname(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, errors.New("name is required"))
+		return
+	}
+
+	resolved, err := ctrl.Service.ResolveHostname(name)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, resolved)
+}
+
+func (ctrl *APIController) ListHostAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := ctrl.Service.ListHostAliases()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, aliases)
+}
+
+func (ctrl *APIController) AddHostAlias(w http.ResponseWriter, r *http.Request) {
+	var req dto.HostAliasRequest
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	alias, err := ctrl.Service.AddHostAlias(req)
+	if errors.Is(err, utils.ErrInvalidHostAlias) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if errors.Is(err, utils.ErrHostNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if errors.Is(err, utils.ErrHostAliasAlreadyExists) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusCreated, alias)
+}
+
+func (ctrl *APIController) DeleteHostAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = ctrl.Service.DeleteHostAlias(id)
+	if errors.Is(err, utils.ErrHostAliasNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ctrl *APIController) MergeHosts(w http.ResponseWriter, r *http.Request) {
+	var req dto.HostMergeRequest
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	err := ctrl.Service.MergeHosts(req, requestUsername(r))
+	if errors.Is(err, utils.ErrInvalidHostMerge) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if errors.Is(err, utils.ErrHostNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestResolveHostname_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	expected := &dto.ResolvedHostname{Name: "DB01", Hostname: "db01.example.com", Found: true}
+	as.EXPECT().ResolveHostname("DB01").Return(expected, nil)
+
+	req, err := http.NewRequest("GET", "/host-aliases/resolve?name=DB01", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(ac.ResolveHostname).ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestAddHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.HostAliasRequest{Alias: "cmdb-db01", Hostname: "db01"}
+
+	testCases := []struct {
+		name     string
+		alias    *model.HostAlias
+		err      error
+		expected int
+	}{
+		{"Success", &model.HostAlias{Alias: "cmdb-db01", Hostname: "db01", Source: model.HostAliasSourceManual}, nil, http.StatusCreated},
+		{"Invalid", nil, utils.ErrInvalidHostAlias, http.StatusUnprocessableEntity},
+		{"Host not found", nil, utils.ErrHostNotFound, http.StatusNotFound},
+		{"Already exists", nil, utils.ErrHostAliasAlreadyExists, http.StatusConflict},
+		{"Internal error", nil, errMock, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as.EXPECT().AddHostAlias(request).Return(tc.alias, tc.err)
+
+			body, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "/host-aliases", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.AddHostAlias).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
+
+func TestDeleteHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+
+	t.Run("Success", func(t *testing.T) {
+		as.EXPECT().DeleteHostAlias(id).Return(nil)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteHostAlias).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		as.EXPECT().DeleteHostAlias(id).Return(utils.ErrHostAliasNotFound)
+
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteHostAlias).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		req, err := http.NewRequest("DELETE", "/", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"id": "foobar"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.DeleteHostAlias).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
+
+func TestMergeHosts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.HostMergeRequest{From: "oldname", To: "newname"}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Success", nil, http.StatusNoContent},
+		{"Invalid", utils.ErrInvalidHostMerge, http.StatusUnprocessableEntity},
+		{"Host not found", utils.ErrHostNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as.EXPECT().MergeHosts(request, "").Return(tc.err)
+
+			body, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "/host-merges", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.MergeHosts).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestSimulateLicensesCompliance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.LicenseSimulationRequest{
+		Changes: []dto.LicenseSimulationChange{
+			{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hosta", DbName: "erp", ToHostname: "hostb"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		simulation *dto.LicenseSimulation
+		err        error
+		expected   int
+	}{
+		{"Success", &dto.LicenseSimulation{Changes: request.Changes, Licenses: []dto.LicenseComplianceDelta{}}, nil, http.StatusOK},
+		{"Invalid", nil, utils.ErrInvalidLicenseSimulation, http.StatusUnprocessableEntity},
+		{"Host not found", nil, utils.ErrHostNotFound, http.StatusNotFound},
+		{"Internal error", nil, errMock, http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			as.EXPECT().SimulateLicensesCompliance(request).Return(tc.simulation, tc.err)
+
+			body, err := json.Marshal(request)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "/hosts/technologies/all/databases/licenses-compliance/simulations", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			http.HandlerFunc(ac.SimulateLicensesCompliance).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+}
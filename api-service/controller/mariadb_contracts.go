@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) mariaDBContractHandlers() contractHandlers[model.MariaDBContract] {
+	return contractHandlers[model.MariaDBContract]{
+		id: func(contract model.MariaDBContract) primitive.ObjectID {
+			return contract.ID
+		},
+		errLicenseTypeIDNotFound: utils.ErrMariaDBLicenseTypeIDNotFound,
+		add:                      ctrl.Service.AddMariaDBContract,
+		update:                   ctrl.Service.UpdateMariaDBContract,
+		remove:                   ctrl.Service.DeleteMariaDBContract,
+		list:                     ctrl.Service.GetMariaDBContracts,
+		listAsXLSX:               ctrl.Service.GetMariaDBContractsAsXLSX,
+	}
+}
+
+func (ctrl *APIController) AddMariaDBContract(w http.ResponseWriter, r *http.Request) {
+	addContract(ctrl, ctrl.mariaDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) UpdateMariaDBContract(w http.ResponseWriter, r *http.Request) {
+	updateContract(ctrl, ctrl.mariaDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) DeleteMariaDBContract(w http.ResponseWriter, r *http.Request) {
+	deleteContract(ctrl, ctrl.mariaDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) GetMariaDBContracts(w http.ResponseWriter, r *http.Request) {
+	getContracts(ctrl, ctrl.mariaDBContractHandlers(), w, r)
+}
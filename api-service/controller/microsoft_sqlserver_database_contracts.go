@@ -53,7 +53,7 @@ func (ctrl *APIController) AddSqlServerDatabaseContract(w http.ResponseWriter, r
 		return
 	}
 
-	agr, err := ctrl.Service.AddSqlServerDatabaseContract(req)
+	agr, err := ctrl.Service.AddSqlServerDatabaseContract(req, requestUsername(r))
 	if errors.Is(err, utils.ErrContractNotFound) ||
 		errors.Is(err, utils.ErrLicenseNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
@@ -80,7 +80,7 @@ func (ctrl *APIController) DeleteSqlServerDatabaseContract(w http.ResponseWriter
 		return
 	}
 
-	if err = ctrl.Service.DeleteSqlServerDatabaseContract(id); errors.Is(err, utils.ErrContractNotFound) {
+	if err = ctrl.Service.DeleteSqlServerDatabaseContract(id, requestUsername(r)); errors.Is(err, utils.ErrContractNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
 	} else if err != nil {
@@ -108,7 +108,7 @@ func (ctrl *APIController) UpdateSqlServerDatabaseContract(w http.ResponseWriter
 		return
 	}
 
-	agr, err := ctrl.Service.UpdateSqlServerDatabaseContract(req)
+	agr, err := ctrl.Service.UpdateSqlServerDatabaseContract(req, requestUsername(r))
 	if errors.Is(err, utils.ErrContractNotFound) ||
 		errors.Is(err, utils.ErrLicenseNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
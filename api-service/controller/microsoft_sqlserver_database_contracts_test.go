@@ -60,7 +60,7 @@ func TestAddOSqlServerDatabaseContract_Success(t *testing.T) {
 
 	result := request
 
-	as.EXPECT().AddSqlServerDatabaseContract(request).Return(&result, nil)
+	as.EXPECT().AddSqlServerDatabaseContract(request, "").Return(&result, nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddSqlServerDatabaseContract)
@@ -161,7 +161,7 @@ func TestAddSqlServerDatabaseContract_InternalServerError(t *testing.T) {
 		LicensesNumber: 20,
 	}
 
-	as.EXPECT().AddSqlServerDatabaseContract(request).Return(nil, aerrMock)
+	as.EXPECT().AddSqlServerDatabaseContract(request, "").Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddSqlServerDatabaseContract)
@@ -194,7 +194,7 @@ func TestUpdateSqlServerDatabaseContract_Success(t *testing.T) {
 	}
 
 	agr := new(model.SqlServerDatabaseContract)
-	as.EXPECT().UpdateSqlServerDatabaseContract(request).Return(agr, nil)
+	as.EXPECT().UpdateSqlServerDatabaseContract(request, "").Return(agr, nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.UpdateSqlServerDatabaseContract)
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) mongoDBContractHandlers() contractHandlers[model.MongoDBContract] {
+	return contractHandlers[model.MongoDBContract]{
+		id: func(contract model.MongoDBContract) primitive.ObjectID {
+			return contract.ID
+		},
+		errLicenseTypeIDNotFound: utils.ErrMongoDBLicenseTypeIDNotFound,
+		add:                      ctrl.Service.AddMongoDBContract,
+		update:                   ctrl.Service.UpdateMongoDBContract,
+		remove:                   ctrl.Service.DeleteMongoDBContract,
+		list:                     ctrl.Service.GetMongoDBContracts,
+		listAsXLSX:               ctrl.Service.GetMongoDBContractsAsXLSX,
+	}
+}
+
+func (ctrl *APIController) AddMongoDBContract(w http.ResponseWriter, r *http.Request) {
+	addContract(ctrl, ctrl.mongoDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) UpdateMongoDBContract(w http.ResponseWriter, r *http.Request) {
+	updateContract(ctrl, ctrl.mongoDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) DeleteMongoDBContract(w http.ResponseWriter, r *http.Request) {
+	deleteContract(ctrl, ctrl.mongoDBContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) GetMongoDBContracts(w http.ResponseWriter, r *http.Request) {
+	getContracts(ctrl, ctrl.mongoDBContractHandlers(), w, r)
+}
@@ -49,7 +49,7 @@ func (ctrl *APIController) AddMySQLContract(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	contractAdded, err := ctrl.Service.AddMySQLContract(contract)
+	contractAdded, err := ctrl.Service.AddMySQLContract(contract, requestUsername(r))
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
@@ -82,7 +82,7 @@ func (ctrl *APIController) UpdateMySQLContract(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	contractUpdated, err := ctrl.Service.UpdateMySQLContract(contract)
+	contractUpdated, err := ctrl.Service.UpdateMySQLContract(contract, requestUsername(r))
 	if errors.Is(err, utils.ErrNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
@@ -145,7 +145,7 @@ func (ctrl *APIController) DeleteMySQLContract(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = ctrl.Service.DeleteMySQLContract(id)
+	err = ctrl.Service.DeleteMySQLContract(id, requestUsername(r))
 	if errors.Is(err, utils.ErrNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
@@ -61,7 +61,7 @@ func TestAddMySQLContract_Success(t *testing.T) {
 	returnAgr.ID, err = primitive.ObjectIDFromHex("aaaaaaaaaaaaaaaaaaaaaaaa")
 	require.Nil(t, err)
 
-	as.EXPECT().AddMySQLContract(contract).
+	as.EXPECT().AddMySQLContract(contract, "").
 		Return(&returnAgr, nil)
 
 	agrBytes, err := json.Marshal(contract)
@@ -228,7 +228,7 @@ func TestAddMySQLContract_InternalServerError(t *testing.T) {
 		Hosts:            []string{},
 	}
 
-	as.EXPECT().AddMySQLContract(contract).
+	as.EXPECT().AddMySQLContract(contract, "").
 		Return(nil, errMock)
 
 	agrBytes, err := json.Marshal(contract)
@@ -275,7 +275,7 @@ func TestUpdateMySQLContract_Success(t *testing.T) {
 		Hosts:            []string{},
 	}
 
-	as.EXPECT().UpdateMySQLContract(contract).
+	as.EXPECT().UpdateMySQLContract(contract, "").
 		Return(&contract, nil)
 
 	agrBytes, err := json.Marshal(contract)
@@ -408,7 +408,7 @@ func TestUpdateMySQLContract_NotFoundError(t *testing.T) {
 	}
 
 	aerr := utils.NewError(utils.ErrNotFound, "test")
-	as.EXPECT().UpdateMySQLContract(contract).
+	as.EXPECT().UpdateMySQLContract(contract, "").
 		Return(nil, aerr)
 
 	agrBytes, err := json.Marshal(contract)
@@ -458,7 +458,7 @@ func TestUpdateMySQLContract_InternalServerError(t *testing.T) {
 		Hosts:            []string{},
 	}
 
-	as.EXPECT().UpdateMySQLContract(contract).
+	as.EXPECT().UpdateMySQLContract(contract, "").
 		Return(nil, errMock)
 
 	agrBytes, err := json.Marshal(contract)
@@ -632,7 +632,7 @@ func TestDeleteMySQLContract_Success(t *testing.T) {
 		Log:     logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")).
+	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), "").
 		Return(nil)
 
 	req, err := http.NewRequest("DELETE", "/", nil)
@@ -693,7 +693,7 @@ func TestDeleteMySQLContract_NotFoundError(t *testing.T) {
 	}
 
 	aerr := utils.NewError(utils.ErrNotFound, "test")
-	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")).
+	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), "").
 		Return(aerr)
 
 	req, err := http.NewRequest("DELETE", "", nil)
@@ -729,7 +729,7 @@ func TestDeleteMySQLContract_InternalServerError(t *testing.T) {
 		Log:     logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")).
+	as.EXPECT().DeleteMySQLContract(utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"), "").
 		Return(errMock)
 
 	req, err := http.NewRequest("DELETE", "", nil)
@@ -65,7 +65,7 @@ func (ctrl *APIController) AddOracleDatabaseContract(w http.ResponseWriter, r *h
 		return
 	}
 
-	agr, err := ctrl.Service.AddOracleDatabaseContract(req)
+	agr, err := ctrl.Service.AddOracleDatabaseContract(req, requestUsername(r))
 	if errors.Is(err, utils.ErrContractNotFound) ||
 		errors.Is(err, utils.ErrOracleDatabaseLicenseTypeIDNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
@@ -104,7 +104,7 @@ func (ctrl *APIController) UpdateOracleDatabaseContract(w http.ResponseWriter, r
 		return
 	}
 
-	agr, err := ctrl.Service.UpdateOracleDatabaseContract(req)
+	agr, err := ctrl.Service.UpdateOracleDatabaseContract(req, requestUsername(r))
 	if errors.Is(err, utils.ErrContractNotFound) ||
 		errors.Is(err, utils.ErrOracleDatabaseLicenseTypeIDNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
@@ -240,7 +240,7 @@ func (ctrl *APIController) DeleteOracleDatabaseContract(w http.ResponseWriter, r
 		return
 	}
 
-	if err = ctrl.Service.DeleteOracleDatabaseContract(id); errors.Is(err, utils.ErrContractNotFound) {
+	if err = ctrl.Service.DeleteOracleDatabaseContract(id, requestUsername(r)); errors.Is(err, utils.ErrContractNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
 	} else if err != nil {
@@ -274,7 +274,7 @@ func (ctrl *APIController) AddHostToOracleDatabaseContract(w http.ResponseWriter
 	}
 	defer r.Body.Close()
 
-	if err = ctrl.Service.AddHostToOracleDatabaseContract(id, string(raw)); errors.Is(err, utils.ErrContractNotFound) ||
+	if err = ctrl.Service.AddHostToOracleDatabaseContract(id, string(raw), requestUsername(r)); errors.Is(err, utils.ErrContractNotFound) ||
 		errors.Is(err, utils.ErrNotInClusterHostNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
@@ -305,7 +305,7 @@ func (ctrl *APIController) DeleteHostFromOracleDatabaseContract(w http.ResponseW
 
 	hostname = mux.Vars(r)["hostname"]
 
-	if err = ctrl.Service.DeleteHostFromOracleDatabaseContract(id, hostname); errors.Is(err, utils.ErrContractNotFound) {
+	if err = ctrl.Service.DeleteHostFromOracleDatabaseContract(id, hostname, requestUsername(r)); errors.Is(err, utils.ErrContractNotFound) {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
 		return
 	} else if err != nil {
@@ -315,3 +315,22 @@ func (ctrl *APIController) DeleteHostFromOracleDatabaseContract(w http.ResponseW
 
 	utils.WriteJSONResponse(w, http.StatusOK, nil)
 }
+
+// GetOracleDatabaseContractsAssignmentTrace return, for each license type, the contracts in the order
+// in which they have been considered, the licenses assigned to each host and the licenses left uncovered
+func (ctrl *APIController) GetOracleDatabaseContractsAssignmentTrace(w http.ResponseWriter, r *http.Request) {
+	locations := strings.Split(r.URL.Query().Get("location"), ",")
+	licenseTypeID := r.URL.Query().Get("license-type-id")
+
+	traces, err := ctrl.Service.GetOracleDatabaseContractsAssignmentTrace(locations, licenseTypeID)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licenseTypes": traces,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
@@ -74,7 +74,7 @@ func TestAddOracleDatabaseContract_Success(t *testing.T) {
 		Restricted:      request.Restricted,
 	}
 
-	as.EXPECT().AddOracleDatabaseContract(request).Return(&result, nil)
+	as.EXPECT().AddOracleDatabaseContract(request, "").Return(&result, nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddOracleDatabaseContract)
@@ -206,7 +206,7 @@ func TestAddOracleDatabaseContract_InternalServerError(t *testing.T) {
 		Count:      20,
 	}
 
-	as.EXPECT().AddOracleDatabaseContract(request).Return(nil, aerrMock)
+	as.EXPECT().AddOracleDatabaseContract(request, "").Return(nil, aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddOracleDatabaseContract)
@@ -246,7 +246,7 @@ func TestUpdateOracleDatabaseContract_Success(t *testing.T) {
 	}
 
 	agr := new(dto.OracleDatabaseContractFE)
-	as.EXPECT().UpdateOracleDatabaseContract(request).Return(agr, nil)
+	as.EXPECT().UpdateOracleDatabaseContract(request, "").Return(agr, nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.UpdateOracleDatabaseContract)
@@ -338,7 +338,7 @@ func TestUpdateOracleDatabaseContract_InternalServerError(t *testing.T) {
 	}
 
 	t.Run("Unknown error", func(t *testing.T) {
-		as.EXPECT().UpdateOracleDatabaseContract(request).Return(nil, aerrMock)
+		as.EXPECT().UpdateOracleDatabaseContract(request, "").Return(nil, aerrMock)
 
 		rr := httptest.NewRecorder()
 		handler := http.HandlerFunc(ac.UpdateOracleDatabaseContract)
@@ -350,7 +350,7 @@ func TestUpdateOracleDatabaseContract_InternalServerError(t *testing.T) {
 		require.Equal(t, http.StatusInternalServerError, rr.Code)
 	})
 	t.Run("Contract not found", func(t *testing.T) {
-		as.EXPECT().UpdateOracleDatabaseContract(request).
+		as.EXPECT().UpdateOracleDatabaseContract(request, "").
 			Return(nil, utils.ErrContractNotFound)
 
 		rr := httptest.NewRecorder()
@@ -363,7 +363,7 @@ func TestUpdateOracleDatabaseContract_InternalServerError(t *testing.T) {
 		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 	})
 	t.Run("Invalid PartID", func(t *testing.T) {
-		as.EXPECT().UpdateOracleDatabaseContract(request).
+		as.EXPECT().UpdateOracleDatabaseContract(request, "").
 			Return(nil, utils.ErrOracleDatabaseLicenseTypeIDNotFound)
 
 		rr := httptest.NewRecorder()
@@ -611,7 +611,7 @@ func TestAddHostToAssociatedPart_Success(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(nil)
+	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddHostToOracleDatabaseContract)
@@ -725,7 +725,7 @@ func TestAddHostToAssociatedPart_FailedContractNotFound(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(utils.ErrContractNotFound)
+	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(utils.ErrContractNotFound)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddHostToOracleDatabaseContract)
@@ -755,7 +755,7 @@ func TestAddHostToAssociatedPart_FailedNotInClusterHostNotFound(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(utils.ErrNotInClusterHostNotFound)
+	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(utils.ErrNotInClusterHostNotFound)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddHostToOracleDatabaseContract)
@@ -785,7 +785,7 @@ func TestAddHostToAssociatedPart_FailedAerrOracleDatabaseContractNotFound(t *tes
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(utils.ErrContractNotFound)
+	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(utils.ErrContractNotFound)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddHostToOracleDatabaseContract)
@@ -815,7 +815,7 @@ func TestAddHostToAssociatedPart_FailedInternalServerError(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(aerrMock)
+	as.EXPECT().AddHostToOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.AddHostToOracleDatabaseContract)
@@ -845,7 +845,7 @@ func TestRemoveHostFromAssociatedPart_Success(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(nil)
+	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteHostFromOracleDatabaseContract)
@@ -934,7 +934,7 @@ func TestRemoveHostFromAssociatedPart_FailedContractNotFound(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(utils.ErrContractNotFound)
+	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(utils.ErrContractNotFound)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteHostFromOracleDatabaseContract)
@@ -965,7 +965,7 @@ func TestRemoveHostFromAssociatedPart_FailedInternalServerError(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost").Return(aerrMock)
+	as.EXPECT().DeleteHostFromOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "foohost", "").Return(aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteHostFromOracleDatabaseContract)
@@ -996,7 +996,7 @@ func TestDeleteAssociatedPartFromOracleDatabaseContract_Success(t *testing.T) {
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e")).Return(nil)
+	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "").Return(nil)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteOracleDatabaseContract)
@@ -1082,7 +1082,7 @@ func TestDeleteAssociatedPartFromOracleDatabaseContract_FailedContractNotFound(t
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e")).Return(utils.ErrContractNotFound)
+	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "").Return(utils.ErrContractNotFound)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteOracleDatabaseContract)
@@ -1112,7 +1112,7 @@ func TestDeleteAssociatedPartFromOracleDatabaseContract_FailedInternalServerErro
 		Log: logger.NewLogger("TEST"),
 	}
 
-	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e")).Return(aerrMock)
+	as.EXPECT().DeleteOracleDatabaseContract(utils.Str2oid("5f50a98611959b1baa17525e"), "").Return(aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.DeleteOracleDatabaseContract)
@@ -1251,3 +1251,63 @@ func TestGetOracleDatabaseContractsXLSX_InternalServerError1(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestGetOracleDatabaseContractsAssignmentTrace_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	traces := []dto.OracleDatabaseContractsAssignmentTrace{
+		{
+			LicenseTypeID:     "PID002",
+			UncoveredLicenses: 2,
+		},
+	}
+
+	as.EXPECT().GetOracleDatabaseContractsAssignmentTrace([]string{"Italy", "Germany"}, "PID002").
+		Return(traces, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleDatabaseContractsAssignmentTrace)
+	req, err := http.NewRequest("GET", "/contracts/oracle/database/assignment-trace?location=Italy,Germany&license-type-id=PID002", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"licenseTypes": traces,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestGetOracleDatabaseContractsAssignmentTrace_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().GetOracleDatabaseContractsAssignmentTrace([]string{""}, "").
+		Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleDatabaseContractsAssignmentTrace)
+	req, err := http.NewRequest("GET", "/contracts/oracle/database/assignment-trace", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
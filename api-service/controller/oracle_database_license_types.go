@@ -50,6 +50,32 @@ func (ctrl *APIController) GetOracleDatabaseLicensesCompliance(w http.ResponseWr
 	utils.WriteJSONResponse(w, http.StatusOK, licenses)
 }
 
+// GetOracleDatabaseContractsLicensesCompliance return list of contracts with usage and compliance of their licenses
+func (ctrl *APIController) GetOracleDatabaseContractsLicensesCompliance(w http.ResponseWriter, r *http.Request) {
+	f, err := dto.GetGlobalFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	locations := []string{}
+	if f.Location != "" {
+		locations = strings.Split(f.Location, ",")
+	}
+
+	contracts, err := ctrl.Service.GetOracleDatabaseContractsLicensesCompliance(locations)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licensesCompliance": contracts,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
 // GetOracleDatabaseLicenseTypes return the list of OracleDatabaseLicenseTypes
 func (ctrl *APIController) GetOracleDatabaseLicenseTypes(w http.ResponseWriter, r *http.Request) {
 	data, err := ctrl.Service.GetOracleDatabaseLicenseTypes()
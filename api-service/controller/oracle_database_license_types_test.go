@@ -499,3 +499,61 @@ func TestDeleteOracleDatabaseLicenseType_FailedInternalServerError(t *testing.T)
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestGetOracleDatabaseContractsLicensesCompliance_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	expectedRes := []dto.OracleDatabaseContractLicenseCompliance{
+		{ContractID: "AID001", CSI: "CSI001", LicenseTypeID: "PID001", Consumed: 8, Covered: 6, Purchased: 6, Compliance: 0.75},
+	}
+	as.EXPECT().
+		GetOracleDatabaseContractsLicensesCompliance([]string{"Italy"}).
+		Return(expectedRes, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleDatabaseContractsLicensesCompliance)
+	req, err := http.NewRequest("GET", "/?location=Italy", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"licensesCompliance": expectedRes,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestGetOracleDatabaseContractsLicensesCompliance_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().
+		GetOracleDatabaseContractsLicensesCompliance([]string{}).
+		Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetOracleDatabaseContractsLicensesCompliance)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/gddo/httputil"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetOracleULACertification return the processors and named users deployed on the date in the request
+// for each license type covered by an unlimited license agreement, as JSON or XLSX
+func (ctrl *APIController) GetOracleULACertification(w http.ResponseWriter, r *http.Request) {
+	date, err := utils.Str2time(r.URL.Query().Get("date"), utils.MAX_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	locations := strings.Split(r.URL.Query().Get("location"), ",")
+
+	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
+
+	switch choice {
+	case "application/json":
+		certification, err := ctrl.Service.GetOracleULACertification(date, locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteJSONResponse(w, http.StatusOK, certification)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		xlsx, err := ctrl.Service.GetOracleULACertificationAsXLSX(date, locations)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, xlsx)
+	}
+}
+
+// GetOracleULAUsageHistory return the historicized usage of the license types covered by an unlimited license agreement
+func (ctrl *APIController) GetOracleULAUsageHistory(w http.ResponseWriter, r *http.Request) {
+	from, err := utils.Str2time(r.URL.Query().Get("from"), utils.MIN_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	to, err := utils.Str2time(r.URL.Query().Get("to"), utils.MAX_TIME)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	history, err := ctrl.Service.GetOracleULAUsageHistory(from, to)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licenseTypes": history,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetOracleULACertification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		date := utils.P("2019-06-01T00:00:00Z")
+		as.EXPECT().GetOracleULACertification(date, []string{"Italy"}).
+			Return(&dto.OracleULACertification{Date: date}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/certification?date=2019-06-01T00:00:00Z&location=Italy", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULACertification).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Default is current hosts", func(t *testing.T) {
+		as.EXPECT().GetOracleULACertification(utils.MAX_TIME, []string{""}).
+			Return(&dto.OracleULACertification{}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/certification", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULACertification).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		xlsx := excelize.File{}
+		as.EXPECT().GetOracleULACertificationAsXLSX(utils.MAX_TIME, []string{""}).
+			Return(&xlsx, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/certification", nil)
+		require.NoError(t, err)
+
+		req.Header.Add("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULACertification).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		_, err = excelize.OpenReader(rr.Body)
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/certification?date=yesterday", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULACertification).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		as.EXPECT().GetOracleULACertification(gomock.Any(), gomock.Any()).Return(nil, errMock)
+
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/certification", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULACertification).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestGetOracleULAUsageHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockAPIServiceInterface(mockCtrl)
+	ac := APIController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		as.EXPECT().GetOracleULAUsageHistory(utils.P("2019-01-01T00:00:00Z"), utils.MAX_TIME).
+			Return([]dto.OracleULAUsageHistory{}, nil)
+
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/usage-history?from=2019-01-01T00:00:00Z", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULAUsageHistory).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid to", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/contracts/oracle/database/ula/usage-history?to=tomorrow", nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetOracleULAUsageHistory).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *APIController) postgreSQLContractHandlers() contractHandlers[model.PostgreSQLContract] {
+	return contractHandlers[model.PostgreSQLContract]{
+		id: func(contract model.PostgreSQLContract) primitive.ObjectID {
+			return contract.ID
+		},
+		errLicenseTypeIDNotFound: utils.ErrPostgreSQLLicenseTypeIDNotFound,
+		add:                      ctrl.Service.AddPostgreSQLContract,
+		update:                   ctrl.Service.UpdatePostgreSQLContract,
+		remove:                   ctrl.Service.DeletePostgreSQLContract,
+		list:                     ctrl.Service.GetPostgreSQLContracts,
+		listAsXLSX:               ctrl.Service.GetPostgreSQLContractsAsXLSX,
+	}
+}
+
+func (ctrl *APIController) AddPostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	addContract(ctrl, ctrl.postgreSQLContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) UpdatePostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	updateContract(ctrl, ctrl.postgreSQLContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) DeletePostgreSQLContract(w http.ResponseWriter, r *http.Request) {
+	deleteContract(ctrl, ctrl.postgreSQLContractHandlers(), w, r)
+}
+
+func (ctrl *APIController) GetPostgreSQLContracts(w http.ResponseWriter, r *http.Request) {
+	getContracts(ctrl, ctrl.postgreSQLContractHandlers(), w, r)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetPostgreSQLLicenseTypes return the list of PostgreSQLLicenseTypes
+func (ctrl *APIController) GetPostgreSQLLicenseTypes(w http.ResponseWriter, r *http.Request) {
+	data, err := ctrl.Service.GetPostgreSQLLicenseTypes()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"license-types": data,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// AddPostgreSQLLicenseType add a PostgreSQL license type
+func (ctrl *APIController) AddPostgreSQLLicenseType(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var req model.PostgreSQLLicenseType
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	if req.ID == "" {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(errors.New("ID must not be empty"), http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	lt, err := ctrl.Service.AddPostgreSQLLicenseType(req)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, lt)
+}
+
+// UpdatePostgreSQLLicenseType update a PostgreSQL license type
+func (ctrl *APIController) UpdatePostgreSQLLicenseType(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	var req model.PostgreSQLLicenseType
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest,
+			utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	req.ID = mux.Vars(r)["id"]
+
+	lt, err := ctrl.Service.UpdatePostgreSQLLicenseType(req)
+	if errors.Is(err, utils.ErrPostgreSQLLicenseTypeIDNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, lt)
+}
+
+// DeletePostgreSQLLicenseType remove a PostgreSQL license type
+func (ctrl *APIController) DeletePostgreSQLLicenseType(w http.ResponseWriter, r *http.Request) {
+	if ctrl.Config.APIService.ReadOnly {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.NewError(errors.New("The API is disabled because the service is put in read-only mode"), "FORBIDDEN_REQUEST"))
+		return
+	}
+
+	err := ctrl.Service.DeletePostgreSQLLicenseType(mux.Vars(r)["id"])
+	if errors.Is(err, utils.ErrPostgreSQLLicenseTypeIDNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, nil)
+}
+
+// GetPostgreSQLLicensesCompliance return the list of PostgreSQL subscriptions with usage and compliance
+func (ctrl *APIController) GetPostgreSQLLicensesCompliance(w http.ResponseWriter, r *http.Request) {
+	f, err := dto.GetGlobalFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	locations := []string{}
+	if f.Location != "" {
+		locations = strings.Split(f.Location, ",")
+	}
+
+	licenses, err := ctrl.Service.GetPostgreSQLLicensesCompliance(locations)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, licenses)
+}
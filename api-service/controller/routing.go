@@ -101,12 +101,23 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts/no-clusters", ctrl.GetVirtualHostWithoutCluster).Methods("GET")
 
 	router.HandleFunc("/hosts/{hostname}", ctrl.GetHost).Methods("GET")
+	router.HandleFunc("/hosts/{hostname}/changes", ctrl.GetHostChanges).Methods("GET")
 	router.HandleFunc("/hosts/{hostname}/create-dr", ctrl.CreateDr).Methods("PUT")
 	router.HandleFunc("/hosts/{hostname}", ctrl.DismissHost).Methods("DELETE")
 	router.HandleFunc("/hosts/{hostname}/technologies/oracle/databases/{dbname}/licenses/{licenseTypeID}/ignored/{ignored}", ctrl.UpdateLicenseIgnoredField).Methods("PUT")
 
 	router.HandleFunc("/hosts/technologies", ctrl.ListTechnologies).Methods("GET")
 
+	router.HandleFunc("/disaster-recovery/pairs", ctrl.ListDisasterRecoveryPairs).Methods("GET")
+	router.HandleFunc("/disaster-recovery/pairs", ctrl.AddDisasterRecoveryPair).Methods("POST")
+	router.HandleFunc("/disaster-recovery/pairs/{id}", ctrl.DeleteDisasterRecoveryPair).Methods("DELETE")
+
+	router.HandleFunc("/host-aliases", ctrl.ListHostAliases).Methods("GET")
+	router.HandleFunc("/host-aliases", ctrl.AddHostAlias).Methods("POST")
+	router.HandleFunc("/host-aliases/resolve", ctrl.ResolveHostname).Methods("GET")
+	router.HandleFunc("/host-aliases/{id}", ctrl.DeleteHostAlias).Methods("DELETE")
+	router.HandleFunc("/host-merges", ctrl.MergeHosts).Methods("POST")
+
 	// ALL TECHNOLOGIES
 	router.HandleFunc("/hosts/technologies/all/databases", ctrl.SearchDatabases).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/statistics", ctrl.GetDatabasesStatistics).Methods("GET")
@@ -116,6 +127,8 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-used-cluster-veritas", ctrl.ListClusterVeritasLicenses).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-used-per-cluster", ctrl.GetUsedLicensesPerCluster).Methods("GET")
 	router.HandleFunc("/hosts/technologies/all/databases/licenses-compliance", ctrl.GetDatabaseLicensesCompliance).Methods("GET")
+	router.HandleFunc("/hosts/technologies/all/databases/licenses-compliance/simulations", ctrl.SimulateLicensesCompliance).Methods("POST")
+	router.HandleFunc("/hosts/technologies/all/databases/licenses-cost", ctrl.GetLicensesCost).Methods("GET")
 
 	router.HandleFunc("/hosts/technologies/all/databases/licenses/locations", ctrl.ListLocationsLicenses).Methods("GET")
 
@@ -177,6 +190,10 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/contracts/oracle/database", ctrl.UpdateOracleDatabaseContract).Methods("PUT")
 	router.HandleFunc("/contracts/oracle/database", ctrl.GetOracleDatabaseContracts).Methods("GET")
 	router.HandleFunc("/contracts/oracle/database/{id}", ctrl.DeleteOracleDatabaseContract).Methods("DELETE")
+	router.HandleFunc("/contracts/oracle/database/ula/certification", ctrl.GetOracleULACertification).Methods("GET")
+	router.HandleFunc("/contracts/oracle/database/ula/usage-history", ctrl.GetOracleULAUsageHistory).Methods("GET")
+	router.HandleFunc("/contracts/oracle/database/assignment-trace", ctrl.GetOracleDatabaseContractsAssignmentTrace).Methods("GET")
+	router.HandleFunc("/contracts/oracle/database/licenses-compliance", ctrl.GetOracleDatabaseContractsLicensesCompliance).Methods("GET")
 
 	router.HandleFunc("/contracts/oracle/database/{id}/hosts", ctrl.AddHostToOracleDatabaseContract).Methods("POST")
 	router.HandleFunc("/contracts/oracle/database/{id}/hosts/{hostname}", ctrl.DeleteHostFromOracleDatabaseContract).Methods("DELETE")
@@ -200,15 +217,44 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/contracts/mysql/database", ctrl.GetMySQLContracts).Methods("GET")
 	router.HandleFunc("/contracts/mysql/database/{id}", ctrl.DeleteMySQLContract).Methods("DELETE")
 
+	// CONTRACT CHANGES
+	router.HandleFunc("/contracts/changes", ctrl.ListContractChanges).Methods("GET")
+	router.HandleFunc("/contracts/as-of", ctrl.GetContractsAsOf).Methods("GET")
+	router.HandleFunc("/contracts/as-of/licenses-compliance", ctrl.GetDatabaseLicensesComplianceAsOf).Methods("GET")
+
 	// SQL SERVER
 	router.HandleFunc("/hosts/technologies/microsoft/databases", ctrl.SearchSqlServerInstances).Methods("GET")
 	router.HandleFunc("/hosts/{hostname}/technologies/microsoft/databases/{dbname}/ignored/{ignored}", ctrl.UpdateSqlServerLicenseIgnoredField).Methods("PUT")
 
 	// POSTGRESQL
 	router.HandleFunc("/hosts/technologies/postgresql/databases", ctrl.SearchPostgreSqlInstances).Methods("GET")
+	router.HandleFunc("/hosts/technologies/postgresql/databases/licenses-compliance", ctrl.GetPostgreSQLLicensesCompliance).Methods("GET")
+
+	// POSTGRESQL CONTRACTS
+	router.HandleFunc("/contracts/postgresql/database", ctrl.AddPostgreSQLContract).Methods("POST")
+	router.HandleFunc("/contracts/postgresql/database", ctrl.UpdatePostgreSQLContract).Methods("PUT")
+	router.HandleFunc("/contracts/postgresql/database", ctrl.GetPostgreSQLContracts).Methods("GET")
+	router.HandleFunc("/contracts/postgresql/database/{id}", ctrl.DeletePostgreSQLContract).Methods("DELETE")
 
 	// MONGODB
 	router.HandleFunc("/hosts/technologies/mongodb/databases", ctrl.SearchMongoDBInstances).Methods("GET")
+	router.HandleFunc("/hosts/technologies/mongodb/databases/licenses-compliance", ctrl.GetMongoDBLicensesCompliance).Methods("GET")
+
+	// MONGODB CONTRACTS
+	router.HandleFunc("/contracts/mongodb/database", ctrl.AddMongoDBContract).Methods("POST")
+	router.HandleFunc("/contracts/mongodb/database", ctrl.UpdateMongoDBContract).Methods("PUT")
+	router.HandleFunc("/contracts/mongodb/database", ctrl.GetMongoDBContracts).Methods("GET")
+	router.HandleFunc("/contracts/mongodb/database/{id}", ctrl.DeleteMongoDBContract).Methods("DELETE")
+
+	// MARIADB
+	router.HandleFunc("/hosts/technologies/mariadb/databases", ctrl.SearchMariaDBInstances).Methods("GET")
+	router.HandleFunc("/hosts/technologies/mariadb/databases/licenses-compliance", ctrl.GetMariaDBLicensesCompliance).Methods("GET")
+
+	// MARIADB CONTRACTS
+	router.HandleFunc("/contracts/mariadb/database", ctrl.AddMariaDBContract).Methods("POST")
+	router.HandleFunc("/contracts/mariadb/database", ctrl.UpdateMariaDBContract).Methods("PUT")
+	router.HandleFunc("/contracts/mariadb/database", ctrl.GetMariaDBContracts).Methods("GET")
+	router.HandleFunc("/contracts/mariadb/database/{id}", ctrl.DeleteMariaDBContract).Methods("DELETE")
 
 	// ALERTS
 	router.HandleFunc("/alerts", ctrl.SearchAlerts).Methods("GET")
@@ -218,6 +264,8 @@ func (ctrl *APIController) setupProtectedRoutes(router *mux.Router) {
 
 	// UPLOADS
 	router.HandleFunc("/contracts/{databaseType}/upload", ctrl.ImportContractFromCSV).Methods("POST")
+	router.HandleFunc("/contracts/{databaseType}/upload/preview", ctrl.PreviewContractsImportFromCSV).Methods("POST")
+	router.HandleFunc("/contracts/{databaseType}/upload/commit", ctrl.CommitContractsImportFromCSV).Methods("POST")
 	router.HandleFunc("/contracts/{databaseType}/sample", ctrl.GetContractSampleCSV).Methods("GET")
 
 	// EXADATA
@@ -244,8 +292,25 @@ func (ctrl *APIController) setupSettingsRoutes(router *mux.Router) {
 	router.HandleFunc("/oracle/database/license-types/{id}", ctrl.DeleteOracleDatabaseLicenseType).Methods("DELETE")
 	router.HandleFunc("/oracle/database/license-types", ctrl.AddOracleDatabaseLicenseType).Methods("POST")
 	router.HandleFunc("/oracle/database/license-types/{id}", ctrl.UpdateOracleDatabaseLicenseType).Methods("PUT")
+	router.HandleFunc("/oracle/core-factor-policies", ctrl.ListCoreFactorPolicies).Methods("GET")
+	router.HandleFunc("/oracle/core-factor-policies", ctrl.AddCoreFactorPolicy).Methods("POST")
+	router.HandleFunc("/oracle/core-factor-policies/active", ctrl.GetActiveCoreFactorPolicy).Methods("GET")
+	router.HandleFunc("/oracle/core-factor-policies/{version}", ctrl.GetCoreFactorPolicy).Methods("GET")
+	router.HandleFunc("/oracle/core-factor-policies/{version}/activate", ctrl.ActivateCoreFactorPolicy).Methods("POST")
 	router.HandleFunc("/microsoft/database/license-types", ctrl.GetSqlServerDatabaseLicenseTypes).Methods("GET")
 	router.HandleFunc("/mysql/database/license-types", ctrl.GetMySqlLicenseTypes).Methods("GET")
+	router.HandleFunc("/postgresql/database/license-types", ctrl.GetPostgreSQLLicenseTypes).Methods("GET")
+	router.HandleFunc("/postgresql/database/license-types", ctrl.AddPostgreSQLLicenseType).Methods("POST")
+	router.HandleFunc("/postgresql/database/license-types/{id}", ctrl.UpdatePostgreSQLLicenseType).Methods("PUT")
+	router.HandleFunc("/postgresql/database/license-types/{id}", ctrl.DeletePostgreSQLLicenseType).Methods("DELETE")
+	router.HandleFunc("/mongodb/database/license-types", ctrl.GetMongoDBLicenseTypes).Methods("GET")
+	router.HandleFunc("/mongodb/database/license-types", ctrl.AddMongoDBLicenseType).Methods("POST")
+	router.HandleFunc("/mongodb/database/license-types/{id}", ctrl.UpdateMongoDBLicenseType).Methods("PUT")
+	router.HandleFunc("/mongodb/database/license-types/{id}", ctrl.DeleteMongoDBLicenseType).Methods("DELETE")
+	router.HandleFunc("/mariadb/database/license-types", ctrl.GetMariaDBLicenseTypes).Methods("GET")
+	router.HandleFunc("/mariadb/database/license-types", ctrl.AddMariaDBLicenseType).Methods("POST")
+	router.HandleFunc("/mariadb/database/license-types/{id}", ctrl.UpdateMariaDBLicenseType).Methods("PUT")
+	router.HandleFunc("/mariadb/database/license-types/{id}", ctrl.DeleteMariaDBLicenseType).Methods("DELETE")
 }
 
 func (ctrl *APIController) setupFrontendAPIRoutes(router *mux.Router) {
@@ -266,6 +331,24 @@ func (ctrl *APIController) setupAdminRoutes(router *mux.Router) {
 	router.HandleFunc("/roles/{roleName}", middleware.Admin(ctrl.UpdateRole)).Methods("PUT")
 	router.HandleFunc("/roles/{roleName}", middleware.Admin(ctrl.RemoveRole)).Methods("DELETE")
 
+	// AGENT CREDENTIALS
+	router.HandleFunc("/agent-credentials", middleware.Admin(ctrl.ListAgentCredentials)).Methods("GET")
+	router.HandleFunc("/agent-credentials", middleware.Admin(ctrl.AddAgentCredential)).Methods("POST")
+	router.HandleFunc("/agent-credentials/{id}/rotate", middleware.Admin(ctrl.RotateAgentCredentialKey)).Methods("POST")
+	router.HandleFunc("/agent-credentials/{id}", middleware.Admin(ctrl.RevokeAgentCredential)).Methods("DELETE")
+
+	// DATA-SERVICE
+	router.HandleFunc("/cmdbs", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET", "POST")
+	router.HandleFunc("/cmdbs/{name}", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET")
+	router.HandleFunc("/cmdbs/{name}/csv", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("POST")
+	router.HandleFunc("/oracle/license-reprocessing", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("POST")
+	router.HandleFunc("/hostdata-queue", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET")
+	router.HandleFunc("/hostdata-queue/dead-letters", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET")
+	router.HandleFunc("/hostdata-quarantine", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET")
+	router.HandleFunc("/hostdata-quarantine/replay", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("POST")
+	router.HandleFunc("/hostdata-quarantine/{id}", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("GET", "PUT")
+	router.HandleFunc("/hostdata-quarantine/{id}/replay", middleware.Admin(ctrl.ForwardToDataServiceAdmin)).Methods("POST")
+
 	// NODES
 	router.HandleFunc("/nodes", ctrl.AddNode).Methods("POST")
 	router.HandleFunc("/nodes/{name}", ctrl.GetNode).Methods("GET")
@@ -19,44 +19,52 @@ import (
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strings"
 
 	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/golang/gddo/httputil"
 	"github.com/gorilla/mux"
 )
 
 const (
-	ORACLE    = "oracle"
-	SQLSERVER = "sqlserver"
-	MYSQL     = "mysql"
+	ORACLE     = "oracle"
+	SQLSERVER  = "sqlserver"
+	MYSQL      = "mysql"
+	POSTGRESQL = "postgresql"
+	MONGODB    = "mongodb"
+	MARIADB    = "mariadb"
 )
 
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
 func (ctrl *APIController) ImportContractFromCSV(w http.ResponseWriter, r *http.Request) {
-	file, _, err := r.FormFile("file")
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+	reader, file, databaseType, ok := ctrl.contractsReaderFromRequest(w, r)
+	if !ok {
 		return
 	}
 
 	defer file.Close()
 
-	databaseType := mux.Vars(r)["databaseType"]
-	if databaseType != ORACLE && databaseType != SQLSERVER && databaseType != MYSQL {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("invalid database type in param"))
-		return
-	}
-
 	c := make(chan error)
+	user := requestUsername(r)
 
-	reader := csv.NewReader(file)
 	go func(reader *csv.Reader) {
 		switch databaseType {
 		case "oracle":
-			c <- ctrl.Service.ImportOracleDatabaseContracts(reader)
+			c <- ctrl.Service.ImportOracleDatabaseContracts(reader, user)
 		case "sqlserver":
-			c <- ctrl.Service.ImportSQLServerDatabaseContracts(reader)
+			c <- ctrl.Service.ImportSQLServerDatabaseContracts(reader, user)
 		case "mysql":
-			c <- ctrl.Service.ImportMySQLDatabaseContracts(reader)
+			c <- ctrl.Service.ImportMySQLDatabaseContracts(reader, user)
+		case "postgresql":
+			c <- ctrl.Service.ImportPostgreSQLContracts(reader, user)
+		case "mongodb":
+			c <- ctrl.Service.ImportMongoDBContracts(reader, user)
+		case "mariadb":
+			c <- ctrl.Service.ImportMariaDBContracts(reader, user)
 		}
 	}(reader)
 
@@ -68,13 +76,109 @@ func (ctrl *APIController) ImportContractFromCSV(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusOK)
 }
 
+func (ctrl *APIController) PreviewContractsImportFromCSV(w http.ResponseWriter, r *http.Request) {
+	reader, file, databaseType, ok := ctrl.contractsReaderFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	defer file.Close()
+
+	preview, err := ctrl.Service.PreviewContractsImport(databaseType, reader)
+	if errors.Is(err, utils.ErrInvalidContractImport) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, preview)
+}
+
+func (ctrl *APIController) CommitContractsImportFromCSV(w http.ResponseWriter, r *http.Request) {
+	reader, file, databaseType, ok := ctrl.contractsReaderFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	defer file.Close()
+
+	preview, err := ctrl.Service.CommitContractsImport(databaseType, reader, r.FormValue("hash"), requestUsername(r))
+	if errors.Is(err, utils.ErrInvalidContractImport) && preview != nil {
+		ctrl.Log.Warn(err)
+		utils.WriteJSONResponse(w, http.StatusUnprocessableEntity, preview)
+
+		return
+	} else if errors.Is(err, utils.ErrContractImportPreviewChanged) {
+		ctrl.Log.Warn(err)
+		utils.WriteJSONResponse(w, http.StatusConflict, preview)
+
+		return
+	} else if errors.Is(err, utils.ErrInvalidContractImport) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	} else if errors.Is(err, utils.ErrTransactionsNotSupported) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotImplemented, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, preview)
+}
+
+// contractsReaderFromRequest return the reader of the uploaded csv or xlsx and its database type,
+// writing the error response if they aren't valid
+func (ctrl *APIController) contractsReaderFromRequest(w http.ResponseWriter, r *http.Request) (*csv.Reader, multipart.File, string, bool) {
+	databaseType := mux.Vars(r)["databaseType"]
+	if databaseType != ORACLE && databaseType != SQLSERVER && databaseType != MYSQL && databaseType != POSTGRESQL && databaseType != MONGODB && databaseType != MARIADB {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("invalid database type in param"))
+		return nil, nil, "", false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return nil, nil, "", false
+	}
+
+	if !strings.EqualFold(filepath.Ext(header.Filename), ".xlsx") && header.Header.Get("Content-Type") != xlsxContentType {
+		return csv.NewReader(file), file, databaseType, true
+	}
+
+	reader, err := ctrl.Service.ReadContractsXLSX(databaseType, file)
+	if err != nil {
+		file.Close()
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+
+		return nil, nil, "", false
+	}
+
+	return reader, file, databaseType, true
+}
+
 func (ctrl *APIController) GetContractSampleCSV(w http.ResponseWriter, r *http.Request) {
 	databaseType := mux.Vars(r)["databaseType"]
-	if databaseType != ORACLE && databaseType != SQLSERVER && databaseType != MYSQL {
+	if databaseType != ORACLE && databaseType != SQLSERVER && databaseType != MYSQL && databaseType != POSTGRESQL && databaseType != MONGODB && databaseType != MARIADB {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, errors.New("invalid database type in param"))
 		return
 	}
 
+	if httputil.NegotiateContentType(r, []string{"text/csv", xlsxContentType}, "text/csv") == xlsxContentType {
+		xlsx, err := ctrl.Service.GetLicenseContractSampleXLSX(databaseType)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sample_%s_contracts.xlsx", databaseType))
+		utils.WriteXLSXResponse(w, xlsx)
+
+		return
+	}
+
 	res, err := ctrl.Service.GetLicenseContractSample(databaseType)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
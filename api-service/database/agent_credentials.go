@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const agentCredentialCollection = "agent_credentials"
+
+func (md *MongoDatabase) ListAgentCredentials() ([]model.AgentCredential, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.AgentCredential, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) GetAgentCredential(id primitive.ObjectID) (*model.AgentCredential, error) {
+	return md.findOneAgentCredential(bson.M{"_id": id})
+}
+
+func (md *MongoDatabase) GetAgentCredentialByName(name string) (*model.AgentCredential, error) {
+	return md.findOneAgentCredential(bson.M{"name": name})
+}
+
+func (md *MongoDatabase) findOneAgentCredential(filter bson.M) (*model.AgentCredential, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		FindOne(context.TODO(), filter)
+	if res.Err() == mongo.ErrNoDocuments {
+		return nil, utils.ErrAgentCredentialNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	result := &model.AgentCredential{}
+	if err := res.Decode(result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) InsertAgentCredential(credential model.AgentCredential) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		InsertOne(context.TODO(), credential)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) UpdateAgentCredentialKey(id primitive.ObjectID, keyHash string, rotatedAt time.Time) error {
+	return md.updateAgentCredential(id, bson.M{
+		"keyHash":   keyHash,
+		"rotatedAt": rotatedAt,
+	})
+}
+
+func (md *MongoDatabase) RevokeAgentCredential(id primitive.ObjectID, revokedAt time.Time) error {
+	return md.updateAgentCredential(id, bson.M{"revokedAt": revokedAt})
+}
+
+func (md *MongoDatabase) updateAgentCredential(id primitive.ObjectID, set bson.M) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.MatchedCount != 1 {
+		return utils.ErrAgentCredentialNotFound
+	}
+
+	return nil
+}
@@ -27,6 +27,7 @@ const (
 	sqlServerPipelinePathMatch  = "$features.microsoft.sqlServer.instances"
 	postgresqlPipelinePathMatch = "$features.postgresql.instances"
 	mongoPipelinePathMatch      = "$features.mongodb.instances"
+	mariadbPipelinePathMatch    = "$features.mariadb.instances"
 )
 
 func (md *MongoDatabase) CountAllHost() (int64, error) {
@@ -143,6 +144,26 @@ func (md *MongoDatabase) CountMongoDbHostsByLocations(locations []string) (int64
 	return md.count(pipeline)
 }
 
+func (md *MongoDatabase) CountMariaDbInstance() (int64, error) {
+	pipeline := md.getCountInstancePipeline(mariadbPipelinePathMatch)
+	return md.count(pipeline)
+}
+
+func (md *MongoDatabase) CountMariaDbInstanceByLocations(locations []string) (int64, error) {
+	pipeline := md.getCountInstancePipeline(mariadbPipelinePathMatch, locations...)
+	return md.count(pipeline)
+}
+
+func (md *MongoDatabase) CountMariaDbHosts() (int64, error) {
+	pipeline := md.getCountHostPipeline(mariadbPipelinePathMatch)
+	return md.count(pipeline)
+}
+
+func (md *MongoDatabase) CountMariaDbHostsByLocations(locations []string) (int64, error) {
+	pipeline := md.getCountHostPipeline(mariadbPipelinePathMatch, locations...)
+	return md.count(pipeline)
+}
+
 func (md *MongoDatabase) getCountInstancePipeline(path string, locations ...string) bson.A {
 	match := bson.D{{Key: "archived", Value: false}}
 	if len(locations) > 0 {
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	contractChangesCollection        = "contract_changes"
+	contractChangeVersionsCollection = "contract_change_versions"
+)
+
+var contractCollections = map[string]string{
+	model.TechnologyOracleDatabase:           oracleDbContractsCollection,
+	model.TechnologyMicrosoftSQLServer:       sqlServerDbContractsCollection,
+	model.TechnologyOracleMySQL:              mySQLContractCollection,
+	model.TechnologyPostgreSQLPostgreSQL:     postgreSQLContractsCollection,
+	model.TechnologyMongoDBMongoDB:           mongoDBContractsCollection,
+	model.TechnologyMariaDBFoundationMariaDB: mariaDBContractsCollection,
+}
+
+// contractTechnologies return the technologies with contracts, sorted
+func contractTechnologies() []string {
+	technologies := make([]string, 0, len(contractCollections))
+	for technology := range contractCollections {
+		technologies = append(technologies, technology)
+	}
+
+	sort.Strings(technologies)
+
+	return technologies
+}
+
+// GetContractSnapshot return the document of the contract as it's saved in the database, nil if it doesn't exist
+func (md *MongoDatabase) GetContractSnapshot(technology string, id primitive.ObjectID) (map[string]interface{}, error) {
+	return md.getContractSnapshot(md.sessionContext(), technology, id)
+}
+
+func (md *MongoDatabase) getContractSnapshot(ctx context.Context, technology string, id primitive.ObjectID) (map[string]interface{}, error) {
+	collection, ok := contractCollections[technology]
+	if !ok {
+		return nil, utils.NewErrorf("%w: unknown technology %s", utils.ErrInvalidContractChange, technology)
+	}
+
+	var doc map[string]interface{}
+
+	err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		FindOne(ctx, bson.M{"_id": id}).
+		Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return doc, nil
+}
+
+// ListContractSnapshots return the documents of all the contracts of the technology as they're saved in the database
+func (md *MongoDatabase) ListContractSnapshots(technology string) ([]map[string]interface{}, error) {
+	collection, ok := contractCollections[technology]
+	if !ok {
+		return nil, utils.NewErrorf("%w: unknown technology %s", utils.ErrInvalidContractChange, technology)
+	}
+
+	ctx := md.sessionContext()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	docs := make([]map[string]interface{}, 0)
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return docs, nil
+}
+
+// InsertContractChange insert the change as the next version of the contract
+func (md *MongoDatabase) InsertContractChange(change model.ContractChange) error {
+	return md.insertContractChange(md.sessionContext(), change)
+}
+
+func (md *MongoDatabase) insertContractChange(ctx context.Context, change model.ContractChange) error {
+	db := md.Client.Database(md.Config.Mongodb.DBName)
+
+	var counter struct {
+		Version int `bson:"version"`
+	}
+
+	err := db.Collection(contractChangeVersionsCollection).
+		FindOneAndUpdate(ctx,
+			bson.M{"_id": change.ContractObjectID},
+			bson.M{"$inc": bson.M{"version": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).
+		Decode(&counter)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	change.Version = counter.Version
+
+	if _, err := db.Collection(contractChangesCollection).InsertOne(ctx, change); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// ListContractChanges return the contract changes that match the filter, sorted by date
+func (md *MongoDatabase) ListContractChanges(filter dto.ContractChangesFilter) ([]model.ContractChange, error) {
+	ctx := context.TODO()
+
+	query := bson.M{
+		"date": bson.M{
+			"$gte": filter.From,
+			"$lte": filter.To,
+		},
+	}
+
+	if filter.Technology != "" {
+		query["technology"] = filter.Technology
+	}
+
+	if filter.ContractObjectID != nil {
+		query["contractObjectID"] = *filter.ContractObjectID
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(contractChangesCollection).
+		Find(ctx, query, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	changes := make([]model.ContractChange, 0)
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return changes, nil
+}
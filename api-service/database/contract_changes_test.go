@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestInsertContractChange() {
+	defer m.db.Client.Database(m.dbname).Collection(contractChangesCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(contractChangeVersionsCollection).DeleteMany(context.TODO(), bson.M{})
+
+	contractID := utils.Str2oid("000000000000000000000001")
+
+	m.T().Run("Concurrent changes get different versions", func(t *testing.T) {
+		const changes = 10
+
+		var wg sync.WaitGroup
+
+		errs := make(chan error, changes)
+
+		for i := 0; i < changes; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				errs <- m.db.InsertContractChange(model.ContractChange{
+					ID:               primitive.NewObjectID(),
+					Technology:       model.TechnologyOracleMySQL,
+					ContractObjectID: contractID,
+					Operation:        model.ContractChangeOperationUpdate,
+					User:             "admin",
+					Date:             utils.P("2020-12-05T14:02:03Z"),
+				})
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		actual, err := m.db.ListContractChanges(dto.ContractChangesFilter{
+			ContractObjectID: &contractID,
+			From:             utils.MIN_TIME,
+			To:               utils.MAX_TIME,
+		})
+		require.NoError(t, err)
+
+		versions := make([]int, 0, len(actual))
+		for _, change := range actual {
+			versions = append(versions, change.Version)
+		}
+
+		sort.Ints(versions)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, versions)
+	})
+
+	m.T().Run("Change in a transaction", func(t *testing.T) {
+		err := m.db.WithTransaction(func(db MongoDatabaseInterface) error {
+			return db.InsertContractChange(model.ContractChange{
+				ID:               primitive.NewObjectID(),
+				Technology:       model.TechnologyOracleMySQL,
+				ContractObjectID: contractID,
+				Operation:        model.ContractChangeOperationDelete,
+				User:             "admin",
+				Date:             utils.P("2020-12-06T14:02:03Z"),
+			})
+		})
+		if errors.Is(err, utils.ErrTransactionsNotSupported) {
+			t.Skip(err)
+		}
+
+		require.NoError(t, err)
+
+		actual, err := m.db.ListContractChanges(dto.ContractChangesFilter{
+			ContractObjectID: &contractID,
+			From:             utils.P("2020-12-06T00:00:00Z"),
+			To:               utils.MAX_TIME,
+		})
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, 11, actual[0].Version)
+	})
+}
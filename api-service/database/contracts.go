@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// insertContract saves a new contract of any technology in collection
+func insertContract[T any](md *MongoDatabase, collection string, contract T) (*T, error) {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		InsertOne(md.sessionContext(), contract)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &contract, nil
+}
+
+// updateContract replaces the contract of any technology with the given id in collection
+func updateContract[T any](md *MongoDatabase, collection string, id primitive.ObjectID, contract T) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		ReplaceOne(md.sessionContext(), bson.M{
+			"_id": id,
+		}, contract)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrContractNotFound
+	}
+
+	return nil
+}
+
+func removeContract(md *MongoDatabase, collection string, id primitive.ObjectID) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		DeleteOne(md.sessionContext(), bson.M{
+			"_id": id,
+		})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.DeletedCount == 0 {
+		return utils.ErrContractNotFound
+	}
+
+	return nil
+}
+
+// listContracts return the contracts of any technology in collection that are in locations
+func listContracts[T any](md *MongoDatabase, collection string, locations []string) ([]T, error) {
+	ctx := md.sessionContext()
+	out := make([]T, 0)
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		Aggregate(ctx, filterExistingLocations(locations))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	if err = cur.All(ctx, &out); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return out, nil
+}
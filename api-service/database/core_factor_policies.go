@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const coreFactorPolicyCollection = "core_factor_policies"
+
+func (md *MongoDatabase) ListCoreFactorPolicies() ([]model.CoreFactorPolicy, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(coreFactorPolicyCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"version": -1}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.CoreFactorPolicy, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) GetCoreFactorPolicy(version int) (*model.CoreFactorPolicy, error) {
+	return md.findCoreFactorPolicy(bson.M{"version": version})
+}
+
+// GetActiveCoreFactorPolicy return the active core factor policy, nil if no policy has been saved
+func (md *MongoDatabase) GetActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error) {
+	policy, err := md.findCoreFactorPolicy(bson.M{"active": true})
+	if errors.Is(err, utils.ErrCoreFactorPolicyNotFound) {
+		return nil, nil
+	}
+
+	return policy, err
+}
+
+func (md *MongoDatabase) findCoreFactorPolicy(filter bson.M) (*model.CoreFactorPolicy, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(coreFactorPolicyCollection).
+		FindOne(context.TODO(), filter)
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, utils.ErrCoreFactorPolicyNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var policy model.CoreFactorPolicy
+	if err := res.Decode(&policy); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	if err := policy.Compile(); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &policy, nil
+}
+
+func (md *MongoDatabase) InsertCoreFactorPolicy(policy model.CoreFactorPolicy) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(coreFactorPolicyCollection).
+		InsertOne(context.TODO(), policy)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// ActivateCoreFactorPolicy makes the policy with version the active one, deactivating the others
+func (md *MongoDatabase) ActivateCoreFactorPolicy(version int) error {
+	ctx := context.TODO()
+	collection := md.Client.Database(md.Config.Mongodb.DBName).Collection(coreFactorPolicyCollection)
+
+	res, err := collection.UpdateOne(ctx, bson.M{"version": version}, bson.M{"$set": bson.M{"active": true}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.MatchedCount == 0 {
+		return utils.ErrCoreFactorPolicyNotFound
+	}
+
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"version": bson.M{"$ne": version}, "active": true},
+		bson.M{"$set": bson.M{"active": false}}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
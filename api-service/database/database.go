@@ -49,6 +49,8 @@ type MongoDatabaseInterface interface {
 	GetHost(hostname string, olderThan time.Time, raw bool) (*dto.HostData, error)
 	GetHostData(hostname string, olderThan time.Time) (*model.HostDataBE, error)
 	GetHostDatas(filter dto.GlobalFilter) ([]model.HostDataBE, error)
+	// GetHostChanges return the changes of the host between from and to, filtered by kinds if not empty
+	GetHostChanges(hostname string, kinds []string, from, to time.Time) ([]model.HostChange, error)
 	// SearchAlerts search alerts
 	SearchAlerts(alertFilter alert_filter.Alert) (*dto.Pagination, error)
 	// GetAlerts get alerts
@@ -139,6 +141,24 @@ type MongoDatabaseInterface interface {
 	// UpdateLicenseIgnoredField update license ignored field (true/false)
 	UpdateLicenseIgnoredField(hostname string, dbname string, licenseTypeID string, ignored bool, ignoredComment string) error
 
+	// GetContractSnapshot return the document of the contract as it's saved in the database, nil if it doesn't exist
+	GetContractSnapshot(technology string, id primitive.ObjectID) (map[string]interface{}, error)
+	// ListContractSnapshots return the documents of all the contracts of the technology as they're saved in the database
+	ListContractSnapshots(technology string) ([]map[string]interface{}, error)
+	// InsertContractChange insert the change as the next version of the contract, taken from a counter of the versions
+	// of the contract incremented atomically
+	InsertContractChange(change model.ContractChange) error
+	// WithTransaction executes fn in a transaction, the operations of the db passed to fn are part of it.
+	// Only the operations on the contracts and their changes support transactions
+	WithTransaction(fn func(db MongoDatabaseInterface) error) error
+	// ListContractChanges return the contract changes that match the filter, sorted by date
+	ListContractChanges(filter dto.ContractChangesFilter) ([]model.ContractChange, error)
+	// GetLicensesComplianceHistory return the historicized compliance of the license types between from and to
+	GetLicensesComplianceHistory(licenseTypeIDs []string, from, to time.Time) ([]dto.LicenseComplianceHistory, error)
+	// GetLastLocationsLicensesCompliance return the compliance of the license types of the locations
+	// on the last day they were historicized
+	GetLastLocationsLicensesCompliance(locations []string) ([]dto.LocationLicenseCompliance, error)
+
 	// InsertOracleDatabaseLicenseType insert an Oracle/Database license type into the database
 	InsertOracleDatabaseLicenseType(licenseType model.OracleDatabaseLicenseType) error
 	// UpdateOracleDatabaseLicenseType update an Oracle/Database license type in the database
@@ -173,8 +193,30 @@ type MongoDatabaseInterface interface {
 	CountAlertsNODATA(alertsFilter dto.AlertsFilter) (int64, error)
 	// DismissHost dismiss the specified host
 	DismissHost(hostname string) error
-	// CreateDR create a clone of the host as a disaster recovery
-	CreateDR(hostname string) (string, error)
+	// CreateDR create a clone of the host as a disaster recovery, named according to pairs
+	CreateDR(hostname string, pairs []model.DisasterRecoveryPair) (string, error)
+	ListDisasterRecoveryPairs() ([]model.DisasterRecoveryPair, error)
+	InsertDisasterRecoveryPair(pair model.DisasterRecoveryPair) error
+	DeleteDisasterRecoveryPair(id primitive.ObjectID) error
+	// FindCurrentHostnames return the hostnames of the current hosts that are, or aren't, disaster recovery
+	FindCurrentHostnames(isDR bool) ([]string, error)
+	// FindCurrentClusterNames return the names of the clusters of the current hosts that aren't disaster recovery
+	FindCurrentClusterNames() ([]string, error)
+	ListHostAliases() ([]model.HostAlias, error)
+	InsertHostAlias(alias model.HostAlias) error
+	DeleteHostAlias(id primitive.ObjectID) error
+	// MergeHost renames the host from to the host to in the hostdata, host changes, alerts, contracts
+	// and aliases, then saves alias as the alias of from. The current hostdata of from is archived if to has one.
+	// Every contract changed is saved as a change made by user on the creation date of alias, identified by newObjectID.
+	// Everything is done in a transaction
+	MergeHost(from, to string, alias model.HostAlias, user string, newObjectID func() primitive.ObjectID) error
+	ListCoreFactorPolicies() ([]model.CoreFactorPolicy, error)
+	GetCoreFactorPolicy(version int) (*model.CoreFactorPolicy, error)
+	// GetActiveCoreFactorPolicy return the active core factor policy, nil if no policy has been saved
+	GetActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error)
+	InsertCoreFactorPolicy(policy model.CoreFactorPolicy) error
+	// ActivateCoreFactorPolicy makes the policy with version the active one, deactivating the others
+	ActivateCoreFactorPolicy(version int) error
 	// GetHostMinValidCreatedAtDate get the host's minimun valid CreatedAt date
 	GetHostMinValidCreatedAtDate(hostname string) (time.Time, error)
 	// GetListValidHostsByRangeDates get list of valid hosts by range dates
@@ -235,9 +277,48 @@ type MongoDatabaseInterface interface {
 	// POSTGRESQL
 	SearchPostgreSqlInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.PostgreSqlInstanceResponse, error)
 
+	GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error)
+	GetPostgreSQLLicenseType(id string) (*model.PostgreSQLLicenseType, error)
+	InsertPostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) error
+	UpdatePostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) error
+	RemovePostgreSQLLicenseType(id string) error
+
+	InsertPostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error)
+	ListPostgreSQLContracts(locations []string) ([]model.PostgreSQLContract, error)
+	RemovePostgreSQLContract(id primitive.ObjectID) error
+	UpdatePostgreSQLContract(contract model.PostgreSQLContract) error
+	GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error)
+
 	// MONGODB
 	SearchMongoDBInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.MongoDBInstanceResponse, error)
 
+	GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error)
+	GetMongoDBLicenseType(id string) (*model.MongoDBLicenseType, error)
+	InsertMongoDBLicenseType(licenseType model.MongoDBLicenseType) error
+	UpdateMongoDBLicenseType(licenseType model.MongoDBLicenseType) error
+	RemoveMongoDBLicenseType(id string) error
+
+	InsertMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error)
+	ListMongoDBContracts(locations []string) ([]model.MongoDBContract, error)
+	RemoveMongoDBContract(id primitive.ObjectID) error
+	UpdateMongoDBContract(contract model.MongoDBContract) error
+	GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error)
+
+	// MARIADB
+	SearchMariaDBInstances(keywords []string, sortBy string, sortDesc bool, page int, pageSize int, location string, environment string, olderThan time.Time) (*dto.MariaDBInstanceResponse, error)
+
+	GetMariaDBLicenseTypes() ([]model.MariaDBLicenseType, error)
+	GetMariaDBLicenseType(id string) (*model.MariaDBLicenseType, error)
+	InsertMariaDBLicenseType(licenseType model.MariaDBLicenseType) error
+	UpdateMariaDBLicenseType(licenseType model.MariaDBLicenseType) error
+	RemoveMariaDBLicenseType(id string) error
+
+	InsertMariaDBContract(contract model.MariaDBContract) (*model.MariaDBContract, error)
+	ListMariaDBContracts(locations []string) ([]model.MariaDBContract, error)
+	RemoveMariaDBContract(id primitive.ObjectID) error
+	UpdateMariaDBContract(contract model.MariaDBContract) error
+	GetMariaDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MariaDBUsedLicense, error)
+
 	// ROLES
 	GetRole(name string) (*model.Role, error)
 	GetRoles() ([]model.Role, error)
@@ -263,6 +344,14 @@ type MongoDatabaseInterface interface {
 	UpdatePassword(username string, password string, salt string) error
 	GetUserLocations(username string) ([]string, error)
 
+	// AGENT CREDENTIALS
+	ListAgentCredentials() ([]model.AgentCredential, error)
+	GetAgentCredential(id primitive.ObjectID) (*model.AgentCredential, error)
+	GetAgentCredentialByName(name string) (*model.AgentCredential, error)
+	InsertAgentCredential(credential model.AgentCredential) error
+	UpdateAgentCredentialKey(id primitive.ObjectID, keyHash string, rotatedAt time.Time) error
+	RevokeAgentCredential(id primitive.ObjectID, revokedAt time.Time) error
+
 	// TREE
 	GetNodesByRoles(roles []string) ([]model.Node, error)
 	GetNodeByName(name string) (*model.Node, error)
@@ -316,6 +405,12 @@ type MongoDatabaseInterface interface {
 	CountMongoDbHosts() (int64, error)
 	CountMongoDbHostsByLocations(locations []string) (int64, error)
 
+	CountMariaDbInstance() (int64, error)
+	CountMariaDbInstanceByLocations(locations []string) (int64, error)
+
+	CountMariaDbHosts() (int64, error)
+	CountMariaDbHostsByLocations(locations []string) (int64, error)
+
 	FindClusterVeritasLicenses(filter dto.GlobalFilter) ([]dto.ClusterVeritasLicense, error)
 }
 
@@ -331,6 +426,8 @@ type MongoDatabase struct {
 	OperatingSystemAggregationRules []config.AggregationRule
 	// Log contains logger formatted
 	Log logger.Logger
+	// sessionCtx is the context of the transaction the operations are part of, nil outside transactions
+	sessionCtx context.Context
 }
 
 // Init initializes the connection to the database
@@ -346,6 +443,8 @@ func (md *MongoDatabase) Init() {
 	if err := md.MigrateConfig(); err != nil {
 		md.Log.Error(err)
 	}
+
+	md.checkTransactionsSupport()
 }
 
 // ConnectToMongodb connects to the MongoDB and return the connection
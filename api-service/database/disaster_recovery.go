@@ -18,16 +18,18 @@ package database
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (md *MongoDatabase) CreateDR(hostname string) (string, error) {
+const disasterRecoveryPairCollection = "disaster_recovery_pairs"
+
+func (md *MongoDatabase) CreateDR(hostname string, pairs []model.DisasterRecoveryPair) (string, error) {
 	filter := bson.M{"archived": false, "isDR": false, "hostname": hostname}
 
 	res := md.Client.Database(md.Config.Mongodb.DBName).
@@ -46,12 +48,12 @@ func (md *MongoDatabase) CreateDR(hostname string) (string, error) {
 	}
 
 	host.ID = primitive.NewObjectID()
-	host.Hostname = fmt.Sprintf("%s_DR", hostname)
+	host.Hostname = model.DRHostname(pairs, hostname)
 	host.IsDR = true
 
 	if host.ClusterMembershipStatus.VeritasClusterServer {
 		for i := 0; i < len(host.ClusterMembershipStatus.VeritasClusterHostnames); i++ {
-			host.ClusterMembershipStatus.VeritasClusterHostnames[i] = fmt.Sprintf("%s_DR", host.ClusterMembershipStatus.VeritasClusterHostnames[i])
+			host.ClusterMembershipStatus.VeritasClusterHostnames[i] = model.DRHostname(pairs, host.ClusterMembershipStatus.VeritasClusterHostnames[i])
 		}
 	}
 
@@ -63,3 +65,95 @@ func (md *MongoDatabase) CreateDR(hostname string) (string, error) {
 
 	return host.Hostname, nil
 }
+
+func (md *MongoDatabase) ListDisasterRecoveryPairs() ([]model.DisasterRecoveryPair, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(disasterRecoveryPairCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"primary": 1}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.DisasterRecoveryPair, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	for i := range result {
+		if err := result[i].Compile(); err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) InsertDisasterRecoveryPair(pair model.DisasterRecoveryPair) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(disasterRecoveryPairCollection).
+		InsertOne(context.TODO(), pair)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) DeleteDisasterRecoveryPair(id primitive.ObjectID) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(disasterRecoveryPairCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.DeletedCount == 0 {
+		return utils.ErrDisasterRecoveryPairNotFound
+	}
+
+	return nil
+}
+
+// FindCurrentHostnames return the hostnames of the current hosts that are, or aren't, disaster recovery
+func (md *MongoDatabase) FindCurrentHostnames(isDR bool) ([]string, error) {
+	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostCollection).
+		Distinct(context.TODO(), "hostname", bson.M{
+			"archived":    false,
+			"dismissedAt": nil,
+			"isDR":        isDR,
+		})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	hostnames := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if hostname, ok := value.(string); ok {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	return hostnames, nil
+}
+
+func (md *MongoDatabase) FindCurrentClusterNames() ([]string, error) {
+	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostCollection).
+		Distinct(context.TODO(), "clusters.name", bson.M{
+			"archived":    false,
+			"dismissedAt": nil,
+			"isDR":        false,
+		})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	names := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if name, ok := value.(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostChangesCollection = "host_changes"
+
+// GetHostChanges return the changes of the host between from and to, filtered by kinds if not empty
+func (md *MongoDatabase) GetHostChanges(hostname string, kinds []string, from, to time.Time) ([]model.HostChange, error) {
+	ctx := context.TODO()
+
+	filter := bson.M{
+		"hostname": hostname,
+		"date": bson.M{
+			"$gte": from,
+			"$lte": to,
+		},
+	}
+
+	if len(kinds) > 0 {
+		filter["kind"] = bson.M{"$in": kinds}
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostChangesCollection).
+		Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	changes := make([]model.HostChange, 0)
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return changes, nil
+}
@@ -266,6 +266,7 @@ func (md *MongoDatabase) FindClusterVeritasLicenses(filter dto.GlobalFilter) ([]
 						{Key: "description", Value: 1},
 						{Key: "metric", Value: 1},
 						{Key: "existingHostsDR", Value: "$existingHostsDR.hostname"},
+						{Key: "isDR", Value: 1},
 						{Key: "count",
 							Value: bson.D{
 								{Key: "$cond",
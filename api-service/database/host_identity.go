@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostAliasCollection = "host_aliases"
+
+func (md *MongoDatabase) ListHostAliases() ([]model.HostAlias, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostAliasCollection).
+		Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"alias": 1}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.HostAlias, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) InsertHostAlias(alias model.HostAlias) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostAliasCollection).
+		InsertOne(context.TODO(), alias)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) DeleteHostAlias(id primitive.ObjectID) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostAliasCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.DeletedCount == 0 {
+		return utils.ErrHostAliasNotFound
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) MergeHost(from, to string, alias model.HostAlias, user string, newObjectID func() primitive.ObjectID) error {
+	return md.withTransaction(func(ctx context.Context) error {
+		return md.mergeHost(ctx, from, to, alias, user, newObjectID)
+	})
+}
+
+func (md *MongoDatabase) mergeHost(ctx context.Context, from, to string, alias model.HostAlias, user string, newObjectID func() primitive.ObjectID) error {
+	db := md.Client.Database(md.Config.Mongodb.DBName)
+
+	count, err := db.Collection(hostCollection).CountDocuments(ctx, bson.M{"hostname": to, "archived": false})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if count > 0 {
+		if _, err := db.Collection(hostCollection).UpdateMany(ctx,
+			bson.M{"hostname": from, "archived": false},
+			bson.M{"$set": bson.M{"archived": true}}); err != nil {
+			return utils.NewError(err, "DB ERROR")
+		}
+	}
+
+	renames := []struct {
+		collection string
+		field      string
+	}{
+		{hostCollection, "hostname"},
+		{hostChangesCollection, "hostname"},
+		{alertsCollection, "otherInfo.hostname"},
+		{hostAliasCollection, "hostname"},
+	}
+
+	for _, r := range renames {
+		if _, err := db.Collection(r.collection).UpdateMany(ctx,
+			bson.M{r.field: from},
+			bson.M{"$set": bson.M{r.field: to}}); err != nil {
+			return utils.NewError(err, "DB ERROR")
+		}
+	}
+
+	for _, technology := range contractTechnologies() {
+		if err := md.mergeContractsHost(ctx, technology, from, to, alias.CreatedAt, user, newObjectID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.Collection(hostAliasCollection).UpdateOne(ctx,
+		bson.M{"alias": alias.Alias},
+		bson.M{
+			"$set":         bson.M{"hostname": alias.Hostname, "source": alias.Source},
+			"$setOnInsert": bson.M{"_id": alias.ID, "createdAt": alias.CreatedAt},
+		},
+		options.Update().SetUpsert(true)); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// mergeContractsHost renames the host from to the host to in the contracts of the technology,
+// saving a change of each contract made by user on date
+func (md *MongoDatabase) mergeContractsHost(ctx context.Context, technology, from, to string, date time.Time, user string,
+	newObjectID func() primitive.ObjectID) error {
+	collection := md.Client.Database(md.Config.Mongodb.DBName).Collection(contractCollections[technology])
+
+	cur, err := collection.Find(ctx, bson.M{"hosts": from}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	var contracts []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	if err := cur.All(ctx, &contracts); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	for _, contract := range contracts {
+		before, err := md.getContractSnapshot(ctx, technology, contract.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := collection.UpdateOne(ctx,
+			bson.M{"_id": contract.ID, "hosts": to},
+			bson.M{"$pull": bson.M{"hosts": from}}); err != nil {
+			return utils.NewError(err, "DB ERROR")
+		}
+
+		if _, err := collection.UpdateOne(ctx,
+			bson.M{"_id": contract.ID, "hosts": from},
+			bson.M{"$set": bson.M{"hosts.$": to}}); err != nil {
+			return utils.NewError(err, "DB ERROR")
+		}
+
+		after, err := md.getContractSnapshot(ctx, technology, contract.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := md.insertContractChange(ctx, model.ContractChange{
+			ID:               newObjectID(),
+			Technology:       technology,
+			ContractObjectID: contract.ID,
+			Operation:        model.ContractChangeOperationMergeHost,
+			User:             user,
+			Date:             date,
+			Before:           before,
+			After:            after,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestMergeHost() {
+	defer m.db.Client.Database(m.dbname).Collection(mongoDBContractsCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(mariaDBContractsCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(contractChangesCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(hostAliasCollection).DeleteMany(context.TODO(), bson.M{})
+
+	mongoDBContract := model.MongoDBContract{
+		ID:    utils.Str2oid("000000000000000000000001"),
+		Hosts: []string{"oldname", "other"},
+	}
+	mariaDBContract := model.MariaDBContract{
+		ID:    utils.Str2oid("000000000000000000000002"),
+		Hosts: []string{"oldname", "newname"},
+	}
+
+	_, err := m.db.Client.Database(m.dbname).Collection(mongoDBContractsCollection).InsertOne(context.TODO(), mongoDBContract)
+	m.Require().NoError(err)
+	_, err = m.db.Client.Database(m.dbname).Collection(mariaDBContractsCollection).InsertOne(context.TODO(), mariaDBContract)
+	m.Require().NoError(err)
+
+	alias := model.HostAlias{
+		ID:        utils.Str2oid("000000000000000000000003"),
+		Alias:     "oldname",
+		Hostname:  "newname",
+		Source:    model.HostAliasSourceMerge,
+		CreatedAt: utils.P("2025-01-10T10:00:00Z"),
+	}
+
+	m.Require().NoError(m.db.MergeHost("oldname", "newname", alias, "user", utils.NewObjectIDForTests()))
+
+	var actualMongoDB model.MongoDBContract
+	m.Require().NoError(m.db.Client.Database(m.dbname).Collection(mongoDBContractsCollection).
+		FindOne(context.TODO(), bson.M{"_id": mongoDBContract.ID}).Decode(&actualMongoDB))
+	m.Assert().Equal([]string{"newname", "other"}, actualMongoDB.Hosts)
+
+	var actualMariaDB model.MariaDBContract
+	m.Require().NoError(m.db.Client.Database(m.dbname).Collection(mariaDBContractsCollection).
+		FindOne(context.TODO(), bson.M{"_id": mariaDBContract.ID}).Decode(&actualMariaDB))
+	m.Assert().Equal([]string{"newname"}, actualMariaDB.Hosts)
+
+	changes, err := m.db.ListContractChanges(dto.ContractChangesFilter{
+		From: utils.MIN_TIME,
+		To:   utils.MAX_TIME,
+	})
+	m.Require().NoError(err)
+	m.Require().Len(changes, 2)
+
+	for _, change := range changes {
+		m.Assert().Equal(model.ContractChangeOperationMergeHost, change.Operation)
+		m.Assert().Equal("user", change.User)
+		m.Assert().Equal(1, change.Version)
+		m.Assert().Contains(change.Before["hosts"], "oldname")
+		m.Assert().NotContains(change.After["hosts"], "oldname")
+	}
+}
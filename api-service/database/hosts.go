@@ -152,11 +152,12 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 					"virtualizationNode":      true,
 					"cluster":                 true,
 					"databases": bson.M{
-						model.TechnologyOracleDatabase:       "$features.oracle.database.databases.name",
-						model.TechnologyMicrosoftSQLServer:   "$features.microsoft.sqlServer.instances.name",
-						model.TechnologyOracleMySQL:          "$features.mysql.instances.name",
-						model.TechnologyPostgreSQLPostgreSQL: "$features.postgresql.instances.name",
-						model.TechnologyMongoDBMongoDB:       "$features.mongodb.instances.name",
+						model.TechnologyOracleDatabase:           "$features.oracle.database.databases.name",
+						model.TechnologyMicrosoftSQLServer:       "$features.microsoft.sqlServer.instances.name",
+						model.TechnologyOracleMySQL:              "$features.mysql.instances.name",
+						model.TechnologyPostgreSQLPostgreSQL:     "$features.postgresql.instances.name",
+						model.TechnologyMongoDBMongoDB:           "$features.mongodb.instances.name",
+						model.TechnologyMariaDBFoundationMariaDB: "$features.mariadb.instances.name",
 					},
 					"missingDatabases": "$features.oracle.database.missingDatabases",
 					"technology": bson.D{
@@ -334,6 +335,40 @@ func (md *MongoDatabase) getHosts(mode string, filters dto.SearchHostsFilters, o
 											},
 											{Key: "then", Value: model.TechnologyMongoDBMongoDB},
 										},
+										bson.D{
+											{Key: "case",
+												Value: bson.D{
+													{Key: "$or",
+														Value: bson.A{
+															bson.D{
+																{Key: "$eq",
+																	Value: bson.A{
+																		bson.D{{Key: "$type", Value: "$features.mariadb"}},
+																		"object",
+																	},
+																},
+															},
+															bson.D{
+																{Key: "$and",
+																	Value: bson.A{
+																		bson.D{{Key: "$isArray", Value: "$features.mariadb.instances"}},
+																		bson.D{
+																			{Key: "$gt",
+																				Value: bson.A{
+																					bson.D{{Key: "$size", Value: "$features.mariadb.instances"}},
+																					0,
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+											{Key: "then", Value: model.TechnologyMariaDBFoundationMariaDB},
+										},
 									},
 								},
 								{Key: "default", Value: primitive.Null{}},
@@ -1107,6 +1142,16 @@ func (md *MongoDatabase) getHostTechnology(hostname string, olderThan time.Time)
 							},
 						},
 					},
+					{Key: "MariaDBFoundation/MariaDB",
+						Value: bson.D{
+							{Key: "$eq",
+								Value: bson.A{
+									bson.D{{Key: "$type", Value: "$features.mariadb"}},
+									"object",
+								},
+							},
+						},
+					},
 				},
 			},
 		},
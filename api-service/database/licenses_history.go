@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	licensesHistoryCollection           = "database_licenses_history"
+	licensesHistoryByLocationCollection = "database_licenses_history_by_location"
+)
+
+// GetLicensesComplianceHistory return the historicized compliance of the license types,
+// keeping only the values between from and to
+func (md *MongoDatabase) GetLicensesComplianceHistory(licenseTypeIDs []string, from, to time.Time) ([]dto.LicenseComplianceHistory, error) {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licensesHistoryCollection).
+		Aggregate(
+			context.TODO(),
+			mu.MAPipeline(
+				mu.APMatch(bson.M{
+					"licenseTypeID": bson.M{"$in": licenseTypeIDs},
+				}),
+				mu.APProject(bson.M{
+					"_id":             0,
+					"licenseTypeID":   1,
+					"itemDescription": 1,
+					"metric":          1,
+					"history": mu.APOFilter("$history", "item", mu.APOAnd(
+						mu.APOGreaterOrEqual("$$item.date", from),
+						mu.APOGreaterOrEqual(to, "$$item.date"),
+					)),
+				}),
+			),
+		)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]dto.LicenseComplianceHistory, 0)
+	if err := cur.All(context.TODO(), &out); err != nil {
+		return nil, utils.NewError(err, "DECODE ERROR")
+	}
+
+	return out, nil
+}
+
+// GetLastLocationsLicensesCompliance return the compliance of the license types of the locations, or of all the locations,
+// on the last day they were historicized. License types no more historicized that day aren't returned
+func (md *MongoDatabase) GetLastLocationsLicensesCompliance(locations []string) ([]dto.LocationLicenseCompliance, error) {
+	match := bson.M{}
+	if len(locations) > 0 {
+		match["location"] = bson.M{"$in": locations}
+	}
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(licensesHistoryByLocationCollection).
+		Aggregate(
+			context.TODO(),
+			mu.MAPipeline(
+				mu.APMatch(match),
+				mu.APProject(bson.M{
+					"_id":             0,
+					"location":        1,
+					"licenseTypeID":   1,
+					"itemDescription": 1,
+					"value":           mu.APOArrayElemAt("$history", -1),
+				}),
+			),
+		)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]dto.LocationLicenseCompliance, 0)
+	if err := cur.All(context.TODO(), &items); err != nil {
+		return nil, utils.NewError(err, "DECODE ERROR")
+	}
+
+	var last time.Time
+
+	for _, item := range items {
+		if item.Value.Date.After(last) {
+			last = item.Value.Date
+		}
+	}
+
+	out := make([]dto.LocationLicenseCompliance, 0, len(items))
+
+	for _, item := range items {
+		if item.Value.Date.Equal(last) {
+			out = append(out, item)
+		}
+	}
+
+	return out, nil
+}
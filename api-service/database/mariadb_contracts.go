@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const mariaDBContractsCollection = "mariadb_contracts"
+
+func (md *MongoDatabase) InsertMariaDBContract(contract model.MariaDBContract) (*model.MariaDBContract, error) {
+	return insertContract(md, mariaDBContractsCollection, contract)
+}
+
+func (md *MongoDatabase) UpdateMariaDBContract(contract model.MariaDBContract) error {
+	return updateContract(md, mariaDBContractsCollection, contract.ID, contract)
+}
+
+func (md *MongoDatabase) RemoveMariaDBContract(id primitive.ObjectID) error {
+	return removeContract(md, mariaDBContractsCollection, id)
+}
+
+func (md *MongoDatabase) ListMariaDBContracts(locations []string) ([]model.MariaDBContract, error) {
+	return listContracts[model.MariaDBContract](md, mariaDBContractsCollection, locations)
+}
+
+// GetMariaDBUsedLicenses return the instances with their edition of the hosts running MariaDB
+func (md *MongoDatabase) GetMariaDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MariaDBUsedLicense, error) {
+	ctx := md.sessionContext()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
+		ctx,
+		mu.MAPipeline(
+			FindByHostname(hostname),
+			FilterByOldnessSteps(filter.OlderThan),
+			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			mu.APMatch(bson.M{
+				"features.mariadb.instances.0": bson.M{"$exists": true},
+			}),
+			mu.APProject(bson.M{
+				"_id":      0,
+				"hostname": 1,
+				"location": 1,
+				"instances": mu.APOMap("$features.mariadb.instances", "instance", bson.M{
+					"name":    "$$instance.name",
+					"edition": "$$instance.edition",
+				}),
+			}),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]dto.MariaDBUsedLicense, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const mariaDBLicenseTypesCollection = "mariadb_license_types"
+
+func (md *MongoDatabase) GetMariaDBLicenseTypes() ([]model.MariaDBLicenseType, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection(mariaDBLicenseTypesCollection).
+		Find(ctx, bson.M{})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	licenseTypes := make([]model.MariaDBLicenseType, 0)
+	if err := cur.All(ctx, &licenseTypes); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return licenseTypes, nil
+}
+
+func (md *MongoDatabase) GetMariaDBLicenseType(id string) (*model.MariaDBLicenseType, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection(mariaDBLicenseTypesCollection).
+		FindOne(context.TODO(), bson.M{"_id": id})
+	if res.Err() == mongo.ErrNoDocuments {
+		return nil, utils.ErrMariaDBLicenseTypeIDNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var licenseType model.MariaDBLicenseType
+	if err := res.Decode(&licenseType); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return &licenseType, nil
+}
+
+// InsertMariaDBLicenseType insert a MariaDB license type into the database
+func (md *MongoDatabase) InsertMariaDBLicenseType(licenseType model.MariaDBLicenseType) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mariaDBLicenseTypesCollection).
+		InsertOne(context.TODO(), licenseType)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// UpdateMariaDBLicenseType update a MariaDB license type in the database
+func (md *MongoDatabase) UpdateMariaDBLicenseType(licenseType model.MariaDBLicenseType) error {
+	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mariaDBLicenseTypesCollection).
+		ReplaceOne(context.TODO(), bson.M{
+			"_id": licenseType.ID,
+		}, licenseType)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if result.MatchedCount != 1 {
+		return utils.ErrMariaDBLicenseTypeIDNotFound
+	}
+
+	return nil
+}
+
+// RemoveMariaDBLicenseType remove a MariaDB license type
+func (md *MongoDatabase) RemoveMariaDBLicenseType(id string) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mariaDBLicenseTypesCollection).
+		DeleteOne(context.TODO(), bson.M{
+			"_id": id,
+		})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.DeletedCount == 0 {
+		return utils.ErrMariaDBLicenseTypeIDNotFound
+	}
+
+	return nil
+}
@@ -16,8 +16,6 @@
 package database
 
 import (
-	"context"
-
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"go.mongodb.org/mongo-driver/bson"
@@ -28,7 +26,7 @@ const sqlServerDbContractsCollection = "ms_sqlserver_database_contracts"
 
 func (md *MongoDatabase) InsertSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error) {
 	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(sqlServerDbContractsCollection).
-		InsertOne(context.TODO(), contract)
+		InsertOne(md.sessionContext(), contract)
 	if err != nil {
 		return nil, utils.NewError(err, "DB ERROR")
 	}
@@ -40,7 +38,7 @@ func (md *MongoDatabase) InsertSqlServerDatabaseContract(contract model.SqlServe
 
 func (md *MongoDatabase) UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) error {
 	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(sqlServerDbContractsCollection).
-		ReplaceOne(context.TODO(), bson.M{
+		ReplaceOne(md.sessionContext(), bson.M{
 			"_id": contract.ID,
 		}, contract)
 	if err != nil {
@@ -56,7 +54,7 @@ func (md *MongoDatabase) UpdateSqlServerDatabaseContract(contract model.SqlServe
 
 func (md *MongoDatabase) RemoveSqlServerDatabaseContract(id primitive.ObjectID) error {
 	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(sqlServerDbContractsCollection).
-		DeleteOne(context.TODO(), bson.M{
+		DeleteOne(md.sessionContext(), bson.M{
 			"_id": id,
 		})
 	if err != nil {
@@ -71,7 +69,7 @@ func (md *MongoDatabase) RemoveSqlServerDatabaseContract(id primitive.ObjectID)
 }
 
 func (md *MongoDatabase) ListSqlServerDatabaseContracts(locations []string) ([]model.SqlServerDatabaseContract, error) {
-	ctx := context.TODO()
+	ctx := md.sessionContext()
 	out := make([]model.SqlServerDatabaseContract, 0)
 
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(sqlServerDbContractsCollection).
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const mongoDBContractsCollection = "mongodb_contracts"
+
+func (md *MongoDatabase) InsertMongoDBContract(contract model.MongoDBContract) (*model.MongoDBContract, error) {
+	return insertContract(md, mongoDBContractsCollection, contract)
+}
+
+func (md *MongoDatabase) UpdateMongoDBContract(contract model.MongoDBContract) error {
+	return updateContract(md, mongoDBContractsCollection, contract.ID, contract)
+}
+
+func (md *MongoDatabase) RemoveMongoDBContract(id primitive.ObjectID) error {
+	return removeContract(md, mongoDBContractsCollection, id)
+}
+
+func (md *MongoDatabase) ListMongoDBContracts(locations []string) ([]model.MongoDBContract, error) {
+	return listContracts[model.MongoDBContract](md, mongoDBContractsCollection, locations)
+}
+
+// GetMongoDBUsedLicenses return the memory, the instances and the replica sets of the hosts running MongoDB
+func (md *MongoDatabase) GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MongoDBUsedLicense, error) {
+	ctx := md.sessionContext()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
+		ctx,
+		mu.MAPipeline(
+			FindByHostname(hostname),
+			FilterByOldnessSteps(filter.OlderThan),
+			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			mu.APMatch(bson.M{
+				"features.mongodb.instances.0": bson.M{"$exists": true},
+			}),
+			mu.APProject(bson.M{
+				"_id":         0,
+				"hostname":    1,
+				"location":    1,
+				"memory":      "$info.memoryTotal",
+				"instances":   "$features.mongodb.instances.name",
+				"editions":    "$features.mongodb.instances.edition",
+				"replicaSets": "$features.mongodb.instances.replicaSet.setName",
+			}),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]dto.MongoDBUsedLicense, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return out, nil
+}
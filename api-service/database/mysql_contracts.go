@@ -16,8 +16,6 @@
 package database
 
 import (
-	"context"
-
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -30,7 +28,7 @@ const mySQLContractCollection = "mysql_contracts"
 func (md *MongoDatabase) AddMySQLContract(contract model.MySQLContract) error {
 	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mySQLContractCollection).
 		InsertOne(
-			context.TODO(),
+			md.sessionContext(),
 			contract,
 		)
 	if err != nil {
@@ -43,7 +41,7 @@ func (md *MongoDatabase) AddMySQLContract(contract model.MySQLContract) error {
 func (md *MongoDatabase) UpdateMySQLContract(contract model.MySQLContract) error {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mySQLContractCollection).
 		ReplaceOne(
-			context.TODO(),
+			md.sessionContext(),
 			bson.M{"_id": contract.ID},
 			contract,
 		)
@@ -60,14 +58,14 @@ func (md *MongoDatabase) UpdateMySQLContract(contract model.MySQLContract) error
 
 func (md *MongoDatabase) GetMySQLContracts(locations []string) ([]model.MySQLContract, error) {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mySQLContractCollection).
-		Aggregate(context.TODO(), filterExistingLocations(locations))
+		Aggregate(md.sessionContext(), filterExistingLocations(locations))
 	if err != nil {
 		return nil, utils.NewError(err, "DB ERROR")
 	}
 
 	contracts := make([]model.MySQLContract, 0)
 
-	err = cur.All(context.TODO(), &contracts)
+	err = cur.All(md.sessionContext(), &contracts)
 	if err != nil {
 		return nil, utils.NewError(err, "Decode ERROR")
 	}
@@ -78,7 +76,7 @@ func (md *MongoDatabase) GetMySQLContracts(locations []string) ([]model.MySQLCon
 func (md *MongoDatabase) DeleteMySQLContract(id primitive.ObjectID) error {
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(mySQLContractCollection).
 		DeleteOne(
-			context.TODO(),
+			md.sessionContext(),
 			bson.M{"_id": id},
 		)
 	if err != nil {
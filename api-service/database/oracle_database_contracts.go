@@ -16,8 +16,6 @@
 package database
 
 import (
-	"context"
-
 	"github.com/amreo/mu"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -34,7 +32,7 @@ const oracleDbContractsCollection = "oracle_database_contracts"
 // InsertOracleDatabaseContract insert an Oracle/Database contract into the database
 func (md *MongoDatabase) InsertOracleDatabaseContract(contract model.OracleDatabaseContract) error {
 	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleDbContractsCollection).
-		InsertOne(context.TODO(), contract)
+		InsertOne(md.sessionContext(), contract)
 	if err != nil {
 		return utils.NewError(err, "DB ERROR")
 	}
@@ -45,7 +43,7 @@ func (md *MongoDatabase) InsertOracleDatabaseContract(contract model.OracleDatab
 // GetOracleDatabaseContract return the contract specified by id
 func (md *MongoDatabase) GetOracleDatabaseContract(id primitive.ObjectID) (*model.OracleDatabaseContract, error) {
 	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleDbContractsCollection).
-		FindOne(context.TODO(), bson.M{
+		FindOne(md.sessionContext(), bson.M{
 			"_id": id,
 		})
 	if res.Err() == mongo.ErrNoDocuments {
@@ -66,7 +64,7 @@ func (md *MongoDatabase) GetOracleDatabaseContract(id primitive.ObjectID) (*mode
 // UpdateOracleDatabaseContract update an Oracle/Database contract in the database
 func (md *MongoDatabase) UpdateOracleDatabaseContract(contract model.OracleDatabaseContract) error {
 	result, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleDbContractsCollection).
-		ReplaceOne(context.TODO(), bson.M{
+		ReplaceOne(md.sessionContext(), bson.M{
 			"_id": contract.ID,
 		}, contract)
 	if err != nil {
@@ -83,7 +81,7 @@ func (md *MongoDatabase) UpdateOracleDatabaseContract(contract model.OracleDatab
 // RemoveOracleDatabaseContract remove an Oracle/Database contract from the database
 func (md *MongoDatabase) RemoveOracleDatabaseContract(id primitive.ObjectID) error {
 	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleDbContractsCollection).
-		DeleteOne(context.TODO(), bson.M{
+		DeleteOne(md.sessionContext(), bson.M{
 			"_id": id,
 		})
 	if err != nil {
@@ -103,7 +101,7 @@ func (md *MongoDatabase) ListOracleDatabaseContracts(filter dto.GetOracleDatabas
 
 	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(oracleDbContractsCollection).
 		Aggregate(
-			context.TODO(),
+			md.sessionContext(),
 			mu.MAPipeline(
 				mu.APOptionalStage(len(filter.Locations) > 0 && !utils.Contains(filter.Locations, ""),
 					bson.M{"$match": bson.M{"$or": bson.A{
@@ -142,7 +140,7 @@ func (md *MongoDatabase) ListOracleDatabaseContracts(filter dto.GetOracleDatabas
 		return nil, utils.NewError(err, "DB ERROR")
 	}
 
-	if err = cur.All(context.TODO(), &out); err != nil {
+	if err = cur.All(md.sessionContext(), &out); err != nil {
 		return nil, utils.NewError(err, "Decode ERROR")
 	}
 
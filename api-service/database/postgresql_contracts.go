@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const postgreSQLContractsCollection = "postgresql_contracts"
+
+func (md *MongoDatabase) InsertPostgreSQLContract(contract model.PostgreSQLContract) (*model.PostgreSQLContract, error) {
+	return insertContract(md, postgreSQLContractsCollection, contract)
+}
+
+func (md *MongoDatabase) UpdatePostgreSQLContract(contract model.PostgreSQLContract) error {
+	return updateContract(md, postgreSQLContractsCollection, contract.ID, contract)
+}
+
+func (md *MongoDatabase) RemovePostgreSQLContract(id primitive.ObjectID) error {
+	return removeContract(md, postgreSQLContractsCollection, id)
+}
+
+func (md *MongoDatabase) ListPostgreSQLContracts(locations []string) ([]model.PostgreSQLContract, error) {
+	return listContracts[model.PostgreSQLContract](md, postgreSQLContractsCollection, locations)
+}
+
+// GetPostgreSQLUsedLicenses return the cores of the hosts running at least a PostgreSQL instance
+func (md *MongoDatabase) GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.PostgreSQLUsedLicense, error) {
+	ctx := md.sessionContext()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Aggregate(
+		ctx,
+		mu.MAPipeline(
+			FindByHostname(hostname),
+			FilterByOldnessSteps(filter.OlderThan),
+			FilterByLocationAndEnvironmentSteps(filter.Location, filter.Environment),
+			mu.APMatch(bson.M{
+				"features.postgresql.instances.0": bson.M{"$exists": true},
+			}),
+			mu.APProject(bson.M{
+				"_id":          0,
+				"hostname":     1,
+				"location":     1,
+				"instances":    "$features.postgresql.instances.name",
+				"versions":     "$features.postgresql.instances.setting.dbVersion",
+				"usedLicenses": "$info.cpuCores",
+			}),
+		),
+	)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	out := make([]dto.PostgreSQLUsedLicense, 0)
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return out, nil
+}
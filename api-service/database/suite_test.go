@@ -60,6 +60,7 @@ func (db *MongodbSuite) SetupSuite() {
 	db.dbname = db.db.Config.Mongodb.DBName
 
 	log := logger.NewLogger("TEST")
+	db.db.Log = log
 
 	err := migration.Migrate(db.db.Config.Mongodb)
 	if err != nil {
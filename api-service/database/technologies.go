@@ -56,6 +56,9 @@ func (md *MongoDatabase) GetHostsCountUsingTechnologies(location string, environ
 				model.TechnologyMongoDBMongoDB: mu.APOSum(
 					mu.APOCond(mu.APOGreater(mu.APOSize(mu.APOIfNull("$features.mongodb.instances", bson.A{})), 0), 1, 0),
 				),
+				model.TechnologyMariaDBFoundationMariaDB: mu.APOSum(
+					mu.APOCond(mu.APOGreater(mu.APOSize(mu.APOIfNull("$features.mariadb.instances", bson.A{})), 0), 1, 0),
+				),
 			}),
 			mu.APUnset("_id"),
 		),
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// withTransaction executes fn in a transaction, retried on transient errors. Every operation of fn must use its ctx.
+// Standalone servers don't support transactions, there fn isn't executed and ErrTransactionsNotSupported is returned.
+// If md is already part of a transaction, fn is executed in it
+func (md *MongoDatabase) withTransaction(fn func(ctx context.Context) error) error {
+	if md.sessionCtx != nil {
+		return fn(md.sessionCtx)
+	}
+
+	ctx := context.TODO()
+
+	supported, err := md.supportsTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		return utils.NewError(utils.ErrTransactionsNotSupported, "DB ERROR")
+	}
+
+	session, err := md.Client.StartSession()
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	var aerr *utils.AdvancedError
+	if err != nil && !errors.As(err, &aerr) {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return err
+}
+
+// WithTransaction executes fn in a transaction with a copy of md whose operations use the context of the transaction
+func (md *MongoDatabase) WithTransaction(fn func(db MongoDatabaseInterface) error) error {
+	return md.withTransaction(func(ctx context.Context) error {
+		tx := *md
+		tx.sessionCtx = ctx
+
+		return fn(&tx)
+	})
+}
+
+// sessionContext return the context of the transaction md is part of, or an empty context
+func (md *MongoDatabase) sessionContext() context.Context {
+	if md.sessionCtx != nil {
+		return md.sessionCtx
+	}
+
+	return context.TODO()
+}
+
+// checkTransactionsSupport warns that the operations needing a transaction are disabled if the server doesn't support them
+func (md *MongoDatabase) checkTransactionsSupport() {
+	supported, err := md.supportsTransactions(context.TODO())
+	if err != nil {
+		md.Log.Error(err)
+		return
+	}
+
+	if !supported {
+		md.Log.Warnf("%s: the changes of the contracts, their imports and the merges of hosts will fail",
+			utils.ErrTransactionsNotSupported)
+	}
+}
+
+// supportsTransactions return true if the server is a member of a replica set or a mongos
+func (md *MongoDatabase) supportsTransactions(ctx context.Context) (bool, error) {
+	var isMaster struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+
+	if err := md.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&isMaster); err != nil {
+		return false, utils.NewError(err, "DB ERROR")
+	}
+
+	return isMaster.SetName != "" || isMaster.Msg == "isdbgrid", nil
+}
@@ -22,4 +22,5 @@ type ClusterVeritasLicense struct {
 	Metric          string   `json:"metric"`
 	Count           float64  `json:"count"`
 	ExistingHostsDR []string `json:"existingHostsDR"`
+	IsDR            bool     `json:"isDR"`
 }
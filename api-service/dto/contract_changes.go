@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// ContractChangesFilter contains the filters used to search the contract changes
+type ContractChangesFilter struct {
+	Technology       string
+	ContractObjectID *primitive.ObjectID
+	From             time.Time
+	To               time.Time
+}
+
+// ContractsAsOf contains the contracts of every technology as they were on Date
+type ContractsAsOf struct {
+	Date       time.Time                         `json:"date"`
+	Oracle     []model.OracleDatabaseContract    `json:"oracle"`
+	SqlServer  []model.SqlServerDatabaseContract `json:"sqlServer"`
+	MySQL      []model.MySQLContract             `json:"mysql"`
+	PostgreSQL []model.PostgreSQLContract        `json:"postgresql"`
+	MongoDB    []model.MongoDBContract           `json:"mongodb"`
+	MariaDB    []model.MariaDBContract           `json:"mariadb"`
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Statuses of the rows of a contracts import
+const (
+	ContractImportRowNew       = "NEW"
+	ContractImportRowChanged   = "CHANGED"
+	ContractImportRowUnchanged = "UNCHANGED"
+)
+
+// ContractImportPreview contains the result of the validation of a contracts csv
+// and how every row compares with the existing contracts.
+// Hash identifies the changes to be saved, the import is committed only with the hash of its preview
+type ContractImportPreview struct {
+	DatabaseType string                `json:"databaseType"`
+	Valid        bool                  `json:"valid"`
+	Hash         string                `json:"hash"`
+	New          int                   `json:"new"`
+	Changed      int                   `json:"changed"`
+	Unchanged    int                   `json:"unchanged"`
+	Rows         []ContractImportRow   `json:"rows"`
+	Errors       []ContractImportError `json:"errors"`
+}
+
+// ContractImportRow contains a valid row of the csv
+type ContractImportRow struct {
+	Row           int                 `json:"row"`
+	Status        string              `json:"status"`
+	ContractID    string              `json:"contractID"`
+	CSI           string              `json:"csi"`
+	LicenseTypeID string              `json:"licenseTypeID"`
+	ExistingID    *primitive.ObjectID `json:"existingID,omitempty"`
+	Diff          []ContractFieldDiff `json:"diff,omitempty"`
+}
+
+// ContractFieldDiff contains the value of a field in the existing contract and in the csv
+type ContractFieldDiff struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ContractImportError contains an error found in a row of the csv
+type ContractImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+type HostAliasRequest struct {
+	Alias    string `json:"alias"`
+	Hostname string `json:"hostname"`
+}
+
+// HostMergeRequest contains the previous and the new name of a renamed host
+type HostMergeRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ResolvedHostname contains the hostname known by ercole matching a name
+type ResolvedHostname struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname"`
+	Found    bool   `json:"found"`
+}
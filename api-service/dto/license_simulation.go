@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "github.com/ercole-io/ercole/v2/model"
+
+// Types of the changes of a license simulation
+const (
+	// LicenseSimulationMoveDatabase moves the database DbName from Hostname to ToHostname
+	LicenseSimulationMoveDatabase = "moveDatabase"
+	// LicenseSimulationChangeHostCPU sets the not zero CPUCores, CPUThreads and CPUSockets of Hostname
+	LicenseSimulationChangeHostCPU = "changeHostCpu"
+	// LicenseSimulationAddClusterMember moves Hostname to the VMs of Cluster
+	LicenseSimulationAddClusterMember = "addClusterMember"
+	// LicenseSimulationRemoveClusterMember removes Hostname from the VMs of Cluster
+	LicenseSimulationRemoveClusterMember = "removeClusterMember"
+	// LicenseSimulationAddContract adds the Oracle database Contract
+	LicenseSimulationAddContract = "addContract"
+)
+
+// LicenseSimulationRequest contains the hypothetical changes to apply, in order, to the current data
+type LicenseSimulationRequest struct {
+	Locations []string                  `json:"locations"`
+	Changes   []LicenseSimulationChange `json:"changes"`
+}
+
+type LicenseSimulationChange struct {
+	Type       string                        `json:"type"`
+	Hostname   string                        `json:"hostname,omitempty"`
+	DbName     string                        `json:"dbName,omitempty"`
+	ToHostname string                        `json:"toHostname,omitempty"`
+	CPUCores   int                           `json:"cpuCores,omitempty"`
+	CPUThreads int                           `json:"cpuThreads,omitempty"`
+	CPUSockets int                           `json:"cpuSockets,omitempty"`
+	Cluster    string                        `json:"cluster,omitempty"`
+	Contract   *model.OracleDatabaseContract `json:"contract,omitempty"`
+}
+
+// LicenseSimulation contains the compliance of every license type before and after the changes
+type LicenseSimulation struct {
+	Changes  []LicenseSimulationChange `json:"changes"`
+	Licenses []LicenseComplianceDelta  `json:"licenses"`
+}
+
+type LicenseComplianceDelta struct {
+	LicenseTypeID   string            `json:"licenseTypeID"`
+	ItemDescription string            `json:"itemDescription"`
+	Metric          string            `json:"metric"`
+	Before          LicenseCompliance `json:"before"`
+	After           LicenseCompliance `json:"after"`
+	ConsumedDelta   float64           `json:"consumedDelta"`
+	CoveredDelta    float64           `json:"coveredDelta"`
+	ComplianceDelta float64           `json:"complianceDelta"`
+}
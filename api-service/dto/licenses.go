@@ -15,12 +15,15 @@
 
 package dto
 
+import "time"
+
 // LicenseCompliance contains the information about usage of a license
 type LicenseCompliance struct {
 	LicenseTypeID   string  `json:"licenseTypeID" bson:"licenseTypeID"`
 	ItemDescription string  `json:"itemDescription" bson:"itemDescription"`
 	Metric          string  `json:"metric" bson:"metric"`
 	Cost            float64 `json:"cost" bson:"cost"`
+	Technology      string  `json:"technology,omitempty" bson:"technology,omitempty"`
 
 	Consumed   float64 `json:"consumed"`
 	Covered    float64 `json:"covered"`
@@ -29,3 +32,28 @@ type LicenseCompliance struct {
 	Unlimited  bool    `json:"unlimited"`
 	Available  float64 `json:"available"`
 }
+
+// LicenseComplianceHistory contains the daily historicized compliance of a license type
+type LicenseComplianceHistory struct {
+	LicenseTypeID   string                           `json:"licenseTypeID" bson:"licenseTypeID"`
+	ItemDescription string                           `json:"itemDescription" bson:"itemDescription"`
+	Metric          string                           `json:"metric" bson:"metric"`
+	History         []LicenseComplianceHistoricValue `json:"history" bson:"history"`
+}
+
+// LicenseComplianceHistoricValue contains the compliance of a license type on Date
+type LicenseComplianceHistoricValue struct {
+	Date      time.Time `json:"date" bson:"date"`
+	Consumed  float64   `json:"consumed" bson:"consumed"`
+	Covered   float64   `json:"covered" bson:"covered"`
+	Purchased float64   `json:"purchased" bson:"purchased"`
+	Unlimited bool      `json:"unlimited" bson:"unlimited"`
+}
+
+// LocationLicenseCompliance contains the last historicized compliance of a license type in a location
+type LocationLicenseCompliance struct {
+	Location        string                         `json:"location" bson:"location"`
+	LicenseTypeID   string                         `json:"licenseTypeID" bson:"licenseTypeID"`
+	ItemDescription string                         `json:"itemDescription" bson:"itemDescription"`
+	Value           LicenseComplianceHistoricValue `json:"value" bson:"value"`
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+// LicensesCost contains the current spend on licenses and the cost of the consumption not covered by them
+type LicensesCost struct {
+	Spend    float64 `json:"spend"`
+	Exposure float64 `json:"exposure"`
+
+	LicenseTypes []LicenseTypeCost   `json:"licenseTypes"`
+	Technologies []LicensesCostGroup `json:"technologies"`
+	Locations    []LicensesCostGroup `json:"locations"`
+	Environments []LicensesCostGroup `json:"environments"`
+	Tags         []LicensesCostGroup `json:"tags"`
+}
+
+// LicenseTypeCost contains the cost of a license type.
+// Spend is the cost of the purchased licenses, Exposure is the cost of the consumed licenses not covered
+type LicenseTypeCost struct {
+	Technology      string  `json:"technology"`
+	LicenseTypeID   string  `json:"licenseTypeID"`
+	ItemDescription string  `json:"itemDescription"`
+	Metric          string  `json:"metric"`
+	Cost            float64 `json:"cost"`
+	CostMissing     bool    `json:"costMissing"`
+	Consumed        float64 `json:"consumed"`
+	Covered         float64 `json:"covered"`
+	Purchased       float64 `json:"purchased"`
+	Unlimited       bool    `json:"unlimited"`
+	Spend           float64 `json:"spend"`
+	Exposure        float64 `json:"exposure"`
+}
+
+// LicensesCostGroup contains the cost of the licenses of a technology, location, environment or tag
+type LicensesCostGroup struct {
+	Group    string  `json:"group"`
+	Spend    float64 `json:"spend"`
+	Exposure float64 `json:"exposure"`
+}
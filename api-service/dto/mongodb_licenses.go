@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import (
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// MongoDBUsedLicense contains the memory, the instances and the replica sets of a host running MongoDB
+type MongoDBUsedLicense struct {
+	Hostname    string   `json:"hostname" bson:"hostname"`
+	Location    string   `json:"location" bson:"location"`
+	Memory      float64  `json:"memory" bson:"memory"`
+	Instances   []string `json:"instances" bson:"instances"`
+	Editions    []string `json:"editions" bson:"editions"`
+	ReplicaSets []string `json:"replicaSets" bson:"replicaSets"`
+}
+
+// Enterprise return true if the host runs a MongoDB Enterprise instance, that needs a subscription
+func (l MongoDBUsedLicense) Enterprise() bool {
+	return utils.Contains(l.Editions, model.MongoDBEditionEnterprise)
+}
@@ -123,3 +123,21 @@ type HostUsingOracleDatabaseLicenses struct {
 	// If LicenseType Metric is Named User Plus Perpetual, value isn't PerUser (must be multiplied *25)
 	OriginalCount float64 `json:"originalCount" bson:"originalCount"`
 }
+
+// OracleDatabaseContractLicenseCompliance contains the information about usage of the licenses of a contract
+type OracleDatabaseContractLicenseCompliance struct {
+	ID              primitive.ObjectID `json:"id"` // ID of contract - licenseType couple
+	ContractID      string             `json:"contractID"`
+	CSI             string             `json:"csi"`
+	Location        string             `json:"location"`
+	LicenseTypeID   string             `json:"licenseTypeID"`
+	ItemDescription string             `json:"itemDescription"`
+	Metric          string             `json:"metric"`
+	Unlimited       bool               `json:"unlimited"`
+
+	// Licenses consumed by the hosts associated to the contract
+	Consumed   float64 `json:"consumed"`
+	Covered    float64 `json:"covered"`
+	Purchased  float64 `json:"purchased"`
+	Compliance float64 `json:"compliance"`
+}
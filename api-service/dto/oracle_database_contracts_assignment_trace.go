@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Phases of the assignment of the Oracle database contracts to the hosts
+const (
+	// Licenses assigned to an host associated to the contract
+	OracleDatabaseContractsAssignmentPhaseAssociatedHost = "ASSOCIATED_HOST"
+	// Licenses assigned to a cluster which contains an host associated to the contract
+	OracleDatabaseContractsAssignmentPhaseCluster = "CLUSTER"
+	// Licenses assigned from a basket contract to any host using the license type
+	OracleDatabaseContractsAssignmentPhaseBasket = "BASKET"
+)
+
+// OracleDatabaseContractsAssignmentTrace describe how the contracts of a license type
+// have been assigned to the hosts and clusters using it
+type OracleDatabaseContractsAssignmentTrace struct {
+	LicenseTypeID   string `json:"licenseTypeID"`
+	ItemDescription string `json:"itemDescription"`
+	Metric          string `json:"metric"`
+	// Contracts in the order in which they have been considered
+	Contracts []OracleDatabaseContractsAssignmentTraceContract `json:"contracts"`
+	// Steps in the order in which they have been made
+	Steps             []OracleDatabaseContractsAssignmentStep      `json:"steps"`
+	Hosts             []OracleDatabaseContractsAssignmentTraceHost `json:"hosts"`
+	UncoveredLicenses float64                                      `json:"uncoveredLicenses"`
+}
+
+// OracleDatabaseContractsAssignmentTraceContract contains the licenses of a contract before and after the assignment
+type OracleDatabaseContractsAssignmentTraceContract struct {
+	Order      int                `json:"order"`
+	ID         primitive.ObjectID `json:"id"` // ID of contract - licenseType couple
+	ContractID string             `json:"contractID"`
+	Basket     bool               `json:"basket"`
+	Restricted bool               `json:"restricted"`
+	Unlimited  bool               `json:"unlimited"`
+	Hosts      []string           `json:"hosts"`
+	// If Metric is Named User Plus Perpetual, values are PerUser (already multiplied *25)
+	AvailableLicenses float64 `json:"availableLicenses"`
+	CoveredLicenses   float64 `json:"coveredLicenses"`
+	RemainingLicenses float64 `json:"remainingLicenses"`
+}
+
+// OracleDatabaseContractsAssignmentStep contains the licenses assigned by a contract to an host or a cluster
+type OracleDatabaseContractsAssignmentStep struct {
+	Phase         string `json:"phase"`
+	ContractOrder int    `json:"contractOrder"`
+	ContractID    string `json:"contractID"`
+	Name          string `json:"name"`
+	// Type describe if it's an host or a cluster
+	Type string `json:"type"`
+	// AssociatedHostname is the host associated to the contract which belongs to the cluster
+	AssociatedHostname string  `json:"associatedHostname,omitempty"`
+	AssignedLicenses   float64 `json:"assignedLicenses"`
+	// Licenses still available in the contract after the step
+	ContractRemainingLicenses float64 `json:"contractRemainingLicenses"`
+	// Licenses of the host or cluster not yet covered after the step
+	UncoveredLicenses float64 `json:"uncoveredLicenses"`
+	// Note explain why no licenses have been assigned
+	Note string `json:"note,omitempty"`
+}
+
+// OracleDatabaseContractsAssignmentTraceHost contains the licenses consumed and covered of an host or a cluster
+type OracleDatabaseContractsAssignmentTraceHost struct {
+	Name              string  `json:"name"`
+	Type              string  `json:"type"`
+	ConsumedLicenses  float64 `json:"consumedLicenses"`
+	CoveredLicenses   float64 `json:"coveredLicenses"`
+	UncoveredLicenses float64 `json:"uncoveredLicenses"`
+}
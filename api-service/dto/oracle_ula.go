@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "time"
+
+// OracleULACertification contains the Oracle processors and named users deployed on Date
+// for each license type covered by an unlimited license agreement, as they must be certified at the ULA exit
+type OracleULACertification struct {
+	Date         time.Time                  `json:"date"`
+	Contracts    []OracleDatabaseContractFE `json:"contracts"`
+	LicenseTypes []OracleULACertifiedUsage  `json:"licenseTypes"`
+	Locations    []OracleULACertifiedUsage  `json:"locations"`
+	Clusters     []OracleULACertifiedUsage  `json:"clusters"`
+	Clouds       []OracleULACertifiedUsage  `json:"clouds"`
+	Hosts        []OracleULAHostDeployment  `json:"hosts"`
+}
+
+// OracleULACertifiedUsage contains the processors and named users of a license type deployed in Group,
+// a location, a cluster or a cloud. Group is empty in the totals of the license types
+type OracleULACertifiedUsage struct {
+	Group           string  `json:"group,omitempty"`
+	LicenseTypeID   string  `json:"licenseTypeID"`
+	ItemDescription string  `json:"itemDescription"`
+	Metric          string  `json:"metric"`
+	HostsCount      int     `json:"hostsCount"`
+	Processors      float64 `json:"processors"`
+	NamedUsers      float64 `json:"namedUsers"`
+}
+
+// OracleULAHostDeployment contains the processors and named users of a license type deployed on a host.
+// If the host is a VM of ClusterName its processors are certified with the ones of the cluster
+type OracleULAHostDeployment struct {
+	Hostname        string   `json:"hostname"`
+	Location        string   `json:"location"`
+	Environment     string   `json:"environment"`
+	CloudMembership string   `json:"cloudMembership"`
+	ClusterName     string   `json:"clusterName"`
+	LicenseTypeID   string   `json:"licenseTypeID"`
+	ItemDescription string   `json:"itemDescription"`
+	Metric          string   `json:"metric"`
+	Databases       []string `json:"databases"`
+	Cores           int      `json:"cores"`
+	CoreFactor      float64  `json:"coreFactor"`
+	Processors      float64  `json:"processors"`
+	NamedUsers      float64  `json:"namedUsers"`
+}
+
+// OracleULAUsageHistory contains the historicized usage of a license type covered by an unlimited
+// license agreement between From and To
+type OracleULAUsageHistory struct {
+	LicenseTypeID   string                           `json:"licenseTypeID"`
+	ItemDescription string                           `json:"itemDescription"`
+	Metric          string                           `json:"metric"`
+	From            time.Time                        `json:"from"`
+	To              time.Time                        `json:"to"`
+	PeakConsumed    float64                          `json:"peakConsumed"`
+	History         []LicenseComplianceHistoricValue `json:"history"`
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import (
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// PostgreSQLUsedLicense contains the cores of a host running PostgreSQL instances
+type PostgreSQLUsedLicense struct {
+	Hostname     string   `json:"hostname" bson:"hostname"`
+	Location     string   `json:"location" bson:"location"`
+	Instances    []string `json:"instances" bson:"instances"`
+	Versions     []string `json:"versions" bson:"versions"`
+	UsedLicenses float64  `json:"usedLicenses" bson:"usedLicenses"`
+}
+
+// Vendors return the vendors of the subscriptions needed by the instances of the host
+func (l PostgreSQLUsedLicense) Vendors() []string {
+	vendors := make([]string, 0)
+
+	for _, version := range l.Versions {
+		vendor := model.PostgreSQLVendorOfVersion(version)
+		if vendor != "" && !utils.Contains(vendors, vendor) {
+			vendors = append(vendors, vendor)
+		}
+	}
+
+	return vendors
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	cr "github.com/ercole-io/ercole/v2/utils/crypto"
+)
+
+func (as *APIService) ListAgentCredentials() ([]model.AgentCredential, error) {
+	return as.Database.ListAgentCredentials()
+}
+
+func (as *APIService) AddAgentCredential(req dto.AgentCredentialRequest) (*dto.AgentCredentialKey, error) {
+	if err := validateAgentCredentialRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, err := as.Database.GetAgentCredentialByName(req.Name)
+	if err == nil {
+		return nil, utils.ErrAgentCredentialAlreadyExists
+	} else if !errors.Is(err, utils.ErrAgentCredentialNotFound) {
+		return nil, err
+	}
+
+	key, hash, err := generateAgentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	credential := model.AgentCredential{
+		ID:               as.NewObjectID(),
+		Name:             req.Name,
+		HostnamePatterns: req.HostnamePatterns,
+		KeyHash:          hash,
+		CreatedAt:        as.TimeNow(),
+	}
+
+	if err := as.Database.InsertAgentCredential(credential); err != nil {
+		return nil, err
+	}
+
+	return &dto.AgentCredentialKey{Credential: credential, Key: key}, nil
+}
+
+func (as *APIService) RotateAgentCredentialKey(id primitive.ObjectID) (*dto.AgentCredentialKey, error) {
+	credential, err := as.Database.GetAgentCredential(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.IsRevoked() {
+		return nil, utils.ErrInvalidAgentCredential
+	}
+
+	key, hash, err := generateAgentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := as.TimeNow()
+	if err := as.Database.UpdateAgentCredentialKey(id, hash, now); err != nil {
+		return nil, err
+	}
+
+	credential.KeyHash, credential.RotatedAt = hash, &now
+
+	return &dto.AgentCredentialKey{Credential: *credential, Key: key}, nil
+}
+
+func (as *APIService) RevokeAgentCredential(id primitive.ObjectID) error {
+	return as.Database.RevokeAgentCredential(id, as.TimeNow())
+}
+
+func validateAgentCredentialRequest(req dto.AgentCredentialRequest) error {
+	if strings.TrimSpace(req.Name) == "" || strings.Contains(req.Name, ":") {
+		return utils.NewError(utils.ErrInvalidAgentCredential, "name must be non-empty and must not contain ':'")
+	}
+
+	if len(req.HostnamePatterns) == 0 {
+		return utils.NewError(utils.ErrInvalidAgentCredential, "at least one hostname pattern is required")
+	}
+
+	for _, pattern := range req.HostnamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return utils.NewErrorf("%w: invalid hostname pattern %q", utils.ErrInvalidAgentCredential, pattern)
+		}
+	}
+
+	return nil
+}
+
+func generateAgentKey() (key, hash string, err error) {
+	key, err = cr.GenerateAPIKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, cr.HashAPIKey(key), nil
+}
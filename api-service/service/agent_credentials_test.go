@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	cr "github.com/ercole-io/ercole/v2/utils/crypto"
+)
+
+func TestAddAgentCredential(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	req := dto.AgentCredentialRequest{Name: "web", HostnamePatterns: []string{"web-*"}}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("web").Return(nil, utils.ErrAgentCredentialNotFound)
+		db.EXPECT().InsertAgentCredential(gomock.Any()).
+			Do(func(credential model.AgentCredential) {
+				assert.Equal(t, "web", credential.Name)
+				assert.Equal(t, []string{"web-*"}, credential.HostnamePatterns)
+				assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), credential.CreatedAt)
+			}).Return(nil)
+
+		actual, err := as.AddAgentCredential(req)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, actual.Key)
+		assert.True(t, cr.MatchAPIKey(actual.Key, actual.Credential.KeyHash))
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("web").Return(&model.AgentCredential{Name: "web"}, nil)
+
+		_, err := as.AddAgentCredential(req)
+		require.ErrorIs(t, err, utils.ErrAgentCredentialAlreadyExists)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		invalid := []dto.AgentCredentialRequest{
+			{Name: "", HostnamePatterns: []string{"web-*"}},
+			{Name: "we:b", HostnamePatterns: []string{"web-*"}},
+			{Name: "web"},
+			{Name: "web", HostnamePatterns: []string{"web-["}},
+		}
+
+		for _, r := range invalid {
+			_, err := as.AddAgentCredential(r)
+			require.ErrorIs(t, err, utils.ErrInvalidAgentCredential)
+		}
+	})
+}
+
+func TestRotateAgentCredentialKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	id := primitive.NewObjectID()
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().GetAgentCredential(id).Return(&model.AgentCredential{ID: id, Name: "web"}, nil)
+		db.EXPECT().UpdateAgentCredentialKey(id, gomock.Any(), utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+		actual, err := as.RotateAgentCredentialKey(id)
+		require.NoError(t, err)
+
+		assert.True(t, cr.MatchAPIKey(actual.Key, actual.Credential.KeyHash))
+		assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), *actual.Credential.RotatedAt)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		db.EXPECT().GetAgentCredential(id).Return(&model.AgentCredential{ID: id, RevokedAt: &revokedAt}, nil)
+
+		_, err := as.RotateAgentCredentialKey(id)
+		require.ErrorIs(t, err, utils.ErrInvalidAgentCredential)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		db.EXPECT().GetAgentCredential(id).Return(nil, utils.ErrAgentCredentialNotFound)
+
+		_, err := as.RotateAgentCredentialKey(id)
+		require.ErrorIs(t, err, utils.ErrAgentCredentialNotFound)
+	})
+}
+
+func TestRevokeAgentCredential(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	id := primitive.NewObjectID()
+	db.EXPECT().RevokeAgentCredential(id, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+	require.NoError(t, as.RevokeAgentCredential(id))
+}
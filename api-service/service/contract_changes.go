@@ -0,0 +1,254 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// auditContractChange executes the change of the contract and saves its new version,
+// with the values of the contract before and after the change, all in a transaction
+func (as *APIService) auditContractChange(technology string, id primitive.ObjectID, operation string, user string,
+	change func(db database.MongoDatabaseInterface) error,
+) error {
+	return as.Database.WithTransaction(func(db database.MongoDatabaseInterface) error {
+		before, err := db.GetContractSnapshot(technology, id)
+		if err != nil {
+			return err
+		}
+
+		if err := change(db); err != nil {
+			return err
+		}
+
+		after, err := db.GetContractSnapshot(technology, id)
+		if err != nil {
+			return err
+		}
+
+		return db.InsertContractChange(model.ContractChange{
+			ID:               as.NewObjectID(),
+			Technology:       technology,
+			ContractObjectID: id,
+			Operation:        operation,
+			User:             user,
+			Date:             as.TimeNow(),
+			Before:           before,
+			After:            after,
+		})
+	})
+}
+
+// ListContractChanges return the changes of the contracts that match the filter
+func (as *APIService) ListContractChanges(filter dto.ContractChangesFilter) ([]model.ContractChange, error) {
+	return as.Database.ListContractChanges(filter)
+}
+
+// GetContractsAsOf return the contracts of every technology as they were on date
+func (as *APIService) GetContractsAsOf(date time.Time) (*dto.ContractsAsOf, error) {
+	changes, err := as.Database.ListContractChanges(dto.ContractChangesFilter{
+		From: utils.MIN_TIME,
+		To:   date,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastChanges := make(map[primitive.ObjectID]model.ContractChange)
+	ids := make([]primitive.ObjectID, 0)
+
+	for _, change := range changes {
+		if _, ok := lastChanges[change.ContractObjectID]; !ok {
+			ids = append(ids, change.ContractObjectID)
+		}
+
+		lastChanges[change.ContractObjectID] = change
+	}
+
+	contracts := &dto.ContractsAsOf{
+		Date:       date,
+		Oracle:     make([]model.OracleDatabaseContract, 0),
+		SqlServer:  make([]model.SqlServerDatabaseContract, 0),
+		MySQL:      make([]model.MySQLContract, 0),
+		PostgreSQL: make([]model.PostgreSQLContract, 0),
+		MongoDB:    make([]model.MongoDBContract, 0),
+		MariaDB:    make([]model.MariaDBContract, 0),
+	}
+
+	for _, id := range ids {
+		change := lastChanges[id]
+		if change.After == nil {
+			continue
+		}
+
+		switch change.Technology {
+		case model.TechnologyOracleDatabase:
+			var contract model.OracleDatabaseContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.Oracle = append(contracts.Oracle, contract)
+		case model.TechnologyMicrosoftSQLServer:
+			var contract model.SqlServerDatabaseContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.SqlServer = append(contracts.SqlServer, contract)
+		case model.TechnologyOracleMySQL:
+			var contract model.MySQLContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.MySQL = append(contracts.MySQL, contract)
+		case model.TechnologyPostgreSQLPostgreSQL:
+			var contract model.PostgreSQLContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.PostgreSQL = append(contracts.PostgreSQL, contract)
+		case model.TechnologyMongoDBMongoDB:
+			var contract model.MongoDBContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.MongoDB = append(contracts.MongoDB, contract)
+		case model.TechnologyMariaDBFoundationMariaDB:
+			var contract model.MariaDBContract
+			if err := change.DecodeAfter(&contract); err != nil {
+				return nil, utils.NewError(err, "DECODE ERROR")
+			}
+
+			contracts.MariaDB = append(contracts.MariaDB, contract)
+		}
+	}
+
+	return contracts, nil
+}
+
+// GetDatabaseLicensesComplianceAsOf return the compliance of the current licenses usage
+// against the contracts as they were on date
+func (as *APIService) GetDatabaseLicensesComplianceAsOf(date time.Time, locations []string) ([]dto.LicenseCompliance, error) {
+	contracts, err := as.GetContractsAsOf(date)
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypes, err := as.GetOracleDatabaseLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := *as
+	asOf.Database = &contractsAsOfDatabase{
+		MongoDatabaseInterface: as.Database,
+		contracts:              *contracts,
+		licenseTypes:           licenseTypes,
+	}
+
+	return asOf.GetDatabaseLicensesCompliance(locations)
+}
+
+// contractsAsOfDatabase returns the contracts as they were on a date instead of the current ones
+type contractsAsOfDatabase struct {
+	database.MongoDatabaseInterface
+
+	contracts    dto.ContractsAsOf
+	licenseTypes map[string]model.OracleDatabaseLicenseType
+}
+
+func (db *contractsAsOfDatabase) ListOracleDatabaseContracts(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error) {
+	contracts := make([]dto.OracleDatabaseContractFE, 0, len(db.contracts.Oracle))
+
+	for _, c := range db.contracts.Oracle {
+		if locationsInclude(filter.Locations, c.Location) {
+			contracts = append(contracts, newOracleDatabaseContractFE(c, db.licenseTypes[c.LicenseTypeID]))
+		}
+	}
+
+	return contracts, nil
+}
+
+func (db *contractsAsOfDatabase) ListSqlServerDatabaseContracts(locations []string) ([]model.SqlServerDatabaseContract, error) {
+	contracts := make([]model.SqlServerDatabaseContract, 0, len(db.contracts.SqlServer))
+
+	for _, c := range db.contracts.SqlServer {
+		if locationsInclude(locations, c.Location) {
+			contracts = append(contracts, c)
+		}
+	}
+
+	return contracts, nil
+}
+
+func (db *contractsAsOfDatabase) GetMySQLContracts(locations []string) ([]model.MySQLContract, error) {
+	contracts := make([]model.MySQLContract, 0, len(db.contracts.MySQL))
+
+	for _, c := range db.contracts.MySQL {
+		if locationsInclude(locations, c.Location) {
+			contracts = append(contracts, c)
+		}
+	}
+
+	return contracts, nil
+}
+
+func (db *contractsAsOfDatabase) ListPostgreSQLContracts(locations []string) ([]model.PostgreSQLContract, error) {
+	contracts := make([]model.PostgreSQLContract, 0, len(db.contracts.PostgreSQL))
+
+	for _, c := range db.contracts.PostgreSQL {
+		if locationsInclude(locations, c.Location) {
+			contracts = append(contracts, c)
+		}
+	}
+
+	return contracts, nil
+}
+
+func (db *contractsAsOfDatabase) ListMongoDBContracts(locations []string) ([]model.MongoDBContract, error) {
+	contracts := make([]model.MongoDBContract, 0, len(db.contracts.MongoDB))
+
+	for _, c := range db.contracts.MongoDB {
+		if locationsInclude(locations, c.Location) {
+			contracts = append(contracts, c)
+		}
+	}
+
+	return contracts, nil
+}
+
+func (db *contractsAsOfDatabase) ListMariaDBContracts(locations []string) ([]model.MariaDBContract, error) {
+	contracts := make([]model.MariaDBContract, 0, len(db.contracts.MariaDB))
+
+	for _, c := range db.contracts.MariaDB {
+		if locationsInclude(locations, c.Location) {
+			contracts = append(contracts, c)
+		}
+	}
+
+	return contracts, nil
+}
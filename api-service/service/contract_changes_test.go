@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetContractsAsOf(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	oracleID := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	mysqlID := utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb")
+	sqlServerID := utils.Str2oid("cccccccccccccccccccccccc")
+	postgreSQLID := utils.Str2oid("dddddddddddddddddddddddd")
+	date := utils.P("2019-06-01T00:00:00Z")
+
+	changes := []model.ContractChange{
+		{
+			Technology:       model.TechnologyOracleDatabase,
+			ContractObjectID: oracleID,
+			Version:          1,
+			Operation:        model.ContractChangeOperationAdd,
+			After:            map[string]interface{}{"_id": oracleID, "contractID": "AID001", "count": 10},
+		},
+		{
+			Technology:       model.TechnologyOracleMySQL,
+			ContractObjectID: mysqlID,
+			Version:          1,
+			Operation:        model.ContractChangeOperationAdd,
+			After:            map[string]interface{}{"_id": mysqlID, "contractID": "AID002"},
+		},
+		{
+			Technology:       model.TechnologyOracleDatabase,
+			ContractObjectID: oracleID,
+			Version:          2,
+			Operation:        model.ContractChangeOperationUpdate,
+			Before:           map[string]interface{}{"_id": oracleID, "contractID": "AID001", "count": 10},
+			After:            map[string]interface{}{"_id": oracleID, "contractID": "AID001", "count": 20},
+		},
+		{
+			Technology:       model.TechnologyMicrosoftSQLServer,
+			ContractObjectID: sqlServerID,
+			Version:          1,
+			Operation:        model.ContractChangeOperationAdd,
+			After:            map[string]interface{}{"_id": sqlServerID, "contractID": "AID003"},
+		},
+		{
+			Technology:       model.TechnologyOracleMySQL,
+			ContractObjectID: mysqlID,
+			Version:          2,
+			Operation:        model.ContractChangeOperationDelete,
+			Before:           map[string]interface{}{"_id": mysqlID, "contractID": "AID002"},
+		},
+		{
+			Technology:       model.TechnologyPostgreSQLPostgreSQL,
+			ContractObjectID: postgreSQLID,
+			Version:          1,
+			Operation:        model.ContractChangeOperationAdd,
+			After:            map[string]interface{}{"_id": postgreSQLID, "contractID": "AID004", "subscriptionsNumber": 8},
+		},
+	}
+
+	db.EXPECT().ListContractChanges(dto.ContractChangesFilter{From: utils.MIN_TIME, To: date}).
+		Return(changes, nil)
+
+	actual, err := as.GetContractsAsOf(date)
+	require.NoError(t, err)
+
+	expected := &dto.ContractsAsOf{
+		Date:       date,
+		Oracle:     []model.OracleDatabaseContract{{ID: oracleID, ContractID: "AID001", Count: 20}},
+		SqlServer:  []model.SqlServerDatabaseContract{{ID: sqlServerID, ContractID: "AID003"}},
+		MySQL:      []model.MySQLContract{},
+		PostgreSQL: []model.PostgreSQLContract{{ID: postgreSQLID, ContractID: "AID004", SubscriptionsNumber: 8}},
+		MongoDB:    []model.MongoDBContract{},
+		MariaDB:    []model.MariaDBContract{},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestAuditContractChange_Error(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+
+	expectTransaction(db)
+	db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, id).Return(nil, errMock)
+
+	err := as.DeleteMySQLContract(id, "admin")
+	require.EqualError(t, err, "MockError")
+}
+
+// expectTransaction expects a transaction on db, executed with db itself
+func expectTransaction(db *MockMongoDatabaseInterface) *gomock.Call {
+	return db.EXPECT().WithTransaction(gomock.Any()).
+		DoAndReturn(func(fn func(database.MongoDatabaseInterface) error) error {
+			return fn(db)
+		})
+}
@@ -0,0 +1,766 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/schema"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// contractImporter contains what is needed to import from a csv the contracts of a technology
+type contractImporter[T any] struct {
+	technology string
+	// key identifies the existing contract updated by a row of the csv
+	key func(contract T) contractImportKey
+	// prepare completes the contract read from a row of the csv and validates it
+	prepare func(contract *T) []error
+	// merge copies into the contract read from the csv the fields of the existing one the csv doesn't contain,
+	// columns are the columns of the csv
+	merge  func(existing T, contract *T, columns map[string]bool)
+	id     func(contract T) primitive.ObjectID
+	add    func(contract T, user string) error
+	update func(contract T, user string) error
+}
+
+type contractImportKey struct {
+	contractID    string
+	csi           string
+	licenseTypeID string
+}
+
+// contractImportOperation contains the change to be saved for a row of the csv,
+// existing is nil if the contract is new
+type contractImportOperation[T any] struct {
+	Row      int `json:"row"`
+	Contract T   `json:"contract"`
+	Existing *T  `json:"existing"`
+}
+
+// PreviewContractsImport validates every row of the csv and compares it with the existing contracts, without saving anything
+func (as *APIService) PreviewContractsImport(databaseType string, reader *csv.Reader) (*dto.ContractImportPreview, error) {
+	switch databaseType {
+	case "oracle":
+		preview, _, err := previewContractsImport(as, as.oracleContractImporter(), databaseType, reader)
+		return preview, err
+	case "sqlserver":
+		preview, _, err := previewContractsImport(as, as.sqlServerContractImporter(), databaseType, reader)
+		return preview, err
+	case "mysql":
+		preview, _, err := previewContractsImport(as, as.mySQLContractImporter(), databaseType, reader)
+		return preview, err
+	case "postgresql":
+		preview, _, err := previewContractsImport(as, as.postgreSQLContractImporter(), databaseType, reader)
+		return preview, err
+	case "mongodb":
+		preview, _, err := previewContractsImport(as, as.mongoDBContractImporter(), databaseType, reader)
+		return preview, err
+	case "mariadb":
+		preview, _, err := previewContractsImport(as, as.mariaDBContractImporter(), databaseType, reader)
+		return preview, err
+	default:
+		return nil, utils.NewErrorf("%w: cannot match database type: %s", utils.ErrInvalidContractImport, databaseType)
+	}
+}
+
+// CommitContractsImport saves the new and changed contracts of the csv, in a transaction, only if every row is valid
+// and the changes are the same of the preview with hash
+func (as *APIService) CommitContractsImport(databaseType string, reader *csv.Reader, hash string, user string) (*dto.ContractImportPreview, error) {
+	switch databaseType {
+	case "oracle":
+		return commitContractsImport(as, (*APIService).oracleContractImporter, databaseType, reader, hash, user)
+	case "sqlserver":
+		return commitContractsImport(as, (*APIService).sqlServerContractImporter, databaseType, reader, hash, user)
+	case "mysql":
+		return commitContractsImport(as, (*APIService).mySQLContractImporter, databaseType, reader, hash, user)
+	case "postgresql":
+		return commitContractsImport(as, (*APIService).postgreSQLContractImporter, databaseType, reader, hash, user)
+	case "mongodb":
+		return commitContractsImport(as, (*APIService).mongoDBContractImporter, databaseType, reader, hash, user)
+	case "mariadb":
+		return commitContractsImport(as, (*APIService).mariaDBContractImporter, databaseType, reader, hash, user)
+	default:
+		return nil, utils.NewErrorf("%w: cannot match database type: %s", utils.ErrInvalidContractImport, databaseType)
+	}
+}
+
+// contractImportRecord contains the contract read from a valid row of the csv
+type contractImportRecord[T any] struct {
+	row      int
+	contract T
+}
+
+// parsedContractsImport contains the rows of the csv, read and validated without comparing them with the existing contracts
+type parsedContractsImport[T any] struct {
+	records []contractImportRecord[T]
+	errors  []dto.ContractImportError
+	// columns are the columns of the csv
+	columns map[string]bool
+}
+
+func previewContractsImport[T any](as *APIService, importer contractImporter[T], databaseType string, reader *csv.Reader,
+) (*dto.ContractImportPreview, []contractImportOperation[T], error) {
+	parsed, err := parseContractsImport(importer, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return compareContractsImport(as, importer, databaseType, parsed)
+}
+
+// parseContractsImport read every row of the csv and validate it
+func parseContractsImport[T any](importer contractImporter[T], reader *csv.Reader) (*parsedContractsImport[T], error) {
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, utils.NewErrorf("%w: the csv is empty", utils.ErrInvalidContractImport)
+	} else if err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidContractImport, err)
+	}
+
+	parsed := &parsedContractsImport[T]{
+		records: make([]contractImportRecord[T], 0),
+		errors:  make([]dto.ContractImportError, 0),
+		columns: make(map[string]bool, len(header)),
+	}
+	addError := func(row int, message string) {
+		parsed.errors = append(parsed.errors, dto.ContractImportError{Row: row, Message: message})
+	}
+
+	for _, column := range header {
+		parsed.columns[column] = true
+	}
+
+	rows := make(map[contractImportKey]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidContractImport, err)
+			}
+
+			addError(parseErr.StartLine, parseErr.Err.Error())
+
+			continue
+		}
+
+		row, _ := reader.FieldPos(0)
+
+		if len(record) != len(header) {
+			addError(row, fmt.Sprintf("expected %d fields, found %d", len(header), len(record)))
+			continue
+		}
+
+		contract, err := unmarshalContractRow[T](header, record)
+		if err != nil {
+			addError(row, err.Error())
+			continue
+		}
+
+		if errs := importer.prepare(&contract); len(errs) > 0 {
+			for _, err := range errs {
+				addError(row, err.Error())
+			}
+
+			continue
+		}
+
+		key := importer.key(contract)
+		if other, ok := rows[key]; ok {
+			addError(row, fmt.Sprintf("same contract of row %d", other))
+			continue
+		}
+
+		rows[key] = row
+
+		parsed.records = append(parsed.records, contractImportRecord[T]{row: row, contract: contract})
+	}
+
+	return parsed, nil
+}
+
+// compareContractsImport compare the rows of the csv with the existing contracts,
+// returning the preview of the import and the changes to be saved
+func compareContractsImport[T any](as *APIService, importer contractImporter[T], databaseType string, parsed *parsedContractsImport[T],
+) (*dto.ContractImportPreview, []contractImportOperation[T], error) {
+	existing, err := listImportableContracts(as, importer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview := &dto.ContractImportPreview{
+		DatabaseType: databaseType,
+		Rows:         make([]dto.ContractImportRow, 0),
+		Errors:       append(make([]dto.ContractImportError, 0, len(parsed.errors)), parsed.errors...),
+	}
+	addError := func(row int, message string) {
+		preview.Errors = append(preview.Errors, dto.ContractImportError{Row: row, Message: message})
+	}
+
+	operations := make([]contractImportOperation[T], 0)
+
+	for _, record := range parsed.records {
+		row, contract := record.row, record.contract
+		key := importer.key(contract)
+
+		importRow := dto.ContractImportRow{
+			Row:           row,
+			ContractID:    key.contractID,
+			CSI:           key.csi,
+			LicenseTypeID: key.licenseTypeID,
+		}
+
+		switch matches := existing[key]; len(matches) {
+		case 0:
+			importRow.Status = dto.ContractImportRowNew
+			preview.New++
+
+			operations = append(operations, contractImportOperation[T]{Row: row, Contract: contract})
+		case 1:
+			importer.merge(matches[0], &contract, parsed.columns)
+
+			id := importer.id(matches[0])
+			importRow.ExistingID = &id
+
+			importRow.Diff, err = diffContracts(matches[0], contract)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if len(importRow.Diff) == 0 {
+				importRow.Status = dto.ContractImportRowUnchanged
+				preview.Unchanged++
+
+				break
+			}
+
+			importRow.Status = dto.ContractImportRowChanged
+			preview.Changed++
+
+			operations = append(operations, contractImportOperation[T]{Row: row, Contract: contract, Existing: &matches[0]})
+		default:
+			addError(row, fmt.Sprintf("matches %d existing contracts", len(matches)))
+			continue
+		}
+
+		preview.Rows = append(preview.Rows, importRow)
+	}
+
+	sort.SliceStable(preview.Errors, func(i, j int) bool {
+		return preview.Errors[i].Row < preview.Errors[j].Row
+	})
+
+	preview.Valid = len(preview.Errors) == 0
+
+	if preview.Hash, err = hashContractsImport(databaseType, operations); err != nil {
+		return nil, nil, err
+	}
+
+	return preview, operations, nil
+}
+
+// hashContractsImport return the hash of the changes to be saved by the import,
+// it changes if the csv or the existing contracts change
+func hashContractsImport[T any](databaseType string, operations []contractImportOperation[T]) (string, error) {
+	raw, err := json.Marshal(struct {
+		DatabaseType string                       `json:"databaseType"`
+		Operations   []contractImportOperation[T] `json:"operations"`
+	}{databaseType, operations})
+	if err != nil {
+		return "", utils.NewError(err, "Encode ERROR")
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// commitContractsImport saves the changes of the csv in a transaction. The csv is compared with the contracts
+// read in the transaction, so a contract changed after the preview is detected by the hash
+func commitContractsImport[T any](as *APIService, newImporter func(as *APIService) contractImporter[T], databaseType string,
+	reader *csv.Reader, hash string, user string,
+) (*dto.ContractImportPreview, error) {
+	parsed, err := parseContractsImport(newImporter(as), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parsed.errors) > 0 {
+		preview, _, err := compareContractsImport(as, newImporter(as), databaseType, parsed)
+		if err != nil {
+			return nil, err
+		}
+
+		return preview, utils.NewErrorf("%w: %d errors found in the csv", utils.ErrInvalidContractImport, len(preview.Errors))
+	}
+
+	var preview *dto.ContractImportPreview
+
+	err = as.Database.WithTransaction(func(db database.MongoDatabaseInterface) error {
+		tx := *as
+		tx.Database = db
+		importer := newImporter(&tx)
+
+		var operations []contractImportOperation[T]
+
+		var err error
+
+		preview, operations, err = compareContractsImport(&tx, importer, databaseType, parsed)
+		if err != nil {
+			return err
+		}
+
+		if !preview.Valid {
+			return utils.NewErrorf("%w: %d errors found in the csv", utils.ErrInvalidContractImport, len(preview.Errors))
+		}
+
+		if preview.Hash != hash {
+			return utils.NewErrorf("%w: the csv or the contracts have changed since the preview", utils.ErrContractImportPreviewChanged)
+		}
+
+		for _, operation := range operations {
+			if operation.Existing == nil {
+				err = importer.add(operation.Contract, user)
+			} else {
+				err = importer.update(operation.Contract, user)
+			}
+
+			if err != nil {
+				return utils.NewErrorf("%w: can't import row %d, nothing has been saved", err, operation.Row)
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, utils.ErrInvalidContractImport) || errors.Is(err, utils.ErrContractImportPreviewChanged) {
+		return preview, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+func listImportableContracts[T any](as *APIService, importer contractImporter[T]) (map[contractImportKey][]T, error) {
+	docs, err := as.Database.ListContractSnapshots(importer.technology)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(map[contractImportKey][]T)
+
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			return nil, utils.NewError(err, "Decode ERROR")
+		}
+
+		var contract T
+		if err := bson.Unmarshal(raw, &contract); err != nil {
+			return nil, utils.NewError(err, "Decode ERROR")
+		}
+
+		key := importer.key(contract)
+		contracts[key] = append(contracts[key], contract)
+	}
+
+	return contracts, nil
+}
+
+func unmarshalContractRow[T any](header, record []string) (T, error) {
+	var contract T
+
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll([][]string{header, record}); err != nil {
+		return contract, err
+	}
+
+	contracts := make([]T, 0, 1)
+	if err := gocsv.UnmarshalCSV(csv.NewReader(&buf), &contracts); err != nil {
+		return contract, err
+	}
+
+	if len(contracts) != 1 {
+		return contract, errors.New("empty row")
+	}
+
+	return contracts[0], nil
+}
+
+// diffContracts return the fields, except the ID, with a different value in the two contracts
+func diffContracts(before, after interface{}) ([]dto.ContractFieldDiff, error) {
+	beforeDoc, err := contractDocument(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterDoc, err := contractDocument(after)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(afterDoc))
+
+	for field := range afterDoc {
+		fields = append(fields, field)
+	}
+
+	for field := range beforeDoc {
+		if _, ok := afterDoc[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	diff := make([]dto.ContractFieldDiff, 0)
+
+	for _, field := range fields {
+		if field == "_id" || sameContractValue(beforeDoc[field], afterDoc[field]) {
+			continue
+		}
+
+		diff = append(diff, dto.ContractFieldDiff{Field: field, Before: beforeDoc[field], After: afterDoc[field]})
+	}
+
+	return diff, nil
+}
+
+func contractDocument(contract interface{}) (map[string]interface{}, error) {
+	raw, err := bson.Marshal(contract)
+	if err != nil {
+		return nil, utils.NewError(err, "Encode ERROR")
+	}
+
+	doc := make(map[string]interface{})
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return doc, nil
+}
+
+// sameContractValue compares the values of a field, considering a missing list equal to an empty one
+func sameContractValue(a, b interface{}) bool {
+	isEmpty := func(v interface{}) bool {
+		list, ok := v.(primitive.A)
+		return v == nil || ok && len(list) == 0
+	}
+
+	if isEmpty(a) && isEmpty(b) {
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func (as *APIService) oracleContractImporter() contractImporter[model.OracleDatabaseContract] {
+	return contractImporter[model.OracleDatabaseContract]{
+		technology: model.TechnologyOracleDatabase,
+		key: func(contract model.OracleDatabaseContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, csi: contract.CSI, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.OracleDatabaseContract) []error {
+			raw, err := json.Marshal(contract)
+			if err != nil {
+				return []error{err}
+			}
+
+			errs := make([]error, 0)
+
+			if err := schema.ValidateOracleContract(raw); err != nil {
+				errs = append(errs, err)
+			}
+
+			if err := contract.Check(); err != nil {
+				errs = append(errs, err)
+			}
+
+			if len(contract.HostsLiteral) > 0 {
+				contract.Hosts = strings.Split(string(contract.HostsLiteral), ",")
+			}
+
+			if hosts, err := checkHosts(as, contract.Hosts); err != nil {
+				errs = append(errs, err)
+			} else {
+				contract.Hosts = hosts
+			}
+
+			if err := checkLicenseTypeIDExists(as, contract); err != nil {
+				errs = append(errs, err)
+			}
+
+			return errs
+		},
+		merge: func(existing model.OracleDatabaseContract, contract *model.OracleDatabaseContract, columns map[string]bool) {
+			contract.ID = existing.ID
+
+			if !columns["Reference Number"] {
+				contract.ReferenceNumber = existing.ReferenceNumber
+			}
+
+			if !columns["ULA"] {
+				contract.Unlimited = existing.Unlimited
+			}
+
+			if !columns["License number"] {
+				contract.Count = existing.Count
+			}
+
+			if !columns["Basket"] {
+				contract.Basket = existing.Basket
+			}
+
+			if !columns["Restricted"] {
+				contract.Restricted = existing.Restricted
+			}
+
+			if !columns["Support Expiration"] {
+				contract.SupportExpiration = existing.SupportExpiration
+			}
+
+			if !columns["Hosts"] {
+				contract.Hosts = existing.Hosts
+			}
+
+			if !columns["Status"] {
+				contract.Status = existing.Status
+			}
+
+			if !columns["Product Order Date"] {
+				contract.ProductOrderDate = existing.ProductOrderDate
+			}
+
+			if !columns["Location"] {
+				contract.Location = existing.Location
+			}
+		},
+		id: func(contract model.OracleDatabaseContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.OracleDatabaseContract, user string) error {
+			_, err := as.AddOracleDatabaseContract(contract, user)
+			return err
+		},
+		update: func(contract model.OracleDatabaseContract, user string) error {
+			_, err := as.UpdateOracleDatabaseContract(contract, user)
+			return err
+		},
+	}
+}
+
+func (as *APIService) sqlServerContractImporter() contractImporter[model.SqlServerDatabaseContract] {
+	return contractImporter[model.SqlServerDatabaseContract]{
+		technology: model.TechnologyMicrosoftSQLServer,
+		key: func(contract model.SqlServerDatabaseContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.SqlServerDatabaseContract) []error {
+			if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
+				return []error{err}
+			}
+
+			return nil
+		},
+		merge: func(existing model.SqlServerDatabaseContract, contract *model.SqlServerDatabaseContract, _ map[string]bool) {
+			contract.ID = existing.ID
+			contract.SupportExpiration = existing.SupportExpiration
+			contract.Hosts = existing.Hosts
+			contract.Clusters = existing.Clusters
+		},
+		id: func(contract model.SqlServerDatabaseContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.SqlServerDatabaseContract, user string) error {
+			_, err := as.AddSqlServerDatabaseContract(contract, user)
+			return err
+		},
+		update: func(contract model.SqlServerDatabaseContract, user string) error {
+			_, err := as.UpdateSqlServerDatabaseContract(contract, user)
+			return err
+		},
+	}
+}
+
+func (as *APIService) mySQLContractImporter() contractImporter[model.MySQLContract] {
+	return contractImporter[model.MySQLContract]{
+		technology: model.TechnologyOracleMySQL,
+		key: func(contract model.MySQLContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, csi: contract.CSI, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.MySQLContract) []error {
+			if !contract.IsValid() {
+				return []error{errors.New("Contract isn't valid")}
+			}
+
+			return nil
+		},
+		merge: func(existing model.MySQLContract, contract *model.MySQLContract, _ map[string]bool) {
+			contract.ID = existing.ID
+			contract.SupportExpiration = existing.SupportExpiration
+			contract.Hosts = existing.Hosts
+			contract.Clusters = existing.Clusters
+		},
+		id: func(contract model.MySQLContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.MySQLContract, user string) error {
+			_, err := as.AddMySQLContract(contract, user)
+			return err
+		},
+		update: func(contract model.MySQLContract, user string) error {
+			_, err := as.UpdateMySQLContract(contract, user)
+			return err
+		},
+	}
+}
+
+func (as *APIService) postgreSQLContractImporter() contractImporter[model.PostgreSQLContract] {
+	return contractImporter[model.PostgreSQLContract]{
+		technology: model.TechnologyPostgreSQLPostgreSQL,
+		key: func(contract model.PostgreSQLContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.PostgreSQLContract) []error {
+			if !contract.IsValid() {
+				return []error{errors.New("Contract isn't valid")}
+			}
+
+			if _, err := as.GetPostgreSQLLicenseType(contract.LicenseTypeID); err != nil {
+				return []error{err}
+			}
+
+			return nil
+		},
+		merge: func(existing model.PostgreSQLContract, contract *model.PostgreSQLContract, _ map[string]bool) {
+			contract.ID = existing.ID
+			contract.SupportExpiration = existing.SupportExpiration
+			contract.Hosts = existing.Hosts
+			contract.Clusters = existing.Clusters
+		},
+		id: func(contract model.PostgreSQLContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.PostgreSQLContract, user string) error {
+			_, err := as.AddPostgreSQLContract(contract, user)
+			return err
+		},
+		update: func(contract model.PostgreSQLContract, user string) error {
+			_, err := as.UpdatePostgreSQLContract(contract, user)
+			return err
+		},
+	}
+}
+
+func (as *APIService) mongoDBContractImporter() contractImporter[model.MongoDBContract] {
+	return contractImporter[model.MongoDBContract]{
+		technology: model.TechnologyMongoDBMongoDB,
+		key: func(contract model.MongoDBContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.MongoDBContract) []error {
+			if !contract.IsValid() {
+				return []error{errors.New("Contract isn't valid")}
+			}
+
+			if _, err := as.GetMongoDBLicenseType(contract.LicenseTypeID); err != nil {
+				return []error{err}
+			}
+
+			return nil
+		},
+		merge: func(existing model.MongoDBContract, contract *model.MongoDBContract, _ map[string]bool) {
+			contract.ID = existing.ID
+			contract.SupportExpiration = existing.SupportExpiration
+			contract.Hosts = existing.Hosts
+			contract.ReplicaSets = existing.ReplicaSets
+		},
+		id: func(contract model.MongoDBContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.MongoDBContract, user string) error {
+			_, err := as.AddMongoDBContract(contract, user)
+			return err
+		},
+		update: func(contract model.MongoDBContract, user string) error {
+			_, err := as.UpdateMongoDBContract(contract, user)
+			return err
+		},
+	}
+}
+
+func (as *APIService) mariaDBContractImporter() contractImporter[model.MariaDBContract] {
+	return contractImporter[model.MariaDBContract]{
+		technology: model.TechnologyMariaDBFoundationMariaDB,
+		key: func(contract model.MariaDBContract) contractImportKey {
+			return contractImportKey{contractID: contract.ContractID, licenseTypeID: contract.LicenseTypeID}
+		},
+		prepare: func(contract *model.MariaDBContract) []error {
+			if !contract.IsValid() {
+				return []error{errors.New("Contract isn't valid")}
+			}
+
+			if _, err := as.GetMariaDBLicenseType(contract.LicenseTypeID); err != nil {
+				return []error{err}
+			}
+
+			return nil
+		},
+		merge: func(existing model.MariaDBContract, contract *model.MariaDBContract, _ map[string]bool) {
+			contract.ID = existing.ID
+			contract.SupportExpiration = existing.SupportExpiration
+			contract.Hosts = existing.Hosts
+		},
+		id: func(contract model.MariaDBContract) primitive.ObjectID {
+			return contract.ID
+		},
+		add: func(contract model.MariaDBContract, user string) error {
+			_, err := as.AddMariaDBContract(contract, user)
+			return err
+		},
+		update: func(contract model.MariaDBContract, user string) error {
+			_, err := as.UpdateMariaDBContract(contract, user)
+			return err
+		},
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var existingMySQLContract = model.MySQLContract{
+	ID:               utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+	Type:             model.MySQLContractTypeHost,
+	ContractID:       "C1",
+	CSI:              "CSI1",
+	LicenseTypeID:    model.MySqlPartNumber,
+	NumberOfLicenses: 10,
+	Hosts:            []string{"pippo"},
+	Location:         "Italy",
+}
+
+func existingMySQLContractSnapshots(t *testing.T) []map[string]interface{} {
+	doc, err := contractDocument(existingMySQLContract)
+	require.NoError(t, err)
+
+	return []map[string]interface{}{doc}
+}
+
+func TestPreviewContractsImport_MySQL(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C1,CSI1,B64911,20,Italy
+HOST,C2,CSI2,B64911,5,Italy
+CLUSTER,C3,CSI3,B64911,0,Italy
+HOST,C2,CSI2,B64911,5,Italy
+HOST,C4,CSI4
+HOST,C1,CSI1,B64911,10,Italy`
+
+	db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+		Return(existingMySQLContractSnapshots(t), nil)
+
+	actual, err := as.PreviewContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)))
+	require.NoError(t, err)
+
+	existingID := existingMySQLContract.ID
+	expected := &dto.ContractImportPreview{
+		DatabaseType: "mysql",
+		Valid:        false,
+		New:          1,
+		Changed:      1,
+		Rows: []dto.ContractImportRow{
+			{
+				Row:           2,
+				Status:        dto.ContractImportRowChanged,
+				ContractID:    "C1",
+				CSI:           "CSI1",
+				LicenseTypeID: model.MySqlPartNumber,
+				ExistingID:    &existingID,
+				Diff: []dto.ContractFieldDiff{
+					{Field: "numberOfLicenses", Before: int64(10), After: int64(20)},
+				},
+			},
+			{
+				Row:           3,
+				Status:        dto.ContractImportRowNew,
+				ContractID:    "C2",
+				CSI:           "CSI2",
+				LicenseTypeID: model.MySqlPartNumber,
+			},
+		},
+		Errors: []dto.ContractImportError{
+			{Row: 4, Message: "Contract isn't valid"},
+			{Row: 5, Message: "same contract of row 3"},
+			{Row: 6, Message: "expected 6 fields, found 3"},
+			{Row: 7, Message: "same contract of row 2"},
+		},
+	}
+
+	assert.Len(t, actual.Hash, 64)
+	expected.Hash = actual.Hash
+	assert.Equal(t, expected, actual)
+}
+
+func TestPreviewContractsImport_Unchanged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C1,CSI1,B64911,10,Italy`
+
+	db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+		Return(existingMySQLContractSnapshots(t), nil)
+
+	actual, err := as.PreviewContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)))
+	require.NoError(t, err)
+
+	assert.True(t, actual.Valid)
+	assert.Equal(t, 1, actual.Unchanged)
+	require.Len(t, actual.Rows, 1)
+	assert.Equal(t, dto.ContractImportRowUnchanged, actual.Rows[0].Status)
+	assert.Empty(t, actual.Rows[0].Diff)
+}
+
+func TestCommitContractsImport_InvalidCSV(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C2,CSI2,B64911,5,Italy
+CLUSTER,C3,CSI3,B64911,0,Italy`
+
+	db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+		Return(existingMySQLContractSnapshots(t), nil)
+
+	actual, err := as.CommitContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)), "", "admin")
+	require.ErrorIs(t, err, utils.ErrInvalidContractImport)
+	assert.False(t, actual.Valid)
+	assert.Len(t, actual.Errors, 1)
+}
+
+// previewHash return the hash of the preview of the import of csvFile
+func previewHash(t *testing.T, as *APIService, db *MockMongoDatabaseInterface, databaseType, csvFile string) string {
+	db.EXPECT().ListContractSnapshots(gomock.Any()).
+		Return(existingMySQLContractSnapshots(t), nil)
+
+	preview, err := as.PreviewContractsImport(databaseType, csv.NewReader(strings.NewReader(csvFile)))
+	require.NoError(t, err)
+
+	return preview.Hash
+}
+
+func TestCommitContractsImport_ErrorInTransaction(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		Log:         logger.NewLogger("TEST"),
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C1,CSI1,B64911,20,Italy
+HOST,C2,CSI2,B64911,5,Italy`
+
+	hash := previewHash(t, &as, db, "mysql", csvFile)
+
+	updated := existingMySQLContract
+	updated.NumberOfLicenses = 20
+
+	newID := utils.Str2oid("000000000000000000000002")
+	added := model.MySQLContract{
+		ID:               newID,
+		Type:             model.MySQLContractTypeHost,
+		ContractID:       "C2",
+		CSI:              "CSI2",
+		LicenseTypeID:    model.MySqlPartNumber,
+		NumberOfLicenses: 5,
+		Location:         "Italy",
+	}
+
+	gomock.InOrder(
+		expectTransaction(db),
+		db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+			Return(existingMySQLContractSnapshots(t), nil),
+
+		expectTransaction(db),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, existingMySQLContract.ID).Return(nil, nil),
+		db.EXPECT().UpdateMySQLContract(updated).Return(nil),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, existingMySQLContract.ID).Return(nil, nil),
+		db.EXPECT().InsertContractChange(gomock.Any()).Return(nil),
+
+		expectTransaction(db),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, newID).Return(nil, nil),
+		db.EXPECT().AddMySQLContract(added).Return(errMock),
+	)
+
+	actual, err := as.CommitContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)), hash, "admin")
+	require.ErrorIs(t, err, errMock)
+	assert.Nil(t, actual)
+}
+
+func TestCommitContractsImport_PreviewChanged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	hash := previewHash(t, &as, db, "mysql", `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C1,CSI1,B64911,20,Italy`)
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C1,CSI1,B64911,30,Italy`
+
+	expectTransaction(db)
+	db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+		Return(existingMySQLContractSnapshots(t), nil)
+
+	actual, err := as.CommitContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)), hash, "admin")
+	require.ErrorIs(t, err, utils.ErrContractImportPreviewChanged)
+	require.NotNil(t, actual)
+	assert.NotEqual(t, hash, actual.Hash)
+}
+
+func TestCommitContractsImport_TransactionsNotSupported(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C2,CSI2,B64911,5,Italy`
+
+	hash := previewHash(t, &as, db, "mysql", csvFile)
+
+	db.EXPECT().WithTransaction(gomock.Any()).Return(utils.ErrTransactionsNotSupported)
+
+	actual, err := as.CommitContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)), hash, "admin")
+	require.ErrorIs(t, err, utils.ErrTransactionsNotSupported)
+	assert.Nil(t, actual)
+}
+
+func TestCommitContractsImport_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		Log:         logger.NewLogger("TEST"),
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	csvFile := `Type,Contract Number,CSI,License Type,Number of Licenses,Location
+HOST,C2,CSI2,B64911,5,Italy`
+
+	hash := previewHash(t, &as, db, "mysql", csvFile)
+
+	db.EXPECT().ListContractSnapshots(model.TechnologyOracleMySQL).
+		Return(existingMySQLContractSnapshots(t), nil)
+	expectTransaction(db).Times(2)
+	db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, gomock.Any()).Return(nil, nil).Times(2)
+	db.EXPECT().AddMySQLContract(gomock.Any()).Return(nil)
+	db.EXPECT().InsertContractChange(gomock.Any()).Return(nil)
+
+	actual, err := as.CommitContractsImport("mysql", csv.NewReader(strings.NewReader(csvFile)), hash, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, 1, actual.New)
+	assert.Equal(t, []dto.ContractImportRow{
+		{Row: 2, Status: dto.ContractImportRowNew, ContractID: "C2", CSI: "CSI2", LicenseTypeID: model.MySqlPartNumber},
+	}, actual.Rows)
+}
+
+func TestOracleContractImporter_Merge(t *testing.T) {
+	as := APIService{}
+
+	existing := model.OracleDatabaseContract{
+		ID:              utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		ContractID:      "C1",
+		CSI:             "CSI1",
+		LicenseTypeID:   "A90611",
+		ReferenceNumber: "R1",
+		Count:           10,
+		Basket:          true,
+		Hosts:           []string{"pippo"},
+		Status:          "ACTIVE",
+		Location:        "Italy",
+	}
+
+	contract := model.OracleDatabaseContract{
+		ContractID:    "C1",
+		CSI:           "CSI1",
+		LicenseTypeID: "A90611",
+		Count:         20,
+		Location:      "Germany",
+	}
+
+	columns := map[string]bool{"Contract Number": true, "CSI": true, "Part Number": true, "License number": true, "Location": true}
+	as.oracleContractImporter().merge(existing, &contract, columns)
+
+	expected := existing
+	expected.Count = 20
+	expected.Location = "Germany"
+	assert.Equal(t, expected, contract)
+}
+
+func TestDiffContracts(t *testing.T) {
+	before := model.MySQLContract{ID: primitive.NewObjectID(), ContractID: "C1", Hosts: nil}
+	after := model.MySQLContract{ContractID: "C1", Hosts: []string{}, Location: "Italy"}
+
+	actual, err := diffContracts(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, []dto.ContractFieldDiff{{Field: "location", Before: "", After: "Italy"}}, actual)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// contractManager contains what is needed to add, update and delete the contracts of a technology
+type contractManager[T any] struct {
+	technology string
+	// id return the ID of the contract, so that it can be set when the contract is added
+	id func(contract *T) *primitive.ObjectID
+	// prepare checks the hosts and the license type of the contract, replacing its hosts with the resolved ones
+	prepare func(contract *T) error
+	insert  func(db database.MongoDatabaseInterface, contract T) (*T, error)
+	update  func(db database.MongoDatabaseInterface, contract T) error
+	remove  func(db database.MongoDatabaseInterface, id primitive.ObjectID) error
+}
+
+func addContract[T any](as *APIService, manager contractManager[T], contract T, user string) (*T, error) {
+	if err := manager.prepare(&contract); err != nil {
+		return nil, err
+	}
+
+	id := manager.id(&contract)
+	*id = as.NewObjectID()
+
+	var res *T
+
+	err := as.auditContractChange(manager.technology, *id, model.ContractChangeOperationAdd, user, func(db database.MongoDatabaseInterface) error {
+		var err error
+
+		res, err = manager.insert(db, contract)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func updateContract[T any](as *APIService, manager contractManager[T], contract T, user string) (*T, error) {
+	if err := manager.prepare(&contract); err != nil {
+		return nil, err
+	}
+
+	err := as.auditContractChange(manager.technology, *manager.id(&contract), model.ContractChangeOperationUpdate, user, func(db database.MongoDatabaseInterface) error {
+		return manager.update(db, contract)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &contract, nil
+}
+
+func deleteContract[T any](as *APIService, manager contractManager[T], id primitive.ObjectID, user string) error {
+	return as.auditContractChange(manager.technology, id, model.ContractChangeOperationDelete, user, func(db database.MongoDatabaseInterface) error {
+		return manager.remove(db, id)
+	})
+}
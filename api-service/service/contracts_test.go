@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestAddMariaDBContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	hosts := []map[string]interface{}{{"hostname": "maria1"}}
+	contract := model.MariaDBContract{
+		ContractID:    "MARIADB-001",
+		LicenseTypeID: "MARIADB-ES-SERVER",
+		ServersNumber: 4,
+		Hosts:         []string{"maria1"},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expected := contract
+		expected.ID = utils.Str2oid("000000000000000000000001")
+
+		db.EXPECT().SearchHosts("hostnames", dto.NewSearchHostsFilters()).Return(hosts, nil)
+		db.EXPECT().GetMariaDBLicenseType("MARIADB-ES-SERVER").Return(&model.MariaDBLicenseType{ID: "MARIADB-ES-SERVER"}, nil)
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyMariaDBFoundationMariaDB, expected.ID).Return(nil, nil)
+		db.EXPECT().InsertMariaDBContract(expected).Return(&expected, nil)
+		db.EXPECT().GetContractSnapshot(model.TechnologyMariaDBFoundationMariaDB, expected.ID).
+			Return(map[string]interface{}{"_id": expected.ID}, nil)
+		db.EXPECT().InsertContractChange(gomock.Any()).
+			Do(func(change model.ContractChange) {
+				assert.Equal(t, model.ContractChangeOperationAdd, change.Operation)
+				assert.Equal(t, "user", change.User)
+				assert.Nil(t, change.Before)
+			}).Return(nil)
+
+		actual, err := as.AddMariaDBContract(contract, "user")
+		require.NoError(t, err)
+		assert.Equal(t, &expected, actual)
+	})
+
+	t.Run("License type not found", func(t *testing.T) {
+		db.EXPECT().SearchHosts("hostnames", dto.NewSearchHostsFilters()).Return(hosts, nil)
+		db.EXPECT().GetMariaDBLicenseType("MARIADB-ES-SERVER").Return(nil, utils.ErrMariaDBLicenseTypeIDNotFound)
+
+		_, err := as.AddMariaDBContract(contract, "user")
+		require.ErrorIs(t, err, utils.ErrMariaDBLicenseTypeIDNotFound)
+	})
+}
+
+func TestUpdateMongoDBContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	contract := model.MongoDBContract{
+		ID:            utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+		ContractID:    "MONGODB-001",
+		LicenseTypeID: "MONGODB-EA",
+		ServersNumber: 2,
+		Hosts:         []string{},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().SearchHosts("hostnames", dto.NewSearchHostsFilters()).Return(nil, nil)
+		db.EXPECT().GetMongoDBLicenseType("MONGODB-EA").Return(&model.MongoDBLicenseType{ID: "MONGODB-EA"}, nil)
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyMongoDBMongoDB, contract.ID).
+			Return(map[string]interface{}{"_id": contract.ID}, nil).Times(2)
+		db.EXPECT().UpdateMongoDBContract(contract).Return(nil)
+		db.EXPECT().InsertContractChange(gomock.Any()).
+			Do(func(change model.ContractChange) {
+				assert.Equal(t, model.ContractChangeOperationUpdate, change.Operation)
+			}).Return(nil)
+
+		actual, err := as.UpdateMongoDBContract(contract, "user")
+		require.NoError(t, err)
+		assert.Equal(t, &contract, actual)
+	})
+
+	t.Run("Contract not found", func(t *testing.T) {
+		db.EXPECT().SearchHosts("hostnames", dto.NewSearchHostsFilters()).Return(nil, nil)
+		db.EXPECT().GetMongoDBLicenseType("MONGODB-EA").Return(&model.MongoDBLicenseType{ID: "MONGODB-EA"}, nil)
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyMongoDBMongoDB, contract.ID).Return(nil, nil)
+		db.EXPECT().UpdateMongoDBContract(contract).Return(utils.ErrContractNotFound)
+
+		_, err := as.UpdateMongoDBContract(contract, "user")
+		require.ErrorIs(t, err, utils.ErrContractNotFound)
+	})
+}
+
+func TestDeletePostgreSQLContract(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+
+	expectTransaction(db)
+	db.EXPECT().GetContractSnapshot(model.TechnologyPostgreSQLPostgreSQL, id).
+		Return(map[string]interface{}{"_id": id}, nil)
+	db.EXPECT().RemovePostgreSQLContract(id).Return(nil)
+	db.EXPECT().GetContractSnapshot(model.TechnologyPostgreSQLPostgreSQL, id).Return(nil, nil)
+	db.EXPECT().InsertContractChange(gomock.Any()).
+		Do(func(change model.ContractChange) {
+			assert.Equal(t, model.ContractChangeOperationDelete, change.Operation)
+			assert.Nil(t, change.After)
+		}).Return(nil)
+
+	require.NoError(t, as.DeletePostgreSQLContract(id, "user"))
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (as *APIService) ListCoreFactorPolicies() ([]model.CoreFactorPolicy, error) {
+	return as.Database.ListCoreFactorPolicies()
+}
+
+func (as *APIService) GetCoreFactorPolicy(version int) (*model.CoreFactorPolicy, error) {
+	return as.Database.GetCoreFactorPolicy(version)
+}
+
+// GetActiveCoreFactorPolicy return the active core factor policy, or the default one if no policy has been saved
+func (as *APIService) GetActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error) {
+	policy, err := as.Database.GetActiveCoreFactorPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		defaultPolicy := model.DefaultCoreFactorPolicy()
+		return &defaultPolicy, nil
+	}
+
+	return policy, nil
+}
+
+// AddCoreFactorPolicy saves the rules of req as the next version of the policy and activates it
+func (as *APIService) AddCoreFactorPolicy(req dto.CoreFactorPolicyRequest) (*model.CoreFactorPolicy, error) {
+	policy := model.CoreFactorPolicy{
+		ID:                as.NewObjectID(),
+		Version:           1,
+		Description:       req.Description,
+		Active:            false,
+		DefaultCoreFactor: req.DefaultCoreFactor,
+		CoreFactorRules:   req.CoreFactorRules,
+		CloudRules:        req.CloudRules,
+		CreatedAt:         as.TimeNow(),
+	}
+
+	if policy.CoreFactorRules == nil {
+		policy.CoreFactorRules = make([]model.CoreFactorRule, 0)
+	}
+
+	if policy.CloudRules == nil {
+		policy.CloudRules = make([]model.CloudVCPURule, 0)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidCoreFactorPolicy, err)
+	}
+
+	policies, err := as.Database.ListCoreFactorPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range policies {
+		if p.Version >= policy.Version {
+			policy.Version = p.Version + 1
+		}
+	}
+
+	if err := as.Database.InsertCoreFactorPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	if err := as.Database.ActivateCoreFactorPolicy(policy.Version); err != nil {
+		return nil, err
+	}
+
+	policy.Active = true
+
+	return &policy, nil
+}
+
+func (as *APIService) ActivateCoreFactorPolicy(version int) error {
+	return as.Database.ActivateCoreFactorPolicy(version)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetActiveCoreFactorPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	db.EXPECT().GetActiveCoreFactorPolicy().Return(nil, nil)
+
+	actual, err := as.GetActiveCoreFactorPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultCoreFactorPolicy(), *actual)
+
+	saved := &model.CoreFactorPolicy{Version: 3, Active: true, DefaultCoreFactor: 1}
+	db.EXPECT().GetActiveCoreFactorPolicy().Return(saved, nil)
+
+	actual, err = as.GetActiveCoreFactorPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, saved, actual)
+}
+
+func TestAddCoreFactorPolicy(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			db.EXPECT().ListCoreFactorPolicies().
+				Return([]model.CoreFactorPolicy{{Version: 2}, {Version: 1}}, nil),
+			db.EXPECT().InsertCoreFactorPolicy(gomock.Any()).
+				Do(func(policy model.CoreFactorPolicy) {
+					assert.Equal(t, 3, policy.Version)
+					assert.False(t, policy.Active)
+					assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), policy.CreatedAt)
+				}).Return(nil),
+			db.EXPECT().ActivateCoreFactorPolicy(3).Return(nil),
+		)
+
+		actual, err := as.AddCoreFactorPolicy(dto.CoreFactorPolicyRequest{
+			Description:       "OCI without hyperthreading",
+			DefaultCoreFactor: 0.5,
+			CloudRules: []model.CloudVCPURule{
+				{Membership: model.CloudMembershipOci, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 1},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, actual.Version)
+		assert.True(t, actual.Active)
+		assert.Equal(t, []model.CoreFactorRule{}, actual.CoreFactorRules)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := as.AddCoreFactorPolicy(dto.CoreFactorPolicyRequest{
+			DefaultCoreFactor: 0.5,
+			CoreFactorRules:   []model.CoreFactorRule{{CPUModel: "sparc", CoreFactor: 0}},
+		})
+		assert.ErrorIs(t, err, utils.ErrInvalidCoreFactorPolicy)
+	})
+}
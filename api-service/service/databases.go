@@ -18,6 +18,7 @@ package service
 
 import (
 	"errors"
+	"math"
 	"sort"
 	"strings"
 
@@ -283,7 +284,8 @@ func (as *APIService) GetDatabasesStatistics(filter dto.GlobalFilter) (*dto.Data
 func (as *APIService) GetUsedLicensesPerDatabases(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
 	type getter func(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error)
 
-	getters := []getter{as.getOracleDatabasesUsedLicenses, as.getMySQLUsedLicenses, as.getSqlServerDatabasesUsedLicenses}
+	getters := []getter{as.getOracleDatabasesUsedLicenses, as.getMySQLUsedLicenses, as.getSqlServerDatabasesUsedLicenses,
+		as.GetPostgreSQLUsedLicenses, as.GetMongoDBUsedLicenses, as.GetMariaDBUsedLicenses}
 
 	usedLicenses := make([]dto.DatabaseUsedLicense, 0)
 
@@ -332,7 +334,12 @@ func (as *APIService) veritasClusterLicenses(hostdata *model.HostDataBE, hostdat
 
 	clusterName := strings.Join(hostnames, ",")
 
-	return float64(clusterCores) * hostdata.CoreFactor(), clusterName, "VeritasCluster", nil
+	policy, err := as.GetActiveCoreFactorPolicy()
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	return float64(clusterCores) * hostdata.CoreFactor(*policy), clusterName, "VeritasCluster", nil
 }
 
 func (as *APIService) GetUsedLicensesPerDatabasesAsXLSX(filter dto.GlobalFilter) (*excelize.File, error) {
@@ -518,7 +525,7 @@ func (as *APIService) getOracleDatabasesUsedLicenses(hostname string, filter dto
 
 	usedLicenses = as.manageStandardDBVersionLicenses(usedLicenses, clusters, hostdatasPerHostname)
 
-	as.CalcVeritasClusterLicenses(usedLicenses)
+	as.CalcVeritasClusterLicenses(usedLicenses, hostdatasPerHostname)
 
 	errHypervisor := as.checkOlvmCappedHypervisorLicenses(hypervisorLicenses, clustersMap)
 	if errHypervisor != nil {
@@ -654,7 +661,7 @@ func (as *APIService) manageStandardDBVersionLicenses(usedLicenses []dto.Databas
 	return usedLicenses
 }
 
-func (as *APIService) CalcVeritasClusterLicenses(usedLicenses []dto.DatabaseUsedLicense) {
+func (as *APIService) CalcVeritasClusterLicenses(usedLicenses []dto.DatabaseUsedLicense, hostdatas map[string]*model.HostDataBE) {
 	for i := 0; i < len(usedLicenses); i++ {
 		ul := &usedLicenses[i]
 
@@ -664,7 +671,7 @@ func (as *APIService) CalcVeritasClusterLicenses(usedLicenses []dto.DatabaseUsed
 			ul.ClusterLicenses = used
 		}
 
-		if ul.ClusterType == "VeritasCluster" && strings.Contains(ul.Hostname, "_DR") {
+		if ul.ClusterType == "VeritasCluster" && hostdatas[ul.Hostname] != nil && hostdatas[ul.Hostname].IsDR {
 			existingHosts := make([]string, 0)
 
 			for _, clusterHost := range strings.Split(ul.ClusterName, ",") {
@@ -710,29 +717,20 @@ func (as *APIService) getMySQLUsedLicenses(hostname string, filter dto.GlobalFil
 }
 
 func (as *APIService) GetDatabaseLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error) {
-	licenses := make([]dto.LicenseCompliance, 0)
-
-	oracle, err := as.GetOracleDatabaseLicensesCompliance(locations)
-	if err != nil {
-		return nil, err
-	}
-
-	licenses = append(licenses, oracle...)
-
-	mysql, err := as.GetMySQLDatabaseLicensesCompliance(locations)
+	technologies, err := as.getDatabaseLicensesComplianceByTechnology(locations)
 	if err != nil {
 		return nil, err
 	}
 
-	licenses = append(licenses, mysql...)
+	licenses := make([]dto.LicenseCompliance, 0)
 
-	sqlServer, err := as.GetSqlServerDatabaseLicensesCompliance(locations)
-	if err != nil {
-		return nil, err
+	for _, technology := range technologies {
+		for _, license := range technology.licenses {
+			license.Technology = technology.technology
+			licenses = append(licenses, license)
+		}
 	}
 
-	licenses = append(licenses, sqlServer...)
-
 	for i := 0; i < len(licenses); {
 		l := licenses[i]
 
@@ -747,6 +745,68 @@ func (as *APIService) GetDatabaseLicensesCompliance(locations []string) ([]dto.L
 	return licenses, nil
 }
 
+// licensesComplianceList completes the coverage and the compliance of the licenses from their consumed and purchased ones,
+// sorted by license type
+func licensesComplianceList(licenses map[string]*dto.LicenseCompliance) []dto.LicenseCompliance {
+	result := make([]dto.LicenseCompliance, 0, len(licenses))
+
+	for _, license := range licenses {
+		license.Covered = math.Min(license.Consumed, license.Purchased)
+		license.Available = license.Purchased - license.Covered
+
+		if license.Consumed == 0 {
+			license.Compliance = 1
+		} else {
+			license.Compliance = license.Covered / license.Consumed
+		}
+
+		result = append(result, *license)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LicenseTypeID < result[j].LicenseTypeID
+	})
+
+	return result
+}
+
+// technologyLicensesCompliance contains the compliance of the licenses of a technology
+type technologyLicensesCompliance struct {
+	technology string
+	licenses   []dto.LicenseCompliance
+}
+
+// getDatabaseLicensesComplianceByTechnology return the compliance of the licenses of each database technology
+func (as *APIService) getDatabaseLicensesComplianceByTechnology(locations []string) ([]technologyLicensesCompliance, error) {
+	getters := []struct {
+		technology string
+		get        func(locations []string) ([]dto.LicenseCompliance, error)
+	}{
+		{model.TechnologyOracleDatabase, as.GetOracleDatabaseLicensesCompliance},
+		{model.TechnologyOracleMySQL, as.GetMySQLDatabaseLicensesCompliance},
+		{model.TechnologyMicrosoftSQLServer, as.GetSqlServerDatabaseLicensesCompliance},
+		{model.TechnologyPostgreSQLPostgreSQL, as.GetPostgreSQLLicensesCompliance},
+		{model.TechnologyMongoDBMongoDB, as.GetMongoDBLicensesCompliance},
+		{model.TechnologyMariaDBFoundationMariaDB, as.GetMariaDBLicensesCompliance},
+	}
+
+	technologies := make([]technologyLicensesCompliance, 0, len(getters))
+
+	for _, getter := range getters {
+		licenses, err := getter.get(locations)
+		if err != nil {
+			return nil, err
+		}
+
+		technologies = append(technologies, technologyLicensesCompliance{
+			technology: getter.technology,
+			licenses:   licenses,
+		})
+	}
+
+	return technologies, nil
+}
+
 func (as *APIService) GetDatabaseLicensesComplianceAsXLSX(locations []string) (*excelize.File, error) {
 	licenses, err := as.GetDatabaseLicensesCompliance(locations)
 	if err != nil {
@@ -692,6 +692,12 @@ func TestGetUsedLicensesPerDatabases_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	usedLicensesMySQL := []dto.MySQLUsedLicense{
 		{
@@ -866,6 +872,12 @@ func TestGetUsedLicensesPerDatabases_VMWareCluster_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	usedLicensesMySQL := []dto.MySQLUsedLicense{}
 	clusters := []dto.Cluster{
@@ -973,6 +985,12 @@ func TestGetUsedLicensesPerDatabases_VeritasCluster_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	usedLicensesMySQL := []dto.MySQLUsedLicense{}
 	clusters := []dto.Cluster{}
@@ -1017,6 +1035,8 @@ func TestGetUsedLicensesPerDatabases_VeritasCluster_Success(t *testing.T) {
 			Return(hostdatas, nil),
 		db.EXPECT().GetClusters(globalFilterAny).
 			Return(clusters, nil),
+		db.EXPECT().GetActiveCoreFactorPolicy().
+			Return(nil, nil).AnyTimes(),
 		db.EXPECT().GetMySQLUsedLicenses("", globalFilter).
 			Return(usedLicensesMySQL, nil),
 		db.EXPECT().GetClusters(globalFilterAny).
@@ -1080,6 +1100,12 @@ func TestGetUsedLicensesPerDatabasesAsXLSX_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	oracleLics := dto.OracleDatabaseUsedLicenseSearchResponse{
 		Content: []dto.OracleDatabaseUsedLicense{{
@@ -1521,6 +1547,8 @@ func TestGetOracleDatabasesUsedLicenses_VeritasCluster_WithActiveDataguardAndGol
 			Return(hostdatas, nil),
 		db.EXPECT().GetClusters(globalFilterAny).
 			Return(clusters, nil),
+		db.EXPECT().GetActiveCoreFactorPolicy().
+			Return(nil, nil).AnyTimes(),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
 			Return(&host, nil).AnyTimes(),
@@ -1803,6 +1831,8 @@ func TestGetOracleDatabasesUsedLicenses_VeritasCluster_WithRacAndRacOneNode_Succ
 			Return(hostdatas, nil),
 		db.EXPECT().GetClusters(globalFilterAny).
 			Return(clusters, nil),
+		db.EXPECT().GetActiveCoreFactorPolicy().
+			Return(nil, nil).AnyTimes(),
 
 		db.EXPECT().GetHost("topolino-hostname", utils.MAX_TIME, false).
 			Return(&host, nil).AnyTimes(),
@@ -2217,6 +2247,18 @@ func TestGetDatabaseLicensesComplianceAsXLSX_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+		db.EXPECT().ListPostgreSQLContracts(gomock.Any()).
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil),
+		db.EXPECT().ListMongoDBContracts(gomock.Any()).
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil),
+		db.EXPECT().ListMariaDBContracts(gomock.Any()).
+			Return([]model.MariaDBContract{}, nil),
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil),
 	)
 
 	db.EXPECT().ExistHostdata("pluto").Return(true, nil).AnyTimes()
@@ -2437,6 +2479,18 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+		db.EXPECT().ListPostgreSQLContracts(gomock.Any()).
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil),
+		db.EXPECT().ListMongoDBContracts(gomock.Any()).
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil),
+		db.EXPECT().ListMariaDBContracts(gomock.Any()).
+			Return([]model.MariaDBContract{}, nil),
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil),
 	)
 
 	db.EXPECT().ExistHostdata("pluto").Return(true, nil).AnyTimes()
@@ -2450,6 +2504,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 			ItemDescription: "Application Testing",
 			Metric:          "Processor Perpetual",
 			Cost:            230,
+			Technology:      model.TechnologyOracleDatabase,
 			Consumed:        20,
 			Covered:         20,
 			Purchased:       50,
@@ -2462,6 +2517,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 			ItemDescription: "Oracle Partitioning",
 			Metric:          "Named User Plus Perpetual",
 			Cost:            250,
+			Technology:      model.TechnologyOracleDatabase,
 			Consumed:        250,
 			Covered:         250,
 			Purchased:       450,
@@ -2474,6 +2530,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 			ItemDescription: model.MySqlItemDescription,
 			Metric:          model.MySQLContractTypeHost,
 			Cost:            0,
+			Technology:      model.TechnologyOracleMySQL,
 			Consumed:        1,
 			Covered:         1,
 			Purchased:       12,
@@ -2486,6 +2543,7 @@ func TestGetDatabaseLicensesCompliance_Success(t *testing.T) {
 			ItemDescription: "SQL Server Enterprise Edition",
 			Metric:          "HOST",
 			Cost:            0,
+			Technology:      model.TechnologyMicrosoftSQLServer,
 			Consumed:        8,
 			Covered:         0,
 			Purchased:       0,
@@ -2508,6 +2566,12 @@ func TestGetUsedLicensesPerHost_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
@@ -2711,6 +2775,12 @@ func TestGetUsedLicensesPerHostAsXLSX_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
@@ -2879,6 +2949,12 @@ func TestGetUsedLicensesPerCluster_OneVm_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
@@ -2961,6 +3037,12 @@ func TestGetUsedLicensesPerCluster_MultipleVms_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
@@ -3090,6 +3172,12 @@ func TestGetUsedLicensesPerClusterAsXLSX_Success(t *testing.T) {
 		Database: db,
 		Log:      logger.NewLogger("TEST"),
 	}
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return([]model.PostgreSQLLicenseType{}, nil)
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{}, nil)
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{}, nil)
 
 	filter := dto.GlobalFilter{
 		Location:    "Dubai",
@@ -3231,6 +3319,18 @@ func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+		db.EXPECT().ListPostgreSQLContracts(gomock.Any()).
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil),
+		db.EXPECT().ListMongoDBContracts(gomock.Any()).
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil),
+		db.EXPECT().ListMariaDBContracts(gomock.Any()).
+			Return([]model.MariaDBContract{}, nil),
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil),
 	)
 
 	db.EXPECT().ExistHostdata("homer").Return(true, nil).AnyTimes()
@@ -3244,6 +3344,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostWithContractContract_Success(
 			ItemDescription: "SQL Server Enterprise Edition",
 			Metric:          "HOST",
 			Cost:            0,
+			Technology:      model.TechnologyMicrosoftSQLServer,
 			Consumed:        8,
 			Covered:         8,
 			Purchased:       12,
@@ -3320,6 +3421,18 @@ func TestGetDatabaseLicensesComplianceSqlServerHostNoContract_Success(t *testing
 		db.EXPECT().GetSqlServerDatabaseLicenseTypes().
 			Times(1).
 			Return(sqlServerLicenseTypes, nil),
+		db.EXPECT().ListPostgreSQLContracts(gomock.Any()).
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil),
+		db.EXPECT().ListMongoDBContracts(gomock.Any()).
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil),
+		db.EXPECT().ListMariaDBContracts(gomock.Any()).
+			Return([]model.MariaDBContract{}, nil),
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil),
 	)
 
 	db.EXPECT().ExistHostdata("homer").Return(true, nil).AnyTimes()
@@ -3333,6 +3446,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostNoContract_Success(t *testing
 			ItemDescription: "SQL Server Enterprise Edition",
 			Metric:          "HOST",
 			Cost:            0,
+			Technology:      model.TechnologyMicrosoftSQLServer,
 			Consumed:        8,
 			Covered:         0,
 			Purchased:       0,
@@ -3503,6 +3617,18 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 			Return(&cluster, nil),
 		db.EXPECT().ExistHostdata("plutohost").
 			Return(true, nil),
+		db.EXPECT().ListPostgreSQLContracts(gomock.Any()).
+			Return([]model.PostgreSQLContract{}, nil),
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil),
+		db.EXPECT().ListMongoDBContracts(gomock.Any()).
+			Return([]model.MongoDBContract{}, nil),
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil),
+		db.EXPECT().ListMariaDBContracts(gomock.Any()).
+			Return([]model.MariaDBContract{}, nil),
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil),
 	)
 
 	db.EXPECT().ExistHostdata("plutocluster").Return(true, nil).AnyTimes()
@@ -3516,6 +3642,7 @@ func TestGetDatabaseLicensesComplianceSqlServerHostInClusterWithContract_Success
 			ItemDescription: "SQL Server Standard Edition",
 			Metric:          "CLUSTER",
 			Cost:            0,
+			Technology:      model.TechnologyMicrosoftSQLServer,
 			Consumed:        40,
 			Covered:         12,
 			Purchased:       12,
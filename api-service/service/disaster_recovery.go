@@ -16,6 +16,188 @@
 // Package service is a package that provides methods for querying data
 package service
 
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// CreateDR creates the DR clone of hostname, named by the pairs.
+// The DR of a pair can't be cloned if it's a host reported by its own agent
 func (as *APIService) CreateDR(hostname string) (string, error) {
-	return as.Database.CreateDR(hostname)
+	pairs, err := as.Database.ListDisasterRecoveryPairs()
+	if err != nil {
+		return "", err
+	}
+
+	hostnames, err := as.Database.FindCurrentHostnames(false)
+	if err != nil {
+		return "", err
+	}
+
+	if dr := model.DRHostname(pairs, hostname); utils.Contains(hostnames, dr) {
+		return "", utils.NewErrorf("%w: the DR %s of %s is a host", utils.ErrInvalidDisasterRecoveryPair, dr, hostname)
+	}
+
+	return as.Database.CreateDR(hostname, pairs)
+}
+
+// findDisasterRecoveryNames return the names that can be the primary of a pair, the hostnames and the cluster names
+// of the current hosts, and the ones that can be its DR, which include the hostnames of the current DR hosts
+func (as *APIService) findDisasterRecoveryNames() (primaries []string, existingDRs map[string]bool, err error) {
+	primaries, err = as.Database.FindCurrentHostnames(false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, err := as.Database.FindCurrentClusterNames()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, cluster := range clusters {
+		if !utils.Contains(primaries, cluster) {
+			primaries = append(primaries, cluster)
+		}
+	}
+
+	drs, err := as.Database.FindCurrentHostnames(true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingDRs = make(map[string]bool, len(primaries)+len(drs))
+	for _, name := range append(drs, primaries...) {
+		existingDRs[name] = true
+	}
+
+	return primaries, existingDRs, nil
+}
+
+// ListDisasterRecoveryPairs return the pairs with the current hosts and clusters they apply to,
+// reporting if the primary and the DR of each of them exist
+func (as *APIService) ListDisasterRecoveryPairs() ([]dto.DisasterRecoveryPairStatus, error) {
+	pairs, err := as.Database.ListDisasterRecoveryPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	primaries, existingDRs, err := as.findDisasterRecoveryNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.DisasterRecoveryPairStatus, 0, len(pairs))
+
+	for _, pair := range pairs {
+		status := dto.DisasterRecoveryPairStatus{
+			Pair:  pair,
+			Hosts: make([]dto.DisasterRecoveryPairHost, 0),
+		}
+
+		if !pair.Regex {
+			status.Hosts = append(status.Hosts, dto.DisasterRecoveryPairHost{
+				Primary:       pair.Primary,
+				DR:            pair.DR,
+				PrimaryExists: utils.Contains(primaries, pair.Primary),
+				DRExists:      existingDRs[pair.DR],
+			})
+
+			result = append(result, status)
+
+			continue
+		}
+
+		for _, primary := range primaries {
+			dr, ok := pair.Match(primary)
+			if !ok || model.DRHostname(pairs, primary) != dr {
+				continue
+			}
+
+			status.Hosts = append(status.Hosts, dto.DisasterRecoveryPairHost{
+				Primary:       primary,
+				DR:            dr,
+				PrimaryExists: true,
+				DRExists:      existingDRs[dr],
+			})
+		}
+
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// AddDisasterRecoveryPair saves a new pair. Both sides of the pair must exist: the primary must be a current host
+// or cluster, or the regex must match at least one of them, and the DR of every primary matched
+// must be a current host, a DR clone or one reported by its own agent, or cluster
+func (as *APIService) AddDisasterRecoveryPair(req dto.DisasterRecoveryPairRequest) (*model.DisasterRecoveryPair, error) {
+	pair := model.DisasterRecoveryPair{
+		ID:        as.NewObjectID(),
+		Primary:   req.Primary,
+		DR:        req.DR,
+		Regex:     req.Regex,
+		CreatedAt: as.TimeNow(),
+	}
+
+	if err := pair.Validate(); err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidDisasterRecoveryPair, err)
+	}
+
+	if err := pair.Compile(); err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidDisasterRecoveryPair, err)
+	}
+
+	pairs, err := as.Database.ListDisasterRecoveryPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pairs {
+		if p.Primary == pair.Primary && p.Regex == pair.Regex {
+			return nil, utils.ErrDisasterRecoveryPairAlreadyExists
+		}
+	}
+
+	primaries, existingDRs, err := as.findDisasterRecoveryNames()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := false
+
+	for _, primary := range primaries {
+		dr, ok := pair.Match(primary)
+		if !ok {
+			continue
+		}
+
+		matched = true
+
+		if dr == primary {
+			return nil, utils.NewErrorf("%w: the DR of %s is itself", utils.ErrInvalidDisasterRecoveryPair, primary)
+		}
+
+		if !existingDRs[dr] {
+			return nil, utils.NewErrorf("%w: DR host %s of %s not found", utils.ErrInvalidDisasterRecoveryPair, dr, primary)
+		}
+	}
+
+	if !matched && pair.Regex {
+		return nil, utils.NewErrorf("%w: no host or cluster matches %s", utils.ErrInvalidDisasterRecoveryPair, pair.Primary)
+	} else if !matched {
+		return nil, utils.NewErrorf("%w: primary host or cluster %s not found", utils.ErrInvalidDisasterRecoveryPair, pair.Primary)
+	}
+
+	if err := as.Database.InsertDisasterRecoveryPair(pair); err != nil {
+		return nil, err
+	}
+
+	return &pair, nil
+}
+
+func (as *APIService) DeleteDisasterRecoveryPair(id primitive.ObjectID) error {
+	return as.Database.DeleteDisasterRecoveryPair(id)
 }
@@ -19,8 +19,14 @@ package service
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
 func TestCreateDR(t *testing.T) {
@@ -34,10 +40,165 @@ func TestCreateDR(t *testing.T) {
 	expected := "test-DR"
 
 	t.Run("Success", func(t *testing.T) {
-		db.EXPECT().CreateDR("test").Return(expected, nil).Times(1)
+		pairs := []model.DisasterRecoveryPair{{Primary: "test", DR: "test-DR"}}
+
+		db.EXPECT().ListDisasterRecoveryPairs().Return(pairs, nil).Times(1)
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"test"}, nil)
+		db.EXPECT().CreateDR("test", pairs).Return(expected, nil).Times(1)
 
 		drname, err := as.CreateDR("test")
 		require.NoError(t, err)
 		require.Equal(t, expected, drname)
 	})
+
+	t.Run("DR reported by its agent", func(t *testing.T) {
+		pairs := []model.DisasterRecoveryPair{{Primary: "test", DR: "standby"}}
+
+		db.EXPECT().ListDisasterRecoveryPairs().Return(pairs, nil)
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"test", "standby"}, nil)
+
+		_, err := as.CreateDR("test")
+		require.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
+}
+
+func TestListDisasterRecoveryPairs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	pairs := []model.DisasterRecoveryPair{
+		{Primary: "db01.mi", DR: "standby01"},
+		{Primary: `(.+)\.mi`, DR: "$1.rm", Regex: true},
+		{Primary: "erp-cluster", DR: "erp-cluster-dr"},
+	}
+
+	db.EXPECT().ListDisasterRecoveryPairs().Return(pairs, nil)
+	db.EXPECT().FindCurrentHostnames(false).Return([]string{"db01.mi", "db02.mi", "web01"}, nil)
+	db.EXPECT().FindCurrentClusterNames().Return([]string{"erp-cluster"}, nil)
+	db.EXPECT().FindCurrentHostnames(true).Return([]string{"standby01"}, nil)
+
+	actual, err := as.ListDisasterRecoveryPairs()
+	require.NoError(t, err)
+
+	expected := []dto.DisasterRecoveryPairStatus{
+		{
+			Pair: pairs[0],
+			Hosts: []dto.DisasterRecoveryPairHost{
+				{Primary: "db01.mi", DR: "standby01", PrimaryExists: true, DRExists: true},
+			},
+		},
+		{
+			Pair: pairs[1],
+			Hosts: []dto.DisasterRecoveryPairHost{
+				{Primary: "db02.mi", DR: "db02.rm", PrimaryExists: true, DRExists: false},
+			},
+		},
+		{
+			Pair: pairs[2],
+			Hosts: []dto.DisasterRecoveryPairHost{
+				{Primary: "erp-cluster", DR: "erp-cluster-dr", PrimaryExists: true, DRExists: false},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestAddDisasterRecoveryPair(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	existing := []model.DisasterRecoveryPair{{Primary: "db01.mi", DR: "standby01"}}
+
+	expectNames := func(hostnames, clusters, drs []string) {
+		db.EXPECT().FindCurrentHostnames(false).Return(hostnames, nil)
+		db.EXPECT().FindCurrentClusterNames().Return(clusters, nil)
+		db.EXPECT().FindCurrentHostnames(true).Return(drs, nil)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi", "db02.mi"}, nil, []string{"standby01", "standby02"})
+		db.EXPECT().InsertDisasterRecoveryPair(gomock.Any()).Do(func(pair model.DisasterRecoveryPair) {
+			assert.Equal(t, "db02.mi", pair.Primary)
+			assert.Equal(t, "standby02", pair.DR)
+			assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), pair.CreatedAt)
+			assert.NotEqual(t, primitive.NilObjectID, pair.ID)
+		}).Return(nil)
+
+		actual, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "db02.mi", DR: "standby02"})
+		require.NoError(t, err)
+		assert.Equal(t, "standby02", actual.DR)
+	})
+
+	t.Run("Invalid regex", func(t *testing.T) {
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "db(", DR: "dr", Regex: true})
+		assert.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "db01.mi", DR: "standby99"})
+		assert.ErrorIs(t, err, utils.ErrDisasterRecoveryPairAlreadyExists)
+	})
+
+	t.Run("Cluster and DR reported by its agent", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi", "erp-cluster-dr"}, []string{"erp-cluster"}, nil)
+		db.EXPECT().InsertDisasterRecoveryPair(gomock.Any()).Return(nil)
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "erp-cluster", DR: "erp-cluster-dr"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Primary not found", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi"}, nil, []string{"standby03"})
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "db03.mi", DR: "standby03"})
+		assert.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
+
+	t.Run("DR not found", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi", "db02.mi"}, nil, []string{"standby01"})
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: "db02.mi", DR: "standby99"})
+		assert.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
+
+	t.Run("Regex", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi", "db02.mi"}, nil, []string{"db01.rm", "db02.rm"})
+		db.EXPECT().InsertDisasterRecoveryPair(gomock.Any()).Return(nil)
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: `(.+)\.mi`, DR: "$1.rm", Regex: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("DR of a host matched by the regex not found", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi", "db02.mi"}, nil, []string{"db01.rm"})
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: `(.+)\.mi`, DR: "$1.rm", Regex: true})
+		assert.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
+
+	t.Run("Regex matching nothing", func(t *testing.T) {
+		db.EXPECT().ListDisasterRecoveryPairs().Return(existing, nil)
+		expectNames([]string{"db01.mi"}, nil, []string{"db01.rm"})
+
+		_, err := as.AddDisasterRecoveryPair(dto.DisasterRecoveryPairRequest{Primary: `(.+)\.to`, DR: "$1.rm", Regex: true})
+		assert.ErrorIs(t, err, utils.ErrInvalidDisasterRecoveryPair)
+	})
 }
@@ -172,21 +172,7 @@ func (as *APIService) oracleStats(locations []string) (*dto.Stats, error) {
 		return nil, err
 	}
 
-	compliancePercentage := float64(0.0)
-
-	if len(compliances) > 0 {
-		totCompliance := float64(0.0)
-
-		for _, v := range compliances {
-			totCompliance += v.Compliance
-		}
-
-		compliancePercentage = (totCompliance * 100) / float64(len(compliances))
-	}
-
-	if compliancePercentage == 0 || hostCount == 0 {
-		compliancePercentage = 100
-	}
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
 
 	return &dto.Stats{
 		Count:                   int(count),
@@ -224,21 +210,7 @@ func (as *APIService) mysqlStats(locations []string) (*dto.Stats, error) {
 		return nil, err
 	}
 
-	compliancePercentage := float64(0.0)
-
-	if len(compliances) > 0 {
-		totCompliance := float64(0.0)
-
-		for _, v := range compliances {
-			totCompliance += v.Compliance
-		}
-
-		compliancePercentage = (totCompliance * 100) / float64(len(compliances))
-	}
-
-	if compliancePercentage == 0 || hostCount == 0 {
-		compliancePercentage = 100
-	}
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
 
 	return &dto.Stats{
 		Count:                   int(count),
@@ -276,21 +248,7 @@ func (as *APIService) sqlServerStats(locations []string) (*dto.Stats, error) {
 		return nil, err
 	}
 
-	compliancePercentage := float64(0.0)
-
-	if len(compliances) > 0 {
-		totCompliance := float64(0.0)
-
-		for _, v := range compliances {
-			totCompliance += v.Compliance
-		}
-
-		compliancePercentage = (totCompliance * 100) / float64(len(compliances))
-	}
-
-	if compliancePercentage == 0 || hostCount == 0 {
-		compliancePercentage = 100
-	}
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
 
 	return &dto.Stats{
 		Count:                   int(count),
@@ -323,11 +281,18 @@ func (as *APIService) postgreSqlStats(locations []string) (*dto.Stats, error) {
 		}
 	}
 
+	compliances, err := as.GetPostgreSQLLicensesCompliance(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
+
 	return &dto.Stats{
 		Count:                   int(count),
 		HostCount:               int(hostCount),
-		CompliancePercentageStr: "100%",
-		CompliancePercentageVal: 100,
+		CompliancePercentageVal: compliancePercentage,
+		CompliancePercentageStr: fmt.Sprintf("%.2f%%", compliancePercentage),
 	}, nil
 }
 
@@ -354,19 +319,77 @@ func (as *APIService) mongoDbStats(locations []string) (*dto.Stats, error) {
 		}
 	}
 
+	compliances, err := as.GetMongoDBLicensesCompliance(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
+
 	return &dto.Stats{
 		Count:                   int(count),
 		HostCount:               int(hostCount),
-		CompliancePercentageStr: "100%",
-		CompliancePercentageVal: 100,
+		CompliancePercentageVal: compliancePercentage,
+		CompliancePercentageStr: fmt.Sprintf("%.2f%%", compliancePercentage),
 	}, nil
 }
 
 func (as *APIService) mariaDbStats(locations []string) (*dto.Stats, error) {
+	var count, hostCount int64
+
+	var err error
+
+	if utils.Contains(locations, model.AllLocation) {
+		if count, err = as.Database.CountMariaDbInstance(); err != nil {
+			return nil, err
+		}
+
+		if hostCount, err = as.Database.CountMariaDbHosts(); err != nil {
+			return nil, err
+		}
+	} else {
+		if count, err = as.Database.CountMariaDbInstanceByLocations(locations); err != nil {
+			return nil, err
+		}
+
+		if hostCount, err = as.Database.CountMariaDbHostsByLocations(locations); err != nil {
+			return nil, err
+		}
+	}
+
+	compliances, err := as.GetMariaDBLicensesCompliance(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	compliancePercentage := licensesCompliancePercentage(compliances, hostCount)
+
 	return &dto.Stats{
-		Count:                   0,
-		HostCount:               0,
-		CompliancePercentageStr: "100%",
-		CompliancePercentageVal: 100,
+		Count:                   int(count),
+		HostCount:               int(hostCount),
+		CompliancePercentageVal: compliancePercentage,
+		CompliancePercentageStr: fmt.Sprintf("%.2f%%", compliancePercentage),
 	}, nil
 }
+
+// licensesCompliancePercentage return the average compliance of the licenses as a percentage,
+// or 100 if there aren't hosts or licenses
+func licensesCompliancePercentage(compliances []dto.LicenseCompliance, hostCount int64) float64 {
+	compliancePercentage := float64(0.0)
+
+	if len(compliances) > 0 {
+		totCompliance := float64(0.0)
+
+		for _, v := range compliances {
+			totCompliance += v.Compliance
+		}
+
+		compliancePercentage = (totCompliance * 100) / float64(len(compliances))
+	}
+
+	if compliancePercentage == 0 || hostCount == 0 {
+		compliancePercentage = 100
+	}
+
+	return compliancePercentage
+}
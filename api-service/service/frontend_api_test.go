@@ -735,6 +735,12 @@ func TestGetComplianceStatsAsAdmin(t *testing.T) {
 		db.EXPECT().
 			CountPostgreSqlHosts().
 			Return(hostsCount, nil),
+		db.EXPECT().
+			ListPostgreSQLContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetPostgreSQLLicenseTypes().
+			Return(nil, nil),
 
 		db.EXPECT().
 			CountMongoDbInstance().
@@ -742,6 +748,25 @@ func TestGetComplianceStatsAsAdmin(t *testing.T) {
 		db.EXPECT().
 			CountMongoDbHosts().
 			Return(hostsCount, nil),
+		db.EXPECT().
+			ListMongoDBContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetMongoDBLicenseTypes().
+			Return(nil, nil),
+
+		db.EXPECT().
+			CountMariaDbInstance().
+			Return(instancesCount, nil),
+		db.EXPECT().
+			CountMariaDbHosts().
+			Return(hostsCount, nil),
+		db.EXPECT().
+			ListMariaDBContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetMariaDBLicenseTypes().
+			Return(nil, nil),
 	)
 
 	user := model.User{
@@ -751,17 +776,17 @@ func TestGetComplianceStatsAsAdmin(t *testing.T) {
 		"ercole": map[string]interface{}{
 			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
-			"count":                   5,
-			"hostCount":               5,
+			"count":                   6,
+			"hostCount":               6,
 		},
 		"mariaDb": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
-			"count":                   0,
-			"hostCount":               0,
+			"count":                   1,
+			"hostCount":               1,
 		},
 		"mongoDb": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
 			"count":                   1,
 			"hostCount":               1,
@@ -779,7 +804,7 @@ func TestGetComplianceStatsAsAdmin(t *testing.T) {
 			"hostCount":               1,
 		},
 		"postgreSql": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
 			"count":                   1,
 			"hostCount":               1,
@@ -1042,6 +1067,12 @@ func TestGetComplianceStatsAsUser(t *testing.T) {
 		db.EXPECT().
 			CountPostgreSqlHostsByLocations(locations).
 			Return(hostsCount, nil),
+		db.EXPECT().
+			ListPostgreSQLContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetPostgreSQLLicenseTypes().
+			Return(nil, nil),
 
 		db.EXPECT().
 			CountMongoDbInstanceByLocations(locations).
@@ -1049,23 +1080,42 @@ func TestGetComplianceStatsAsUser(t *testing.T) {
 		db.EXPECT().
 			CountMongoDbHostsByLocations(locations).
 			Return(hostsCount, nil),
+		db.EXPECT().
+			ListMongoDBContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetMongoDBLicenseTypes().
+			Return(nil, nil),
+
+		db.EXPECT().
+			CountMariaDbInstanceByLocations(locations).
+			Return(instancesCount, nil),
+		db.EXPECT().
+			CountMariaDbHostsByLocations(locations).
+			Return(hostsCount, nil),
+		db.EXPECT().
+			ListMariaDBContracts(gomock.Any()).
+			Return(nil, nil),
+		db.EXPECT().
+			GetMariaDBLicenseTypes().
+			Return(nil, nil),
 	)
 
 	expectedRes := map[string]interface{}{
 		"ercole": map[string]interface{}{
 			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
-			"count":                   5,
-			"hostCount":               5,
+			"count":                   6,
+			"hostCount":               6,
 		},
 		"mariaDb": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
-			"count":                   0,
-			"hostCount":               0,
+			"count":                   1,
+			"hostCount":               1,
 		},
 		"mongoDb": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
 			"count":                   1,
 			"hostCount":               1,
@@ -1083,7 +1133,7 @@ func TestGetComplianceStatsAsUser(t *testing.T) {
 			"hostCount":               1,
 		},
 		"postgreSql": map[string]interface{}{
-			"compliancePercentageStr": "100%",
+			"compliancePercentageStr": "100.00%",
 			"compliancePercentageVal": 100,
 			"count":                   1,
 			"hostCount":               1,
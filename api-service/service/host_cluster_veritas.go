@@ -19,6 +19,7 @@ import (
 
 	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils/exutils"
 )
 
@@ -34,11 +35,22 @@ func (as *APIService) GetClusterVeritasLicenses(filter dto.GlobalFilter) ([]dto.
 		clusterVeritasLicensesMap[clusterLicenses.ID] = append(clusterVeritasLicensesMap[clusterLicenses.ID], clusterLicenses)
 	}
 
+	var primaryClusterIDs map[string]string
+
 	for k, v := range clusterVeritasLicensesMap {
-		if strings.Contains(k, "_DR") {
+		if v[0].IsDR {
+			if primaryClusterIDs == nil {
+				pairs, err := as.Database.ListDisasterRecoveryPairs()
+				if err != nil {
+					return nil, err
+				}
+
+				primaryClusterIDs = getPrimaryClusterIDs(clusterVeritasLicensesMap, pairs)
+			}
+
 			existingHostsDR := v[0].ExistingHostsDR
 
-			realclusterID := strings.Replace(k, "_DR", "", -1)
+			realclusterID := primaryClusterIDs[k]
 			realclusterLicenses := clusterVeritasLicensesMap[realclusterID]
 
 			for _, realLicense := range realclusterLicenses {
@@ -50,6 +62,7 @@ func (as *APIService) GetClusterVeritasLicenses(filter dto.GlobalFilter) ([]dto.
 						Metric:        realLicense.Metric,
 						Count:         clusterVeritasLicensesMap[k][0].Count,
 						Hostnames:     existingHostsDR,
+						IsDR:          true,
 					})
 				}
 			}
@@ -64,7 +77,7 @@ func (as *APIService) GetClusterVeritasLicenses(filter dto.GlobalFilter) ([]dto.
 
 func replaceWithRealHostDR(licenses []dto.ClusterVeritasLicense) []dto.ClusterVeritasLicense {
 	for i := 0; i < len(licenses); i++ {
-		if strings.Contains(licenses[i].ID, "_DR") && len(licenses[i].ExistingHostsDR) > 0 {
+		if licenses[i].IsDR && len(licenses[i].ExistingHostsDR) > 0 {
 			licenses[i].Hostnames = licenses[i].ExistingHostsDR
 		}
 
@@ -76,6 +89,26 @@ func replaceWithRealHostDR(licenses []dto.ClusterVeritasLicense) []dto.ClusterVe
 	return licenses
 }
 
+// getPrimaryClusterIDs return the IDs of the primary clusters by the ID of their DR clone
+func getPrimaryClusterIDs(licenses map[string][]dto.ClusterVeritasLicense, pairs []model.DisasterRecoveryPair) map[string]string {
+	ids := make(map[string]string)
+
+	for k, v := range licenses {
+		if v[0].IsDR {
+			continue
+		}
+
+		drHostnames := make([]string, 0, len(v[0].Hostnames))
+		for _, hostname := range v[0].Hostnames {
+			drHostnames = append(drHostnames, model.DRHostname(pairs, hostname))
+		}
+
+		ids[strings.Join(drHostnames, "-")] = k
+	}
+
+	return ids
+}
+
 func removeDuplicates(licenses []dto.ClusterVeritasLicense) []dto.ClusterVeritasLicense {
 	check := make(map[string]bool)
 	unique := []dto.ClusterVeritasLicense{}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -54,3 +55,55 @@ func TestGetClusterVeritassLicenses_Success(t *testing.T) {
 
 	assert.Equal(t, expected, res)
 }
+
+func TestGetClusterVeritasLicenses_DisasterRecoveryPairs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config:   config.Configuration{},
+	}
+
+	licenses := []dto.ClusterVeritasLicense{
+		{
+			ID:            "db01.mi-db02.mi",
+			Hostnames:     []string{"db01.mi", "db02.mi"},
+			LicenseTypeID: "A90611",
+			Description:   "Oracle Database Enterprise Edition",
+			Metric:        "Processor Perpetual",
+			Count:         2,
+		},
+		{
+			ID:              "db01.rm-db02.rm",
+			Hostnames:       []string{"db01.rm", "db02.rm"},
+			LicenseTypeID:   "A90619",
+			Description:     "Partitioning",
+			Metric:          "Processor Perpetual",
+			Count:           1,
+			ExistingHostsDR: []string{"db01.rm"},
+			IsDR:            true,
+		},
+	}
+
+	f := dto.GlobalFilter{}
+
+	db.EXPECT().FindClusterVeritasLicenses(f).Return(licenses, nil)
+	db.EXPECT().ListDisasterRecoveryPairs().Return([]model.DisasterRecoveryPair{
+		{Primary: `(.+)\.mi`, DR: "$1.rm", Regex: true},
+	}, nil)
+
+	res, err := as.GetClusterVeritasLicenses(f)
+	require.NoError(t, err)
+
+	require.Len(t, res, 3)
+	assert.Equal(t, dto.ClusterVeritasLicense{
+		ID:            "db01.rm-db02.rm",
+		Hostnames:     []string{"db01.rm"},
+		LicenseTypeID: "A90611",
+		Description:   "Oracle Database Enterprise Edition",
+		Metric:        "Processor Perpetual",
+		Count:         1,
+		IsDR:          true,
+	}, res[2])
+}
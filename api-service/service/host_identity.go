@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/hostidentity"
+)
+
+// hostIdentityResolver return a resolver of the names of hostnames and of the host aliases
+func (as *APIService) hostIdentityResolver(hostnames []string) (*hostidentity.Resolver, error) {
+	aliases, err := as.Database.ListHostAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	return hostidentity.NewResolver(as.Config.HostIdentity, hostnames, aliases)
+}
+
+// ResolveHostname return the current host matching name by hostname, alias or normalization rules
+func (as *APIService) ResolveHostname(name string) (*dto.ResolvedHostname, error) {
+	hostnames, err := as.Database.FindCurrentHostnames(false)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := as.hostIdentityResolver(hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, found := resolver.Resolve(name)
+
+	return &dto.ResolvedHostname{
+		Name:     name,
+		Hostname: hostname,
+		Found:    found,
+	}, nil
+}
+
+func (as *APIService) ListHostAliases() ([]model.HostAlias, error) {
+	return as.Database.ListHostAliases()
+}
+
+// AddHostAlias saves a new alias of a current host. The alias can't be the hostname of a current host,
+// since the hostdata received with an alias are saved with the hostname of the alias
+func (as *APIService) AddHostAlias(req dto.HostAliasRequest) (*model.HostAlias, error) {
+	if req.Alias == "" || req.Hostname == "" {
+		return nil, utils.NewErrorf("%w: alias and hostname are required", utils.ErrInvalidHostAlias)
+	}
+
+	if req.Alias == req.Hostname {
+		return nil, utils.NewErrorf("%w: alias and hostname must be different", utils.ErrInvalidHostAlias)
+	}
+
+	hostnames, err := as.Database.FindCurrentHostnames(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.Contains(hostnames, req.Hostname) {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrHostNotFound, req.Hostname)
+	}
+
+	if utils.Contains(hostnames, req.Alias) {
+		return nil, utils.NewErrorf("%w: %s is a current host", utils.ErrInvalidHostAlias, req.Alias)
+	}
+
+	aliases, err := as.Database.ListHostAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range aliases {
+		if a.Alias == req.Alias {
+			return nil, utils.ErrHostAliasAlreadyExists
+		}
+	}
+
+	alias := model.HostAlias{
+		ID:        as.NewObjectID(),
+		Alias:     req.Alias,
+		Hostname:  req.Hostname,
+		Source:    model.HostAliasSourceManual,
+		CreatedAt: as.TimeNow(),
+	}
+
+	if err := as.Database.InsertHostAlias(alias); err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
+
+func (as *APIService) DeleteHostAlias(id primitive.ObjectID) error {
+	return as.Database.DeleteHostAlias(id)
+}
+
+// MergeHosts carries over the history of a renamed host to its new hostname
+// and saves the previous hostname as an alias of the new one. The contracts changed are saved as changes made by user
+func (as *APIService) MergeHosts(req dto.HostMergeRequest, user string) error {
+	if req.From == "" || req.To == "" {
+		return utils.NewErrorf("%w: from and to are required", utils.ErrInvalidHostMerge)
+	}
+
+	if req.From == req.To {
+		return utils.NewErrorf("%w: from and to must be different", utils.ErrInvalidHostMerge)
+	}
+
+	hostnames, err := as.Database.FindCurrentHostnames(false)
+	if err != nil {
+		return err
+	}
+
+	for _, hostname := range []string{req.From, req.To} {
+		if !utils.Contains(hostnames, hostname) {
+			return utils.NewErrorf("%w: %s", utils.ErrHostNotFound, hostname)
+		}
+	}
+
+	alias := model.HostAlias{
+		ID:        as.NewObjectID(),
+		Alias:     req.From,
+		Hostname:  req.To,
+		Source:    model.HostAliasSourceMerge,
+		CreatedAt: as.TimeNow(),
+	}
+
+	return as.Database.MergeHost(req.From, req.To, alias, user, as.NewObjectID)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestResolveHostname(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	db.EXPECT().FindCurrentHostnames(false).Return([]string{"db01.example.com", "db02"}, nil).Times(2)
+	db.EXPECT().ListHostAliases().Return([]model.HostAlias{{Alias: "legacy", Hostname: "db02"}}, nil).Times(2)
+
+	actual, err := as.ResolveHostname("LEGACY.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ResolvedHostname{Name: "LEGACY.example.com", Hostname: "db02", Found: true}, actual)
+
+	actual, err = as.ResolveHostname("db03")
+	require.NoError(t, err)
+	assert.Equal(t, &dto.ResolvedHostname{Name: "db03", Hostname: "db03", Found: false}, actual)
+}
+
+func TestAddHostAlias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"db01", "db02"}, nil)
+		db.EXPECT().ListHostAliases().Return([]model.HostAlias{{Alias: "legacy", Hostname: "db02"}}, nil)
+		db.EXPECT().InsertHostAlias(gomock.Any()).
+			Do(func(alias model.HostAlias) {
+				assert.Equal(t, "cmdb-db01", alias.Alias)
+				assert.Equal(t, "db01", alias.Hostname)
+				assert.Equal(t, model.HostAliasSourceManual, alias.Source)
+				assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), alias.CreatedAt)
+			}).Return(nil)
+
+		actual, err := as.AddHostAlias(dto.HostAliasRequest{Alias: "cmdb-db01", Hostname: "db01"})
+		require.NoError(t, err)
+		assert.Equal(t, "db01", actual.Hostname)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := as.AddHostAlias(dto.HostAliasRequest{Alias: "db01", Hostname: "db01"})
+		assert.ErrorIs(t, err, utils.ErrInvalidHostAlias)
+	})
+
+	t.Run("Alias is a current host", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"db01", "db02"}, nil)
+
+		_, err := as.AddHostAlias(dto.HostAliasRequest{Alias: "db02", Hostname: "db01"})
+		assert.ErrorIs(t, err, utils.ErrInvalidHostAlias)
+	})
+
+	t.Run("Host not found", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"db02"}, nil)
+
+		_, err := as.AddHostAlias(dto.HostAliasRequest{Alias: "cmdb-db01", Hostname: "db01"})
+		assert.ErrorIs(t, err, utils.ErrHostNotFound)
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"db01", "db02"}, nil)
+		db.EXPECT().ListHostAliases().Return([]model.HostAlias{{Alias: "legacy", Hostname: "db02"}}, nil)
+
+		_, err := as.AddHostAlias(dto.HostAliasRequest{Alias: "legacy", Hostname: "db01"})
+		assert.ErrorIs(t, err, utils.ErrHostAliasAlreadyExists)
+	})
+}
+
+func TestMergeHosts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"oldname", "newname"}, nil)
+		db.EXPECT().MergeHost("oldname", "newname", gomock.Any(), "user", gomock.Any()).
+			Do(func(from, to string, alias model.HostAlias, user string, newObjectID func() primitive.ObjectID) {
+				assert.Equal(t, "oldname", alias.Alias)
+				assert.Equal(t, "newname", alias.Hostname)
+				assert.Equal(t, model.HostAliasSourceMerge, alias.Source)
+			}).Return(nil)
+
+		require.NoError(t, as.MergeHosts(dto.HostMergeRequest{From: "oldname", To: "newname"}, "user"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := as.MergeHosts(dto.HostMergeRequest{From: "oldname"}, "user")
+		assert.ErrorIs(t, err, utils.ErrInvalidHostMerge)
+	})
+
+	t.Run("Host not found", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"newname"}, nil)
+
+		err := as.MergeHosts(dto.HostMergeRequest{From: "oldname", To: "newname"}, "user")
+		assert.ErrorIs(t, err, utils.ErrHostNotFound)
+	})
+
+	t.Run("Destination host not found", func(t *testing.T) {
+		db.EXPECT().FindCurrentHostnames(false).Return([]string{"oldname"}, nil)
+
+		err := as.MergeHosts(dto.HostMergeRequest{From: "oldname", To: "newname"}, "user")
+		assert.ErrorIs(t, err, utils.ErrHostNotFound)
+	})
+}
+
+func TestCheckHosts_Resolve(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	db.EXPECT().SearchHosts("hostnames", dto.NewSearchHostsFilters()).
+		Return([]map[string]interface{}{
+			{"hostname": "db01.example.com"},
+			{"hostname": "db02"},
+		}, nil)
+	db.EXPECT().ListHostAliases().Return([]model.HostAlias{{Alias: "legacy", Hostname: "db02"}}, nil)
+
+	actual, err := checkHosts(&as, []string{"db02", "DB01", "legacy"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db02", "db01.example.com", "db02"}, actual)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/ercole-io/ercole/v2/utils/exutils"
+	"github.com/ercole-io/ercole/v2/utils/hostidentity"
 )
 
 func (as *APIService) SearchHosts(mode string, filters dto.SearchHostsFilters) ([]map[string]interface{}, error) {
@@ -399,6 +400,11 @@ func (as *APIService) GetHostDataSummaries(filters dto.SearchHostsFilters) ([]dt
 	return hosts, nil
 }
 
+// GetHostChanges return the changes of the host between from and to, filtered by kinds if not empty
+func (as *APIService) GetHostChanges(hostname string, kinds []string, from, to time.Time) ([]model.HostChange, error) {
+	return as.Database.GetHostChanges(hostname, kinds, from, to)
+}
+
 // GetHost return the host specified in the hostname param
 func (as *APIService) GetHost(hostname string, olderThan time.Time, raw bool) (*dto.HostData, error) {
 	host, err := as.Database.GetHost(hostname, olderThan, raw)
@@ -568,13 +574,15 @@ func (as *APIService) setMemoryTarget(host *dto.HostData) {
 	host.MemorySumFlag = flag
 }
 
-func checkHosts(as *APIService, hosts []string) error {
+// checkHosts return the hostnames of hosts, that must be current hosts not in cluster.
+// The names that aren't hostnames are resolved through the host aliases and the normalization rules
+func checkHosts(as *APIService, hosts []string) ([]string, error) {
 	commonFilters := dto.NewSearchHostsFilters()
 	notInClusterHosts, err := as.SearchHosts("hostnames",
 		commonFilters)
 
 	if err != nil {
-		return utils.NewError(err, "")
+		return nil, utils.NewError(err, "")
 	}
 
 	notInClusterHostnames := make([]string, len(notInClusterHosts))
@@ -582,16 +590,30 @@ func checkHosts(as *APIService, hosts []string) error {
 		notInClusterHostnames[i] = h["hostname"].(string)
 	}
 
-hosts_loop:
+	var resolver *hostidentity.Resolver
+
+	resolved := make([]string, 0, len(hosts))
+
 	for _, host := range hosts {
-		for _, notInClusterHostname := range notInClusterHostnames {
-			if host == notInClusterHostname {
-				continue hosts_loop
+		if utils.Contains(notInClusterHostnames, host) {
+			resolved = append(resolved, host)
+			continue
+		}
+
+		if resolver == nil {
+			resolver, err = as.hostIdentityResolver(notInClusterHostnames)
+			if err != nil {
+				return nil, err
 			}
 		}
 
-		return utils.ErrHostNotFound
+		hostname, found := resolver.Resolve(host)
+		if !found {
+			return nil, utils.ErrHostNotFound
+		}
+
+		resolved = append(resolved, hostname)
 	}
 
-	return nil
+	return resolved, nil
 }
@@ -0,0 +1,575 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// SimulateLicensesCompliance return the compliance of every license type before and after applying,
+// in order, the changes of req to a view of the current hosts, clusters and contracts.
+// The Oracle processor licenses of the moved databases and of the hosts with a different CPU
+// are recounted with the active core factor policy
+func (as *APIService) SimulateLicensesCompliance(req dto.LicenseSimulationRequest) (*dto.LicenseSimulation, error) {
+	if len(req.Locations) == 0 {
+		req.Locations = []string{""}
+	}
+
+	simulationDB, err := as.newLicenseSimulationDatabase(req.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := as.GetDatabaseLicensesCompliance(req.Locations)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := *as
+	simulation.Database = simulationDB
+
+	after, err := simulation.GetDatabaseLicensesCompliance(req.Locations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LicenseSimulation{
+		Changes:  req.Changes,
+		Licenses: licenseComplianceDeltas(before, after),
+	}, nil
+}
+
+func licenseComplianceDeltas(before, after []dto.LicenseCompliance) []dto.LicenseComplianceDelta {
+	deltas := make(map[string]*dto.LicenseComplianceDelta)
+
+	get := func(l dto.LicenseCompliance) *dto.LicenseComplianceDelta {
+		delta, ok := deltas[l.LicenseTypeID]
+		if !ok {
+			unused := dto.LicenseCompliance{
+				LicenseTypeID:   l.LicenseTypeID,
+				ItemDescription: l.ItemDescription,
+				Metric:          l.Metric,
+				Cost:            l.Cost,
+				Compliance:      1,
+			}
+
+			delta = &dto.LicenseComplianceDelta{
+				LicenseTypeID:   l.LicenseTypeID,
+				ItemDescription: l.ItemDescription,
+				Metric:          l.Metric,
+				Before:          unused,
+				After:           unused,
+			}
+			deltas[l.LicenseTypeID] = delta
+		}
+
+		return delta
+	}
+
+	for _, l := range before {
+		get(l).Before = l
+	}
+
+	for _, l := range after {
+		get(l).After = l
+	}
+
+	result := make([]dto.LicenseComplianceDelta, 0, len(deltas))
+
+	for _, delta := range deltas {
+		delta.ConsumedDelta = delta.After.Consumed - delta.Before.Consumed
+		delta.CoveredDelta = delta.After.Covered - delta.Before.Covered
+		delta.ComplianceDelta = delta.After.Compliance - delta.Before.Compliance
+
+		result = append(result, *delta)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LicenseTypeID < result[j].LicenseTypeID
+	})
+
+	return result
+}
+
+// licenseSimulationDatabase reads the data from the database and returns it as if the changes had been applied
+type licenseSimulationDatabase struct {
+	database.MongoDatabaseInterface
+
+	policy       model.CoreFactorPolicy
+	licenseTypes map[string]model.OracleDatabaseLicenseType
+	hostdatas    map[string]model.HostDataBE
+
+	// hosts contains the simulated infos of the hosts with a different CPU
+	hosts map[string]model.Host
+	// databases contains the hostname where every moved database ends, by its current host and name
+	databases      map[simulationDatabaseKey]string
+	movedDatabases []simulationDatabaseKey
+	clusterChanges []dto.LicenseSimulationChange
+	contracts      []model.OracleDatabaseContract
+}
+
+type simulationDatabaseKey struct {
+	hostname string
+	name     string
+}
+
+func (as *APIService) newLicenseSimulationDatabase(changes []dto.LicenseSimulationChange) (*licenseSimulationDatabase, error) {
+	policy, err := as.GetActiveCoreFactorPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypes, err := as.GetOracleDatabaseLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	hostdatas, err := as.Database.GetHostDatas(dto.GlobalFilter{OlderThan: utils.MAX_TIME})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := as.Database.GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME})
+	if err != nil {
+		return nil, err
+	}
+
+	db := &licenseSimulationDatabase{
+		MongoDatabaseInterface: as.Database,
+		policy:                 *policy,
+		licenseTypes:           licenseTypes,
+		hostdatas:              make(map[string]model.HostDataBE, len(hostdatas)),
+		hosts:                  make(map[string]model.Host),
+		databases:              make(map[simulationDatabaseKey]string),
+	}
+
+	for _, hd := range hostdatas {
+		db.hostdatas[hd.Hostname] = hd
+	}
+
+	clusterNames := make(map[string]bool, len(clusters))
+	for _, cluster := range clusters {
+		clusterNames[cluster.Name] = true
+	}
+
+	for i, change := range changes {
+		if err := db.addChange(as, change, clusterNames); err != nil {
+			return nil, utils.NewErrorf("changes[%d]: %w", i, err)
+		}
+	}
+
+	return db, nil
+}
+
+func (db *licenseSimulationDatabase) addChange(as *APIService, change dto.LicenseSimulationChange, clusterNames map[string]bool) error {
+	switch change.Type {
+	case dto.LicenseSimulationMoveDatabase:
+		if change.DbName == "" || change.ToHostname == "" {
+			return utils.NewErrorf("%w: dbName and toHostname are required", utils.ErrInvalidLicenseSimulation)
+		}
+
+		if err := db.checkHosts(change.Hostname, change.ToHostname); err != nil {
+			return err
+		}
+
+		key, ok := db.findDatabase(change.Hostname, change.DbName)
+		if !ok {
+			return utils.NewErrorf("%w: database %s not found on %s",
+				utils.ErrInvalidLicenseSimulation, change.DbName, change.Hostname)
+		}
+
+		if _, moved := db.databases[key]; !moved {
+			db.movedDatabases = append(db.movedDatabases, key)
+		}
+
+		db.databases[key] = change.ToHostname
+
+	case dto.LicenseSimulationChangeHostCPU:
+		if change.CPUCores < 0 || change.CPUThreads < 0 || change.CPUSockets < 0 ||
+			change.CPUCores+change.CPUThreads+change.CPUSockets == 0 {
+			return utils.NewErrorf("%w: cpuCores, cpuThreads or cpuSockets are required", utils.ErrInvalidLicenseSimulation)
+		}
+
+		if err := db.checkHosts(change.Hostname); err != nil {
+			return err
+		}
+
+		info := db.hostInfo(change.Hostname)
+
+		if change.CPUCores > 0 {
+			info.CPUCores = change.CPUCores
+		}
+
+		if change.CPUThreads > 0 {
+			info.CPUThreads = change.CPUThreads
+		}
+
+		if change.CPUSockets > 0 {
+			info.CPUSockets = change.CPUSockets
+		}
+
+		if info.CPUCores > 0 && info.CPUThreads >= info.CPUCores {
+			info.ThreadsPerCore = info.CPUThreads / info.CPUCores
+		}
+
+		db.hosts[change.Hostname] = info
+
+	case dto.LicenseSimulationAddClusterMember, dto.LicenseSimulationRemoveClusterMember:
+		if !clusterNames[change.Cluster] {
+			return utils.NewErrorf("%w: %s", utils.ErrClusterNotFound, change.Cluster)
+		}
+
+		if change.Hostname == "" {
+			return utils.NewErrorf("%w: hostname is required", utils.ErrInvalidLicenseSimulation)
+		}
+
+		db.clusterChanges = append(db.clusterChanges, change)
+
+	case dto.LicenseSimulationAddContract:
+		if change.Contract == nil {
+			return utils.NewErrorf("%w: contract is required", utils.ErrInvalidLicenseSimulation)
+		}
+
+		if _, ok := db.licenseTypes[change.Contract.LicenseTypeID]; !ok {
+			return utils.NewErrorf("%w: %s", utils.ErrOracleDatabaseLicenseTypeIDNotFound, change.Contract.LicenseTypeID)
+		}
+
+		if err := change.Contract.Check(); err != nil {
+			return utils.NewErrorf("%w: %s", utils.ErrInvalidLicenseSimulation, err)
+		}
+
+		contract := *change.Contract
+		contract.ID = as.NewObjectID()
+
+		db.contracts = append(db.contracts, contract)
+
+	default:
+		return utils.NewErrorf("%w: unknown change type %q", utils.ErrInvalidLicenseSimulation, change.Type)
+	}
+
+	return nil
+}
+
+func (db *licenseSimulationDatabase) checkHosts(hostnames ...string) error {
+	for _, hostname := range hostnames {
+		if _, ok := db.hostdatas[hostname]; !ok {
+			return utils.NewErrorf("%w: %s", utils.ErrHostNotFound, hostname)
+		}
+	}
+
+	return nil
+}
+
+// findDatabase return the current host and the name of the database name, that is on hostname after the previous changes
+func (db *licenseSimulationDatabase) findDatabase(hostname, name string) (simulationDatabaseKey, bool) {
+	for _, key := range db.movedDatabases {
+		if key.name == name && db.databases[key] == hostname {
+			return key, true
+		}
+	}
+
+	key := simulationDatabaseKey{hostname: hostname, name: name}
+	if _, moved := db.databases[key]; moved {
+		return key, false
+	}
+
+	return key, hostHasDatabase(db.hostdatas[hostname], name)
+}
+
+func hostHasDatabase(hostdata model.HostDataBE, name string) bool {
+	if hostdata.Features.Oracle != nil && hostdata.Features.Oracle.Database != nil {
+		for _, db := range hostdata.Features.Oracle.Database.Databases {
+			if db.Name == name {
+				return true
+			}
+		}
+	}
+
+	if hostdata.Features.MySQL != nil {
+		for _, instance := range hostdata.Features.MySQL.Instances {
+			if instance.Name == name {
+				return true
+			}
+		}
+	}
+
+	if hostdata.Features.Microsoft != nil && hostdata.Features.Microsoft.SQLServer != nil {
+		for _, instance := range hostdata.Features.Microsoft.SQLServer.Instances {
+			if instance.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// databaseHostname return the hostname where the database name of hostname is after the changes
+func (db *licenseSimulationDatabase) databaseHostname(hostname, name string) string {
+	if moved, ok := db.databases[simulationDatabaseKey{hostname: hostname, name: name}]; ok {
+		return moved
+	}
+
+	return hostname
+}
+
+func (db *licenseSimulationDatabase) hostInfo(hostname string) model.Host {
+	if info, ok := db.hosts[hostname]; ok {
+		return info
+	}
+
+	return db.hostdatas[hostname].Info
+}
+
+// processorsRatio return the ratio between the processors of the simulated host to and the ones of the current host from
+func (db *licenseSimulationDatabase) processorsRatio(from, to string) float64 {
+	current := db.hostdatas[from]
+	before := float64(current.Info.CPUCores) * db.policy.HostCoreFactor(current.Info, current.Cloud)
+
+	info := db.hostInfo(to)
+	after := float64(info.CPUCores) * db.policy.HostCoreFactor(info, db.hostdatas[to].Cloud)
+
+	if before == 0 {
+		return 1
+	}
+
+	return after / before
+}
+
+func (db *licenseSimulationDatabase) SearchOracleDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool,
+	page int, pageSize int, location string, environment string, olderThan time.Time,
+) (*dto.OracleDatabaseUsedLicenseSearchResponse, error) {
+	res, err := db.MongoDatabaseInterface.SearchOracleDatabaseUsedLicenses(hostname, sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]dto.OracleDatabaseUsedLicense, 0, len(res.Content))
+
+	for _, l := range res.Content {
+		simulatedHostname := db.databaseHostname(l.Hostname, l.DbName)
+
+		if db.licenseTypes[l.LicenseTypeID].Metric == model.LicenseTypeMetricProcessorPerpetual {
+			l.UsedLicenses *= db.processorsRatio(l.Hostname, simulatedHostname)
+		}
+
+		l.Hostname = simulatedHostname
+		content = append(content, l)
+	}
+
+	return &dto.OracleDatabaseUsedLicenseSearchResponse{Content: content, Metadata: res.Metadata}, nil
+}
+
+func (db *licenseSimulationDatabase) GetMySQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.MySQLUsedLicense, error) {
+	licenses, err := db.MongoDatabaseInterface.GetMySQLUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range licenses {
+		licenses[i].Hostname = db.databaseHostname(licenses[i].Hostname, licenses[i].InstanceName)
+	}
+
+	return licenses, nil
+}
+
+func (db *licenseSimulationDatabase) SearchSqlServerDatabaseUsedLicenses(hostname string, sortBy string, sortDesc bool,
+	page int, pageSize int, location string, environment string, olderThan time.Time,
+) (*dto.SqlServerDatabaseUsedLicenseSearchResponse, error) {
+	res, err := db.MongoDatabaseInterface.SearchSqlServerDatabaseUsedLicenses(hostname, sortBy, sortDesc, page, pageSize, location, environment, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res.Content {
+		res.Content[i].Hostname = db.databaseHostname(res.Content[i].Hostname, res.Content[i].DbName)
+	}
+
+	return res, nil
+}
+
+func (db *licenseSimulationDatabase) GetHostData(hostname string, olderThan time.Time) (*model.HostDataBE, error) {
+	hostdata, err := db.MongoDatabaseInterface.GetHostData(hostname, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := db.simulateHostData(*hostdata)
+
+	return &simulated, nil
+}
+
+func (db *licenseSimulationDatabase) GetHostDatas(filter dto.GlobalFilter) ([]model.HostDataBE, error) {
+	hostdatas, err := db.MongoDatabaseInterface.GetHostDatas(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range hostdatas {
+		hostdatas[i] = db.simulateHostData(hostdatas[i])
+	}
+
+	return hostdatas, nil
+}
+
+// simulateHostData return a copy of hostdata with the simulated CPU and Oracle databases
+func (db *licenseSimulationDatabase) simulateHostData(hostdata model.HostDataBE) model.HostDataBE {
+	if info, ok := db.hosts[hostdata.Hostname]; ok {
+		hostdata.Info = info
+	}
+
+	databases := make([]model.OracleDatabase, 0)
+	changed := false
+
+	if hostdata.Features.Oracle != nil && hostdata.Features.Oracle.Database != nil {
+		for _, od := range hostdata.Features.Oracle.Database.Databases {
+			if db.databaseHostname(hostdata.Hostname, od.Name) != hostdata.Hostname {
+				changed = true
+				continue
+			}
+
+			databases = append(databases, od)
+		}
+	}
+
+	for _, key := range db.movedDatabases {
+		if key.hostname == hostdata.Hostname || db.databases[key] != hostdata.Hostname {
+			continue
+		}
+
+		from := db.hostdatas[key.hostname]
+		if from.Features.Oracle == nil || from.Features.Oracle.Database == nil {
+			continue
+		}
+
+		for _, od := range from.Features.Oracle.Database.Databases {
+			if od.Name == key.name {
+				databases = append(databases, od)
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return hostdata
+	}
+
+	oracle := model.OracleFeature{}
+	if hostdata.Features.Oracle != nil {
+		oracle = *hostdata.Features.Oracle
+	}
+
+	oracleDatabase := model.OracleDatabaseFeature{}
+	if oracle.Database != nil {
+		oracleDatabase = *oracle.Database
+	}
+
+	oracleDatabase.Databases = databases
+	oracle.Database = &oracleDatabase
+	hostdata.Features.Oracle = &oracle
+
+	return hostdata
+}
+
+func (db *licenseSimulationDatabase) GetClusters(filter dto.GlobalFilter) ([]dto.Cluster, error) {
+	clusters, err := db.MongoDatabaseInterface.GetClusters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range clusters {
+		clusters[i] = db.simulateCluster(clusters[i])
+	}
+
+	return clusters, nil
+}
+
+func (db *licenseSimulationDatabase) GetCluster(clusterName string, olderThan time.Time) (*dto.Cluster, error) {
+	cluster, err := db.MongoDatabaseInterface.GetCluster(clusterName, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := db.simulateCluster(*cluster)
+
+	return &simulated, nil
+}
+
+// simulateCluster return a copy of cluster with the simulated VMs. A host added to a cluster is removed from the others
+func (db *licenseSimulationDatabase) simulateCluster(cluster dto.Cluster) dto.Cluster {
+	if len(db.clusterChanges) == 0 {
+		return cluster
+	}
+
+	vms := make([]dto.VM, 0, len(cluster.VMs))
+	vms = append(vms, cluster.VMs...)
+
+	for _, change := range db.clusterChanges {
+		if change.Type == dto.LicenseSimulationAddClusterMember || change.Cluster == cluster.Name {
+			for i := 0; i < len(vms); {
+				if vms[i].Hostname == change.Hostname {
+					vms = append(vms[:i], vms[i+1:]...)
+					continue
+				}
+
+				i++
+			}
+		}
+
+		if change.Type == dto.LicenseSimulationAddClusterMember && change.Cluster == cluster.Name {
+			vms = append(vms, dto.VM{
+				Hostname:          change.Hostname,
+				Name:              change.Hostname,
+				IsErcoleInstalled: true,
+			})
+		}
+	}
+
+	cluster.VMs = vms
+	cluster.VMsCount = len(vms)
+	cluster.VMsErcoleAgentCount = 0
+
+	for _, vm := range vms {
+		if vm.IsErcoleInstalled {
+			cluster.VMsErcoleAgentCount++
+		}
+	}
+
+	return cluster
+}
+
+func (db *licenseSimulationDatabase) ListOracleDatabaseContracts(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error) {
+	contracts, err := db.MongoDatabaseInterface.ListOracleDatabaseContracts(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range db.contracts {
+		if !locationsInclude(filter.Locations, c.Location) {
+			continue
+		}
+
+		contracts = append(contracts, newOracleDatabaseContractFE(c, db.licenseTypes[c.LicenseTypeID]))
+	}
+
+	return contracts, nil
+}
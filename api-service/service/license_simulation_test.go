@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newLicenseSimulationTestData() ([]model.OracleDatabaseLicenseType, []model.HostDataBE, []dto.Cluster) {
+	licenseTypes := []model.OracleDatabaseLicenseType{
+		{ID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Metric: model.LicenseTypeMetricProcessorPerpetual},
+		{ID: "A90610", ItemDescription: "Oracle Database Enterprise Edition", Metric: model.LicenseTypeMetricNamedUserPlusPerpetual},
+	}
+
+	oracleFeatures := func(dbs ...string) model.Features {
+		databases := make([]model.OracleDatabase, 0)
+		for _, db := range dbs {
+			databases = append(databases, model.OracleDatabase{Name: db})
+		}
+
+		return model.Features{Oracle: &model.OracleFeature{Database: &model.OracleDatabaseFeature{Databases: databases}}}
+	}
+
+	hostdatas := []model.HostDataBE{
+		{
+			Hostname: "hosta",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 4, CPUThreads: 8, ThreadsPerCore: 2},
+			Features: oracleFeatures("erp", "crm"),
+		},
+		{
+			Hostname: "hostb",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 8, CPUThreads: 16, ThreadsPerCore: 2},
+			Features: oracleFeatures(),
+		},
+	}
+
+	clusters := []dto.Cluster{
+		{Name: "cluster1", VMs: []dto.VM{{Hostname: "hosta", IsErcoleInstalled: true}}},
+		{Name: "cluster2", VMs: []dto.VM{}},
+	}
+
+	return licenseTypes, hostdatas, clusters
+}
+
+func TestNewLicenseSimulationDatabase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	licenseTypes, hostdatas, clusters := newLicenseSimulationTestData()
+
+	db.EXPECT().GetActiveCoreFactorPolicy().Return(nil, nil).AnyTimes()
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil).AnyTimes()
+	db.EXPECT().GetHostDatas(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(hostdatas, nil).AnyTimes()
+	db.EXPECT().GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(clusters, nil).AnyTimes()
+
+	testCases := []struct {
+		name   string
+		change dto.LicenseSimulationChange
+		err    error
+	}{
+		{"Unknown type", dto.LicenseSimulationChange{Type: "foobar"}, utils.ErrInvalidLicenseSimulation},
+		{"Host not found", dto.LicenseSimulationChange{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hosta", DbName: "erp", ToHostname: "hostc"}, utils.ErrHostNotFound},
+		{"Database not found", dto.LicenseSimulationChange{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hostb", DbName: "erp", ToHostname: "hosta"}, utils.ErrInvalidLicenseSimulation},
+		{"Missing CPU", dto.LicenseSimulationChange{Type: dto.LicenseSimulationChangeHostCPU, Hostname: "hosta"}, utils.ErrInvalidLicenseSimulation},
+		{"Cluster not found", dto.LicenseSimulationChange{Type: dto.LicenseSimulationAddClusterMember, Hostname: "hostb", Cluster: "cluster3"}, utils.ErrClusterNotFound},
+		{"Missing contract", dto.LicenseSimulationChange{Type: dto.LicenseSimulationAddContract}, utils.ErrInvalidLicenseSimulation},
+		{"License type not found", dto.LicenseSimulationChange{Type: dto.LicenseSimulationAddContract, Contract: &model.OracleDatabaseContract{LicenseTypeID: "foo"}}, utils.ErrOracleDatabaseLicenseTypeIDNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := as.newLicenseSimulationDatabase([]dto.LicenseSimulationChange{tc.change})
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	t.Run("Moves database twice", func(t *testing.T) {
+		simulation, err := as.newLicenseSimulationDatabase([]dto.LicenseSimulationChange{
+			{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hosta", DbName: "erp", ToHostname: "hostb"},
+			{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hostb", DbName: "erp", ToHostname: "hosta"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hosta", simulation.databaseHostname("hosta", "erp"))
+	})
+}
+
+func TestLicenseSimulationDatabase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	licenseTypes, hostdatas, clusters := newLicenseSimulationTestData()
+
+	db.EXPECT().GetActiveCoreFactorPolicy().Return(nil, nil)
+	db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+	db.EXPECT().GetHostDatas(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(hostdatas, nil)
+	db.EXPECT().GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(clusters, nil)
+
+	simulation, err := as.newLicenseSimulationDatabase([]dto.LicenseSimulationChange{
+		{Type: dto.LicenseSimulationMoveDatabase, Hostname: "hosta", DbName: "erp", ToHostname: "hostb"},
+		{Type: dto.LicenseSimulationChangeHostCPU, Hostname: "hosta", CPUCores: 2, CPUThreads: 4},
+		{Type: dto.LicenseSimulationAddClusterMember, Hostname: "hosta", Cluster: "cluster2"},
+		{Type: dto.LicenseSimulationAddContract, Contract: &model.OracleDatabaseContract{LicenseTypeID: "A90611", Count: 10, Hosts: []string{"hostb"}}},
+	})
+	require.NoError(t, err)
+
+	t.Run("Used licenses", func(t *testing.T) {
+		db.EXPECT().SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME).
+			Return(&dto.OracleDatabaseUsedLicenseSearchResponse{Content: []dto.OracleDatabaseUsedLicense{
+				{LicenseTypeID: "A90611", DbName: "erp", Hostname: "hosta", UsedLicenses: 2},
+				{LicenseTypeID: "A90610", DbName: "erp", Hostname: "hosta", UsedLicenses: 50},
+				{LicenseTypeID: "A90611", DbName: "crm", Hostname: "hosta", UsedLicenses: 2},
+			}}, nil)
+
+		actual, err := simulation.SearchOracleDatabaseUsedLicenses("", "", false, -1, -1, "", "", utils.MAX_TIME)
+		require.NoError(t, err)
+		assert.Equal(t, []dto.OracleDatabaseUsedLicense{
+			{LicenseTypeID: "A90611", DbName: "erp", Hostname: "hostb", UsedLicenses: 4},
+			{LicenseTypeID: "A90610", DbName: "erp", Hostname: "hostb", UsedLicenses: 50},
+			{LicenseTypeID: "A90611", DbName: "crm", Hostname: "hosta", UsedLicenses: 1},
+		}, actual.Content)
+	})
+
+	t.Run("Hostdatas", func(t *testing.T) {
+		_, current, _ := newLicenseSimulationTestData()
+		db.EXPECT().GetHostDatas(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(current, nil)
+
+		actual, err := simulation.GetHostDatas(dto.GlobalFilter{OlderThan: utils.MAX_TIME})
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, 2, actual[0].Info.CPUCores)
+		assert.Equal(t, []model.OracleDatabase{{Name: "crm"}}, actual[0].Features.Oracle.Database.Databases)
+		assert.Equal(t, []model.OracleDatabase{{Name: "erp"}}, actual[1].Features.Oracle.Database.Databases)
+
+		assert.Len(t, hostdatas[0].Features.Oracle.Database.Databases, 2)
+	})
+
+	t.Run("Clusters", func(t *testing.T) {
+		_, _, current := newLicenseSimulationTestData()
+		db.EXPECT().GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).Return(current, nil)
+
+		actual, err := simulation.GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME})
+		require.NoError(t, err)
+		assert.Empty(t, actual[0].VMs)
+		assert.Equal(t, []dto.VM{{Hostname: "hosta", Name: "hosta", IsErcoleInstalled: true}}, actual[1].VMs)
+		assert.Equal(t, 1, actual[1].VMsErcoleAgentCount)
+	})
+
+	t.Run("Contracts", func(t *testing.T) {
+		filter := dto.NewGetOracleDatabaseContractsFilter()
+		db.EXPECT().ListOracleDatabaseContracts(filter).Return([]dto.OracleDatabaseContractFE{}, nil)
+
+		actual, err := simulation.ListOracleDatabaseContracts(filter)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, 10.0, actual[0].LicensesPerCore)
+		assert.Equal(t, 10.0, actual[0].AvailableLicensesPerCore)
+		assert.Equal(t, model.LicenseTypeMetricProcessorPerpetual, actual[0].Metric)
+		assert.Equal(t, []dto.OracleDatabaseContractAssociatedHostFE{{Hostname: "hostb"}}, actual[0].Hosts)
+	})
+}
+
+func TestLicenseComplianceDeltas(t *testing.T) {
+	before := []dto.LicenseCompliance{
+		{LicenseTypeID: "A90611", Consumed: 4, Covered: 4, Compliance: 1},
+	}
+	after := []dto.LicenseCompliance{
+		{LicenseTypeID: "A90611", Consumed: 8, Covered: 4, Compliance: 0.5},
+		{LicenseTypeID: "A90649", Consumed: 2, Covered: 0, Compliance: 0},
+	}
+
+	actual := licenseComplianceDeltas(before, after)
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, "A90611", actual[0].LicenseTypeID)
+	assert.Equal(t, 4.0, actual[0].ConsumedDelta)
+	assert.Equal(t, -0.5, actual[0].ComplianceDelta)
+
+	assert.Equal(t, "A90649", actual[1].LicenseTypeID)
+	assert.Equal(t, 1.0, actual[1].Before.Compliance)
+	assert.Equal(t, 2.0, actual[1].ConsumedDelta)
+	assert.Equal(t, -1.0, actual[1].ComplianceDelta)
+}
@@ -0,0 +1,455 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+// GetLicensesCost return the current spend on the licenses of the locations and the cost of the consumption
+// not covered by them, by license type, technology, location, environment and tag.
+// The cost by location is computed from the last historicized compliance of the locations.
+// The cost of a license type is split among environments and tags in proportion to the licenses consumed by their hosts:
+// the cost of licenses not consumed by any host isn't allocated and a host with many tags is counted in each of them
+func (as *APIService) GetLicensesCost(locations []string) (*dto.LicensesCost, error) {
+	technologies, err := as.getDatabaseLicensesComplianceByTechnology(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := &dto.LicensesCost{
+		LicenseTypes: licenseTypesCost(technologies),
+	}
+
+	byTechnology := licensesCostGroups{}
+
+	for _, ltc := range cost.LicenseTypes {
+		cost.Spend += ltc.Spend
+		cost.Exposure += ltc.Exposure
+
+		byTechnology.add(ltc.Technology, ltc.Spend, ltc.Exposure)
+	}
+
+	cost.Technologies = byTechnology.sorted()
+
+	if cost.Locations, err = as.getLicensesCostByLocation(locations, cost.LicenseTypes); err != nil {
+		return nil, err
+	}
+
+	if cost.Environments, cost.Tags, err = as.getLicensesCostByEnvironmentAndTag(locations, cost.LicenseTypes); err != nil {
+		return nil, err
+	}
+
+	return cost, nil
+}
+
+// licenseTypesCost return the cost of the license types used, covered or purchased of each technology
+func licenseTypesCost(technologies []technologyLicensesCompliance) []dto.LicenseTypeCost {
+	costs := make([]dto.LicenseTypeCost, 0)
+
+	for _, technology := range technologies {
+		for _, license := range technology.licenses {
+			if license.Consumed == 0 && license.Covered == 0 && license.Purchased == 0 {
+				continue
+			}
+
+			costs = append(costs, newLicenseTypeCost(technology.technology, license))
+		}
+	}
+
+	return costs
+}
+
+// newLicenseTypeCost return the spend on the purchased licenses and the exposure of the consumed licenses not covered.
+// The consumption of unlimited licenses is never exposed, a license type without a cost is flagged
+func newLicenseTypeCost(technology string, license dto.LicenseCompliance) dto.LicenseTypeCost {
+	ltc := dto.LicenseTypeCost{
+		Technology:      technology,
+		LicenseTypeID:   license.LicenseTypeID,
+		ItemDescription: license.ItemDescription,
+		Metric:          license.Metric,
+		Cost:            license.Cost,
+		CostMissing:     license.Cost == 0,
+		Consumed:        license.Consumed,
+		Covered:         license.Covered,
+		Purchased:       license.Purchased,
+		Unlimited:       license.Unlimited,
+		Spend:           license.Purchased * license.Cost,
+	}
+
+	if !license.Unlimited {
+		ltc.Exposure = math.Max(license.Consumed-license.Covered, 0) * license.Cost
+	}
+
+	return ltc
+}
+
+// getLicensesCostByLocation split the cost of the license types among the locations
+// by their last historicized compliance, valued at the current cost of the license types
+func (as *APIService) getLicensesCostByLocation(locations []string, licenseTypes []dto.LicenseTypeCost) ([]dto.LicensesCostGroup, error) {
+	if len(locations) == 1 && locations[0] == "" {
+		locations = nil
+	}
+
+	licenses, err := as.Database.GetLastLocationsLicensesCompliance(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	return locationsLicensesCost(licenses, licenseTypes).sorted(), nil
+}
+
+// locationsLicensesCost return the spend and the exposure of the licenses compliance of each location.
+// The consumption of unlimited licenses is never exposed
+func locationsLicensesCost(licenses []dto.LocationLicenseCompliance, licenseTypes []dto.LicenseTypeCost) licensesCostGroups {
+	costs := make(map[string]float64, len(licenseTypes))
+	for _, ltc := range licenseTypes {
+		costs[licenseTypeKey(ltc.LicenseTypeID, ltc.ItemDescription)] = ltc.Cost
+	}
+
+	byLocation := licensesCostGroups{}
+
+	for _, license := range licenses {
+		cost := costs[licenseTypeKey(license.LicenseTypeID, license.ItemDescription)]
+
+		var exposure float64
+		if !license.Value.Unlimited {
+			exposure = math.Max(license.Value.Consumed-license.Value.Covered, 0) * cost
+		}
+
+		byLocation.add(license.Location, license.Value.Purchased*cost, exposure)
+	}
+
+	return byLocation
+}
+
+// licenseTypeKey identifies a license type by its ID, or by its description when it hasn't one
+func licenseTypeKey(licenseTypeID, itemDescription string) string {
+	if licenseTypeID == "" {
+		return itemDescription
+	}
+
+	return licenseTypeID
+}
+
+func (as *APIService) getLicensesCostByEnvironmentAndTag(locations []string, licenseTypes []dto.LicenseTypeCost) ([]dto.LicensesCostGroup, []dto.LicensesCostGroup, error) {
+	usages, err := as.getLicensesCostUsages(locations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := dto.GlobalFilter{
+		Location:  strings.Join(locations, ","),
+		OlderThan: utils.MAX_TIME,
+	}
+
+	hostdatas, err := as.Database.GetHostDatas(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, err := as.Database.GetClusters(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	environments, tags := allocateLicensesCost(licenseTypes, usages, hostdatas, clusters)
+
+	return environments.sorted(), tags.sorted(), nil
+}
+
+// licenseUsage contains the licenses of a technology's license type consumed by a host or a cluster
+type licenseUsage struct {
+	technology    string
+	licenseTypeID string
+	name          string
+	cluster       bool
+	consumed      float64
+}
+
+// getLicensesCostUsages return the licenses consumed by each host and cluster of the locations, of every technology
+func (as *APIService) getLicensesCostUsages(locations []string) ([]licenseUsage, error) {
+	oracleUsages, err := as.getLicensesUsage(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]licenseUsage, 0, len(oracleUsages))
+
+	for _, usage := range oracleUsages {
+		usages = append(usages, licenseUsage{
+			technology:    model.TechnologyOracleDatabase,
+			licenseTypeID: usage.LicenseTypeID,
+			name:          usage.Name,
+			cluster:       usage.Type == "cluster",
+			consumed:      usage.OriginalCount,
+		})
+	}
+
+	filter := dto.GlobalFilter{
+		Location:  strings.Join(locations, ","),
+		OlderThan: utils.MAX_TIME,
+	}
+
+	getters := []struct {
+		technology string
+		get        func(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error)
+	}{
+		{model.TechnologyOracleMySQL, as.getMySQLUsedLicenses},
+		{model.TechnologyMicrosoftSQLServer, as.getSqlServerDatabasesUsedLicenses},
+		{model.TechnologyPostgreSQLPostgreSQL, as.GetPostgreSQLUsedLicenses},
+		{model.TechnologyMongoDBMongoDB, as.GetMongoDBUsedLicenses},
+		{model.TechnologyMariaDBFoundationMariaDB, as.GetMariaDBUsedLicenses},
+	}
+
+	for _, getter := range getters {
+		usedLicenses, err := getter.get("", filter)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, databaseLicensesUsages(getter.technology, usedLicenses)...)
+	}
+
+	return usages, nil
+}
+
+// databaseLicensesUsages return the usages of the used licenses of a technology.
+// The licenses of a cluster are reported by each of its hosts, they are counted once for the cluster
+func databaseLicensesUsages(technology string, usedLicenses []dto.DatabaseUsedLicense) []licenseUsage {
+	usages := make([]licenseUsage, 0, len(usedLicenses))
+	clusters := make(map[string]bool)
+
+	for _, usedLicense := range usedLicenses {
+		if usedLicense.Ignored {
+			continue
+		}
+
+		if usedLicense.ClusterName == "" || usedLicense.ClusterLicenses == 0 {
+			usages = append(usages, licenseUsage{
+				technology:    technology,
+				licenseTypeID: usedLicense.LicenseTypeID,
+				name:          usedLicense.Hostname,
+				consumed:      usedLicense.UsedLicenses,
+			})
+
+			continue
+		}
+
+		key := usedLicense.LicenseTypeID + "/" + usedLicense.ClusterName
+		if clusters[key] {
+			continue
+		}
+
+		clusters[key] = true
+
+		usages = append(usages, licenseUsage{
+			technology:    technology,
+			licenseTypeID: usedLicense.LicenseTypeID,
+			name:          usedLicense.ClusterName,
+			cluster:       true,
+			consumed:      usedLicense.ClusterLicenses,
+		})
+	}
+
+	return usages
+}
+
+// allocateLicensesCost split the cost of each license type among the environments and the tags of the hosts and clusters
+// in proportion to the licenses they consume. A cluster has its own environment and the tags of its VMs
+func allocateLicensesCost(licenseTypes []dto.LicenseTypeCost, usages []licenseUsage,
+	hostdatas []model.HostDataBE, clusters []dto.Cluster) (licensesCostGroups, licensesCostGroups) {
+	hostdatasMap := make(map[string]model.HostDataBE, len(hostdatas))
+	for _, hostdata := range hostdatas {
+		hostdatasMap[hostdata.Hostname] = hostdata
+	}
+
+	clustersMap := make(map[string]dto.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		clustersMap[cluster.Name] = cluster
+	}
+
+	consumedByLicenseType := make(map[string]float64)
+	for _, usage := range usages {
+		consumedByLicenseType[usage.technology+"/"+usage.licenseTypeID] += usage.consumed
+	}
+
+	costByLicenseType := make(map[string]dto.LicenseTypeCost, len(licenseTypes))
+	for _, ltc := range licenseTypes {
+		costByLicenseType[ltc.Technology+"/"+ltc.LicenseTypeID] = ltc
+	}
+
+	environments, tags := licensesCostGroups{}, licensesCostGroups{}
+
+	for _, usage := range usages {
+		key := usage.technology + "/" + usage.licenseTypeID
+		ltc, ok := costByLicenseType[key]
+		consumed := consumedByLicenseType[key]
+
+		if !ok || consumed == 0 || (ltc.Spend == 0 && ltc.Exposure == 0) {
+			continue
+		}
+
+		var environment string
+
+		var hostTags []string
+
+		if usage.cluster {
+			cluster := clustersMap[usage.name]
+			environment = cluster.Environment
+
+			for _, vm := range cluster.VMs {
+				for _, tag := range hostdatasMap[vm.Hostname].Tags {
+					if !utils.Contains(hostTags, tag) {
+						hostTags = append(hostTags, tag)
+					}
+				}
+			}
+		} else {
+			hostdata := hostdatasMap[usage.name]
+			environment = hostdata.Environment
+			hostTags = hostdata.Tags
+		}
+
+		share := usage.consumed / consumed
+		spend, exposure := ltc.Spend*share, ltc.Exposure*share
+
+		environments.add(environment, spend, exposure)
+
+		for _, tag := range hostTags {
+			tags.add(tag, spend, exposure)
+		}
+	}
+
+	return environments, tags
+}
+
+// licensesCostGroups sums the cost of the licenses by group
+type licensesCostGroups map[string]*dto.LicensesCostGroup
+
+func (g licensesCostGroups) add(group string, spend, exposure float64) {
+	cost, ok := g[group]
+	if !ok {
+		cost = &dto.LicensesCostGroup{Group: group}
+		g[group] = cost
+	}
+
+	cost.Spend += spend
+	cost.Exposure += exposure
+}
+
+func (g licensesCostGroups) sorted() []dto.LicensesCostGroup {
+	groups := make([]dto.LicensesCostGroup, 0, len(g))
+	for _, cost := range g {
+		groups = append(groups, *cost)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Group < groups[j].Group
+	})
+
+	return groups
+}
+
+func (as *APIService) GetLicensesCostAsXLSX(locations []string) (*excelize.File, error) {
+	cost, err := as.GetLicensesCost(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "License Types"
+	headers := []string{
+		"Technology",
+		"Part Number",
+		"Description",
+		"Metric",
+		"Cost",
+		"Cost Missing",
+		"Purchased",
+		"Consumed",
+		"Covered",
+		"ULA",
+		"Spend",
+		"Exposure",
+	}
+
+	file, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, val := range cost.LicenseTypes {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), val.Technology)
+		file.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		file.SetCellValue(sheet, nextAxis(), val.ItemDescription)
+		file.SetCellValue(sheet, nextAxis(), val.Metric)
+		file.SetCellValue(sheet, nextAxis(), val.Cost)
+		file.SetCellValue(sheet, nextAxis(), val.CostMissing)
+		file.SetCellValue(sheet, nextAxis(), val.Purchased)
+		file.SetCellValue(sheet, nextAxis(), val.Consumed)
+		file.SetCellValue(sheet, nextAxis(), val.Covered)
+		file.SetCellValue(sheet, nextAxis(), val.Unlimited)
+		file.SetCellValue(sheet, nextAxis(), val.Spend)
+		file.SetCellValue(sheet, nextAxis(), val.Exposure)
+	}
+
+	nextAxis := axisHelp.NewRow()
+	file.SetCellValue(sheet, nextAxis(), "Total")
+
+	for i := 0; i < len(headers)-3; i++ {
+		nextAxis()
+	}
+
+	file.SetCellValue(sheet, nextAxis(), cost.Spend)
+	file.SetCellValue(sheet, nextAxis(), cost.Exposure)
+
+	setLicensesCostGroupsSheet(file, "Technologies", "Technology", cost.Technologies)
+	setLicensesCostGroupsSheet(file, "Locations", "Location", cost.Locations)
+	setLicensesCostGroupsSheet(file, "Environments", "Environment", cost.Environments)
+	setLicensesCostGroupsSheet(file, "Tags", "Tag", cost.Tags)
+
+	return file, nil
+}
+
+func setLicensesCostGroupsSheet(file *excelize.File, sheet, group string, groups []dto.LicensesCostGroup) {
+	file.NewSheet(sheet)
+
+	axisHelp := exutils.NewAxisHelper(0)
+	axisHelp.NewRowAndFill(file, sheet,
+		group,
+		"Spend",
+		"Exposure",
+	)
+
+	for _, val := range groups {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), val.Group)
+		file.SetCellValue(sheet, nextAxis(), val.Spend)
+		file.SetCellValue(sheet, nextAxis(), val.Exposure)
+	}
+}
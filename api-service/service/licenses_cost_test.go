@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func TestLicenseTypesCost(t *testing.T) {
+	technologies := []technologyLicensesCompliance{
+		{
+			technology: model.TechnologyOracleDatabase,
+			licenses: []dto.LicenseCompliance{
+				{
+					LicenseTypeID:   "A90611",
+					ItemDescription: "Oracle Database Enterprise Edition",
+					Metric:          "Processor Perpetual",
+					Cost:            100,
+					Consumed:        30,
+					Covered:         20,
+					Purchased:       25,
+				},
+				{
+					LicenseTypeID: "A90649",
+					Cost:          50,
+					Consumed:      40,
+					Covered:       40,
+					Purchased:     0,
+					Unlimited:     true,
+				},
+				{
+					LicenseTypeID: "A90650",
+					Cost:          50,
+				},
+			},
+		},
+		{
+			technology: model.TechnologyOracleMySQL,
+			licenses: []dto.LicenseCompliance{
+				{
+					LicenseTypeID: "MySQL Enterprise per host",
+					Consumed:      3,
+					Purchased:     1,
+				},
+			},
+		},
+	}
+
+	expected := []dto.LicenseTypeCost{
+		{
+			Technology:      model.TechnologyOracleDatabase,
+			LicenseTypeID:   "A90611",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          "Processor Perpetual",
+			Cost:            100,
+			Consumed:        30,
+			Covered:         20,
+			Purchased:       25,
+			Spend:           2500,
+			Exposure:        1000,
+		},
+		{
+			Technology:    model.TechnologyOracleDatabase,
+			LicenseTypeID: "A90649",
+			Cost:          50,
+			Consumed:      40,
+			Covered:       40,
+			Unlimited:     true,
+		},
+		{
+			Technology:    model.TechnologyOracleMySQL,
+			LicenseTypeID: "MySQL Enterprise per host",
+			CostMissing:   true,
+			Consumed:      3,
+			Purchased:     1,
+		},
+	}
+
+	assert.Equal(t, expected, licenseTypesCost(technologies))
+}
+
+func TestNewLicenseTypeCost_UnlimitedIsNeverExposed(t *testing.T) {
+	license := dto.LicenseCompliance{
+		LicenseTypeID: "A90611",
+		Cost:          100,
+		Consumed:      30,
+		Covered:       0,
+		Purchased:     10,
+		Unlimited:     true,
+	}
+
+	actual := newLicenseTypeCost(model.TechnologyOracleDatabase, license)
+
+	assert.Equal(t, 1000.0, actual.Spend)
+	assert.Equal(t, 0.0, actual.Exposure)
+}
+
+func TestLocationsLicensesCost(t *testing.T) {
+	licenseTypes := []dto.LicenseTypeCost{
+		{LicenseTypeID: "A90611", Cost: 100},
+		{LicenseTypeID: "A90649", Cost: 50},
+		{ItemDescription: "MySQL Enterprise per cluster", Cost: 10},
+	}
+
+	licenses := []dto.LocationLicenseCompliance{
+		{
+			Location:      "Italy",
+			LicenseTypeID: "A90611",
+			Value:         dto.LicenseComplianceHistoricValue{Consumed: 5, Covered: 3, Purchased: 3},
+		},
+		{
+			Location:      "Italy",
+			LicenseTypeID: "A90649",
+			Value:         dto.LicenseComplianceHistoricValue{Consumed: 8, Covered: 2, Purchased: 2, Unlimited: true},
+		},
+		{
+			Location:        "Germany",
+			ItemDescription: "MySQL Enterprise per cluster",
+			Value:           dto.LicenseComplianceHistoricValue{Consumed: 4, Covered: 1, Purchased: 1},
+		},
+		{
+			Location:      "Germany",
+			LicenseTypeID: "L10006",
+			Value:         dto.LicenseComplianceHistoricValue{Consumed: 4, Purchased: 4},
+		},
+	}
+
+	assert.Equal(t, []dto.LicensesCostGroup{
+		{Group: "Germany", Spend: 10, Exposure: 30},
+		{Group: "Italy", Spend: 400, Exposure: 200},
+	}, locationsLicensesCost(licenses, licenseTypes).sorted())
+}
+
+func TestDatabaseLicensesUsages(t *testing.T) {
+	usedLicenses := []dto.DatabaseUsedLicense{
+		{Hostname: "pg1", LicenseTypeID: "EDB-CORE", UsedLicenses: 4},
+		{Hostname: "pg2", LicenseTypeID: "EDB-CORE", UsedLicenses: 2, Ignored: true},
+		{Hostname: "vm1", LicenseTypeID: "EDB-CORE", UsedLicenses: 2, ClusterName: "cluster1", ClusterLicenses: 8},
+		{Hostname: "vm2", LicenseTypeID: "EDB-CORE", UsedLicenses: 2, ClusterName: "cluster1", ClusterLicenses: 8},
+	}
+
+	expected := []licenseUsage{
+		{technology: model.TechnologyPostgreSQLPostgreSQL, licenseTypeID: "EDB-CORE", name: "pg1", consumed: 4},
+		{technology: model.TechnologyPostgreSQLPostgreSQL, licenseTypeID: "EDB-CORE", name: "cluster1", cluster: true, consumed: 8},
+	}
+
+	assert.Equal(t, expected, databaseLicensesUsages(model.TechnologyPostgreSQLPostgreSQL, usedLicenses))
+}
+
+func TestAllocateLicensesCost(t *testing.T) {
+	licenseTypes := []dto.LicenseTypeCost{
+		{
+			Technology:    model.TechnologyOracleDatabase,
+			LicenseTypeID: "A90611",
+			Spend:         3000,
+			Exposure:      600,
+		},
+		{
+			Technology:    model.TechnologyOracleDatabase,
+			LicenseTypeID: "A90649",
+		},
+		{
+			Technology:    model.TechnologyMongoDBMongoDB,
+			LicenseTypeID: "MDB-EA-SERVER",
+			Spend:         100,
+		},
+	}
+
+	usages := []licenseUsage{
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "A90611", name: "pippo", consumed: 1},
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "A90611", name: "pluto", consumed: 2},
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "A90611", name: "cluster1", cluster: true, consumed: 3},
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "A90649", name: "pippo", consumed: 4},
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "L10006", name: "pippo", consumed: 4},
+		{technology: model.TechnologyOracleDatabase, licenseTypeID: "MDB-EA-SERVER", name: "pippo", consumed: 1},
+		{technology: model.TechnologyMongoDBMongoDB, licenseTypeID: "MDB-EA-SERVER", name: "pluto", consumed: 1},
+	}
+
+	hostdatas := []model.HostDataBE{
+		{Hostname: "pippo", Environment: "PROD", Tags: []string{"erp", "finance"}},
+		{Hostname: "pluto", Environment: "TST", Tags: []string{"erp"}},
+		{Hostname: "vm1", Environment: "PROD", Tags: []string{"web"}},
+		{Hostname: "vm2", Environment: "PROD", Tags: []string{"web", "erp"}},
+	}
+
+	clusters := []dto.Cluster{
+		{
+			Name:        "cluster1",
+			Environment: "PROD",
+			VMs: []dto.VM{
+				{Hostname: "vm1"},
+				{Hostname: "vm2"},
+			},
+		},
+	}
+
+	environments, tags := allocateLicensesCost(licenseTypes, usages, hostdatas, clusters)
+
+	assert.Equal(t, []dto.LicensesCostGroup{
+		{Group: "PROD", Spend: 2000, Exposure: 400},
+		{Group: "TST", Spend: 1100, Exposure: 200},
+	}, environments.sorted())
+
+	assert.Equal(t, []dto.LicensesCostGroup{
+		{Group: "erp", Spend: 3100, Exposure: 600},
+		{Group: "finance", Spend: 500, Exposure: 100},
+		{Group: "web", Spend: 1500, Exposure: 300},
+	}, tags.sorted())
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetMariaDBLicenseTypes return the list of MariaDBLicenseType
+func (as *APIService) GetMariaDBLicenseTypes() ([]model.MariaDBLicenseType, error) {
+	return as.Database.GetMariaDBLicenseTypes()
+}
+
+// GetMariaDBLicenseTypesAsMap return the list of MariaDBLicenseType as map by ID
+func (as *APIService) GetMariaDBLicenseTypesAsMap() (map[string]model.MariaDBLicenseType, error) {
+	licenseTypes, err := as.GetMariaDBLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypesMap := make(map[string]model.MariaDBLicenseType, len(licenseTypes))
+	for _, licenseType := range licenseTypes {
+		licenseTypesMap[licenseType.ID] = licenseType
+	}
+
+	return licenseTypesMap, nil
+}
+
+// GetMariaDBLicenseType return a MariaDBLicenseType by ID
+func (as *APIService) GetMariaDBLicenseType(id string) (*model.MariaDBLicenseType, error) {
+	return as.Database.GetMariaDBLicenseType(id)
+}
+
+func (as *APIService) AddMariaDBLicenseType(licenseType model.MariaDBLicenseType) (*model.MariaDBLicenseType, error) {
+	if err := as.Database.InsertMariaDBLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) UpdateMariaDBLicenseType(licenseType model.MariaDBLicenseType) (*model.MariaDBLicenseType, error) {
+	if err := as.Database.UpdateMariaDBLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) DeleteMariaDBLicenseType(id string) error {
+	return as.Database.RemoveMariaDBLicenseType(id)
+}
+
+// mariaDBUsage contains the server subscription of a license type needed by a host
+type mariaDBUsage struct {
+	hostname      string
+	licenseTypeID string
+	instances     []string
+}
+
+// getMariaDBUsages return the subscriptions needed by the hosts running MariaDB.
+// A host needs a server subscription of every license type matching the edition of one of its instances
+func (as *APIService) getMariaDBUsages(hostname string, filter dto.GlobalFilter, lts map[string]model.MariaDBLicenseType,
+) ([]mariaDBUsage, error) {
+	hosts, err := as.Database.GetMariaDBUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypeIDs := make([]string, 0, len(lts))
+	for id := range lts {
+		licenseTypeIDs = append(licenseTypeIDs, id)
+	}
+
+	sort.Strings(licenseTypeIDs)
+
+	usages := make([]mariaDBUsage, 0)
+
+	for _, host := range hosts {
+		for _, licenseTypeID := range licenseTypeIDs {
+			instances := make([]string, 0)
+
+			for _, instance := range host.Instances {
+				if instance.Edition == lts[licenseTypeID].Edition {
+					instances = append(instances, instance.Name)
+				}
+			}
+
+			if len(instances) == 0 {
+				continue
+			}
+
+			usages = append(usages, mariaDBUsage{
+				hostname:      host.Hostname,
+				licenseTypeID: licenseTypeID,
+				instances:     instances,
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+// GetMariaDBUsedLicenses return the server subscriptions used by every MariaDB instance
+func (as *APIService) GetMariaDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
+	lts, err := as.GetMariaDBLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.DatabaseUsedLicense{}, nil
+	}
+
+	usages, err := as.getMariaDBUsages(hostname, filter, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses := make([]dto.DatabaseUsedLicense, 0, len(usages))
+
+	for _, usage := range usages {
+		for _, instance := range usage.instances {
+			usedLicenses = append(usedLicenses, dto.DatabaseUsedLicense{
+				Hostname:      usage.hostname,
+				DbName:        instance,
+				LicenseTypeID: usage.licenseTypeID,
+				Description:   lts[usage.licenseTypeID].ItemDescription,
+				Metric:        lts[usage.licenseTypeID].Metric,
+				UsedLicenses:  1,
+			})
+		}
+	}
+
+	return usedLicenses, nil
+}
+
+// GetMariaDBLicensesCompliance return the compliance of the MariaDB subscriptions
+func (as *APIService) GetMariaDBLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error) {
+	contracts, err := as.Database.ListMariaDBContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	lts, err := as.GetMariaDBLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.LicenseCompliance{}, nil
+	}
+
+	usages, err := as.getMariaDBUsages("", dto.GlobalFilter{
+		Location:    strings.Join(locations, ","),
+		Environment: "",
+		OlderThan:   utils.MAX_TIME,
+	}, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make(map[string]*dto.LicenseCompliance)
+
+	getLicense := func(licenseTypeID string) *dto.LicenseCompliance {
+		license, ok := licenses[licenseTypeID]
+		if !ok {
+			license = &dto.LicenseCompliance{
+				LicenseTypeID:   licenseTypeID,
+				ItemDescription: lts[licenseTypeID].ItemDescription,
+				Metric:          lts[licenseTypeID].Metric,
+				Cost:            lts[licenseTypeID].Cost,
+			}
+			licenses[licenseTypeID] = license
+		}
+
+		return license
+	}
+
+	for _, contract := range contracts {
+		getLicense(contract.LicenseTypeID).Purchased += float64(contract.ServersNumber)
+	}
+
+	for _, usage := range usages {
+		getLicense(usage.licenseTypeID).Consumed++
+	}
+
+	return licensesComplianceList(licenses), nil
+}
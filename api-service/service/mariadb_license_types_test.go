@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetMariaDBLicensesCompliance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	licenseTypes := []model.MariaDBLicenseType{
+		{
+			ID:              "MARIADB-ES-SERVER",
+			ItemDescription: "MariaDB Enterprise Server",
+			Metric:          model.MariaDBLicenseTypeMetricServer,
+			Edition:         model.MariaDBEditionEnterprise,
+		},
+	}
+
+	t.Run("No license types", func(t *testing.T) {
+		db.EXPECT().ListMariaDBContracts([]string{"Italy"}).
+			Return([]model.MariaDBContract{}, nil)
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return([]model.MariaDBLicenseType{}, nil)
+
+		actual, err := as.GetMariaDBLicensesCompliance([]string{"Italy"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []dto.LicenseCompliance{}, actual)
+	})
+
+	t.Run("Enterprise hosts", func(t *testing.T) {
+		contracts := []model.MariaDBContract{
+			{
+				ContractID:    "MARIADB-001",
+				LicenseTypeID: "MARIADB-ES-SERVER",
+				ServersNumber: 2,
+			},
+		}
+
+		db.EXPECT().ListMariaDBContracts([]string{}).
+			Return(contracts, nil)
+		db.EXPECT().GetMariaDBLicenseTypes().
+			Return(licenseTypes, nil)
+		db.EXPECT().GetMariaDBUsedLicenses("", dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+			Return([]dto.MariaDBUsedLicense{
+				{Hostname: "maria1", Instances: []dto.MariaDBUsedLicenseInstance{
+					{Name: "maria1:3306", Edition: model.MariaDBEditionEnterprise},
+					{Name: "maria1:3307", Edition: model.MariaDBEditionEnterprise},
+				}},
+				{Hostname: "maria2", Instances: []dto.MariaDBUsedLicenseInstance{
+					{Name: "maria2:3306", Edition: model.MariaDBEditionEnterprise},
+				}},
+				{Hostname: "maria3", Instances: []dto.MariaDBUsedLicenseInstance{
+					{Name: "maria3:3306", Edition: model.MariaDBEditionEnterprise},
+				}},
+				{Hostname: "maria4", Instances: []dto.MariaDBUsedLicenseInstance{
+					{Name: "maria4:3306", Edition: model.MariaDBEditionCommunity},
+				}},
+			}, nil)
+
+		actual, err := as.GetMariaDBLicensesCompliance([]string{})
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{
+				LicenseTypeID:   "MARIADB-ES-SERVER",
+				ItemDescription: "MariaDB Enterprise Server",
+				Metric:          model.MariaDBLicenseTypeMetricServer,
+				Consumed:        3,
+				Covered:         2,
+				Purchased:       2,
+				Compliance:      2.0 / 3.0,
+				Available:       0,
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		db.EXPECT().ListMariaDBContracts([]string{}).
+			Return(nil, errMock)
+
+		actual, err := as.GetMariaDBLicensesCompliance([]string{})
+		assert.ErrorIs(t, err, errMock)
+
+		assert.Nil(t, actual)
+	})
+}
+
+func TestGetMariaDBUsedLicenses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	filter := dto.GlobalFilter{OlderThan: utils.MAX_TIME}
+
+	db.EXPECT().GetMariaDBLicenseTypes().
+		Return([]model.MariaDBLicenseType{
+			{
+				ID:              "MARIADB-ES-SERVER",
+				ItemDescription: "MariaDB Enterprise Server",
+				Metric:          model.MariaDBLicenseTypeMetricServer,
+				Edition:         model.MariaDBEditionEnterprise,
+			},
+		}, nil)
+	db.EXPECT().GetMariaDBUsedLicenses("maria1", filter).
+		Return([]dto.MariaDBUsedLicense{
+			{Hostname: "maria1", Instances: []dto.MariaDBUsedLicenseInstance{
+				{Name: "maria1:3306", Edition: model.MariaDBEditionEnterprise},
+				{Name: "maria1:3307", Edition: model.MariaDBEditionCommunity},
+			}},
+		}, nil)
+
+	actual, err := as.GetMariaDBUsedLicenses("maria1", filter)
+	require.NoError(t, err)
+
+	expected := []dto.DatabaseUsedLicense{
+		{
+			Hostname:      "maria1",
+			DbName:        "maria1:3306",
+			LicenseTypeID: "MARIADB-ES-SERVER",
+			Description:   "MariaDB Enterprise Server",
+			Metric:        model.MariaDBLicenseTypeMetricServer,
+			UsedLicenses:  1,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -17,23 +17,37 @@ package service
 
 import (
 	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/ercole-io/ercole/v2/api-service/database"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils/exutils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func (as *APIService) AddSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
+func (as *APIService) AddSqlServerDatabaseContract(contract model.SqlServerDatabaseContract, user string) (*model.SqlServerDatabaseContract, error) {
+	hosts, err := checkHosts(as, contract.Hosts)
+	if err != nil {
 		return nil, err
 	}
 
+	contract.Hosts = hosts
+
 	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
 		return nil, err
 	}
 
 	contract.ID = as.NewObjectID()
 
-	return as.Database.InsertSqlServerDatabaseContract(contract)
+	var res *model.SqlServerDatabaseContract
+
+	err = as.auditContractChange(model.TechnologyMicrosoftSQLServer, contract.ID, model.ContractChangeOperationAdd, user, func(db database.MongoDatabaseInterface) error {
+		res, err = db.InsertSqlServerDatabaseContract(contract)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
 }
 
 func (as *APIService) sqlServerLicenseTypeIDExists(licenseTypeID string) error {
@@ -110,20 +124,28 @@ func (as *APIService) GetSqlServerDatabaseContractsAsXLSX(locations []string) (*
 	return sheets, err
 }
 
-func (as *APIService) DeleteSqlServerDatabaseContract(id primitive.ObjectID) error {
-	return as.Database.RemoveSqlServerDatabaseContract(id)
+func (as *APIService) DeleteSqlServerDatabaseContract(id primitive.ObjectID, user string) error {
+	return as.auditContractChange(model.TechnologyMicrosoftSQLServer, id, model.ContractChangeOperationDelete, user, func(db database.MongoDatabaseInterface) error {
+		return db.RemoveSqlServerDatabaseContract(id)
+	})
 }
 
-func (as *APIService) UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
+func (as *APIService) UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract, user string) (*model.SqlServerDatabaseContract, error) {
+	hosts, err := checkHosts(as, contract.Hosts)
+	if err != nil {
 		return nil, err
 	}
 
+	contract.Hosts = hosts
+
 	if err := as.sqlServerLicenseTypeIDExists(contract.LicenseTypeID); err != nil {
 		return nil, err
 	}
 
-	if err := as.Database.UpdateSqlServerDatabaseContract(contract); err != nil {
+	err = as.auditContractChange(model.TechnologyMicrosoftSQLServer, contract.ID, model.ContractChangeOperationUpdate, user, func(db database.MongoDatabaseInterface) error {
+		return db.UpdateSqlServerDatabaseContract(contract)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+func (as *APIService) mongoDBContractManager() contractManager[model.MongoDBContract] {
+	return contractManager[model.MongoDBContract]{
+		technology: model.TechnologyMongoDBMongoDB,
+		id: func(contract *model.MongoDBContract) *primitive.ObjectID {
+			return &contract.ID
+		},
+		prepare: func(contract *model.MongoDBContract) error {
+			hosts, err := checkHosts(as, contract.Hosts)
+			if err != nil {
+				return err
+			}
+
+			contract.Hosts = hosts
+
+			_, err = as.GetMongoDBLicenseType(contract.LicenseTypeID)
+
+			return err
+		},
+		insert: database.MongoDatabaseInterface.InsertMongoDBContract,
+		update: database.MongoDatabaseInterface.UpdateMongoDBContract,
+		remove: database.MongoDatabaseInterface.RemoveMongoDBContract,
+	}
+}
+
+func (as *APIService) AddMongoDBContract(contract model.MongoDBContract, user string) (*model.MongoDBContract, error) {
+	return addContract(as, as.mongoDBContractManager(), contract, user)
+}
+
+func (as *APIService) GetMongoDBContracts(locations []string) ([]model.MongoDBContract, error) {
+	return as.Database.ListMongoDBContracts(locations)
+}
+
+func (as *APIService) GetMongoDBContractsAsXLSX(locations []string) (*excelize.File, error) {
+	contracts, err := as.GetMongoDBContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "Contracts"
+	headers := []string{
+		"ContractID",
+		"License Type",
+		"Servers Number",
+		"Support Expiration",
+		"Location",
+		"Hosts",
+		"Replica Sets",
+	}
+
+	sheets, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, val := range contracts {
+		nextAxis := axisHelp.NewRow()
+		sheets.SetCellValue(sheet, nextAxis(), val.ContractID)
+		sheets.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		sheets.SetCellValue(sheet, nextAxis(), val.ServersNumber)
+
+		if val.SupportExpiration != nil {
+			sheets.SetCellValue(sheet, nextAxis(), val.SupportExpiration)
+		} else {
+			sheets.SetCellValue(sheet, nextAxis(), "")
+		}
+
+		sheets.SetCellValue(sheet, nextAxis(), val.Location)
+
+		for _, val2 := range val.Hosts {
+			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
+			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
+
+			sheets.SetCellValue(sheet, duplicateRowNextAxis(), val2)
+		}
+
+		for _, val2 := range val.ReplicaSets {
+			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
+			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
+
+			sheets.SetCellValue(sheet, duplicateRowNextAxis(), val2)
+		}
+	}
+
+	return sheets, err
+}
+
+func (as *APIService) DeleteMongoDBContract(id primitive.ObjectID, user string) error {
+	return deleteContract(as, as.mongoDBContractManager(), id, user)
+}
+
+func (as *APIService) UpdateMongoDBContract(contract model.MongoDBContract, user string) (*model.MongoDBContract, error) {
+	return updateContract(as, as.mongoDBContractManager(), contract, user)
+}
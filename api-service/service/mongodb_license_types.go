@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetMongoDBLicenseTypes return the list of MongoDBLicenseType
+func (as *APIService) GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error) {
+	return as.Database.GetMongoDBLicenseTypes()
+}
+
+// GetMongoDBLicenseTypesAsMap return the list of MongoDBLicenseType as map by ID
+func (as *APIService) GetMongoDBLicenseTypesAsMap() (map[string]model.MongoDBLicenseType, error) {
+	licenseTypes, err := as.GetMongoDBLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypesMap := make(map[string]model.MongoDBLicenseType, len(licenseTypes))
+	for _, licenseType := range licenseTypes {
+		licenseTypesMap[licenseType.ID] = licenseType
+	}
+
+	return licenseTypesMap, nil
+}
+
+// GetMongoDBLicenseType return a MongoDBLicenseType by ID
+func (as *APIService) GetMongoDBLicenseType(id string) (*model.MongoDBLicenseType, error) {
+	return as.Database.GetMongoDBLicenseType(id)
+}
+
+func (as *APIService) AddMongoDBLicenseType(licenseType model.MongoDBLicenseType) (*model.MongoDBLicenseType, error) {
+	if err := as.Database.InsertMongoDBLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) UpdateMongoDBLicenseType(licenseType model.MongoDBLicenseType) (*model.MongoDBLicenseType, error) {
+	if err := as.Database.UpdateMongoDBLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) DeleteMongoDBLicenseType(id string) error {
+	return as.Database.RemoveMongoDBLicenseType(id)
+}
+
+// mongoDBUsage contains the server subscriptions of a license type needed by a host
+type mongoDBUsage struct {
+	host          dto.MongoDBUsedLicense
+	licenseTypeID string
+	servers       float64
+}
+
+// getMongoDBUsages return the subscriptions needed by the hosts running MongoDB.
+// A host consumes the license type of a contract if it's listed in the contract or it's a member
+// of a replica set listed in the contract. The other hosts running MongoDB Enterprise consume the first license type,
+// while MongoDB Community doesn't need a subscription
+func (as *APIService) getMongoDBUsages(hostname string, filter dto.GlobalFilter, contracts []model.MongoDBContract,
+	lts map[string]model.MongoDBLicenseType,
+) ([]mongoDBUsage, error) {
+	hosts, err := as.Database.GetMongoDBUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypeIDs := make([]string, 0, len(lts))
+	for id := range lts {
+		licenseTypeIDs = append(licenseTypeIDs, id)
+	}
+
+	sort.Strings(licenseTypeIDs)
+
+	usages := make([]mongoDBUsage, 0)
+
+	for _, host := range hosts {
+		hostLicenseTypeIDs := make([]string, 0)
+
+		for _, contract := range contracts {
+			if !utils.Contains(contract.Hosts, host.Hostname) && !containsAny(contract.ReplicaSets, host.ReplicaSets) {
+				continue
+			}
+
+			if !utils.Contains(hostLicenseTypeIDs, contract.LicenseTypeID) {
+				hostLicenseTypeIDs = append(hostLicenseTypeIDs, contract.LicenseTypeID)
+			}
+		}
+
+		if len(hostLicenseTypeIDs) == 0 && host.Enterprise() && len(licenseTypeIDs) > 0 {
+			hostLicenseTypeIDs = append(hostLicenseTypeIDs, licenseTypeIDs[0])
+		}
+
+		for _, licenseTypeID := range hostLicenseTypeIDs {
+			usages = append(usages, mongoDBUsage{
+				host:          host,
+				licenseTypeID: licenseTypeID,
+				servers:       lts[licenseTypeID].ServersByMemory(host.Memory),
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+func containsAny(values []string, others []string) bool {
+	for _, other := range others {
+		if other != "" && utils.Contains(values, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetMongoDBUsedLicenses return the server subscriptions used by every host running MongoDB
+func (as *APIService) GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
+	lts, err := as.GetMongoDBLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.DatabaseUsedLicense{}, nil
+	}
+
+	contracts, err := as.Database.ListMongoDBContracts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := as.getMongoDBUsages(hostname, filter, contracts, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses := make([]dto.DatabaseUsedLicense, 0, len(usages))
+
+	for _, usage := range usages {
+		usedLicenses = append(usedLicenses, dto.DatabaseUsedLicense{
+			Hostname:      usage.host.Hostname,
+			DbName:        strings.Join(usage.host.Instances, ","),
+			LicenseTypeID: usage.licenseTypeID,
+			Description:   lts[usage.licenseTypeID].ItemDescription,
+			Metric:        lts[usage.licenseTypeID].Metric,
+			UsedLicenses:  usage.servers,
+		})
+	}
+
+	return usedLicenses, nil
+}
+
+// GetMongoDBLicensesCompliance return the compliance of the MongoDB Enterprise subscriptions
+func (as *APIService) GetMongoDBLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error) {
+	contracts, err := as.Database.ListMongoDBContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	lts, err := as.GetMongoDBLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.LicenseCompliance{}, nil
+	}
+
+	usages, err := as.getMongoDBUsages("", dto.GlobalFilter{
+		Location:    strings.Join(locations, ","),
+		Environment: "",
+		OlderThan:   utils.MAX_TIME,
+	}, contracts, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make(map[string]*dto.LicenseCompliance)
+
+	getLicense := func(licenseTypeID string) *dto.LicenseCompliance {
+		license, ok := licenses[licenseTypeID]
+		if !ok {
+			license = &dto.LicenseCompliance{
+				LicenseTypeID:   licenseTypeID,
+				ItemDescription: lts[licenseTypeID].ItemDescription,
+				Metric:          lts[licenseTypeID].Metric,
+				Cost:            lts[licenseTypeID].Cost,
+			}
+			licenses[licenseTypeID] = license
+		}
+
+		return license
+	}
+
+	for _, contract := range contracts {
+		getLicense(contract.LicenseTypeID).Purchased += float64(contract.ServersNumber)
+	}
+
+	for _, usage := range usages {
+		getLicense(usage.licenseTypeID).Consumed += usage.servers
+	}
+
+	return licensesComplianceList(licenses), nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetMongoDBLicensesCompliance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	licenseTypes := []model.MongoDBLicenseType{
+		{
+			ID:              "MDB-EA-SERVER",
+			ItemDescription: "MongoDB Enterprise Advanced",
+			Metric:          model.MongoDBLicenseTypeMetricServer,
+			RAMTier:         model.MongoDBDefaultRAMTier,
+		},
+	}
+
+	t.Run("No license types", func(t *testing.T) {
+		db.EXPECT().ListMongoDBContracts([]string{"Italy"}).
+			Return([]model.MongoDBContract{}, nil)
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return([]model.MongoDBLicenseType{}, nil)
+
+		actual, err := as.GetMongoDBLicensesCompliance([]string{"Italy"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []dto.LicenseCompliance{}, actual)
+	})
+
+	t.Run("Hosts, replica sets and Enterprise hosts without contract", func(t *testing.T) {
+		contracts := []model.MongoDBContract{
+			{
+				ContractID:    "MDB-001",
+				LicenseTypeID: "MDB-EA-SERVER",
+				ServersNumber: 2,
+				Hosts:         []string{"mongo1", "mongo2"},
+			},
+			{
+				ContractID:    "MDB-002",
+				LicenseTypeID: "MDB-EA-SERVER",
+				ServersNumber: 2,
+				ReplicaSets:   []string{"rs0"},
+			},
+		}
+
+		db.EXPECT().ListMongoDBContracts([]string{}).
+			Return(contracts, nil)
+		db.EXPECT().GetMongoDBLicenseTypes().
+			Return(licenseTypes, nil)
+		db.EXPECT().GetMongoDBUsedLicenses("", dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+			Return([]dto.MongoDBUsedLicense{
+				{Hostname: "mongo1", Memory: 512, Instances: []string{"mongod"}},
+				{Hostname: "mongo2", Memory: 128, Instances: []string{"mongod"}, ReplicaSets: []string{"rs0"}},
+				{Hostname: "mongo3", Memory: 300, Instances: []string{"mongod"}, ReplicaSets: []string{"rs0"}},
+				{Hostname: "mongo4", Memory: 64, Instances: []string{"mongod"}, ReplicaSets: []string{"rs1"}},
+				{Hostname: "mongo5", Memory: 600, Instances: []string{"mongod"}, Editions: []string{model.MongoDBEditionEnterprise}},
+				{Hostname: "mongo6", Memory: 64, Instances: []string{"mongod"}, Editions: []string{model.MongoDBEditionCommunity}},
+			}, nil)
+
+		actual, err := as.GetMongoDBLicensesCompliance([]string{})
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{
+				LicenseTypeID:   "MDB-EA-SERVER",
+				ItemDescription: "MongoDB Enterprise Advanced",
+				Metric:          model.MongoDBLicenseTypeMetricServer,
+				Consumed:        8,
+				Covered:         4,
+				Purchased:       4,
+				Compliance:      0.5,
+				Available:       0,
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		db.EXPECT().ListMongoDBContracts([]string{}).
+			Return(nil, errMock)
+
+		actual, err := as.GetMongoDBLicensesCompliance([]string{})
+		assert.ErrorIs(t, err, errMock)
+
+		assert.Nil(t, actual)
+	})
+}
+
+func TestGetMongoDBUsedLicenses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	filter := dto.GlobalFilter{OlderThan: utils.MAX_TIME}
+
+	db.EXPECT().GetMongoDBLicenseTypes().
+		Return([]model.MongoDBLicenseType{
+			{
+				ID:              "MDB-EA-SERVER",
+				ItemDescription: "MongoDB Enterprise Advanced",
+				Metric:          model.MongoDBLicenseTypeMetricServer,
+				RAMTier:         model.MongoDBDefaultRAMTier,
+			},
+		}, nil)
+	db.EXPECT().ListMongoDBContracts(nil).
+		Return([]model.MongoDBContract{
+			{
+				ContractID:    "MDB-001",
+				LicenseTypeID: "MDB-EA-SERVER",
+				ServersNumber: 4,
+				ReplicaSets:   []string{"rs0"},
+			},
+		}, nil)
+	db.EXPECT().GetMongoDBUsedLicenses("mongo1", filter).
+		Return([]dto.MongoDBUsedLicense{
+			{Hostname: "mongo1", Memory: 512, Instances: []string{"shard1", "shard2"}, ReplicaSets: []string{"rs0"}},
+		}, nil)
+
+	actual, err := as.GetMongoDBUsedLicenses("mongo1", filter)
+	require.NoError(t, err)
+
+	expected := []dto.DatabaseUsedLicense{
+		{
+			Hostname:      "mongo1",
+			DbName:        "shard1,shard2",
+			LicenseTypeID: "MDB-EA-SERVER",
+			Description:   "MongoDB Enterprise Advanced",
+			Metric:        model.MongoDBLicenseTypeMetricServer,
+			UsedLicenses:  2,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
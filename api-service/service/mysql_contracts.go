@@ -20,14 +20,17 @@ import (
 	"github.com/360EntSecGroup-Skylar/excelize"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/ercole-io/ercole/v2/api-service/database"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils/exutils"
 )
 
-func (as *APIService) AddMySQLContract(contract model.MySQLContract) (*model.MySQLContract, error) {
+func (as *APIService) AddMySQLContract(contract model.MySQLContract, user string) (*model.MySQLContract, error) {
 	contract.ID = as.NewObjectID()
 
-	err := as.Database.AddMySQLContract(contract)
+	err := as.auditContractChange(model.TechnologyOracleMySQL, contract.ID, model.ContractChangeOperationAdd, user, func(db database.MongoDatabaseInterface) error {
+		return db.AddMySQLContract(contract)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +38,11 @@ func (as *APIService) AddMySQLContract(contract model.MySQLContract) (*model.MyS
 	return &contract, nil
 }
 
-func (as *APIService) UpdateMySQLContract(contract model.MySQLContract) (*model.MySQLContract, error) {
-	if err := as.Database.UpdateMySQLContract(contract); err != nil {
+func (as *APIService) UpdateMySQLContract(contract model.MySQLContract, user string) (*model.MySQLContract, error) {
+	err := as.auditContractChange(model.TechnologyOracleMySQL, contract.ID, model.ContractChangeOperationUpdate, user, func(db database.MongoDatabaseInterface) error {
+		return db.UpdateMySQLContract(contract)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -52,8 +58,11 @@ func (as *APIService) GetMySQLContracts(locations []string) ([]model.MySQLContra
 	return contracts, nil
 }
 
-func (as *APIService) DeleteMySQLContract(id primitive.ObjectID) error {
-	if err := as.Database.DeleteMySQLContract(id); err != nil {
+func (as *APIService) DeleteMySQLContract(id primitive.ObjectID, user string) error {
+	err := as.auditContractChange(model.TechnologyOracleMySQL, id, model.ContractChangeOperationDelete, user, func(db database.MongoDatabaseInterface) error {
+		return db.DeleteMySQLContract(id)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -35,6 +35,7 @@ func TestAddMySQLContract(t *testing.T) {
 	as := APIService{
 		Database:    db,
 		NewObjectID: utils.NewObjectIDForTests(),
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
 	}
 
 	t.Run("Success", func(t *testing.T) {
@@ -46,8 +47,24 @@ func TestAddMySQLContract(t *testing.T) {
 			Clusters:         []string{"pippo"},
 			Hosts:            []string{"pluto"},
 		}
-		db.EXPECT().AddMySQLContract(expected).
-			Return(nil).Times(1)
+		gomock.InOrder(
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, expected.ID).
+				Return(nil, nil).Times(1),
+			db.EXPECT().AddMySQLContract(expected).
+				Return(nil).Times(1),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, expected.ID).
+				Return(map[string]interface{}{"_id": expected.ID}, nil).Times(1),
+			db.EXPECT().InsertContractChange(model.ContractChange{
+				ID:               utils.Str2oid("000000000000000000000002"),
+				Technology:       model.TechnologyOracleMySQL,
+				ContractObjectID: expected.ID,
+				Operation:        model.ContractChangeOperationAdd,
+				User:             "admin",
+				Date:             utils.P("2019-11-05T14:02:03Z"),
+				After:            map[string]interface{}{"_id": expected.ID},
+			}).Return(nil).Times(1),
+		)
 
 		contract := model.MySQLContract{
 			Type:             "server",
@@ -56,7 +73,7 @@ func TestAddMySQLContract(t *testing.T) {
 			Clusters:         []string{"pippo"},
 			Hosts:            []string{"pluto"},
 		}
-		actual, err := as.AddMySQLContract(contract)
+		actual, err := as.AddMySQLContract(contract, "admin")
 		require.NoError(t, err)
 
 		assert.Equal(t, &expected, actual)
@@ -64,13 +81,16 @@ func TestAddMySQLContract(t *testing.T) {
 
 	t.Run("Error", func(t *testing.T) {
 		contract := model.MySQLContract{
-			ID:            utils.Str2oid("000000000000000000000002"),
+			ID:            utils.Str2oid("000000000000000000000003"),
 			LicenseTypeID: model.MySqlPartNumber,
 		}
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, contract.ID).
+			Return(nil, nil).Times(1)
 		db.EXPECT().AddMySQLContract(contract).
 			Return(errMock).Times(1)
 
-		actual, err := as.AddMySQLContract(contract)
+		actual, err := as.AddMySQLContract(contract, "admin")
 		assert.EqualError(t, err, "MockError")
 
 		assert.Nil(t, actual)
@@ -82,17 +102,36 @@ func TestUpdateMySQLContract(t *testing.T) {
 	defer mockCtrl.Finish()
 	db := NewMockMongoDatabaseInterface(mockCtrl)
 	as := APIService{
-		Database: db,
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
 	}
 
 	t.Run("Success", func(t *testing.T) {
 		contract := model.MySQLContract{
 			LicenseTypeID: model.MySqlPartNumber,
 		}
-		db.EXPECT().UpdateMySQLContract(contract).
-			Return(nil).Times(1)
-
-		actual, err := as.UpdateMySQLContract(contract)
+		gomock.InOrder(
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, contract.ID).
+				Return(map[string]interface{}{"numberOfLicenses": 1}, nil).Times(1),
+			db.EXPECT().UpdateMySQLContract(contract).
+				Return(nil).Times(1),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, contract.ID).
+				Return(map[string]interface{}{"numberOfLicenses": 2}, nil).Times(1),
+			db.EXPECT().InsertContractChange(model.ContractChange{
+				ID:               utils.Str2oid("000000000000000000000001"),
+				Technology:       model.TechnologyOracleMySQL,
+				ContractObjectID: contract.ID,
+				Operation:        model.ContractChangeOperationUpdate,
+				User:             "admin",
+				Date:             utils.P("2019-11-05T14:02:03Z"),
+				Before:           map[string]interface{}{"numberOfLicenses": 1},
+				After:            map[string]interface{}{"numberOfLicenses": 2},
+			}).Return(nil).Times(1),
+		)
+
+		actual, err := as.UpdateMySQLContract(contract, "admin")
 		require.NoError(t, err)
 		assert.Equal(t, contract, *actual)
 	})
@@ -101,10 +140,13 @@ func TestUpdateMySQLContract(t *testing.T) {
 		contract := model.MySQLContract{
 			LicenseTypeID: model.MySqlPartNumber,
 		}
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, contract.ID).
+			Return(nil, nil).Times(1)
 		db.EXPECT().UpdateMySQLContract(contract).
 			Return(errMock).Times(1)
 
-		actual, err := as.UpdateMySQLContract(contract)
+		actual, err := as.UpdateMySQLContract(contract, "admin")
 		require.EqualError(t, err, "MockError")
 		assert.Nil(t, actual)
 	})
@@ -190,24 +232,45 @@ func TestDeleteMySQLContract(t *testing.T) {
 	defer mockCtrl.Finish()
 	db := NewMockMongoDatabaseInterface(mockCtrl)
 	as := APIService{
-		Database: db,
+		Database:    db,
+		NewObjectID: utils.NewObjectIDForTests(),
+		TimeNow:     utils.Btc(utils.P("2019-11-05T14:02:03Z")),
 	}
 
 	t.Run("Success", func(t *testing.T) {
 		id := utils.Str2oid("iiiiiiiiiiiiiiiiiiiiiiii")
-		db.EXPECT().DeleteMySQLContract(id).
-			Return(nil).Times(1)
-
-		err := as.DeleteMySQLContract(id)
+		gomock.InOrder(
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, id).
+				Return(map[string]interface{}{"_id": id}, nil).Times(1),
+			db.EXPECT().DeleteMySQLContract(id).
+				Return(nil).Times(1),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, id).
+				Return(nil, nil).Times(1),
+			db.EXPECT().InsertContractChange(model.ContractChange{
+				ID:               utils.Str2oid("000000000000000000000001"),
+				Technology:       model.TechnologyOracleMySQL,
+				ContractObjectID: id,
+				Operation:        model.ContractChangeOperationDelete,
+				User:             "admin",
+				Date:             utils.P("2019-11-05T14:02:03Z"),
+				Before:           map[string]interface{}{"_id": id},
+			}).Return(nil).Times(1),
+		)
+
+		err := as.DeleteMySQLContract(id, "admin")
 		require.NoError(t, err)
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		id := utils.Str2oid("iiiiiiiiiiiiiiiiiiiiiiii")
+		expectTransaction(db)
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleMySQL, id).
+			Return(map[string]interface{}{"_id": id}, nil).Times(1)
 		db.EXPECT().DeleteMySQLContract(id).
 			Return(errMock).Times(1)
 
-		err := as.DeleteMySQLContract(id)
+		err := as.DeleteMySQLContract(id, "admin")
 		require.EqualError(t, err, "MockError")
 	})
 }
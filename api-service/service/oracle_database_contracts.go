@@ -27,23 +27,29 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/ercole-io/ercole/v2/api-service/database"
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
-func (as *APIService) AddOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
+func (as *APIService) AddOracleDatabaseContract(contract model.OracleDatabaseContract, user string) (*dto.OracleDatabaseContractFE, error) {
+	hosts, err := checkHosts(as, contract.Hosts)
+	if err != nil {
 		return nil, err
 	}
 
+	contract.Hosts = hosts
+
 	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
 		return nil, err
 	}
 
 	contract.ID = as.NewObjectID()
 
-	err := as.Database.InsertOracleDatabaseContract(contract)
+	err = as.auditContractChange(model.TechnologyOracleDatabase, contract.ID, model.ContractChangeOperationAdd, user, func(db database.MongoDatabaseInterface) error {
+		return db.InsertOracleDatabaseContract(contract)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -71,16 +77,22 @@ func checkLicenseTypeIDExists(as *APIService, contract *model.OracleDatabaseCont
 	return nil
 }
 
-func (as *APIService) UpdateOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error) {
-	if err := checkHosts(as, contract.Hosts); err != nil {
+func (as *APIService) UpdateOracleDatabaseContract(contract model.OracleDatabaseContract, user string) (*dto.OracleDatabaseContractFE, error) {
+	hosts, err := checkHosts(as, contract.Hosts)
+	if err != nil {
 		return nil, err
 	}
 
+	contract.Hosts = hosts
+
 	if err := checkLicenseTypeIDExists(as, &contract); err != nil {
 		return nil, err
 	}
 
-	if err := as.Database.UpdateOracleDatabaseContract(contract); err != nil {
+	err = as.auditContractChange(model.TechnologyOracleDatabase, contract.ID, model.ContractChangeOperationUpdate, user, func(db database.MongoDatabaseInterface) error {
+		return db.UpdateOracleDatabaseContract(contract)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -216,6 +228,15 @@ func (as *APIService) GetOracleDatabaseContractsAsXLSX(filter dto.GetOracleDatab
 func (as *APIService) assignOracleDatabaseContractsToHosts(
 	agrs []dto.OracleDatabaseContractFE,
 	usages []dto.HostUsingOracleDatabaseLicenses) error {
+	return as.doAssignOracleDatabaseContractsToHosts(agrs, usages, nil)
+}
+
+// doAssignOracleDatabaseContractsToHosts assign available licenses in each contracts to hosts using licenses,
+// recording each step in tracer if it isn't nil
+func (as *APIService) doAssignOracleDatabaseContractsToHosts(
+	agrs []dto.OracleDatabaseContractFE,
+	usages []dto.HostUsingOracleDatabaseLicenses,
+	tracer *oracleDatabaseContractsAssignmentTracer) error {
 	licenseTypes, err := as.Database.GetOracleDatabaseLicenseTypes()
 	if err != nil {
 		return err
@@ -232,8 +253,9 @@ func (as *APIService) assignOracleDatabaseContractsToHosts(
 	licenseTypesMap := buildLicenseTypesMap(licenseTypes)
 
 	fillContractsInfo(as, agrs, licenseTypesMap)
+	tracer.start(agrs, licenseTypesMap)
 
-	err = assignContractsLicensesToItsAssociatedHosts(as, agrs, usagesMap)
+	err = assignContractsLicensesToItsAssociatedHosts(as, agrs, usagesMap, tracer)
 	if err != nil {
 		return err
 	}
@@ -260,9 +282,10 @@ func (as *APIService) assignOracleDatabaseContractsToHosts(
 		}
 	}
 
-	assignLicensesFromBasketContracts(as, agrs, usages)
+	assignLicensesFromBasketContracts(as, agrs, usages, tracer)
 
 	calculateTotalCoveredAndConsumedLicenses(agrs, usagesMap)
+	tracer.finish(agrs, usages)
 
 	return nil
 }
@@ -347,7 +370,8 @@ func fillContractsInfo(as *APIService, agrs []dto.OracleDatabaseContractFE, lice
 func assignContractsLicensesToItsAssociatedHosts(
 	as *APIService,
 	contracts []dto.OracleDatabaseContractFE,
-	usagesMap map[string]map[string]*dto.HostUsingOracleDatabaseLicenses) error {
+	usagesMap map[string]map[string]*dto.HostUsingOracleDatabaseLicenses,
+	tracer *oracleDatabaseContractsAssignmentTracer) error {
 	hostnamesPerLicense := make(map[string]map[string]bool)
 
 	for i := range contracts {
@@ -365,6 +389,9 @@ func assignContractsLicensesToItsAssociatedHosts(
 				associatedHost.TotalCoveredLicensesCount = 0
 				associatedHost.CoveredLicensesCount = 0
 
+				tracer.step(dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost, contract, associatedHost.Hostname,
+					usagesMap[contract.LicenseTypeID][associatedHost.Hostname], "", 0, "no licenses available in the contract")
+
 				continue
 			}
 
@@ -378,10 +405,13 @@ func assignContractsLicensesToItsAssociatedHosts(
 
 			if usages, ok = usagesMap[ltID]; !ok {
 				// no host use this license
+				tracer.step(dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost, contract, associatedHost.Hostname,
+					nil, "", 0, "no host uses the license type")
+
 				continue
 			}
 
-			err := as.assignContractsLicensesToHostBelongToCluster(usages, contract, associatedHost, hostnamesPerLicense)
+			err := as.assignContractsLicensesToHostBelongToCluster(usages, contract, associatedHost, hostnamesPerLicense, tracer)
 			if err != nil {
 				return err
 			}
@@ -389,14 +419,23 @@ func assignContractsLicensesToItsAssociatedHosts(
 			hostUsingLicenses, ok = usagesMap[ltID][associatedHost.Hostname]
 			if !ok {
 				// host doesn't use this license
+				tracer.step(dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost, contract, associatedHost.Hostname,
+					nil, "", 0, "the host doesn't use the license type")
+
 				continue
 			}
 
 			if hostUsingLicenses == nil || hostUsingLicenses.LicenseCount <= 0 {
+				tracer.step(dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost, contract, associatedHost.Hostname,
+					hostUsingLicenses, "", 0, "the licenses of the host are already covered")
+
 				continue
 			}
 
+			uncoveredLicenses := hostUsingLicenses.LicenseCount
 			doAssignContractLicensesToAssociatedHost(contract, hostUsingLicenses, associatedHost)
+			tracer.step(dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost, contract, associatedHost.Hostname,
+				hostUsingLicenses, "", uncoveredLicenses-hostUsingLicenses.LicenseCount, "")
 
 			if as.Config.APIService.DebugOracleDatabaseContractsAssignmentAlgorithm {
 				as.Log.Debugf(`Distributing %f licenses to host %s. agr.Metrics=%s \
@@ -421,7 +460,8 @@ func (as *APIService) assignContractsLicensesToHostBelongToCluster(
 	usages map[string]*dto.HostUsingOracleDatabaseLicenses,
 	contract *dto.OracleDatabaseContractFE,
 	associatedHost *dto.OracleDatabaseContractAssociatedHostFE,
-	hostnamesPerLicense map[string]map[string]bool) error {
+	hostnamesPerLicense map[string]map[string]bool,
+	tracer *oracleDatabaseContractsAssignmentTracer) error {
 	for _, usage := range usages {
 		if usage == nil || usage.Type != "cluster" || contract.Restricted {
 			continue
@@ -453,6 +493,9 @@ func (as *APIService) assignContractsLicensesToHostBelongToCluster(
 					contract.AvailableLicensesPerCore -= usage.LicenseCount
 				}
 
+				tracer.step(dto.OracleDatabaseContractsAssignmentPhaseCluster, contract, usage.Name,
+					usage, associatedHost.Hostname, usage.LicenseCount, "")
+
 				break
 			}
 		}
@@ -539,7 +582,8 @@ func doAssignContractLicensesToAssociatedHost(
 func assignLicensesFromBasketContracts(
 	as *APIService,
 	agrs []dto.OracleDatabaseContractFE,
-	usages []dto.HostUsingOracleDatabaseLicenses) {
+	usages []dto.HostUsingOracleDatabaseLicenses,
+	tracer *oracleDatabaseContractsAssignmentTracer) {
 	for i := range usages {
 		usage := &usages[i]
 
@@ -562,7 +606,10 @@ func assignLicensesFromBasketContracts(
 				continue
 			}
 
+			uncoveredLicenses := usage.LicenseCount
 			doAssignLicenseFromBasketContract(agr, usage)
+			tracer.step(dto.OracleDatabaseContractsAssignmentPhaseBasket, agr, usage.Name,
+				usage, "", uncoveredLicenses-usage.LicenseCount, "")
 
 			if as.Config.APIService.DebugOracleDatabaseContractsAssignmentAlgorithm {
 				as.Log.Debugf("Distributing with metric [%s] [ULA? %t] %f licenses to obj %s. objCount=0 licenseTypeID=%s\n",
@@ -654,15 +701,20 @@ func checkOracleDatabaseContractMatchFilter(agr dto.OracleDatabaseContractFE, fi
 		(filters.AvailableLicensesPerUserGTE == -1 || agr.AvailableLicensesPerUser >= float64(filters.AvailableLicensesPerCoreGTE))
 }
 
-func (as *APIService) DeleteOracleDatabaseContract(id primitive.ObjectID) error {
-	return as.Database.RemoveOracleDatabaseContract(id)
+func (as *APIService) DeleteOracleDatabaseContract(id primitive.ObjectID, user string) error {
+	return as.auditContractChange(model.TechnologyOracleDatabase, id, model.ContractChangeOperationDelete, user, func(db database.MongoDatabaseInterface) error {
+		return db.RemoveOracleDatabaseContract(id)
+	})
 }
 
-func (as *APIService) AddHostToOracleDatabaseContract(id primitive.ObjectID, hostname string) error {
-	if err := checkHosts(as, []string{hostname}); err != nil {
+func (as *APIService) AddHostToOracleDatabaseContract(id primitive.ObjectID, hostname string, user string) error {
+	hosts, err := checkHosts(as, []string{hostname})
+	if err != nil {
 		return err
 	}
 
+	hostname = hosts[0]
+
 	contract, err := as.Database.GetOracleDatabaseContract(id)
 	if err != nil {
 		return err
@@ -676,14 +728,19 @@ func (as *APIService) AddHostToOracleDatabaseContract(id primitive.ObjectID, hos
 
 	contract.Hosts = append(contract.Hosts, hostname)
 
-	return as.Database.UpdateOracleDatabaseContract(*contract)
+	return as.auditContractChange(model.TechnologyOracleDatabase, id, model.ContractChangeOperationAddHost, user, func(db database.MongoDatabaseInterface) error {
+		return db.UpdateOracleDatabaseContract(*contract)
+	})
 }
 
-func (as *APIService) DeleteHostFromOracleDatabaseContract(id primitive.ObjectID, hostname string) error {
-	if err := checkHosts(as, []string{hostname}); err != nil {
+func (as *APIService) DeleteHostFromOracleDatabaseContract(id primitive.ObjectID, hostname string, user string) error {
+	hosts, err := checkHosts(as, []string{hostname})
+	if err != nil {
 		return err
 	}
 
+	hostname = hosts[0]
+
 	contract, err := as.Database.GetOracleDatabaseContract(id)
 	if err != nil {
 		return err
@@ -700,14 +757,19 @@ func (as *APIService) DeleteHostFromOracleDatabaseContract(id primitive.ObjectID
 		}
 	}
 
-	return as.Database.UpdateOracleDatabaseContract(*contract)
+	return as.auditContractChange(model.TechnologyOracleDatabase, id, model.ContractChangeOperationDeleteHost, user, func(db database.MongoDatabaseInterface) error {
+		return db.UpdateOracleDatabaseContract(*contract)
+	})
 }
 
 func (as *APIService) DeleteHostFromOracleDatabaseContracts(hostname string) error {
-	if err := checkHosts(as, []string{hostname}); err != nil {
+	hosts, err := checkHosts(as, []string{hostname})
+	if err != nil {
 		return err
 	}
 
+	hostname = hosts[0]
+
 	listContracts, err := as.Database.ListOracleDatabaseContracts(dto.NewGetOracleDatabaseContractsFilter())
 	if err != nil {
 		return err
@@ -726,7 +788,9 @@ func (as *APIService) DeleteHostFromOracleDatabaseContracts(hostname string) err
 					contract.Hosts[0:i],
 					contract.Hosts[i+1:len(contract.Hosts)]...)
 
-				errContract := as.Database.UpdateOracleDatabaseContract(*contract)
+				errContract := as.auditContractChange(model.TechnologyOracleDatabase, la.ID, model.ContractChangeOperationDeleteHost, model.ContractChangeSystemUser, func(db database.MongoDatabaseInterface) error {
+					return db.UpdateOracleDatabaseContract(*contract)
+				})
 				if errContract != nil {
 					return err
 				}
@@ -736,3 +800,42 @@ func (as *APIService) DeleteHostFromOracleDatabaseContracts(hostname string) err
 
 	return nil
 }
+
+// newOracleDatabaseContractFE return the contract as it's returned by the database, without any license assigned to its hosts
+func newOracleDatabaseContractFE(c model.OracleDatabaseContract, licenseType model.OracleDatabaseLicenseType) dto.OracleDatabaseContractFE {
+	contract := dto.OracleDatabaseContractFE{
+		ID:              c.ID,
+		ContractID:      c.ContractID,
+		CSI:             c.CSI,
+		LicenseTypeID:   c.LicenseTypeID,
+		ItemDescription: licenseType.ItemDescription,
+		Metric:          licenseType.Metric,
+		ReferenceNumber: c.ReferenceNumber,
+		Unlimited:       c.Unlimited,
+		Basket:          c.Basket,
+		Restricted:      c.Restricted,
+		Hosts:           make([]dto.OracleDatabaseContractAssociatedHostFE, 0, len(c.Hosts)),
+		Status:          c.Status,
+		Location:        c.Location,
+	}
+
+	for _, hostname := range c.Hosts {
+		contract.Hosts = append(contract.Hosts, dto.OracleDatabaseContractAssociatedHostFE{Hostname: hostname})
+	}
+
+	switch licenseType.Metric {
+	case model.LicenseTypeMetricProcessorPerpetual, model.LicenseTypeMetricComputerPerpetual:
+		contract.LicensesPerCore = float64(c.Count)
+		contract.AvailableLicensesPerCore = float64(c.Count)
+	case model.LicenseTypeMetricNamedUserPlusPerpetual:
+		contract.LicensesPerUser = float64(c.Count)
+		contract.AvailableLicensesPerUser = float64(c.Count)
+	}
+
+	return contract
+}
+
+// locationsInclude return true if location is one of the locations, or if there isn't any filter on the locations
+func locationsInclude(locations []string, location string) bool {
+	return len(locations) == 0 || utils.Contains(locations, "") || utils.Contains(locations, location)
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// GetOracleDatabaseContractsAssignmentTrace return the trace of the assignment of the contracts
+// to the hosts for each license type, or only for licenseTypeID if it isn't empty
+func (as *APIService) GetOracleDatabaseContractsAssignmentTrace(locations []string, licenseTypeID string) ([]dto.OracleDatabaseContractsAssignmentTrace, error) {
+	filter := dto.NewGetOracleDatabaseContractsFilter()
+	filter.Locations = locations
+
+	contracts, err := as.Database.ListOracleDatabaseContracts(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := as.getLicensesUsage(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := newOracleDatabaseContractsAssignmentTracer()
+
+	if err := as.doAssignOracleDatabaseContractsToHosts(contracts, usages, tracer); err != nil {
+		return nil, err
+	}
+
+	return tracer.getTraces(licenseTypeID), nil
+}
+
+// oracleDatabaseContractsAssignmentTracer collects the steps made by the assignment algorithm.
+// All the methods can be called on a nil tracer and do nothing
+type oracleDatabaseContractsAssignmentTracer struct {
+	traces         map[string]*dto.OracleDatabaseContractsAssignmentTrace
+	contractOrders map[primitive.ObjectID]int
+	licenseTypes   map[string]*model.OracleDatabaseLicenseType
+}
+
+func newOracleDatabaseContractsAssignmentTracer() *oracleDatabaseContractsAssignmentTracer {
+	return &oracleDatabaseContractsAssignmentTracer{
+		traces:         make(map[string]*dto.OracleDatabaseContractsAssignmentTrace),
+		contractOrders: make(map[primitive.ObjectID]int),
+	}
+}
+
+func (t *oracleDatabaseContractsAssignmentTracer) getTrace(licenseTypeID string) *dto.OracleDatabaseContractsAssignmentTrace {
+	trace, ok := t.traces[licenseTypeID]
+	if !ok {
+		trace = &dto.OracleDatabaseContractsAssignmentTrace{
+			LicenseTypeID: licenseTypeID,
+			Contracts:     make([]dto.OracleDatabaseContractsAssignmentTraceContract, 0),
+			Steps:         make([]dto.OracleDatabaseContractsAssignmentStep, 0),
+			Hosts:         make([]dto.OracleDatabaseContractsAssignmentTraceHost, 0),
+		}
+
+		if licenseType, ok := t.licenseTypes[licenseTypeID]; ok {
+			trace.ItemDescription = licenseType.ItemDescription
+			trace.Metric = licenseType.Metric
+		}
+
+		t.traces[licenseTypeID] = trace
+	}
+
+	return trace
+}
+
+// start record the contracts in the order in which they will be considered
+func (t *oracleDatabaseContractsAssignmentTracer) start(contracts []dto.OracleDatabaseContractFE,
+	licenseTypes map[string]*model.OracleDatabaseLicenseType) {
+	if t == nil {
+		return
+	}
+
+	t.licenseTypes = licenseTypes
+
+	for _, contract := range contracts {
+		trace := t.getTrace(contract.LicenseTypeID)
+
+		hostnames := make([]string, 0, len(contract.Hosts))
+		for _, host := range contract.Hosts {
+			hostnames = append(hostnames, host.Hostname)
+		}
+
+		order := len(trace.Contracts) + 1
+		t.contractOrders[contract.ID] = order
+
+		trace.Contracts = append(trace.Contracts, dto.OracleDatabaseContractsAssignmentTraceContract{
+			Order:             order,
+			ID:                contract.ID,
+			ContractID:        contract.ContractID,
+			Basket:            contract.Basket,
+			Restricted:        contract.Restricted,
+			Unlimited:         contract.Unlimited,
+			Hosts:             hostnames,
+			AvailableLicenses: contractAvailableLicenses(&contract),
+		})
+	}
+}
+
+// step record the licenses assigned by contract to the host or cluster usage.
+// usage can be nil if the host doesn't use the license type
+func (t *oracleDatabaseContractsAssignmentTracer) step(phase string,
+	contract *dto.OracleDatabaseContractFE,
+	name string,
+	usage *dto.HostUsingOracleDatabaseLicenses,
+	associatedHostname string,
+	assignedLicenses float64,
+	note string) {
+	if t == nil {
+		return
+	}
+
+	step := dto.OracleDatabaseContractsAssignmentStep{
+		Phase:                     phase,
+		ContractOrder:             t.contractOrders[contract.ID],
+		ContractID:                contract.ContractID,
+		Name:                      name,
+		Type:                      "host",
+		AssociatedHostname:        associatedHostname,
+		AssignedLicenses:          assignedLicenses,
+		ContractRemainingLicenses: contractAvailableLicenses(contract),
+		Note:                      note,
+	}
+
+	if usage != nil {
+		step.Type = usage.Type
+		step.UncoveredLicenses = usage.LicenseCount
+	}
+
+	trace := t.getTrace(contract.LicenseTypeID)
+	trace.Steps = append(trace.Steps, step)
+}
+
+// finish record the licenses remaining in the contracts and the licenses covered of the hosts
+func (t *oracleDatabaseContractsAssignmentTracer) finish(contracts []dto.OracleDatabaseContractFE, usages []dto.HostUsingOracleDatabaseLicenses) {
+	if t == nil {
+		return
+	}
+
+	for i := range contracts {
+		contract := &contracts[i]
+		trace := t.getTrace(contract.LicenseTypeID)
+
+		traced := &trace.Contracts[t.contractOrders[contract.ID]-1]
+		traced.CoveredLicenses = contract.CoveredLicenses
+		traced.RemainingLicenses = contractAvailableLicenses(contract)
+	}
+
+	for _, usage := range usages {
+		trace := t.getTrace(usage.LicenseTypeID)
+
+		trace.Hosts = append(trace.Hosts, dto.OracleDatabaseContractsAssignmentTraceHost{
+			Name:              usage.Name,
+			Type:              usage.Type,
+			ConsumedLicenses:  usage.OriginalCount,
+			CoveredLicenses:   usage.OriginalCount - usage.LicenseCount,
+			UncoveredLicenses: usage.LicenseCount,
+		})
+		trace.UncoveredLicenses += usage.LicenseCount
+	}
+
+	for _, trace := range t.traces {
+		sort.Slice(trace.Hosts, func(i, j int) bool {
+			return trace.Hosts[i].Name < trace.Hosts[j].Name
+		})
+	}
+}
+
+// getTraces return the traces sorted by license type, only the one of licenseTypeID if it isn't empty
+func (t *oracleDatabaseContractsAssignmentTracer) getTraces(licenseTypeID string) []dto.OracleDatabaseContractsAssignmentTrace {
+	res := make([]dto.OracleDatabaseContractsAssignmentTrace, 0, len(t.traces))
+
+	for _, trace := range t.traces {
+		if licenseTypeID != "" && trace.LicenseTypeID != licenseTypeID {
+			continue
+		}
+
+		res = append(res, *trace)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].LicenseTypeID < res[j].LicenseTypeID
+	})
+
+	return res
+}
+
+// contractAvailableLicenses return the licenses yet available in contract according to its metric
+func contractAvailableLicenses(contract *dto.OracleDatabaseContractFE) float64 {
+	if contract.Metric == model.LicenseTypeMetricNamedUserPlusPerpetual {
+		return contract.AvailableLicensesPerUser
+	}
+
+	return contract.AvailableLicensesPerCore
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestDoAssignOracleDatabaseContractsToHosts_Trace(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database:    db,
+		Config:      config.Configuration{},
+		NewObjectID: utils.NewObjectIDForTests(),
+	}
+
+	parts := []model.OracleDatabaseLicenseType{
+		{
+			ID:              "PID002",
+			ItemDescription: "Oracle Partitioning",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+		},
+		{
+			ID:              "PID003",
+			ItemDescription: "Oracle Diagnostics Pack",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+		},
+	}
+	db.EXPECT().GetOracleDatabaseLicenseTypes().
+		Return(parts, nil).Times(2)
+
+	newContracts := func() []dto.OracleDatabaseContractFE {
+		return []dto.OracleDatabaseContractFE{
+			{
+				ID:                       utils.Str2oid("5f4d0ab1c6bc19e711bbcce7"),
+				ContractID:               "AID002",
+				LicenseTypeID:            "PID002",
+				Basket:                   true,
+				Hosts:                    []dto.OracleDatabaseContractAssociatedHostFE{},
+				LicensesPerCore:          4,
+				AvailableLicensesPerCore: 4,
+			},
+			{
+				ID:            utils.Str2oid("5f4d0ab1c6bc19e711bbcce6"),
+				ContractID:    "AID001",
+				LicenseTypeID: "PID002",
+				Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+					{Hostname: "unused-host"},
+					{Hostname: "test-db"},
+				},
+				LicensesPerCore:          5,
+				AvailableLicensesPerCore: 5,
+			},
+		}
+	}
+	newUsages := func() []dto.HostUsingOracleDatabaseLicenses {
+		return []dto.HostUsingOracleDatabaseLicenses{
+			{Name: "test-db", LicenseTypeID: "PID002", LicenseCount: 3, OriginalCount: 3, Type: "host"},
+			{Name: "other-db", LicenseTypeID: "PID002", LicenseCount: 6, OriginalCount: 6, Type: "host"},
+			{Name: "lonely-db", LicenseTypeID: "PID003", LicenseCount: 2, OriginalCount: 2, Type: "host"},
+		}
+	}
+
+	tracer := newOracleDatabaseContractsAssignmentTracer()
+
+	err := as.doAssignOracleDatabaseContractsToHosts(newContracts(), newUsages(), tracer)
+	require.NoError(t, err)
+
+	expected := []dto.OracleDatabaseContractsAssignmentTrace{
+		{
+			LicenseTypeID:   "PID002",
+			ItemDescription: "Oracle Partitioning",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			Contracts: []dto.OracleDatabaseContractsAssignmentTraceContract{
+				{
+					Order:             1,
+					ID:                utils.Str2oid("5f4d0ab1c6bc19e711bbcce6"),
+					ContractID:        "AID001",
+					Hosts:             []string{"unused-host", "test-db"},
+					AvailableLicenses: 5,
+					CoveredLicenses:   3,
+					RemainingLicenses: 2,
+				},
+				{
+					Order:             2,
+					ID:                utils.Str2oid("5f4d0ab1c6bc19e711bbcce7"),
+					ContractID:        "AID002",
+					Basket:            true,
+					Hosts:             []string{},
+					AvailableLicenses: 4,
+					CoveredLicenses:   4,
+					RemainingLicenses: 0,
+				},
+			},
+			Steps: []dto.OracleDatabaseContractsAssignmentStep{
+				{
+					Phase:                     dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost,
+					ContractOrder:             1,
+					ContractID:                "AID001",
+					Name:                      "test-db",
+					Type:                      "host",
+					AssignedLicenses:          3,
+					ContractRemainingLicenses: 2,
+					UncoveredLicenses:         0,
+				},
+				{
+					Phase:                     dto.OracleDatabaseContractsAssignmentPhaseAssociatedHost,
+					ContractOrder:             1,
+					ContractID:                "AID001",
+					Name:                      "unused-host",
+					Type:                      "host",
+					ContractRemainingLicenses: 2,
+					Note:                      "the host doesn't use the license type",
+				},
+				{
+					Phase:                     dto.OracleDatabaseContractsAssignmentPhaseBasket,
+					ContractOrder:             2,
+					ContractID:                "AID002",
+					Name:                      "other-db",
+					Type:                      "host",
+					AssignedLicenses:          4,
+					ContractRemainingLicenses: 0,
+					UncoveredLicenses:         2,
+				},
+			},
+			Hosts: []dto.OracleDatabaseContractsAssignmentTraceHost{
+				{Name: "other-db", Type: "host", ConsumedLicenses: 6, CoveredLicenses: 4, UncoveredLicenses: 2},
+				{Name: "test-db", Type: "host", ConsumedLicenses: 3, CoveredLicenses: 3, UncoveredLicenses: 0},
+			},
+			UncoveredLicenses: 2,
+		},
+		{
+			LicenseTypeID:   "PID003",
+			ItemDescription: "Oracle Diagnostics Pack",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			Contracts:       []dto.OracleDatabaseContractsAssignmentTraceContract{},
+			Steps:           []dto.OracleDatabaseContractsAssignmentStep{},
+			Hosts: []dto.OracleDatabaseContractsAssignmentTraceHost{
+				{Name: "lonely-db", Type: "host", ConsumedLicenses: 2, CoveredLicenses: 0, UncoveredLicenses: 2},
+			},
+			UncoveredLicenses: 2,
+		},
+	}
+
+	assert.Equal(t, expected, tracer.getTraces(""))
+	assert.Equal(t, expected[1:], tracer.getTraces("PID003"))
+
+	t.Run("Without tracer", func(t *testing.T) {
+		contracts := newContracts()
+
+		err := as.doAssignOracleDatabaseContractsToHosts(contracts, newUsages(), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(2), contracts[0].AvailableLicensesPerCore)
+		assert.Equal(t, float64(0), contracts[1].AvailableLicensesPerCore)
+	})
+}
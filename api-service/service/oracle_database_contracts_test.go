@@ -74,8 +74,17 @@ func TestAddOracleDatabaseContract_Success_InsertNew(t *testing.T) {
 		}, nil),
 		db.EXPECT().GetOracleDatabaseLicenseType("PID001").
 			Return(&lt1, nil),
+		expectTransaction(db),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, expectedAgr.ID).
+			Return(nil, nil),
 		db.EXPECT().InsertOracleDatabaseContract(expectedAgr).
 			Return(nil),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, expectedAgr.ID).
+			Return(map[string]interface{}{"_id": expectedAgr.ID}, nil),
+		db.EXPECT().InsertContractChange(gomock.Any()).Do(func(change model.ContractChange) {
+			assert.Equal(t, model.ContractChangeOperationAdd, change.Operation)
+			assert.Equal(t, "admin", change.User)
+		}).Return(nil),
 	)
 
 	searchedContractItem := dto.OracleDatabaseContractFE{
@@ -99,7 +108,7 @@ func TestAddOracleDatabaseContract_Success_InsertNew(t *testing.T) {
 		return []dto.OracleDatabaseContractFE{searchedContractItem}, nil
 	}
 
-	res, err := as.AddOracleDatabaseContract(contract)
+	res, err := as.AddOracleDatabaseContract(contract, "admin")
 	require.NoError(t, err)
 	assert.Equal(t,
 		searchedContractItem,
@@ -145,9 +154,10 @@ func TestAddOracleDatabaseContracts_Fail(t *testing.T) {
 					{"hostname": "pippo"},
 					{"hostname": "pluto"},
 				}, nil),
+			db.EXPECT().ListHostAliases().Return(nil, nil),
 		)
 
-		res, err := as.AddOracleDatabaseContract(addRequest)
+		res, err := as.AddOracleDatabaseContract(addRequest, "admin")
 		assert.EqualError(t, err, utils.ErrHostNotFound.Error())
 		assert.Nil(t, res)
 	})
@@ -191,7 +201,7 @@ func TestAddOracleDatabaseContracts_Fail(t *testing.T) {
 				Return(nil, nil),
 		)
 
-		res, err := as.AddOracleDatabaseContract(contractWrongLicenseType)
+		res, err := as.AddOracleDatabaseContract(contractWrongLicenseType, "admin")
 
 		assert.EqualError(t, err, utils.ErrOracleDatabaseLicenseTypeIDNotFound.Error())
 		assert.Nil(t, res)
@@ -238,7 +248,16 @@ func TestUpdateOracleDatabaseContract_Success(t *testing.T) {
 			}, nil),
 		db.EXPECT().GetOracleDatabaseLicenseType("PID001").
 			Return(&lt1, nil),
+		expectTransaction(db),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, contract.ID).
+			Return(nil, nil),
 		db.EXPECT().UpdateOracleDatabaseContract(contract).Return(nil),
+		db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, contract.ID).
+			Return(map[string]interface{}{"_id": contract.ID}, nil),
+		db.EXPECT().InsertContractChange(gomock.Any()).Do(func(change model.ContractChange) {
+			assert.Equal(t, model.ContractChangeOperationUpdate, change.Operation)
+			assert.Equal(t, "admin", change.User)
+		}).Return(nil),
 	)
 
 	searchedContractItem := dto.OracleDatabaseContractFE{
@@ -262,7 +281,7 @@ func TestUpdateOracleDatabaseContract_Success(t *testing.T) {
 		return []dto.OracleDatabaseContractFE{searchedContractItem}, nil
 	}
 
-	actualContract, err := as.UpdateOracleDatabaseContract(contract)
+	actualContract, err := as.UpdateOracleDatabaseContract(contract, "admin")
 	require.NoError(t, err)
 	assert.Equal(t, searchedContractItem, *actualContract)
 }
@@ -309,7 +328,7 @@ func TestUpdateOracleDatabaseContract_Fail_LicenseTypeIdNotValid(t *testing.T) {
 			Return(nil, nil),
 	)
 
-	actual, err := as.UpdateOracleDatabaseContract(contract)
+	actual, err := as.UpdateOracleDatabaseContract(contract, "admin")
 
 	assert.EqualError(t, err, utils.ErrOracleDatabaseLicenseTypeIDNotFound.Error())
 	assert.Nil(t, actual)
@@ -2507,11 +2526,14 @@ func TestDeleteOracleDatabaseContract(t *testing.T) {
 
 	t.Run("Fail: can't find contract", func(t *testing.T) {
 		gomock.InOrder(
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, contractID).
+				Return(nil, nil),
 			db.EXPECT().RemoveOracleDatabaseContract(contractID).
 				Return(utils.ErrContractNotFound),
 		)
 
-		err := as.DeleteOracleDatabaseContract(contractID)
+		err := as.DeleteOracleDatabaseContract(contractID, "admin")
 		require.EqualError(t, err, utils.ErrContractNotFound.Error())
 	})
 
@@ -2529,11 +2551,20 @@ func TestDeleteOracleDatabaseContract(t *testing.T) {
 		}
 
 		gomock.InOrder(
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, contract.ID).
+				Return(map[string]interface{}{"_id": contract.ID}, nil),
 			db.EXPECT().RemoveOracleDatabaseContract(contract.ID).
 				Return(nil),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, contract.ID).
+				Return(nil, nil),
+			db.EXPECT().InsertContractChange(gomock.Any()).Do(func(change model.ContractChange) {
+				assert.Equal(t, model.ContractChangeOperationDelete, change.Operation)
+				assert.Equal(t, "admin", change.User)
+			}).Return(nil),
 		)
 
-		err := as.DeleteOracleDatabaseContract(contractID)
+		err := as.DeleteOracleDatabaseContract(contractID, "admin")
 		assert.Nil(t, err)
 	})
 
@@ -2564,9 +2595,10 @@ func TestAddHostToOracleDatabaseContract(t *testing.T) {
 				{"hostname": "foobar"},
 				{"hostname": "ercsoldbx"},
 			}, nil),
+			db.EXPECT().ListHostAliases().Return(nil, nil),
 		)
 
-		err := as.AddHostToOracleDatabaseContract(anotherAssociatedPartID, "pippo")
+		err := as.AddHostToOracleDatabaseContract(anotherAssociatedPartID, "pippo", "admin")
 		assert.EqualError(t, err, utils.ErrHostNotFound.Error())
 	})
 
@@ -2585,7 +2617,7 @@ func TestAddHostToOracleDatabaseContract(t *testing.T) {
 				Return(nil, utils.ErrContractNotFound),
 		)
 
-		err := as.AddHostToOracleDatabaseContract(id, "foobar")
+		err := as.AddHostToOracleDatabaseContract(id, "foobar", "admin")
 		assert.EqualError(t, err, utils.ErrContractNotFound.Error())
 	})
 
@@ -2624,11 +2656,20 @@ func TestAddHostToOracleDatabaseContract(t *testing.T) {
 				}, nil),
 			db.EXPECT().GetOracleDatabaseContract(id).
 				Return(&contract, nil),
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, id).
+				Return(map[string]interface{}{"_id": id}, nil),
 			db.EXPECT().UpdateOracleDatabaseContract(contractPostAdd).
 				Return(nil),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, id).
+				Return(map[string]interface{}{"_id": id}, nil),
+			db.EXPECT().InsertContractChange(gomock.Any()).Do(func(change model.ContractChange) {
+				assert.Equal(t, model.ContractChangeOperationAddHost, change.Operation)
+				assert.Equal(t, "admin", change.User)
+			}).Return(nil),
 		)
 
-		err := as.AddHostToOracleDatabaseContract(id, "foobar")
+		err := as.AddHostToOracleDatabaseContract(id, "foobar", "admin")
 		assert.Nil(t, err)
 	})
 }
@@ -2660,7 +2701,7 @@ func TestDeleteHostFromOracleDatabaseContract(t *testing.T) {
 				Return(nil, utils.ErrContractNotFound),
 		)
 
-		err := as.DeleteHostFromOracleDatabaseContract(id, "pippo")
+		err := as.DeleteHostFromOracleDatabaseContract(id, "pippo", "admin")
 		require.EqualError(t, err, utils.ErrContractNotFound.Error())
 	})
 
@@ -2699,11 +2740,20 @@ func TestDeleteHostFromOracleDatabaseContract(t *testing.T) {
 				}, nil),
 			db.EXPECT().GetOracleDatabaseContract(anotherId).
 				Return(&contract, nil),
+			expectTransaction(db),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, anotherId).
+				Return(map[string]interface{}{"_id": anotherId}, nil),
 			db.EXPECT().UpdateOracleDatabaseContract(contractPostAdd).
 				Return(nil),
+			db.EXPECT().GetContractSnapshot(model.TechnologyOracleDatabase, anotherId).
+				Return(map[string]interface{}{"_id": anotherId}, nil),
+			db.EXPECT().InsertContractChange(gomock.Any()).Do(func(change model.ContractChange) {
+				assert.Equal(t, model.ContractChangeOperationDeleteHost, change.Operation)
+				assert.Equal(t, "admin", change.User)
+			}).Return(nil),
 		)
 
-		err := as.DeleteHostFromOracleDatabaseContract(anotherId, "ercsoldbx")
+		err := as.DeleteHostFromOracleDatabaseContract(anotherId, "ercsoldbx", "admin")
 		assert.Nil(t, err)
 	})
 }
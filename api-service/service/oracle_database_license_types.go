@@ -17,6 +17,7 @@ package service
 
 import (
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/ercole-io/ercole/v2/api-service/dto"
@@ -152,6 +153,59 @@ func (as *APIService) GetOracleDatabaseLicensesCompliance(locations []string) ([
 	return result, nil
 }
 
+// GetOracleDatabaseContractsLicensesCompliance return the usage and compliance of the licenses of each contract.
+// The consumed licenses are the ones of the hosts associated to the contract
+func (as *APIService) GetOracleDatabaseContractsLicensesCompliance(locations []string) ([]dto.OracleDatabaseContractLicenseCompliance, error) {
+	filter := dto.NewGetOracleDatabaseContractsFilter()
+	filter.Locations = locations
+
+	contracts, err := as.GetOracleDatabaseContracts(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.OracleDatabaseContractLicenseCompliance, 0, len(contracts))
+
+	for _, contract := range contracts {
+		license := dto.OracleDatabaseContractLicenseCompliance{
+			ID:              contract.ID,
+			ContractID:      contract.ContractID,
+			CSI:             contract.CSI,
+			Location:        contract.Location,
+			LicenseTypeID:   contract.LicenseTypeID,
+			ItemDescription: contract.ItemDescription,
+			Metric:          contract.Metric,
+			Unlimited:       contract.Unlimited,
+			Covered:         math.Round(contract.CoveredLicenses),
+			Purchased:       math.Round(contract.LicensesPerCore + contract.LicensesPerUser),
+		}
+
+		for _, host := range contract.Hosts {
+			license.Consumed += host.ConsumedLicensesCount
+		}
+
+		license.Consumed = math.Round(license.Consumed)
+
+		if license.Unlimited || license.Consumed == 0 {
+			license.Compliance = 1
+		} else {
+			license.Compliance = math.Min(license.Covered/license.Consumed, 1)
+		}
+
+		result = append(result, license)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ContractID != result[j].ContractID {
+			return result[i].ContractID < result[j].ContractID
+		}
+
+		return result[i].LicenseTypeID < result[j].LicenseTypeID
+	})
+
+	return result, nil
+}
+
 func (as *APIService) getLicensesUsage(locations []string) ([]dto.HostUsingOracleDatabaseLicenses, error) {
 	filter := dto.GlobalFilter{
 		Location:    strings.Join(locations, ","),
@@ -610,3 +610,75 @@ func TestDeleteOracleDatabaseLicenseTypes(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestGetOracleDatabaseContractsLicensesCompliance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		Config:   config.Configuration{},
+	}
+
+	as.mockGetOracleDatabaseContracts = func(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error) {
+		assert.Equal(t, []string{"Italy"}, filter.Locations)
+
+		return []dto.OracleDatabaseContractFE{
+			{
+				ID:              utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+				ContractID:      "AID002",
+				CSI:             "CSI002",
+				LicenseTypeID:   "PID002",
+				Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
+				Unlimited:       true,
+				Hosts:           []dto.OracleDatabaseContractAssociatedHostFE{{Hostname: "test-db", ConsumedLicensesCount: 50}},
+				CoveredLicenses: 50,
+			},
+			{
+				ID:              utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+				ContractID:      "AID001",
+				CSI:             "CSI001",
+				Location:        "Italy",
+				LicenseTypeID:   "PID001",
+				Metric:          model.LicenseTypeMetricProcessorPerpetual,
+				LicensesPerCore: 6,
+				Hosts: []dto.OracleDatabaseContractAssociatedHostFE{
+					{Hostname: "test-db", ConsumedLicensesCount: 4.5},
+					{Hostname: "test-db2", ConsumedLicensesCount: 3.5},
+				},
+				CoveredLicenses: 6,
+			},
+		}, nil
+	}
+
+	actual, err := as.GetOracleDatabaseContractsLicensesCompliance([]string{"Italy"})
+	require.NoError(t, err)
+
+	expected := []dto.OracleDatabaseContractLicenseCompliance{
+		{
+			ID:            utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+			ContractID:    "AID001",
+			CSI:           "CSI001",
+			Location:      "Italy",
+			LicenseTypeID: "PID001",
+			Metric:        model.LicenseTypeMetricProcessorPerpetual,
+			Consumed:      8,
+			Covered:       6,
+			Purchased:     6,
+			Compliance:    0.75,
+		},
+		{
+			ID:            utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			ContractID:    "AID002",
+			CSI:           "CSI002",
+			LicenseTypeID: "PID002",
+			Metric:        model.LicenseTypeMetricNamedUserPlusPerpetual,
+			Unlimited:     true,
+			Consumed:      50,
+			Covered:       50,
+			Compliance:    1,
+		},
+	}
+
+	assert.Equal(t, expected, actual)
+}
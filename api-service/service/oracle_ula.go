@@ -0,0 +1,496 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+// GetOracleULACertification return the processors and named users deployed on date for each license type
+// covered by an unlimited license agreement. The processors of a host are its cores multiplied by the core factor,
+// or its vCPUs divided by the ratio of its cloud, rounded up. The VMs of a cluster without capped CPUs
+// are certified once with the cores of the whole cluster multiplied by the default core factor
+func (as *APIService) GetOracleULACertification(date time.Time, locations []string) (*dto.OracleULACertification, error) {
+	contracts, err := as.getOracleULAContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	certification := &dto.OracleULACertification{
+		Date:         date,
+		Contracts:    contracts,
+		LicenseTypes: make([]dto.OracleULACertifiedUsage, 0),
+		Locations:    make([]dto.OracleULACertifiedUsage, 0),
+		Clusters:     make([]dto.OracleULACertifiedUsage, 0),
+		Clouds:       make([]dto.OracleULACertifiedUsage, 0),
+		Hosts:        make([]dto.OracleULAHostDeployment, 0),
+	}
+
+	if date == utils.MAX_TIME {
+		certification.Date = as.TimeNow()
+	}
+
+	if len(contracts) == 0 {
+		return certification, nil
+	}
+
+	licenseTypes := make(map[string]dto.OracleDatabaseContractFE, len(contracts))
+	for _, contract := range contracts {
+		licenseTypes[contract.LicenseTypeID] = contract
+	}
+
+	policy, err := as.GetActiveCoreFactorPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := dto.GlobalFilter{
+		Location:  strings.Join(locations, ","),
+		OlderThan: date,
+	}
+
+	hostdatas, err := as.Database.GetHostDatas(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := as.Database.GetClusters(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	clustersByVM := make(map[string]*dto.Cluster)
+
+	for i := range clusters {
+		for _, vm := range clusters[i].VMs {
+			if !vm.CappedCPU && vm.Hostname != "" {
+				clustersByVM[vm.Hostname] = &clusters[i]
+			}
+		}
+	}
+
+	sort.Slice(hostdatas, func(i, j int) bool {
+		return hostdatas[i].Hostname < hostdatas[j].Hostname
+	})
+
+	totals, byLocation, byCloud, byCluster := oracleULAUsages{}, oracleULAUsages{}, oracleULAUsages{}, oracleULAUsages{}
+	clusterLocations := make(map[string]string)
+
+	for _, hostdata := range hostdatas {
+		databases := oracleULADatabases(hostdata, licenseTypes)
+
+		ltIDs := make([]string, 0, len(databases))
+		for ltID := range databases {
+			ltIDs = append(ltIDs, ltID)
+		}
+
+		sort.Strings(ltIDs)
+
+		coreFactor := hostdata.CoreFactor(*policy)
+
+		for _, ltID := range ltIDs {
+			lt := licenseTypes[ltID]
+
+			deployment := dto.OracleULAHostDeployment{
+				Hostname:        hostdata.Hostname,
+				Location:        hostdata.Location,
+				Environment:     hostdata.Environment,
+				CloudMembership: oracleULACloud(hostdata.Cloud.Membership),
+				LicenseTypeID:   ltID,
+				ItemDescription: lt.ItemDescription,
+				Metric:          lt.Metric,
+				Databases:       databases[ltID],
+				Cores:           hostdata.Info.CPUCores,
+				CoreFactor:      coreFactor,
+				Processors:      math.Ceil(float64(hostdata.Info.CPUCores) * coreFactor),
+			}
+			deployment.NamedUsers = oracleULANamedUsers(lt.Metric, deployment.Processors)
+
+			if cluster, ok := clustersByVM[hostdata.Hostname]; ok {
+				deployment.ClusterName = cluster.Name
+				clusterLocations[cluster.Name] = cluster.Location
+
+				processors := 0.0
+				if !byCluster.has(cluster.Name, ltID) {
+					processors = math.Ceil(float64(cluster.CPU) * policy.DefaultCoreFactor)
+				}
+
+				byCluster.add(cluster.Name, lt, 1, processors)
+			} else {
+				totals.add("", lt, 1, deployment.Processors)
+				byLocation.add(deployment.Location, lt, 1, deployment.Processors)
+				byCloud.add(deployment.CloudMembership, lt, 1, deployment.Processors)
+			}
+
+			certification.Hosts = append(certification.Hosts, deployment)
+		}
+	}
+
+	certification.Clusters = byCluster.sorted()
+
+	for _, usage := range certification.Clusters {
+		lt := licenseTypes[usage.LicenseTypeID]
+
+		totals.add("", lt, usage.HostsCount, usage.Processors)
+		byLocation.add(clusterLocations[usage.Group], lt, usage.HostsCount, usage.Processors)
+		byCloud.add(model.CloudMembershipNone, lt, usage.HostsCount, usage.Processors)
+	}
+
+	certification.LicenseTypes = totals.sorted()
+	certification.Locations = byLocation.sorted()
+	certification.Clouds = byCloud.sorted()
+
+	return certification, nil
+}
+
+// getOracleULAContracts return the Oracle contracts of the locations that are unlimited license agreements
+func (as *APIService) getOracleULAContracts(locations []string) ([]dto.OracleDatabaseContractFE, error) {
+	filter := dto.NewGetOracleDatabaseContractsFilter()
+	filter.Locations = locations
+
+	contracts, err := as.Database.ListOracleDatabaseContracts(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ulaContracts := make([]dto.OracleDatabaseContractFE, 0)
+
+	for _, contract := range contracts {
+		if contract.Unlimited {
+			ulaContracts = append(ulaContracts, contract)
+		}
+	}
+
+	sort.Slice(ulaContracts, func(i, j int) bool {
+		if ulaContracts[i].LicenseTypeID != ulaContracts[j].LicenseTypeID {
+			return ulaContracts[i].LicenseTypeID < ulaContracts[j].LicenseTypeID
+		}
+
+		return ulaContracts[i].ContractID < ulaContracts[j].ContractID
+	})
+
+	return ulaContracts, nil
+}
+
+// oracleULADatabases return the names of the databases of the host using each license type covered by the agreements
+func oracleULADatabases(hostdata model.HostDataBE, licenseTypes map[string]dto.OracleDatabaseContractFE) map[string][]string {
+	databases := make(map[string][]string)
+
+	if hostdata.Features.Oracle == nil || hostdata.Features.Oracle.Database == nil {
+		return databases
+	}
+
+	for _, database := range hostdata.Features.Oracle.Database.Databases {
+		for _, license := range database.Licenses {
+			if license.Count <= 0 || license.Ignored {
+				continue
+			}
+
+			if _, ok := licenseTypes[license.LicenseTypeID]; !ok {
+				continue
+			}
+
+			if !utils.Contains(databases[license.LicenseTypeID], database.Name) {
+				databases[license.LicenseTypeID] = append(databases[license.LicenseTypeID], database.Name)
+			}
+		}
+	}
+
+	return databases
+}
+
+// oracleULANamedUsers return the minimum named users to certify for processors, only for the Named User Plus license types
+func oracleULANamedUsers(metric string, processors float64) float64 {
+	if metric != model.LicenseTypeMetricNamedUserPlusPerpetual {
+		return 0
+	}
+
+	return processors * model.FactorNamedUser
+}
+
+func oracleULACloud(membership string) string {
+	if membership == model.CloudMembershipUnknown {
+		return model.CloudMembershipNone
+	}
+
+	return membership
+}
+
+// oracleULAUsages sums the usages of the license types by group
+type oracleULAUsages map[[2]string]*dto.OracleULACertifiedUsage
+
+func (u oracleULAUsages) has(group, licenseTypeID string) bool {
+	_, ok := u[[2]string{group, licenseTypeID}]
+	return ok
+}
+
+func (u oracleULAUsages) add(group string, lt dto.OracleDatabaseContractFE, hostsCount int, processors float64) {
+	key := [2]string{group, lt.LicenseTypeID}
+
+	usage, ok := u[key]
+	if !ok {
+		usage = &dto.OracleULACertifiedUsage{
+			Group:           group,
+			LicenseTypeID:   lt.LicenseTypeID,
+			ItemDescription: lt.ItemDescription,
+			Metric:          lt.Metric,
+		}
+		u[key] = usage
+	}
+
+	usage.HostsCount += hostsCount
+	usage.Processors += processors
+	usage.NamedUsers += oracleULANamedUsers(lt.Metric, processors)
+}
+
+func (u oracleULAUsages) sorted() []dto.OracleULACertifiedUsage {
+	usages := make([]dto.OracleULACertifiedUsage, 0, len(u))
+	for _, usage := range u {
+		usages = append(usages, *usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Group != usages[j].Group {
+			return usages[i].Group < usages[j].Group
+		}
+
+		return usages[i].LicenseTypeID < usages[j].LicenseTypeID
+	})
+
+	return usages
+}
+
+func (as *APIService) GetOracleULACertificationAsXLSX(date time.Time, locations []string) (*excelize.File, error) {
+	certification, err := as.GetOracleULACertification(date, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "License Types"
+	headers := []string{
+		"Part Number",
+		"Description",
+		"Metric",
+		"Hosts",
+		"Processors",
+		"Named Users",
+	}
+
+	file, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, val := range certification.LicenseTypes {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		file.SetCellValue(sheet, nextAxis(), val.ItemDescription)
+		file.SetCellValue(sheet, nextAxis(), val.Metric)
+		file.SetCellValue(sheet, nextAxis(), val.HostsCount)
+		file.SetCellValue(sheet, nextAxis(), val.Processors)
+		file.SetCellValue(sheet, nextAxis(), val.NamedUsers)
+	}
+
+	setOracleULAUsagesSheet(file, "Locations", "Location", certification.Locations)
+	setOracleULAUsagesSheet(file, "Clusters", "Cluster", certification.Clusters)
+	setOracleULAUsagesSheet(file, "Clouds", "Cloud", certification.Clouds)
+
+	sheet = "Hosts"
+	file.NewSheet(sheet)
+
+	axisHelp = exutils.NewAxisHelper(0)
+	axisHelp.NewRowAndFill(file, sheet,
+		"Hostname",
+		"Location",
+		"Environment",
+		"Cloud",
+		"Cluster",
+		"Part Number",
+		"Description",
+		"Metric",
+		"Databases",
+		"Cores",
+		"Core Factor",
+		"Processors",
+		"Named Users",
+	)
+
+	for _, val := range certification.Hosts {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), val.Hostname)
+		file.SetCellValue(sheet, nextAxis(), val.Location)
+		file.SetCellValue(sheet, nextAxis(), val.Environment)
+		file.SetCellValue(sheet, nextAxis(), val.CloudMembership)
+		file.SetCellValue(sheet, nextAxis(), val.ClusterName)
+		file.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		file.SetCellValue(sheet, nextAxis(), val.ItemDescription)
+		file.SetCellValue(sheet, nextAxis(), val.Metric)
+		file.SetCellValue(sheet, nextAxis(), strings.Join(val.Databases, ", "))
+		file.SetCellValue(sheet, nextAxis(), val.Cores)
+		file.SetCellValue(sheet, nextAxis(), val.CoreFactor)
+		file.SetCellValue(sheet, nextAxis(), val.Processors)
+		file.SetCellValue(sheet, nextAxis(), val.NamedUsers)
+	}
+
+	return file, nil
+}
+
+func setOracleULAUsagesSheet(file *excelize.File, sheet, group string, usages []dto.OracleULACertifiedUsage) {
+	file.NewSheet(sheet)
+
+	axisHelp := exutils.NewAxisHelper(0)
+	axisHelp.NewRowAndFill(file, sheet,
+		group,
+		"Part Number",
+		"Description",
+		"Metric",
+		"Hosts",
+		"Processors",
+		"Named Users",
+	)
+
+	for _, val := range usages {
+		nextAxis := axisHelp.NewRow()
+		file.SetCellValue(sheet, nextAxis(), val.Group)
+		file.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		file.SetCellValue(sheet, nextAxis(), val.ItemDescription)
+		file.SetCellValue(sheet, nextAxis(), val.Metric)
+		file.SetCellValue(sheet, nextAxis(), val.HostsCount)
+		file.SetCellValue(sheet, nextAxis(), val.Processors)
+		file.SetCellValue(sheet, nextAxis(), val.NamedUsers)
+	}
+}
+
+// GetOracleULAUsageHistory return the historicized usage of the license types covered by an unlimited license agreement.
+// If from or to aren't set, the history of each license type starts at the earliest product order date
+// and ends at the latest support expiration of its agreements
+func (as *APIService) GetOracleULAUsageHistory(from, to time.Time) ([]dto.OracleULAUsageHistory, error) {
+	contracts, err := as.getOracleULAContracts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usagesByLicenseType := make(map[string]*dto.OracleULAUsageHistory)
+	licenseTypeIDs := make([]string, 0)
+
+	for _, c := range contracts {
+		contract, err := as.Database.GetOracleDatabaseContract(c.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		start, end := utils.MIN_TIME, utils.MAX_TIME
+		if contract.ProductOrderDate != nil && !contract.ProductOrderDate.IsZero() {
+			start = contract.ProductOrderDate.Time
+		}
+
+		if contract.SupportExpiration != nil && !contract.SupportExpiration.IsZero() {
+			end = contract.SupportExpiration.Time
+		}
+
+		usage, ok := usagesByLicenseType[c.LicenseTypeID]
+		if !ok {
+			usage = &dto.OracleULAUsageHistory{
+				LicenseTypeID:   c.LicenseTypeID,
+				ItemDescription: c.ItemDescription,
+				Metric:          c.Metric,
+				From:            start,
+				To:              end,
+				History:         make([]dto.LicenseComplianceHistoricValue, 0),
+			}
+			usagesByLicenseType[c.LicenseTypeID] = usage
+			licenseTypeIDs = append(licenseTypeIDs, c.LicenseTypeID)
+		}
+
+		if start.Before(usage.From) {
+			usage.From = start
+		}
+
+		if end.After(usage.To) {
+			usage.To = end
+		}
+	}
+
+	usages := make([]dto.OracleULAUsageHistory, 0, len(licenseTypeIDs))
+
+	if len(licenseTypeIDs) == 0 {
+		return usages, nil
+	}
+
+	minFrom, maxTo := utils.MAX_TIME, utils.MIN_TIME
+
+	for _, usage := range usagesByLicenseType {
+		if from != utils.MIN_TIME {
+			usage.From = from
+		}
+
+		if to != utils.MAX_TIME {
+			usage.To = to
+		}
+
+		if usage.From.Before(minFrom) {
+			minFrom = usage.From
+		}
+
+		if usage.To.After(maxTo) {
+			maxTo = usage.To
+		}
+	}
+
+	histories, err := as.Database.GetLicensesComplianceHistory(licenseTypeIDs, minFrom, maxTo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, history := range histories {
+		usage, ok := usagesByLicenseType[history.LicenseTypeID]
+		if !ok {
+			continue
+		}
+
+		for _, value := range history.History {
+			if value.Date.Before(usage.From) || value.Date.After(usage.To) {
+				continue
+			}
+
+			usage.History = append(usage.History, value)
+			usage.PeakConsumed = math.Max(usage.PeakConsumed, value.Consumed)
+		}
+	}
+
+	for _, licenseTypeID := range licenseTypeIDs {
+		usage := usagesByLicenseType[licenseTypeID]
+		sort.Slice(usage.History, func(i, j int) bool {
+			return usage.History[i].Date.Before(usage.History[j].Date)
+		})
+
+		usages = append(usages, *usage)
+	}
+
+	return usages, nil
+}
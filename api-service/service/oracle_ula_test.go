@@ -0,0 +1,285 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func newOracleULATestContracts() []dto.OracleDatabaseContractFE {
+	return []dto.OracleDatabaseContractFE{
+		{
+			ID:              utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa"),
+			ContractID:      "ULA-1",
+			LicenseTypeID:   "A90611",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			Unlimited:       true,
+			Basket:          true,
+		},
+		{
+			ID:              utils.Str2oid("bbbbbbbbbbbbbbbbbbbbbbbb"),
+			ContractID:      "ULA-1",
+			LicenseTypeID:   "A90610",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          model.LicenseTypeMetricNamedUserPlusPerpetual,
+			Unlimited:       true,
+			Basket:          true,
+		},
+		{
+			ID:              utils.Str2oid("cccccccccccccccccccccccc"),
+			ContractID:      "PERPETUAL-1",
+			LicenseTypeID:   "L10005",
+			ItemDescription: "Real Application Clusters",
+			Metric:          model.LicenseTypeMetricProcessorPerpetual,
+			LicensesPerCore: 4,
+		},
+	}
+}
+
+func TestGetOracleULACertification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	oracleFeatures := func(licenses ...model.OracleDatabaseLicense) model.Features {
+		return model.Features{Oracle: &model.OracleFeature{Database: &model.OracleDatabaseFeature{
+			Databases: []model.OracleDatabase{{Name: "ERCOLE", Licenses: licenses}},
+		}}}
+	}
+
+	hostdatas := []model.HostDataBE{
+		{
+			Hostname: "vm2",
+			Location: "Germany",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 2},
+			Features: oracleFeatures(model.OracleDatabaseLicense{LicenseTypeID: "A90610", Count: 1}),
+		},
+		{
+			Hostname: "host1",
+			Location: "Italy",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 3},
+			Features: oracleFeatures(
+				model.OracleDatabaseLicense{LicenseTypeID: "A90611", Count: 1.5},
+				model.OracleDatabaseLicense{LicenseTypeID: "L10005", Count: 1.5},
+			),
+		},
+		{
+			Hostname: "host2",
+			Location: "Italy",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 2, CPUThreads: 4, ThreadsPerCore: 2},
+			Cloud:    model.Cloud{Membership: model.CloudMembershipAws},
+			Features: oracleFeatures(model.OracleDatabaseLicense{LicenseTypeID: "A90611", Count: 1}),
+		},
+		{
+			Hostname: "host3",
+			Location: "Italy",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 8},
+			Features: oracleFeatures(model.OracleDatabaseLicense{LicenseTypeID: "A90611", Count: 4, Ignored: true}),
+		},
+		{
+			Hostname: "vm1",
+			Location: "Germany",
+			Info:     model.Host{CPUModel: "Intel(R) Xeon(R)", CPUCores: 2},
+			Features: oracleFeatures(model.OracleDatabaseLicense{LicenseTypeID: "A90610", Count: 1}),
+		},
+	}
+
+	clusters := []dto.Cluster{
+		{
+			Name:     "cluster1",
+			Location: "Germany",
+			CPU:      16,
+			VMs:      []dto.VM{{Hostname: "vm1"}, {Hostname: "vm2"}, {Hostname: "vm3"}},
+		},
+	}
+
+	date := utils.P("2019-10-01T00:00:00Z")
+	filter := dto.GlobalFilter{Location: "", OlderThan: date}
+
+	db.EXPECT().ListOracleDatabaseContracts(gomock.Any()).Return(newOracleULATestContracts(), nil)
+	db.EXPECT().GetActiveCoreFactorPolicy().Return(nil, nil)
+	db.EXPECT().GetHostDatas(filter).Return(hostdatas, nil)
+	db.EXPECT().GetClusters(filter).Return(clusters, nil)
+
+	actual, err := as.GetOracleULACertification(date, []string{""})
+	require.NoError(t, err)
+
+	assert.Equal(t, date, actual.Date)
+	require.Len(t, actual.Contracts, 2)
+	assert.Equal(t, "A90610", actual.Contracts[0].LicenseTypeID)
+	assert.Equal(t, "A90611", actual.Contracts[1].LicenseTypeID)
+
+	ee := "Oracle Database Enterprise Edition"
+	nup := model.LicenseTypeMetricNamedUserPlusPerpetual
+	proc := model.LicenseTypeMetricProcessorPerpetual
+
+	assert.Equal(t, []dto.OracleULACertifiedUsage{
+		{LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, HostsCount: 2, Processors: 8, NamedUsers: 200},
+		{LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, HostsCount: 2, Processors: 4},
+	}, actual.LicenseTypes)
+
+	assert.Equal(t, []dto.OracleULACertifiedUsage{
+		{Group: "Germany", LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, HostsCount: 2, Processors: 8, NamedUsers: 200},
+		{Group: "Italy", LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, HostsCount: 2, Processors: 4},
+	}, actual.Locations)
+
+	assert.Equal(t, []dto.OracleULACertifiedUsage{
+		{Group: "cluster1", LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, HostsCount: 2, Processors: 8, NamedUsers: 200},
+	}, actual.Clusters)
+
+	assert.Equal(t, []dto.OracleULACertifiedUsage{
+		{Group: model.CloudMembershipAws, LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, HostsCount: 1, Processors: 2},
+		{Group: model.CloudMembershipNone, LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, HostsCount: 2, Processors: 8, NamedUsers: 200},
+		{Group: model.CloudMembershipNone, LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, HostsCount: 1, Processors: 2},
+	}, actual.Clouds)
+
+	assert.Equal(t, []dto.OracleULAHostDeployment{
+		{
+			Hostname: "host1", Location: "Italy", CloudMembership: model.CloudMembershipNone,
+			LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, Databases: []string{"ERCOLE"},
+			Cores: 3, CoreFactor: 0.5, Processors: 2,
+		},
+		{
+			Hostname: "host2", Location: "Italy", CloudMembership: model.CloudMembershipAws,
+			LicenseTypeID: "A90611", ItemDescription: ee, Metric: proc, Databases: []string{"ERCOLE"},
+			Cores: 2, CoreFactor: 1, Processors: 2,
+		},
+		{
+			Hostname: "vm1", Location: "Germany", CloudMembership: model.CloudMembershipNone, ClusterName: "cluster1",
+			LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, Databases: []string{"ERCOLE"},
+			Cores: 2, CoreFactor: 0.5, Processors: 1, NamedUsers: 25,
+		},
+		{
+			Hostname: "vm2", Location: "Germany", CloudMembership: model.CloudMembershipNone, ClusterName: "cluster1",
+			LicenseTypeID: "A90610", ItemDescription: ee, Metric: nup, Databases: []string{"ERCOLE"},
+			Cores: 2, CoreFactor: 0.5, Processors: 1, NamedUsers: 25,
+		},
+	}, actual.Hosts)
+}
+
+func TestGetOracleULACertification_NoULA(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+	}
+
+	db.EXPECT().ListOracleDatabaseContracts(gomock.Any()).Return(newOracleULATestContracts()[2:], nil)
+
+	actual, err := as.GetOracleULACertification(utils.MAX_TIME, []string{""})
+	require.NoError(t, err)
+
+	assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), actual.Date)
+	assert.Empty(t, actual.Contracts)
+	assert.Empty(t, actual.Hosts)
+	assert.Empty(t, actual.LicenseTypes)
+}
+
+func TestGetOracleULAUsageHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	var ula model.OracleDatabaseContract
+
+	err := json.Unmarshal([]byte(`{
+		"contractID": "ULA-1",
+		"licenseTypeID": "A90611",
+		"unlimited": true,
+		"productOrderDate": "2019-01-01T00:00:00Z",
+		"supportExpiration": "2021-12-31T00:00:00Z"
+	}`), &ula)
+	require.NoError(t, err)
+
+	contracts := newOracleULATestContracts()
+
+	db.EXPECT().ListOracleDatabaseContracts(gomock.Any()).Return(contracts, nil).Times(2)
+	db.EXPECT().GetOracleDatabaseContract(contracts[0].ID).Return(&ula, nil).Times(2)
+	db.EXPECT().GetOracleDatabaseContract(contracts[1].ID).Return(&model.OracleDatabaseContract{}, nil).Times(2)
+
+	history := []dto.LicenseComplianceHistory{
+		{
+			LicenseTypeID: "A90611",
+			History: []dto.LicenseComplianceHistoricValue{
+				{Date: utils.P("2022-01-01T00:00:00Z"), Consumed: 40},
+				{Date: utils.P("2019-06-01T00:00:00Z"), Consumed: 12},
+				{Date: utils.P("2018-12-01T00:00:00Z"), Consumed: 30},
+				{Date: utils.P("2019-03-01T00:00:00Z"), Consumed: 10},
+			},
+		},
+		{
+			LicenseTypeID: "A90610",
+			History: []dto.LicenseComplianceHistoricValue{
+				{Date: utils.P("2018-12-01T00:00:00Z"), Consumed: 50},
+			},
+		},
+	}
+
+	t.Run("Over the term of the agreements", func(t *testing.T) {
+		db.EXPECT().GetLicensesComplianceHistory([]string{"A90610", "A90611"}, utils.MIN_TIME, utils.MAX_TIME).Return(history, nil)
+
+		actual, err := as.GetOracleULAUsageHistory(utils.MIN_TIME, utils.MAX_TIME)
+		require.NoError(t, err)
+
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "A90610", actual[0].LicenseTypeID)
+		assert.Equal(t, utils.MIN_TIME, actual[0].From)
+		assert.Equal(t, float64(50), actual[0].PeakConsumed)
+		assert.Len(t, actual[0].History, 1)
+
+		assert.Equal(t, "A90611", actual[1].LicenseTypeID)
+		assert.Equal(t, utils.P("2019-01-01T00:00:00Z"), actual[1].From)
+		assert.Equal(t, utils.P("2021-12-31T00:00:00Z"), actual[1].To)
+		assert.Equal(t, float64(12), actual[1].PeakConsumed)
+		assert.Equal(t, []dto.LicenseComplianceHistoricValue{
+			{Date: utils.P("2019-03-01T00:00:00Z"), Consumed: 10},
+			{Date: utils.P("2019-06-01T00:00:00Z"), Consumed: 12},
+		}, actual[1].History)
+	})
+
+	t.Run("Between from and to", func(t *testing.T) {
+		from, to := utils.P("2019-05-01T00:00:00Z"), utils.P("2023-01-01T00:00:00Z")
+		db.EXPECT().GetLicensesComplianceHistory([]string{"A90610", "A90611"}, from, to).Return(history, nil)
+
+		actual, err := as.GetOracleULAUsageHistory(from, to)
+		require.NoError(t, err)
+
+		require.Len(t, actual, 2)
+		assert.Empty(t, actual[0].History)
+		assert.Equal(t, float64(40), actual[1].PeakConsumed)
+		assert.Len(t, actual[1].History, 2)
+	})
+}
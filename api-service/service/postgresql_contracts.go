@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/database"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+func (as *APIService) postgreSQLContractManager() contractManager[model.PostgreSQLContract] {
+	return contractManager[model.PostgreSQLContract]{
+		technology: model.TechnologyPostgreSQLPostgreSQL,
+		id: func(contract *model.PostgreSQLContract) *primitive.ObjectID {
+			return &contract.ID
+		},
+		prepare: func(contract *model.PostgreSQLContract) error {
+			hosts, err := checkHosts(as, contract.Hosts)
+			if err != nil {
+				return err
+			}
+
+			contract.Hosts = hosts
+
+			_, err = as.GetPostgreSQLLicenseType(contract.LicenseTypeID)
+
+			return err
+		},
+		insert: database.MongoDatabaseInterface.InsertPostgreSQLContract,
+		update: database.MongoDatabaseInterface.UpdatePostgreSQLContract,
+		remove: database.MongoDatabaseInterface.RemovePostgreSQLContract,
+	}
+}
+
+func (as *APIService) AddPostgreSQLContract(contract model.PostgreSQLContract, user string) (*model.PostgreSQLContract, error) {
+	return addContract(as, as.postgreSQLContractManager(), contract, user)
+}
+
+func (as *APIService) GetPostgreSQLContracts(locations []string) ([]model.PostgreSQLContract, error) {
+	return as.Database.ListPostgreSQLContracts(locations)
+}
+
+func (as *APIService) GetPostgreSQLContractsAsXLSX(locations []string) (*excelize.File, error) {
+	contracts, err := as.GetPostgreSQLContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "Contracts"
+	headers := []string{
+		"Type",
+		"ContractID",
+		"License Type",
+		"Subscriptions Number",
+		"Support Expiration",
+		"Location",
+		"Hosts",
+		"Clusters",
+	}
+
+	sheets, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+
+	for _, val := range contracts {
+		nextAxis := axisHelp.NewRow()
+		sheets.SetCellValue(sheet, nextAxis(), val.Type)
+		sheets.SetCellValue(sheet, nextAxis(), val.ContractID)
+		sheets.SetCellValue(sheet, nextAxis(), val.LicenseTypeID)
+		sheets.SetCellValue(sheet, nextAxis(), val.SubscriptionsNumber)
+
+		if val.SupportExpiration != nil {
+			sheets.SetCellValue(sheet, nextAxis(), val.SupportExpiration)
+		} else {
+			sheets.SetCellValue(sheet, nextAxis(), "")
+		}
+
+		sheets.SetCellValue(sheet, nextAxis(), val.Location)
+
+		for _, val2 := range val.Hosts {
+			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
+			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
+
+			sheets.SetCellValue(sheet, duplicateRowNextAxis(), val2)
+		}
+
+		for _, val2 := range val.Clusters {
+			sheets.DuplicateRow(sheet, axisHelp.GetIndexRow())
+			duplicateRowNextAxis := axisHelp.NewRowSincePreviousColumn()
+
+			sheets.SetCellValue(sheet, duplicateRowNextAxis(), val2)
+		}
+	}
+
+	return sheets, err
+}
+
+func (as *APIService) DeletePostgreSQLContract(id primitive.ObjectID, user string) error {
+	return deleteContract(as, as.postgreSQLContractManager(), id, user)
+}
+
+func (as *APIService) UpdatePostgreSQLContract(contract model.PostgreSQLContract, user string) (*model.PostgreSQLContract, error) {
+	return updateContract(as, as.postgreSQLContractManager(), contract, user)
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetPostgreSQLLicenseTypes return the list of PostgreSQLLicenseType
+func (as *APIService) GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error) {
+	return as.Database.GetPostgreSQLLicenseTypes()
+}
+
+// GetPostgreSQLLicenseTypesAsMap return the list of PostgreSQLLicenseType as map by ID
+func (as *APIService) GetPostgreSQLLicenseTypesAsMap() (map[string]model.PostgreSQLLicenseType, error) {
+	licenseTypes, err := as.GetPostgreSQLLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypesMap := make(map[string]model.PostgreSQLLicenseType, len(licenseTypes))
+	for _, licenseType := range licenseTypes {
+		licenseTypesMap[licenseType.ID] = licenseType
+	}
+
+	return licenseTypesMap, nil
+}
+
+// GetPostgreSQLLicenseType return a PostgreSQLLicenseType by ID
+func (as *APIService) GetPostgreSQLLicenseType(id string) (*model.PostgreSQLLicenseType, error) {
+	return as.Database.GetPostgreSQLLicenseType(id)
+}
+
+func (as *APIService) AddPostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) (*model.PostgreSQLLicenseType, error) {
+	if err := as.Database.InsertPostgreSQLLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) UpdatePostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) (*model.PostgreSQLLicenseType, error) {
+	if err := as.Database.UpdatePostgreSQLLicenseType(licenseType); err != nil {
+		return nil, err
+	}
+
+	return &licenseType, nil
+}
+
+func (as *APIService) DeletePostgreSQLLicenseType(id string) error {
+	return as.Database.RemovePostgreSQLLicenseType(id)
+}
+
+// postgreSQLUsage contains the cores of a license type consumed by a host running PostgreSQL
+type postgreSQLUsage struct {
+	host          dto.PostgreSQLUsedLicense
+	licenseTypeID string
+	// cluster is the cluster listed in a contract, whose cores are consumed in place of the ones of the host
+	cluster *dto.Cluster
+}
+
+// getPostgreSQLUsages return the subscriptions needed by the hosts running PostgreSQL.
+// The hosts listed in a contract, or members of a cluster listed in a contract, consume its license type.
+// The other hosts running EDB or Crunchy Data instances consume the first license type of the vendor,
+// while the community PostgreSQL doesn't need a subscription
+func (as *APIService) getPostgreSQLUsages(hostname string, filter dto.GlobalFilter, contracts []model.PostgreSQLContract,
+	lts map[string]model.PostgreSQLLicenseType,
+) ([]postgreSQLUsage, error) {
+	hosts, err := as.Database.GetPostgreSQLUsedLicenses(hostname, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hosts) == 0 {
+		return []postgreSQLUsage{}, nil
+	}
+
+	hostsByName := make(map[string]dto.PostgreSQLUsedLicense, len(hosts))
+	for _, host := range hosts {
+		hostsByName[host.Hostname] = host
+	}
+
+	clustersList, err := as.Database.GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make(map[string]*dto.Cluster, len(clustersList))
+	for i := range clustersList {
+		clusters[clustersList[i].Name] = &clustersList[i]
+	}
+
+	usages := make([]postgreSQLUsage, 0)
+	covered := make(map[string]map[string]bool)
+
+	use := func(host dto.PostgreSQLUsedLicense, licenseTypeID string, cluster *dto.Cluster) {
+		if covered[host.Hostname] == nil {
+			covered[host.Hostname] = make(map[string]bool)
+		}
+
+		if covered[host.Hostname][licenseTypeID] {
+			return
+		}
+
+		covered[host.Hostname][licenseTypeID] = true
+		usages = append(usages, postgreSQLUsage{host: host, licenseTypeID: licenseTypeID, cluster: cluster})
+	}
+
+	// clusters first, so the hosts of a cluster already covered aren't counted twice
+	for _, contract := range contracts {
+		if contract.Type != model.PostgreSQLContractTypeCluster {
+			continue
+		}
+
+		for _, clusterName := range contract.Clusters {
+			cluster, ok := clusters[clusterName]
+			if !ok {
+				continue
+			}
+
+			for _, vm := range cluster.VMs {
+				if host, ok := hostsByName[vm.Hostname]; ok {
+					use(host, contract.LicenseTypeID, cluster)
+				}
+			}
+		}
+	}
+
+	for _, contract := range contracts {
+		if contract.Type != model.PostgreSQLContractTypeHost {
+			continue
+		}
+
+		for _, hostname := range contract.Hosts {
+			if host, ok := hostsByName[hostname]; ok {
+				use(host, contract.LicenseTypeID, nil)
+			}
+		}
+	}
+
+	licenseTypeIDs := make([]string, 0, len(lts))
+	for id := range lts {
+		licenseTypeIDs = append(licenseTypeIDs, id)
+	}
+
+	sort.Strings(licenseTypeIDs)
+
+	for _, host := range hosts {
+		for _, vendor := range host.Vendors() {
+			if hostUsesPostgreSQLVendor(covered[host.Hostname], lts, vendor) {
+				continue
+			}
+
+			for _, licenseTypeID := range licenseTypeIDs {
+				if lts[licenseTypeID].Vendor == vendor {
+					use(host, licenseTypeID, nil)
+					break
+				}
+			}
+		}
+	}
+
+	return usages, nil
+}
+
+func hostUsesPostgreSQLVendor(licenseTypeIDs map[string]bool, lts map[string]model.PostgreSQLLicenseType, vendor string) bool {
+	for licenseTypeID := range licenseTypeIDs {
+		if lts[licenseTypeID].Vendor == vendor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetPostgreSQLUsedLicenses return the cores of the subscriptions used by every host running PostgreSQL
+func (as *APIService) GetPostgreSQLUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error) {
+	lts, err := as.GetPostgreSQLLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.DatabaseUsedLicense{}, nil
+	}
+
+	contracts, err := as.Database.ListPostgreSQLContracts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	usages, err := as.getPostgreSQLUsages(hostname, filter, contracts, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	usedLicenses := make([]dto.DatabaseUsedLicense, 0, len(usages))
+
+	for _, usage := range usages {
+		usedLicense := dto.DatabaseUsedLicense{
+			Hostname:      usage.host.Hostname,
+			DbName:        strings.Join(usage.host.Instances, ","),
+			LicenseTypeID: usage.licenseTypeID,
+			Description:   lts[usage.licenseTypeID].ItemDescription,
+			Metric:        lts[usage.licenseTypeID].Metric,
+			UsedLicenses:  usage.host.UsedLicenses,
+		}
+
+		if usage.cluster != nil {
+			usedLicense.ClusterName = usage.cluster.Name
+			usedLicense.ClusterLicenses = float64(usage.cluster.CPU)
+		}
+
+		usedLicenses = append(usedLicenses, usedLicense)
+	}
+
+	return usedLicenses, nil
+}
+
+// GetPostgreSQLLicensesCompliance return the compliance of the PostgreSQL subscriptions
+func (as *APIService) GetPostgreSQLLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error) {
+	contracts, err := as.Database.ListPostgreSQLContracts(locations)
+	if err != nil {
+		return nil, err
+	}
+
+	lts, err := as.GetPostgreSQLLicenseTypesAsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lts) == 0 {
+		return []dto.LicenseCompliance{}, nil
+	}
+
+	usages, err := as.getPostgreSQLUsages("", dto.GlobalFilter{
+		Location:    strings.Join(locations, ","),
+		Environment: "",
+		OlderThan:   utils.MAX_TIME,
+	}, contracts, lts)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make(map[string]*dto.LicenseCompliance)
+
+	getLicense := func(licenseTypeID string) *dto.LicenseCompliance {
+		license, ok := licenses[licenseTypeID]
+		if !ok {
+			license = &dto.LicenseCompliance{
+				LicenseTypeID:   licenseTypeID,
+				ItemDescription: lts[licenseTypeID].ItemDescription,
+				Metric:          lts[licenseTypeID].Metric,
+				Cost:            lts[licenseTypeID].Cost,
+			}
+			licenses[licenseTypeID] = license
+		}
+
+		return license
+	}
+
+	for _, contract := range contracts {
+		getLicense(contract.LicenseTypeID).Purchased += float64(contract.SubscriptionsNumber)
+	}
+
+	countedClusters := make(map[string]map[string]bool)
+
+	for _, usage := range usages {
+		license := getLicense(usage.licenseTypeID)
+
+		if usage.cluster == nil {
+			license.Consumed += usage.host.UsedLicenses
+			continue
+		}
+
+		if countedClusters[usage.licenseTypeID] == nil {
+			countedClusters[usage.licenseTypeID] = make(map[string]bool)
+		}
+
+		if !countedClusters[usage.licenseTypeID][usage.cluster.Name] {
+			license.Consumed += float64(usage.cluster.CPU)
+			countedClusters[usage.licenseTypeID][usage.cluster.Name] = true
+		}
+	}
+
+	return licensesComplianceList(licenses), nil
+}
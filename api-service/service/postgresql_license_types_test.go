@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+var postgreSQLLicenseTypes = []model.PostgreSQLLicenseType{
+	{
+		ID:              "EDB-ENT-CORE",
+		ItemDescription: "EDB Postgres Enterprise",
+		Vendor:          model.PostgreSQLVendorEDB,
+		Metric:          model.PostgreSQLLicenseTypeMetricCore,
+	},
+	{
+		ID:              "CRUNCHY-CORE",
+		ItemDescription: "Crunchy Certified PostgreSQL",
+		Vendor:          model.PostgreSQLVendorCrunchy,
+		Metric:          model.PostgreSQLLicenseTypeMetricCore,
+	},
+}
+
+var postgreSQLContracts = []model.PostgreSQLContract{
+	{
+		Type:                model.PostgreSQLContractTypeHost,
+		ContractID:          "EDB-001",
+		LicenseTypeID:       "EDB-ENT-CORE",
+		SubscriptionsNumber: 4,
+		Hosts:               []string{"pg1", "vm1"},
+	},
+	{
+		Type:                model.PostgreSQLContractTypeCluster,
+		ContractID:          "EDB-002",
+		LicenseTypeID:       "EDB-ENT-CORE",
+		SubscriptionsNumber: 16,
+		Clusters:            []string{"cluster1", "cluster2"},
+	},
+	{
+		Type:                model.PostgreSQLContractTypeHost,
+		ContractID:          "CRUNCHY-001",
+		LicenseTypeID:       "CRUNCHY-CORE",
+		SubscriptionsNumber: 4,
+		Hosts:               []string{"pg3", "notexisting"},
+	},
+}
+
+var postgreSQLUsedLicenses = []dto.PostgreSQLUsedLicense{
+	{Hostname: "pg1", Instances: []string{"main"}, Versions: []string{"PostgreSQL 10.20"}, UsedLicenses: 4},
+	{Hostname: "vm1", Instances: []string{"main", "reports"}, Versions: []string{"PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)", "PostgreSQL 14.7"}, UsedLicenses: 2},
+	{Hostname: "pg3", Instances: []string{"main"}, Versions: []string{"PostgreSQL 15.4"}, UsedLicenses: 8},
+	{Hostname: "pg4", Instances: []string{"main"}, Versions: []string{"PostgreSQL 14.7 (EnterpriseDB Advanced Server 14.7.0)"}, UsedLicenses: 32},
+	{Hostname: "pg5", Instances: []string{"main"}, Versions: []string{"PostgreSQL 16.1"}, UsedLicenses: 64},
+}
+
+var postgreSQLClusters = []dto.Cluster{
+	{Name: "cluster1", CPU: 16, VMs: []dto.VM{{Hostname: "vm1"}, {Hostname: "vm2"}}},
+	{Name: "cluster2", CPU: 64, VMs: []dto.VM{{Hostname: "vm3"}}},
+}
+
+func TestGetPostgreSQLLicensesCompliance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	t.Run("No license types", func(t *testing.T) {
+		db.EXPECT().ListPostgreSQLContracts([]string{"Italy"}).
+			Return([]model.PostgreSQLContract{}, nil)
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return([]model.PostgreSQLLicenseType{}, nil)
+
+		actual, err := as.GetPostgreSQLLicensesCompliance([]string{"Italy"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []dto.LicenseCompliance{}, actual)
+	})
+
+	t.Run("Hosts, clusters and hosts without contract", func(t *testing.T) {
+		db.EXPECT().ListPostgreSQLContracts([]string{}).
+			Return(postgreSQLContracts, nil)
+		db.EXPECT().GetPostgreSQLLicenseTypes().
+			Return(postgreSQLLicenseTypes, nil)
+		db.EXPECT().GetPostgreSQLUsedLicenses("", dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+			Return(postgreSQLUsedLicenses, nil)
+		db.EXPECT().GetClusters(dto.GlobalFilter{OlderThan: utils.MAX_TIME}).
+			Return(postgreSQLClusters, nil)
+
+		actual, err := as.GetPostgreSQLLicensesCompliance([]string{})
+		require.NoError(t, err)
+
+		expected := []dto.LicenseCompliance{
+			{
+				LicenseTypeID:   "CRUNCHY-CORE",
+				ItemDescription: "Crunchy Certified PostgreSQL",
+				Metric:          model.PostgreSQLLicenseTypeMetricCore,
+				Consumed:        8,
+				Covered:         4,
+				Purchased:       4,
+				Compliance:      0.5,
+				Available:       0,
+			},
+			{
+				LicenseTypeID:   "EDB-ENT-CORE",
+				ItemDescription: "EDB Postgres Enterprise",
+				Metric:          model.PostgreSQLLicenseTypeMetricCore,
+				Consumed:        52,
+				Covered:         20,
+				Purchased:       20,
+				Compliance:      20.0 / 52,
+				Available:       0,
+			},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		db.EXPECT().ListPostgreSQLContracts([]string{}).
+			Return(nil, errMock)
+
+		actual, err := as.GetPostgreSQLLicensesCompliance([]string{})
+		assert.ErrorIs(t, err, errMock)
+
+		assert.Nil(t, actual)
+	})
+}
+
+func TestGetPostgreSQLUsedLicenses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := APIService{
+		Database: db,
+	}
+
+	filter := dto.GlobalFilter{OlderThan: utils.MAX_TIME}
+
+	db.EXPECT().GetPostgreSQLLicenseTypes().
+		Return(postgreSQLLicenseTypes, nil)
+	db.EXPECT().ListPostgreSQLContracts(nil).
+		Return(postgreSQLContracts, nil)
+	db.EXPECT().GetPostgreSQLUsedLicenses("", filter).
+		Return(postgreSQLUsedLicenses, nil)
+	db.EXPECT().GetClusters(filter).
+		Return(postgreSQLClusters, nil)
+
+	actual, err := as.GetPostgreSQLUsedLicenses("", filter)
+	require.NoError(t, err)
+
+	expected := []dto.DatabaseUsedLicense{
+		{
+			Hostname:        "vm1",
+			DbName:          "main,reports",
+			LicenseTypeID:   "EDB-ENT-CORE",
+			Description:     "EDB Postgres Enterprise",
+			Metric:          model.PostgreSQLLicenseTypeMetricCore,
+			UsedLicenses:    2,
+			ClusterName:     "cluster1",
+			ClusterLicenses: 16,
+		},
+		{
+			Hostname:      "pg1",
+			DbName:        "main",
+			LicenseTypeID: "EDB-ENT-CORE",
+			Description:   "EDB Postgres Enterprise",
+			Metric:        model.PostgreSQLLicenseTypeMetricCore,
+			UsedLicenses:  4,
+		},
+		{
+			Hostname:      "pg3",
+			DbName:        "main",
+			LicenseTypeID: "CRUNCHY-CORE",
+			Description:   "Crunchy Certified PostgreSQL",
+			Metric:        model.PostgreSQLLicenseTypeMetricCore,
+			UsedLicenses:  8,
+		},
+		{
+			Hostname:      "pg4",
+			DbName:        "main",
+			LicenseTypeID: "EDB-ENT-CORE",
+			Description:   "EDB Postgres Enterprise",
+			Metric:        model.PostgreSQLLicenseTypeMetricCore,
+			UsedLicenses:  32,
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -18,6 +18,7 @@ package service
 
 import (
 	"encoding/csv"
+	"io"
 	"time"
 
 	"github.com/360EntSecGroup-Skylar/excelize"
@@ -45,6 +46,8 @@ type APIServiceInterface interface {
 	GetHostDataSummaries(filters dto.SearchHostsFilters) ([]dto.HostDataSummary, error)
 	// GetHost return the host specified in the hostname param
 	GetHost(hostname string, olderThan time.Time, raw bool) (*dto.HostData, error)
+	// GetHostChanges return the changes of the host between from and to, filtered by kinds if not empty
+	GetHostChanges(hostname string, kinds []string, from, to time.Time) ([]model.HostChange, error)
 	// ListManagedTechnologies returns the list of technologies with some stats
 	ListManagedTechnologies(sortBy string, sortDesc bool, location string, environment string, olderThan time.Time) ([]model.TechnologyStatus, error)
 	// SearchAlerts search alerts
@@ -130,22 +133,49 @@ type APIServiceInterface interface {
 
 	// ORACLE DATABASE CONTRACTS
 
-	AddOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error)
-	UpdateOracleDatabaseContract(contract model.OracleDatabaseContract) (*dto.OracleDatabaseContractFE, error)
+	AddOracleDatabaseContract(contract model.OracleDatabaseContract, user string) (*dto.OracleDatabaseContractFE, error)
+	UpdateOracleDatabaseContract(contract model.OracleDatabaseContract, user string) (*dto.OracleDatabaseContractFE, error)
 	GetOracleDatabaseContracts(filter dto.GetOracleDatabaseContractsFilter) ([]dto.OracleDatabaseContractFE, error)
 	GetOracleDatabaseContractsAsXLSX(filter dto.GetOracleDatabaseContractsFilter) (*excelize.File, error)
-	DeleteOracleDatabaseContract(id primitive.ObjectID) error
-	AddHostToOracleDatabaseContract(id primitive.ObjectID, hostname string) error
-	DeleteHostFromOracleDatabaseContract(id primitive.ObjectID, hostname string) error
+	DeleteOracleDatabaseContract(id primitive.ObjectID, user string) error
+	AddHostToOracleDatabaseContract(id primitive.ObjectID, hostname string, user string) error
+	DeleteHostFromOracleDatabaseContract(id primitive.ObjectID, hostname string, user string) error
 	DeleteHostFromOracleDatabaseContracts(hostname string) error
-
-	ImportOracleDatabaseContracts(reader *csv.Reader) error
+	// GetOracleULACertification return the processors and named users deployed on date for each license type
+	// covered by an unlimited license agreement, by host, cluster, location and cloud
+	GetOracleULACertification(date time.Time, locations []string) (*dto.OracleULACertification, error)
+	GetOracleULACertificationAsXLSX(date time.Time, locations []string) (*excelize.File, error)
+	// GetOracleULAUsageHistory return the historicized usage of the license types covered by an unlimited
+	// license agreement between from and to, by default over the term of the agreements
+	GetOracleULAUsageHistory(from, to time.Time) ([]dto.OracleULAUsageHistory, error)
+	// GetOracleDatabaseContractsAssignmentTrace return, for each license type, the contracts considered
+	// by the assignment algorithm, the licenses assigned to each host and the licenses left uncovered
+	GetOracleDatabaseContractsAssignmentTrace(locations []string, licenseTypeID string) ([]dto.OracleDatabaseContractsAssignmentTrace, error)
+
+	ImportOracleDatabaseContracts(reader *csv.Reader, user string) error
 	GetLicenseContractSample(dbtype string) ([]byte, error)
+	GetLicenseContractSampleXLSX(dbtype string) (*excelize.File, error)
+	// ReadContractsXLSX converts the first sheet of the workbook to the csv read by the contracts import
+	ReadContractsXLSX(dbtype string, file io.Reader) (*csv.Reader, error)
+	// PreviewContractsImport validates every row of the csv and compares it with the existing contracts, without saving anything
+	PreviewContractsImport(databaseType string, reader *csv.Reader) (*dto.ContractImportPreview, error)
+	// CommitContractsImport saves the new and changed contracts of the csv, in a transaction, only if every row is valid
+	// and the changes are the same of the preview with hash
+	CommitContractsImport(databaseType string, reader *csv.Reader, hash string, user string) (*dto.ContractImportPreview, error)
+
+	// ListContractChanges return the changes of the contracts that match the filter
+	ListContractChanges(filter dto.ContractChangesFilter) ([]model.ContractChange, error)
+	// GetContractsAsOf return the contracts of every technology as they were on date
+	GetContractsAsOf(date time.Time) (*dto.ContractsAsOf, error)
+	// GetDatabaseLicensesComplianceAsOf return the compliance of the current licenses usage against the contracts as they were on date
+	GetDatabaseLicensesComplianceAsOf(date time.Time, locations []string) ([]dto.LicenseCompliance, error)
 
 	// ORACLE DATABASE LICENSES
 
 	GetOracleDatabaseLicenseTypes() ([]model.OracleDatabaseLicenseType, error)
 	GetOracleDatabaseLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error)
+	// GetOracleDatabaseContractsLicensesCompliance return the usage and compliance of the licenses of each contract
+	GetOracleDatabaseContractsLicensesCompliance(locations []string) ([]dto.OracleDatabaseContractLicenseCompliance, error)
 	DeleteOracleDatabaseLicenseType(id string) error
 	AddOracleDatabaseLicenseType(licenseType model.OracleDatabaseLicenseType) (*model.OracleDatabaseLicenseType, error)
 	UpdateOracleDatabaseLicenseType(licenseType model.OracleDatabaseLicenseType) (*model.OracleDatabaseLicenseType, error)
@@ -214,13 +244,31 @@ type APIServiceInterface interface {
 	SearchSqlServerInstancesAsXLSX(filter dto.SearchSqlServerInstancesFilter) (*excelize.File, error)
 
 	// SQL SERVER DATABASE CONTRACTS
-	AddSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error)
+	AddSqlServerDatabaseContract(contract model.SqlServerDatabaseContract, user string) (*model.SqlServerDatabaseContract, error)
 	GetSqlServerDatabaseContracts(locations []string) ([]model.SqlServerDatabaseContract, error)
 	GetSqlServerDatabaseContractsAsXLSX(locations []string) (*excelize.File, error)
-	DeleteSqlServerDatabaseContract(id primitive.ObjectID) error
-	UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract) (*model.SqlServerDatabaseContract, error)
+	DeleteSqlServerDatabaseContract(id primitive.ObjectID, user string) error
+	UpdateSqlServerDatabaseContract(contract model.SqlServerDatabaseContract, user string) (*model.SqlServerDatabaseContract, error)
+
+	ImportSQLServerDatabaseContracts(reader *csv.Reader, user string) error
+
+	// POSTGRESQL LICENSES
+	GetPostgreSQLLicenseTypes() ([]model.PostgreSQLLicenseType, error)
+	GetPostgreSQLLicenseTypesAsMap() (map[string]model.PostgreSQLLicenseType, error)
+	GetPostgreSQLLicenseType(id string) (*model.PostgreSQLLicenseType, error)
+	AddPostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) (*model.PostgreSQLLicenseType, error)
+	UpdatePostgreSQLLicenseType(licenseType model.PostgreSQLLicenseType) (*model.PostgreSQLLicenseType, error)
+	DeletePostgreSQLLicenseType(id string) error
+	GetPostgreSQLLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error)
 
-	ImportSQLServerDatabaseContracts(reader *csv.Reader) error
+	// POSTGRESQL CONTRACTS
+	AddPostgreSQLContract(contract model.PostgreSQLContract, user string) (*model.PostgreSQLContract, error)
+	GetPostgreSQLContracts(locations []string) ([]model.PostgreSQLContract, error)
+	GetPostgreSQLContractsAsXLSX(locations []string) (*excelize.File, error)
+	DeletePostgreSQLContract(id primitive.ObjectID, user string) error
+	UpdatePostgreSQLContract(contract model.PostgreSQLContract, user string) (*model.PostgreSQLContract, error)
+
+	ImportPostgreSQLContracts(reader *csv.Reader, user string) error
 
 	// AckAlerts ack the specified alerts
 	AckAlerts(alertsFilter dto.AlertsFilter) error
@@ -228,6 +276,21 @@ type APIServiceInterface interface {
 	DismissHost(hostname string) error
 
 	CreateDR(hostname string) (string, error)
+	ListDisasterRecoveryPairs() ([]dto.DisasterRecoveryPairStatus, error)
+	AddDisasterRecoveryPair(req dto.DisasterRecoveryPairRequest) (*model.DisasterRecoveryPair, error)
+	DeleteDisasterRecoveryPair(id primitive.ObjectID) error
+
+	ResolveHostname(name string) (*dto.ResolvedHostname, error)
+	ListHostAliases() ([]model.HostAlias, error)
+	AddHostAlias(req dto.HostAliasRequest) (*model.HostAlias, error)
+	DeleteHostAlias(id primitive.ObjectID) error
+	MergeHosts(req dto.HostMergeRequest, user string) error
+
+	ListCoreFactorPolicies() ([]model.CoreFactorPolicy, error)
+	GetCoreFactorPolicy(version int) (*model.CoreFactorPolicy, error)
+	GetActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error)
+	AddCoreFactorPolicy(req dto.CoreFactorPolicyRequest) (*model.CoreFactorPolicy, error)
+	ActivateCoreFactorPolicy(version int) error
 
 	GetMissingDatabases() ([]dto.OracleDatabaseMissingDbs, error)
 	GetMissingDatabasesByHostname(hostname string) ([]model.MissingDatabase, error)
@@ -265,6 +328,12 @@ type APIServiceInterface interface {
 	GetUsedLicensesPerClusterAsXLSX(filter dto.GlobalFilter) (*excelize.File, error)
 	GetDatabaseLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error)
 	GetDatabaseLicensesComplianceAsXLSX(locations []string) (*excelize.File, error)
+	// GetLicensesCost return the current spend on the licenses and the cost of the consumption not covered by them,
+	// by license type, technology, location, environment and tag
+	GetLicensesCost(locations []string) (*dto.LicensesCost, error)
+	GetLicensesCostAsXLSX(locations []string) (*excelize.File, error)
+	// SimulateLicensesCompliance return the compliance of every license type before and after the changes of req
+	SimulateLicensesCompliance(req dto.LicenseSimulationRequest) (*dto.LicenseSimulation, error)
 
 	GetClusterVeritasLicenses(filter dto.GlobalFilter) ([]dto.ClusterVeritasLicense, error)
 	GetClusterVeritasLicensesXlsx(filter dto.GlobalFilter) (*excelize.File, error)
@@ -280,13 +349,13 @@ type APIServiceInterface interface {
 
 	// MYSQL CONTRACTS
 
-	AddMySQLContract(contract model.MySQLContract) (*model.MySQLContract, error)
-	UpdateMySQLContract(contract model.MySQLContract) (*model.MySQLContract, error)
+	AddMySQLContract(contract model.MySQLContract, user string) (*model.MySQLContract, error)
+	UpdateMySQLContract(contract model.MySQLContract, user string) (*model.MySQLContract, error)
 	GetMySQLContracts(locations []string) ([]model.MySQLContract, error)
 	GetMySQLContractsAsXLSX(locations []string) (*excelize.File, error)
-	DeleteMySQLContract(id primitive.ObjectID) error
+	DeleteMySQLContract(id primitive.ObjectID, user string) error
 
-	ImportMySQLDatabaseContracts(reader *csv.Reader) error
+	ImportMySQLDatabaseContracts(reader *csv.Reader, user string) error
 
 	// POSTGRESQL
 	// SearchSqlServerInstances search databases
@@ -300,6 +369,50 @@ type APIServiceInterface interface {
 	// SearchOracleDatabases search databases
 	SearchMongoDBInstancesAsXLSX(filter dto.SearchMongoDBInstancesFilter) (*excelize.File, error)
 
+	// MONGODB LICENSES
+	GetMongoDBLicenseTypes() ([]model.MongoDBLicenseType, error)
+	GetMongoDBLicenseTypesAsMap() (map[string]model.MongoDBLicenseType, error)
+	GetMongoDBLicenseType(id string) (*model.MongoDBLicenseType, error)
+	AddMongoDBLicenseType(licenseType model.MongoDBLicenseType) (*model.MongoDBLicenseType, error)
+	UpdateMongoDBLicenseType(licenseType model.MongoDBLicenseType) (*model.MongoDBLicenseType, error)
+	DeleteMongoDBLicenseType(id string) error
+	GetMongoDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error)
+	GetMongoDBLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error)
+
+	// MONGODB CONTRACTS
+	AddMongoDBContract(contract model.MongoDBContract, user string) (*model.MongoDBContract, error)
+	GetMongoDBContracts(locations []string) ([]model.MongoDBContract, error)
+	GetMongoDBContractsAsXLSX(locations []string) (*excelize.File, error)
+	DeleteMongoDBContract(id primitive.ObjectID, user string) error
+	UpdateMongoDBContract(contract model.MongoDBContract, user string) (*model.MongoDBContract, error)
+
+	ImportMongoDBContracts(reader *csv.Reader, user string) error
+
+	// MARIADB
+	// SearchMariaDBInstances search instances
+	SearchMariaDBInstances(filter dto.SearchMariaDBInstancesFilter) (*dto.MariaDBInstanceResponse, error)
+	// SearchMariaDBInstancesAsXLSX search instances returning them as XLSX
+	SearchMariaDBInstancesAsXLSX(filter dto.SearchMariaDBInstancesFilter) (*excelize.File, error)
+
+	// MARIADB LICENSES
+	GetMariaDBLicenseTypes() ([]model.MariaDBLicenseType, error)
+	GetMariaDBLicenseTypesAsMap() (map[string]model.MariaDBLicenseType, error)
+	GetMariaDBLicenseType(id string) (*model.MariaDBLicenseType, error)
+	AddMariaDBLicenseType(licenseType model.MariaDBLicenseType) (*model.MariaDBLicenseType, error)
+	UpdateMariaDBLicenseType(licenseType model.MariaDBLicenseType) (*model.MariaDBLicenseType, error)
+	DeleteMariaDBLicenseType(id string) error
+	GetMariaDBUsedLicenses(hostname string, filter dto.GlobalFilter) ([]dto.DatabaseUsedLicense, error)
+	GetMariaDBLicensesCompliance(locations []string) ([]dto.LicenseCompliance, error)
+
+	// MARIADB CONTRACTS
+	AddMariaDBContract(contract model.MariaDBContract, user string) (*model.MariaDBContract, error)
+	GetMariaDBContracts(locations []string) ([]model.MariaDBContract, error)
+	GetMariaDBContractsAsXLSX(locations []string) (*excelize.File, error)
+	DeleteMariaDBContract(id primitive.ObjectID, user string) error
+	UpdateMariaDBContract(contract model.MariaDBContract, user string) (*model.MariaDBContract, error)
+
+	ImportMariaDBContracts(reader *csv.Reader, user string) error
+
 	// ROLES
 	GetRole(name string) (*model.Role, error)
 	GetRoles() ([]model.Role, error)
@@ -332,6 +445,11 @@ type APIServiceInterface interface {
 	MatchPassword(user *model.User, password string) bool
 	GetUserLocations(username string) ([]string, error)
 
+	ListAgentCredentials() ([]model.AgentCredential, error)
+	AddAgentCredential(req dto.AgentCredentialRequest) (*dto.AgentCredentialKey, error)
+	RotateAgentCredentialKey(id primitive.ObjectID) (*dto.AgentCredentialKey, error)
+	RevokeAgentCredential(id primitive.ObjectID) error
+
 	GetNodes(groups []string) ([]model.Node, error)
 	GetNode(name string) (*model.Node, error)
 	AddNode(node model.Node) error
@@ -72,44 +72,26 @@ func (as *APIService) ListManagedTechnologies(sortBy string, sortDesc bool, loca
 
 	statuses = append(statuses, *sqlServerStatus)
 
-	postgreSQLStatus := model.TechnologyStatus{
-		Product:            model.TechnologyPostgreSQLPostgreSQL,
-		ConsumedByHosts:    0,
-		CoveredByContracts: 0,
-		TotalCost:          0,
-		PaidCost:           0,
-		Compliance:         1,
-		UnpaidDues:         0,
-		HostsCount:         int(hostsCountByTechnology[model.TechnologyPostgreSQLPostgreSQL]),
+	postgreSQLStatus, err := createPostgreSQLTechnologyStatus(as, hostsCountByTechnology[model.TechnologyPostgreSQLPostgreSQL])
+	if err != nil {
+		return nil, err
 	}
 
-	statuses = append(statuses, postgreSQLStatus)
+	statuses = append(statuses, *postgreSQLStatus)
 
-	mongoDBStatus := model.TechnologyStatus{
-		Product:            model.TechnologyMongoDBMongoDB,
-		ConsumedByHosts:    0,
-		CoveredByContracts: 0,
-		TotalCost:          0,
-		PaidCost:           0,
-		Compliance:         1,
-		UnpaidDues:         0,
-		HostsCount:         int(hostsCountByTechnology[model.TechnologyMongoDBMongoDB]),
+	mongoDBStatus, err := createMongoDBTechnologyStatus(as, hostsCountByTechnology[model.TechnologyMongoDBMongoDB])
+	if err != nil {
+		return nil, err
 	}
 
-	statuses = append(statuses, mongoDBStatus)
+	statuses = append(statuses, *mongoDBStatus)
 
-	mariaDBStatus := model.TechnologyStatus{
-		Product:            model.TechnologyMariaDBFoundationMariaDB,
-		ConsumedByHosts:    0,
-		CoveredByContracts: 0,
-		TotalCost:          0,
-		PaidCost:           0,
-		Compliance:         1,
-		UnpaidDues:         0,
-		HostsCount:         0,
+	mariaDBStatus, err := createMariaDBTechnologyStatus(as, hostsCountByTechnology[model.TechnologyMariaDBFoundationMariaDB])
+	if err != nil {
+		return nil, err
 	}
 
-	statuses = append(statuses, mariaDBStatus)
+	statuses = append(statuses, *mariaDBStatus)
 
 	return statuses, nil
 }
@@ -200,3 +182,56 @@ func createMySqlTechnologyStatus(as *APIService, hostsCount float64) (*model.Tec
 
 	return &status, nil
 }
+
+func createPostgreSQLTechnologyStatus(as *APIService, hostsCount float64) (*model.TechnologyStatus, error) {
+	licensesCompliance, err := as.GetPostgreSQLLicensesCompliance([]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	return createSubscriptionsTechnologyStatus(model.TechnologyPostgreSQLPostgreSQL, hostsCount, licensesCompliance), nil
+}
+
+func createMongoDBTechnologyStatus(as *APIService, hostsCount float64) (*model.TechnologyStatus, error) {
+	licensesCompliance, err := as.GetMongoDBLicensesCompliance([]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	return createSubscriptionsTechnologyStatus(model.TechnologyMongoDBMongoDB, hostsCount, licensesCompliance), nil
+}
+
+func createMariaDBTechnologyStatus(as *APIService, hostsCount float64) (*model.TechnologyStatus, error) {
+	licensesCompliance, err := as.GetMariaDBLicensesCompliance([]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	return createSubscriptionsTechnologyStatus(model.TechnologyMariaDBFoundationMariaDB, hostsCount, licensesCompliance), nil
+}
+
+// createSubscriptionsTechnologyStatus return the status of a technology licensed by subscriptions with a cost,
+// where the unpaid dues are the cost of the subscriptions consumed but not covered by contracts
+func createSubscriptionsTechnologyStatus(product string, hostsCount float64, licensesCompliance []dto.LicenseCompliance) *model.TechnologyStatus {
+	status := model.TechnologyStatus{
+		Product:    product,
+		HostsCount: int(hostsCount),
+	}
+
+	for _, licenseCompliance := range licensesCompliance {
+		status.ConsumedByHosts += licenseCompliance.Consumed
+		status.CoveredByContracts += licenseCompliance.Covered
+		status.TotalCost += licenseCompliance.Consumed * licenseCompliance.Cost
+		status.PaidCost += licenseCompliance.Covered * licenseCompliance.Cost
+	}
+
+	status.UnpaidDues = status.TotalCost - status.PaidCost
+
+	if status.ConsumedByHosts == 0 {
+		status.Compliance = 1
+	} else {
+		status.Compliance = status.CoveredByContracts / status.ConsumedByHosts
+	}
+
+	return &status
+}
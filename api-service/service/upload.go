@@ -15,17 +15,26 @@
 package service
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/schema"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
 	"github.com/gocarina/gocsv"
 )
 
-func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader) error {
+func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader, user string) error {
 	contracts := make([]model.OracleDatabaseContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
@@ -46,7 +55,7 @@ func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader) error {
 			contract.Hosts = strings.Split(string(contract.HostsLiteral), ",")
 		}
 
-		if _, err := as.AddOracleDatabaseContract(contract); err != nil {
+		if _, err := as.AddOracleDatabaseContract(contract, user); err != nil {
 			return err
 		}
 	}
@@ -54,7 +63,7 @@ func (as *APIService) ImportOracleDatabaseContracts(reader *csv.Reader) error {
 	return nil
 }
 
-func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader) error {
+func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader, user string) error {
 	contracts := make([]model.SqlServerDatabaseContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
@@ -70,7 +79,7 @@ func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader) error
 			contract.Clusters = strings.Split(string(contract.ClusterLiteral), "|||")
 		}
 
-		if _, err := as.AddSqlServerDatabaseContract(contract); err != nil {
+		if _, err := as.AddSqlServerDatabaseContract(contract, user); err != nil {
 			return err
 		}
 	}
@@ -78,7 +87,7 @@ func (as *APIService) ImportSQLServerDatabaseContracts(reader *csv.Reader) error
 	return nil
 }
 
-func (as *APIService) ImportMySQLDatabaseContracts(reader *csv.Reader) error {
+func (as *APIService) ImportMySQLDatabaseContracts(reader *csv.Reader, user string) error {
 	contracts := make([]model.MySQLContract, 0)
 
 	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
@@ -94,7 +103,75 @@ func (as *APIService) ImportMySQLDatabaseContracts(reader *csv.Reader) error {
 			contract.Clusters = strings.Split(string(contract.ClusterLiteral), "|||")
 		}
 
-		if _, err := as.AddMySQLContract(contract); err != nil {
+		if _, err := as.AddMySQLContract(contract, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (as *APIService) ImportPostgreSQLContracts(reader *csv.Reader, user string) error {
+	contracts := make([]model.PostgreSQLContract, 0)
+
+	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		if len(contract.HostsLiteral) > 0 {
+			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+		}
+
+		if len(contract.ClusterLiteral) > 0 {
+			contract.Clusters = strings.Split(string(contract.ClusterLiteral), "|||")
+		}
+
+		if _, err := as.AddPostgreSQLContract(contract, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (as *APIService) ImportMongoDBContracts(reader *csv.Reader, user string) error {
+	contracts := make([]model.MongoDBContract, 0)
+
+	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		if len(contract.HostsLiteral) > 0 {
+			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+		}
+
+		if len(contract.ReplicaSetsLiteral) > 0 {
+			contract.ReplicaSets = strings.Split(string(contract.ReplicaSetsLiteral), "|||")
+		}
+
+		if _, err := as.AddMongoDBContract(contract, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (as *APIService) ImportMariaDBContracts(reader *csv.Reader, user string) error {
+	contracts := make([]model.MariaDBContract, 0)
+
+	if err := gocsv.UnmarshalCSV(reader, &contracts); err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		if len(contract.HostsLiteral) > 0 {
+			contract.Hosts = strings.Split(string(contract.HostsLiteral), "|||")
+		}
+
+		if _, err := as.AddMariaDBContract(contract, user); err != nil {
 			return err
 		}
 	}
@@ -113,7 +190,211 @@ func (as *APIService) GetLicenseContractSample(dbtype string) ([]byte, error) {
 	case "mysql":
 		empData := []model.MySQLContract{}
 		return gocsv.MarshalBytes(empData)
+	case "postgresql":
+		empData := []model.PostgreSQLContract{}
+		return gocsv.MarshalBytes(empData)
+	case "mongodb":
+		empData := []model.MongoDBContract{}
+		return gocsv.MarshalBytes(empData)
+	case "mariadb":
+		empData := []model.MariaDBContract{}
+		return gocsv.MarshalBytes(empData)
 	default:
 		return nil, fmt.Errorf("cannot match database type: %s", dbtype)
 	}
 }
+
+func (as *APIService) GetLicenseContractSampleXLSX(dbtype string) (*excelize.File, error) {
+	contract, err := contractSample(dbtype)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := contractCSVFields(contract)
+
+	headers := make([]string, 0, len(fields))
+	for _, field := range fields {
+		headers = append(headers, field.header)
+	}
+
+	return exutils.NewXLSX(as.Config, "Contracts", headers...)
+}
+
+// ReadContractsXLSX converts the first sheet of the workbook to the csv read by the contracts import.
+// Columns are matched to the csv headers ignoring case and surrounding spaces,
+// dates, numbers and booleans are converted to the format of the csv
+func (as *APIService) ReadContractsXLSX(dbtype string, file io.Reader) (*csv.Reader, error) {
+	contract, err := contractSample(dbtype)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := exutils.ReadFirstSheet(file)
+	if err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidContractImport, err)
+	}
+
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+	fields := contractCSVFields(contract)
+
+	var types []reflect.Type
+
+	for _, row := range rows {
+		// empty lines are skipped by the csv reader but keep the numbering of the rows of the sheet
+		if isEmptyRow(row) {
+			buf.WriteString("\n")
+			continue
+		}
+
+		if types == nil {
+			types = make([]reflect.Type, len(row))
+
+			header := make([]string, len(row))
+			for i, cell := range row {
+				header[i] = strings.TrimSpace(cell.Value)
+
+				for _, field := range fields {
+					if strings.EqualFold(header[i], field.header) {
+						header[i] = field.header
+						types[i] = field.typ
+					}
+				}
+			}
+
+			if err := writer.Write(header); err != nil {
+				return nil, err
+			}
+
+			writer.Flush()
+
+			continue
+		}
+
+		record := make([]string, len(types))
+		for i := range record {
+			if i < len(row) {
+				record[i] = normalizeContractCell(row[i], types[i])
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+
+		writer.Flush()
+	}
+
+	if types == nil {
+		return nil, utils.NewErrorf("%w: the sheet is empty", utils.ErrInvalidContractImport)
+	}
+
+	return csv.NewReader(&buf), nil
+}
+
+func contractSample(dbtype string) (interface{}, error) {
+	switch dbtype {
+	case "oracle":
+		return model.OracleDatabaseContract{}, nil
+	case "sqlserver":
+		return model.SqlServerDatabaseContract{}, nil
+	case "mysql":
+		return model.MySQLContract{}, nil
+	case "postgresql":
+		return model.PostgreSQLContract{}, nil
+	case "mongodb":
+		return model.MongoDBContract{}, nil
+	case "mariadb":
+		return model.MariaDBContract{}, nil
+	default:
+		return nil, utils.NewErrorf("%w: cannot match database type: %s", utils.ErrInvalidContractImport, dbtype)
+	}
+}
+
+type contractCSVField struct {
+	header string
+	typ    reflect.Type
+}
+
+// contractCSVFields return the fields of the contract with a csv header, in order
+func contractCSVFields(contract interface{}) []contractCSVField {
+	t := reflect.TypeOf(contract)
+	fields := make([]contractCSVField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		header := strings.Split(t.Field(i).Tag.Get("csv"), ",")[0]
+		if header == "" || header == "-" {
+			continue
+		}
+
+		typ := t.Field(i).Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		fields = append(fields, contractCSVField{header: header, typ: typ})
+	}
+
+	return fields
+}
+
+// normalizeContractCell converts the value of the cell to the format of the csv, leaving it as it is if it can't be parsed
+func normalizeContractCell(cell exutils.Cell, typ reflect.Type) string {
+	value := strings.TrimSpace(cell.Value)
+	if value == "" || typ == nil {
+		return value
+	}
+
+	cell.Value = value
+
+	switch {
+	case isDateType(typ):
+		if date, err := exutils.ParseDateCell(cell); err == nil {
+			return date.Format(model.ContractCSVDateFormat)
+		}
+	case typ.Kind() == reflect.Bool:
+		if b, err := exutils.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		if n, err := exutils.ParseNumberCell(cell); err == nil && n == math.Trunc(n) {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		if n, err := exutils.ParseNumberCell(cell); err == nil {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	}
+
+	return value
+}
+
+func isDateType(typ reflect.Type) bool {
+	timeType := reflect.TypeOf(time.Time{})
+	if typ == timeType {
+		return true
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Anonymous && typ.Field(i).Type == timeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isEmptyRow(row []exutils.Cell) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell.Value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadContractsXLSX(t *testing.T) {
+	as := APIService{}
+
+	xlsx := excelize.NewFile()
+	rows := [][]interface{}{
+		{" contract number ", "CSI", "PART NUMBER", "ULA", "License number", "Support Expiration", "Notes"},
+		{},
+		{"AID001", "CSI001", "A90611", "yes", "1.000.000", "31/12/2024", "first"},
+		{"AID002", "CSI002", "A90611", "no", "12,0", "2025-06-30", ""},
+		{"AID003", "CSI003", "A90611", "no", 1500, 45838, 0.125},
+		{"AID004", "CSI004", "A90611", "no", 0.125, 45838, ""},
+	}
+
+	for i, row := range rows {
+		for j, value := range row {
+			xlsx.SetCellValue("Sheet1", excelize.ToAlphaString(j)+string(rune('1'+i)), value)
+		}
+	}
+
+	dateStyle, err := xlsx.NewStyle(`{"number_format": 14}`)
+	require.NoError(t, err)
+	xlsx.SetCellStyle("Sheet1", "F5", "F5", dateStyle)
+
+	var buf bytes.Buffer
+	require.NoError(t, xlsx.Write(&buf))
+
+	reader, err := as.ReadContractsXLSX("oracle", &buf)
+	require.NoError(t, err)
+
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"Contract Number", "CSI", "Part Number", "ULA", "License number", "Support Expiration", "Notes"},
+		{"AID001", "CSI001", "A90611", "true", "1000000", "31/12/2024", "first"},
+		{"AID002", "CSI002", "A90611", "false", "12", "30/06/2025", ""},
+		{"AID003", "CSI003", "A90611", "false", "1500", "30/06/2025", "0.125"},
+		{"AID004", "CSI004", "A90611", "false", "0.125", "45838", ""},
+	}, records)
+
+	line, _ := reader.FieldPos(0)
+	assert.Equal(t, 6, line)
+}
+
+func TestReadContractsXLSX_InvalidFile(t *testing.T) {
+	as := APIService{}
+
+	_, err := as.ReadContractsXLSX("oracle", bytes.NewReader([]byte("not a workbook")))
+	assert.Error(t, err)
+
+	_, err = as.ReadContractsXLSX("postgresql", bytes.NewReader([]byte{}))
+	assert.Error(t, err)
+}
@@ -32,6 +32,10 @@ type ChartControllerInterface interface {
 	// GetOracleDatabaseChart return the chart data related to oracle databases
 	GetOracleDatabaseChart(w http.ResponseWriter, r *http.Request)
 	GetLicenseComplianceHistory(w http.ResponseWriter, r *http.Request)
+	// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location
+	GetLocationsLicenseComplianceHistory(w http.ResponseWriter, r *http.Request)
+	// GetContractsLicenseComplianceHistory return the licenses compliance history of each Oracle database contract
+	GetContractsLicenseComplianceHistory(w http.ResponseWriter, r *http.Request)
 
 	// GetChangeChart return the chart data related to changes
 	GetChangeChart(w http.ResponseWriter, r *http.Request)
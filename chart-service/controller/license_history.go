@@ -16,8 +16,13 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/golang/gddo/httputil"
+
+	"github.com/ercole-io/ercole/v2/chart-service/dto"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
@@ -46,3 +51,145 @@ func (ctrl *ChartController) GetLicenseComplianceHistory(w http.ResponseWriter,
 
 	utils.WriteJSONResponse(w, http.StatusOK, response)
 }
+
+// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location
+func (ctrl *ChartController) GetLocationsLicenseComplianceHistory(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseLicenseComplianceHistoryFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := ctrl.Service.GetLocationsLicenseComplianceHistory(*filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licenseComplianceHistory": history,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// GetContractsLicenseComplianceHistory return the licenses compliance history of each Oracle database contract
+func (ctrl *ChartController) GetContractsLicenseComplianceHistory(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseLicenseComplianceHistoryFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := ctrl.Service.GetContractsLicenseComplianceHistory(*filter)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"licenseComplianceHistory": history,
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func parseLicenseComplianceHistoryFilter(r *http.Request) (*dto.LicenseComplianceHistoryFilter, error) {
+	var err error
+
+	query := r.URL.Query()
+
+	filter := dto.LicenseComplianceHistoryFilter{
+		Locations:     []string{},
+		LicenseTypeID: query.Get("license-type-id"),
+		ContractID:    query.Get("contract-id"),
+		CSI:           query.Get("csi"),
+		Aggregation:   query.Get("aggregation"),
+	}
+
+	if location := query.Get("location"); location != "" {
+		filter.Locations = strings.Split(location, ",")
+	}
+
+	if filter.Start, err = utils.Str2time(query.Get("start"), utils.MIN_TIME); err != nil {
+		return nil, err
+	}
+
+	if filter.End, err = utils.Str2time(query.Get("end"), utils.MAX_TIME); err != nil {
+		return nil, err
+	}
+
+	switch filter.Aggregation {
+	case "":
+		filter.Aggregation = dto.LicenseComplianceHistoryAggregationDaily
+	case dto.LicenseComplianceHistoryAggregationDaily,
+		dto.LicenseComplianceHistoryAggregationWeekly,
+		dto.LicenseComplianceHistoryAggregationMonthly:
+	default:
+		return nil, utils.NewError(errors.New("Unsupported aggregation"), "UNSUPPORTED_AGGREGATION")
+	}
+
+	return &filter, nil
+}
+
+// GetLicensesCostForecast return the monthly history of the licenses cost and its projection
+func (ctrl *ChartController) GetLicensesCostForecast(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseLicensesCostForecastFilter(r)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	choice := httputil.NegotiateContentType(r, []string{"application/json", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}, "application/json")
+
+	switch choice {
+	case "application/json":
+		forecast, err := ctrl.Service.GetLicensesCostForecast(*filter)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteJSONResponse(w, http.StatusOK, forecast)
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		xlsx, err := ctrl.Service.GetLicensesCostForecastAsXLSX(*filter)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		utils.WriteXLSXResponse(w, xlsx)
+	}
+}
+
+func parseLicensesCostForecastFilter(r *http.Request) (*dto.LicensesCostForecastFilter, error) {
+	var err error
+
+	query := r.URL.Query()
+
+	filter := dto.LicensesCostForecastFilter{
+		Locations: []string{},
+	}
+
+	if location := query.Get("location"); location != "" {
+		filter.Locations = strings.Split(location, ",")
+	}
+
+	if filter.Start, err = utils.Str2time(query.Get("start"), utils.MIN_TIME); err != nil {
+		return nil, err
+	}
+
+	if filter.End, err = utils.Str2time(query.Get("end"), utils.MAX_TIME); err != nil {
+		return nil, err
+	}
+
+	if filter.Months, err = utils.Str2int(query.Get("months"), 12); err != nil {
+		return nil, err
+	}
+
+	if filter.Months < 1 || filter.Months > 120 {
+		return nil, utils.NewError(errors.New("Unsupported number of months"), "UNSUPPORTED_MONTHS")
+	}
+
+	return &filter, nil
+}
@@ -20,6 +20,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
@@ -90,3 +91,261 @@ func TestGetLicenseComplianceHistory_InternalServerError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
+
+func TestGetLocationsLicenseComplianceHistory_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	history := []dto.LocationLicenseComplianceHistory{
+		{
+			Location: "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "A90611",
+				History:       []dto.LicenseComplianceHistoricValue{},
+			},
+		},
+	}
+
+	filter := dto.LicenseComplianceHistoryFilter{
+		Locations:     []string{"Italy", "Germany"},
+		LicenseTypeID: "A90611",
+		Start:         utils.P("2021-01-01T00:00:00Z"),
+		End:           utils.MAX_TIME,
+		Aggregation:   dto.LicenseComplianceHistoryAggregationWeekly,
+	}
+
+	as.EXPECT().GetLocationsLicenseComplianceHistory(filter).
+		Return(history, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLocationsLicenseComplianceHistory)
+	req, err := http.NewRequest("GET", "/?location=Italy,Germany&license-type-id=A90611&start=2021-01-01T00:00:00Z&aggregation=weekly", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"licenseComplianceHistory": history,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestGetLocationsLicenseComplianceHistory_UnsupportedAggregation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLocationsLicenseComplianceHistory)
+	req, err := http.NewRequest("GET", "/?aggregation=yearly", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetContractsLicenseComplianceHistory_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	history := []dto.ContractLicenseComplianceHistory{
+		{
+			ContractID: "AID001",
+			CSI:        "CSI001",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "A90611",
+				History:       []dto.LicenseComplianceHistoricValue{},
+			},
+		},
+	}
+
+	filter := dto.LicenseComplianceHistoryFilter{
+		Locations:   []string{},
+		ContractID:  "AID001",
+		CSI:         "CSI001",
+		Start:       utils.MIN_TIME,
+		End:         utils.MAX_TIME,
+		Aggregation: dto.LicenseComplianceHistoryAggregationDaily,
+	}
+
+	as.EXPECT().GetContractsLicenseComplianceHistory(filter).
+		Return(history, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetContractsLicenseComplianceHistory)
+	req, err := http.NewRequest("GET", "/?contract-id=AID001&csi=CSI001", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	expected := map[string]interface{}{
+		"licenseComplianceHistory": history,
+	}
+	assert.JSONEq(t, utils.ToJSON(expected), rr.Body.String())
+}
+
+func TestGetContractsLicenseComplianceHistory_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().GetContractsLicenseComplianceHistory(gomock.Any()).
+		Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetContractsLicenseComplianceHistory)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestGetLicensesCostForecast_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	filter := dto.LicensesCostForecastFilter{
+		Locations: []string{"Italy", "Germany"},
+		Start:     utils.P("2019-01-01T00:00:00Z"),
+		End:       utils.MAX_TIME,
+		Months:    6,
+	}
+	forecast := &dto.LicensesCostForecast{
+		History: []dto.LicensesCostValue{
+			{Date: utils.P("2019-01-01T00:00:00Z"), Spend: 1000, Exposure: 200},
+		},
+		Forecast: []dto.LicensesCostValue{
+			{Date: utils.P("2019-02-01T00:00:00Z"), Spend: 1000, Exposure: 200},
+		},
+		LicenseTypes: []dto.LicenseTypeCostForecast{},
+	}
+	as.EXPECT().GetLicensesCostForecast(filter).Return(forecast, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLicensesCostForecast)
+	req, err := http.NewRequest("GET", "/?location=Italy,Germany&start=2019-01-01T00:00:00Z&months=6", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(forecast), rr.Body.String())
+}
+
+func TestGetLicensesCostForecast_XLSX(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	filter := dto.LicensesCostForecastFilter{
+		Locations: []string{},
+		Start:     utils.MIN_TIME,
+		End:       utils.MAX_TIME,
+		Months:    12,
+	}
+	xlsx := excelize.File{}
+	as.EXPECT().GetLicensesCostForecastAsXLSX(filter).Return(&xlsx, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLicensesCostForecast)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.Header.Add("Accept", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	_, err = excelize.OpenReader(rr.Body)
+	require.NoError(t, err)
+}
+
+func TestGetLicensesCostForecast_UnsupportedMonths(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLicensesCostForecast)
+	req, err := http.NewRequest("GET", "/?months=0", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetLicensesCostForecast_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockChartServiceInterface(mockCtrl)
+	ac := ChartController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().GetLicensesCostForecast(gomock.Any()).Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetLicensesCostForecast)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -53,6 +53,9 @@ func (ctrl *ChartController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/settings/technologies-metrics", ctrl.GetTechnologiesMetrics).Methods("GET")
 
 	router.HandleFunc("/technologies/all/license-history", ctrl.GetLicenseComplianceHistory).Methods("GET")
+	router.HandleFunc("/technologies/all/license-history/locations", ctrl.GetLocationsLicenseComplianceHistory).Methods("GET")
+	router.HandleFunc("/technologies/all/license-cost-forecast", ctrl.GetLicensesCostForecast).Methods("GET")
+	router.HandleFunc("/technologies/oracle/database/license-history/contracts", ctrl.GetContractsLicenseComplianceHistory).Methods("GET")
 	router.HandleFunc("/technologies/oracle/database", ctrl.GetOracleDatabaseChart).Methods("GET")
 
 	router.HandleFunc("/technologies/changes", ctrl.GetChangeChart).Methods("GET")
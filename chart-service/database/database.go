@@ -43,6 +43,10 @@ type MongoDatabaseInterface interface {
 	// GetOracleDatabaseChartByWork return the chart data about the work of all database
 	GetOracleDatabaseChartByWork(location string, environment string, olderThan time.Time) ([]dto.ChartBubble, error)
 	GetLicenseComplianceHistory(start, end time.Time) ([]dto.LicenseComplianceHistory, error)
+	// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location matching filter
+	GetLocationsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.LocationLicenseComplianceHistory, error)
+	// GetContractsLicenseComplianceHistory return the licenses compliance history of each contract matching filter
+	GetContractsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.ContractLicenseComplianceHistory, error)
 
 	GetHostCores(location, environment string, olderThan, newerThan time.Time) ([]dto.HostCores, error)
 }
@@ -97,3 +97,99 @@ func (md *MongoDatabase) GetLicenseComplianceHistory(start, end time.Time) ([]dt
 
 	return items, nil
 }
+
+// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location matching filter
+func (md *MongoDatabase) GetLocationsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.LocationLicenseComplianceHistory, error) {
+	pipeline := licenseComplianceHistoryPipeline(filter, "location", "licenseTypeID", "itemDescription", "metric")
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection("database_licenses_history_by_location").
+		Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]dto.LocationLicenseComplianceHistory, 0)
+
+	err = cur.All(context.TODO(), &items)
+	if err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return items, nil
+}
+
+// GetContractsLicenseComplianceHistory return the licenses compliance history of each contract matching filter
+func (md *MongoDatabase) GetContractsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.ContractLicenseComplianceHistory, error) {
+	pipeline := licenseComplianceHistoryPipeline(filter, "contractID", "csi", "location", "licenseTypeID", "itemDescription", "metric")
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection("database_licenses_history_by_contract").
+		Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]dto.ContractLicenseComplianceHistory, 0)
+
+	err = cur.All(context.TODO(), &items)
+	if err != nil {
+		return nil, utils.NewError(err, "Decode ERROR")
+	}
+
+	return items, nil
+}
+
+// licenseComplianceHistoryPipeline return the pipeline which match the documents of filter,
+// projecting fields and the history between filter.Start and filter.End
+func licenseComplianceHistoryPipeline(filter dto.LicenseComplianceHistoryFilter, fields ...string) bson.A {
+	match := bson.D{}
+
+	if len(filter.Locations) > 0 {
+		match = append(match, bson.E{Key: "location", Value: bson.D{{Key: "$in", Value: filter.Locations}}})
+	}
+
+	if filter.LicenseTypeID != "" {
+		match = append(match, bson.E{Key: "licenseTypeID", Value: filter.LicenseTypeID})
+	}
+
+	if filter.ContractID != "" {
+		match = append(match, bson.E{Key: "contractID", Value: filter.ContractID})
+	}
+
+	if filter.CSI != "" {
+		match = append(match, bson.E{Key: "csi", Value: filter.CSI})
+	}
+
+	project := bson.D{}
+	for _, field := range fields {
+		project = append(project, bson.E{Key: field, Value: 1})
+	}
+
+	project = append(project, bson.E{
+		Key: "history",
+		Value: bson.D{
+			{Key: "$filter",
+				Value: bson.D{
+					{Key: "input", Value: "$history"},
+					{Key: "as", Value: "item"},
+					{Key: "cond",
+						Value: bson.D{
+							{Key: "$and",
+								Value: bson.A{
+									bson.D{{Key: "$gt", Value: bson.A{"$$item.date", filter.Start}}},
+									bson.D{{Key: "$lt", Value: bson.A{"$$item.date", filter.End}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	return bson.A{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$project", Value: project}},
+	}
+}
@@ -55,3 +55,48 @@ func (m *MongodbSuite) TestGetLicenseComplianceHistory() {
 		assert.JSONEq(t, utils.ToJSON(expectedOut), utils.ToJSON(out))
 	})
 }
+
+func (m *MongodbSuite) TestGetLocationsLicenseComplianceHistory() {
+	collection := m.db.Client.Database(m.dbname).Collection("database_licenses_history_by_location")
+	defer collection.DeleteMany(context.TODO(), bson.M{})
+
+	_, err := collection.InsertMany(context.TODO(), []interface{}{
+		bson.M{
+			"location": "Italy", "licenseTypeID": "A90611", "itemDescription": "TEST", "metric": "TEST",
+			"history": bson.A{
+				bson.M{"date": utils.P("2019-06-24T00:00:00Z"), "consumed": 10.0, "covered": 10.0, "purchased": 20.0},
+				bson.M{"date": utils.P("2020-06-24T00:00:00Z"), "consumed": 12.0, "covered": 10.0, "purchased": 20.0},
+			},
+		},
+		bson.M{
+			"location": "Germany", "licenseTypeID": "A90611", "itemDescription": "TEST", "metric": "TEST",
+			"history": bson.A{
+				bson.M{"date": utils.P("2019-06-24T00:00:00Z"), "consumed": 1.0, "covered": 1.0, "purchased": 1.0},
+			},
+		},
+	})
+	m.Require().NoError(err)
+
+	out, err := m.db.GetLocationsLicenseComplianceHistory(dto.LicenseComplianceHistoryFilter{
+		Locations:     []string{"Italy"},
+		LicenseTypeID: "A90611",
+		Start:         utils.P("2020-01-01T00:00:00Z"),
+		End:           utils.MAX_TIME,
+	})
+	m.Require().NoError(err)
+
+	expectedOut := []dto.LocationLicenseComplianceHistory{
+		{
+			Location: "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID:   "A90611",
+				ItemDescription: "TEST",
+				Metric:          "TEST",
+				History: []dto.LicenseComplianceHistoricValue{
+					{Date: utils.P("2020-06-24T00:00:00Z"), Consumed: 12, Covered: 10, Purchased: 20},
+				},
+			},
+		},
+	}
+	assert.JSONEq(m.T(), utils.ToJSON(expectedOut), utils.ToJSON(out))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package dto
+
+import "time"
+
+// LicensesCostForecastFilter contains the filters of the licenses cost forecast.
+// Empty values don't filter
+type LicensesCostForecastFilter struct {
+	Locations []string
+	Start     time.Time
+	End       time.Time
+	// Months is the number of months projected after the last month of the history
+	Months int
+}
+
+// LicensesCostForecast contains the monthly history of the licenses cost and its projection
+type LicensesCostForecast struct {
+	History      []LicensesCostValue       `json:"history"`
+	Forecast     []LicensesCostValue       `json:"forecast"`
+	LicenseTypes []LicenseTypeCostForecast `json:"licenseTypes"`
+}
+
+// LicenseTypeCostForecast contains the monthly history of the cost of a license type and its projection
+type LicenseTypeCostForecast struct {
+	LicenseTypeID   string              `json:"licenseTypeID"`
+	ItemDescription string              `json:"itemDescription"`
+	Metric          string              `json:"metric"`
+	Cost            float64             `json:"cost"`
+	History         []LicensesCostValue `json:"history"`
+	Forecast        []LicensesCostValue `json:"forecast"`
+}
+
+// LicensesCostValue contains the spend on the purchased licenses
+// and the exposure of the consumed licenses not covered in the month of Date
+type LicensesCostValue struct {
+	Date     time.Time `json:"date"`
+	Spend    float64   `json:"spend"`
+	Exposure float64   `json:"exposure"`
+}
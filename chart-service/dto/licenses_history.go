@@ -16,6 +16,8 @@ package dto
 
 import (
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type LicenseComplianceHistory struct {
@@ -30,4 +32,40 @@ type LicenseComplianceHistoricValue struct {
 	Consumed  float64   `json:"consumed" bson:"consumed"`
 	Covered   float64   `json:"covered" bson:"covered"`
 	Purchased float64   `json:"purchased" bson:"purchased"`
+	Unlimited bool      `json:"unlimited" bson:"unlimited"`
+}
+
+// Aggregations of the licenses compliance history
+const (
+	LicenseComplianceHistoryAggregationDaily   = "daily"
+	LicenseComplianceHistoryAggregationWeekly  = "weekly"
+	LicenseComplianceHistoryAggregationMonthly = "monthly"
+)
+
+// LicenseComplianceHistoryFilter contains the filters of the licenses compliance history.
+// Empty values don't filter
+type LicenseComplianceHistoryFilter struct {
+	Locations     []string
+	LicenseTypeID string
+	ContractID    string
+	CSI           string
+	Start         time.Time
+	End           time.Time
+	// Aggregation keep only the last value of each day, week or month
+	Aggregation string
+}
+
+// LocationLicenseComplianceHistory contains the historicized compliance of a license type in a location
+type LocationLicenseComplianceHistory struct {
+	Location                 string `json:"location" bson:"location"`
+	LicenseComplianceHistory `bson:",inline"`
+}
+
+// ContractLicenseComplianceHistory contains the historicized compliance of the licenses of an Oracle database contract
+type ContractLicenseComplianceHistory struct {
+	ID                       primitive.ObjectID `json:"id" bson:"_id"` // ID of contract - licenseType couple
+	ContractID               string             `json:"contractID" bson:"contractID"`
+	CSI                      string             `json:"csi" bson:"csi"`
+	Location                 string             `json:"location" bson:"location"`
+	LicenseComplianceHistory `bson:",inline"`
 }
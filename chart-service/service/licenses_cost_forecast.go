@@ -0,0 +1,353 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package service is a package that provides methods for querying data
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+
+	"github.com/ercole-io/ercole/v2/chart-service/dto"
+	"github.com/ercole-io/ercole/v2/utils/exutils"
+)
+
+// GetLicensesCostForecast return the monthly spend and exposure of the license types with a cost,
+// computed from the licenses compliance history of the locations, and project them for the months of filter
+// following the linear trend of the history
+func (as *ChartService) GetLicensesCostForecast(filter dto.LicensesCostForecastFilter) (*dto.LicensesCostForecast, error) {
+	licenses, err := as.getLicensesComplianceHistoryOfLocations(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	costs, err := as.getLicenseTypesCost()
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &dto.LicensesCostForecast{
+		LicenseTypes: make([]dto.LicenseTypeCostForecast, 0),
+	}
+
+	histories := make([][]dto.LicensesCostValue, 0)
+
+	for _, license := range licenses {
+		cost := costs[license.LicenseTypeID]
+		if cost == 0 {
+			continue
+		}
+
+		history := monthlyLicensesCost(license.History, cost)
+
+		forecast.LicenseTypes = append(forecast.LicenseTypes, dto.LicenseTypeCostForecast{
+			LicenseTypeID:   license.LicenseTypeID,
+			ItemDescription: license.ItemDescription,
+			Metric:          license.Metric,
+			Cost:            cost,
+			History:         history,
+			Forecast:        forecastLicensesCost(history, filter.Months),
+		})
+
+		histories = append(histories, history)
+	}
+
+	sort.Slice(forecast.LicenseTypes, func(i, j int) bool {
+		return forecast.LicenseTypes[i].LicenseTypeID < forecast.LicenseTypes[j].LicenseTypeID
+	})
+
+	forecast.History = sumLicensesCost(histories)
+	forecast.Forecast = forecastLicensesCost(forecast.History, filter.Months)
+
+	return forecast, nil
+}
+
+// getLicenseTypesCost return the cost of the license types of every technology with a cost, by ID
+func (as *ChartService) getLicenseTypesCost() (map[string]float64, error) {
+	costs := make(map[string]float64)
+
+	oracleLicenseTypes, err := as.getOracleDatabaseLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, licenseType := range oracleLicenseTypes {
+		costs[id] = licenseType.Cost
+	}
+
+	postgreSQLLicenseTypes, err := as.getPostgreSQLLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, licenseType := range postgreSQLLicenseTypes {
+		costs[id] = licenseType.Cost
+	}
+
+	mongoDBLicenseTypes, err := as.getMongoDBLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, licenseType := range mongoDBLicenseTypes {
+		costs[id] = licenseType.Cost
+	}
+
+	mariaDBLicenseTypes, err := as.getMariaDBLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, licenseType := range mariaDBLicenseTypes {
+		costs[id] = licenseType.Cost
+	}
+
+	return costs, nil
+}
+
+// getLicensesComplianceHistoryOfLocations return the licenses compliance history of all the locations,
+// or the sum of the histories of the locations of filter
+func (as *ChartService) getLicensesComplianceHistoryOfLocations(filter dto.LicensesCostForecastFilter) ([]dto.LicenseComplianceHistory, error) {
+	if len(filter.Locations) == 0 {
+		return as.GetLicenseComplianceHistory(filter.Start, filter.End)
+	}
+
+	items, err := as.GetLocationsLicenseComplianceHistory(dto.LicenseComplianceHistoryFilter{
+		Locations:   filter.Locations,
+		Start:       filter.Start,
+		End:         filter.End,
+		Aggregation: dto.LicenseComplianceHistoryAggregationDaily,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	licenses := make([]dto.LicenseComplianceHistory, 0)
+	indexes := make(map[string]int)
+
+	for _, item := range items {
+		i, ok := indexes[item.LicenseTypeID]
+		if !ok {
+			indexes[item.LicenseTypeID] = len(licenses)
+			licenses = append(licenses, item.LicenseComplianceHistory)
+
+			continue
+		}
+
+		licenses[i].History = mergeLicenseComplianceHistoricValues(licenses[i].History, item.History)
+	}
+
+	return licenses, nil
+}
+
+// monthlyLicensesCost return the spend and the exposure of the last value of each month of the daily history.
+// The consumption of unlimited licenses is never exposed
+func monthlyLicensesCost(history []dto.LicenseComplianceHistoricValue, cost float64) []dto.LicensesCostValue {
+	monthly := aggregateLicenseComplianceHistory(history, dto.LicenseComplianceHistoryAggregationMonthly)
+
+	costs := make([]dto.LicensesCostValue, 0, len(monthly))
+
+	for _, val := range monthly {
+		value := dto.LicensesCostValue{
+			Date:  val.Date,
+			Spend: val.Purchased * cost,
+		}
+
+		if !val.Unlimited {
+			value.Exposure = math.Max(val.Consumed-val.Covered, 0) * cost
+		}
+
+		costs = append(costs, value)
+	}
+
+	return costs
+}
+
+// sumLicensesCost sum the monthly histories by date.
+// A history without a value in a month between its first and last one counts with its previous value
+func sumLicensesCost(histories [][]dto.LicensesCostValue) []dto.LicensesCostValue {
+	dates := make([]time.Time, 0)
+	seen := make(map[time.Time]bool)
+
+	for _, history := range histories {
+		for _, val := range history {
+			if !seen[val.Date] {
+				seen[val.Date] = true
+				dates = append(dates, val.Date)
+			}
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	sum := make([]dto.LicensesCostValue, 0, len(dates))
+
+	for _, date := range dates {
+		total := dto.LicensesCostValue{Date: date}
+
+		for _, history := range histories {
+			if len(history) == 0 || date.Before(history[0].Date) || date.After(history[len(history)-1].Date) {
+				continue
+			}
+
+			i := sort.Search(len(history), func(i int) bool {
+				return history[i].Date.After(date)
+			}) - 1
+
+			total.Spend += history[i].Spend
+			total.Exposure += history[i].Exposure
+		}
+
+		sum = append(sum, total)
+	}
+
+	return sum
+}
+
+// forecastLicensesCost project the spend and the exposure of the monthly history for months after its last month,
+// following the least squares line of the history. Projected values are never negative
+func forecastLicensesCost(history []dto.LicensesCostValue, months int) []dto.LicensesCostValue {
+	forecast := make([]dto.LicensesCostValue, 0, months)
+
+	if len(history) == 0 {
+		return forecast
+	}
+
+	first, last := history[0].Date, history[len(history)-1].Date
+
+	xs := make([]float64, 0, len(history))
+	spends := make([]float64, 0, len(history))
+	exposures := make([]float64, 0, len(history))
+
+	for _, val := range history {
+		xs = append(xs, float64(monthsBetween(first, val.Date)))
+		spends = append(spends, val.Spend)
+		exposures = append(exposures, val.Exposure)
+	}
+
+	spendIntercept, spendSlope := linearRegression(xs, spends)
+	exposureIntercept, exposureSlope := linearRegression(xs, exposures)
+
+	for i := 1; i <= months; i++ {
+		date := time.Date(last.Year(), last.Month()+time.Month(i), 1, 0, 0, 0, 0, last.Location())
+		x := float64(monthsBetween(first, date))
+
+		forecast = append(forecast, dto.LicensesCostValue{
+			Date:     date,
+			Spend:    math.Max(spendIntercept+spendSlope*x, 0),
+			Exposure: math.Max(exposureIntercept+exposureSlope*x, 0),
+		})
+	}
+
+	return forecast
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// linearRegression return the intercept and the slope of the least squares line of the points.
+// The line of a single point, or of points with the same x, is flat
+func linearRegression(xs, ys []float64) (intercept, slope float64) {
+	n := float64(len(xs))
+
+	var sumX, sumY, sumXY, sumXX float64
+
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return sumY / n, 0
+	}
+
+	slope = (n*sumXY - sumX*sumY) / denominator
+	intercept = (sumY - slope*sumX) / n
+
+	return intercept, slope
+}
+
+func (as *ChartService) GetLicensesCostForecastAsXLSX(filter dto.LicensesCostForecastFilter) (*excelize.File, error) {
+	forecast, err := as.GetLicensesCostForecast(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := "Total"
+	headers := []string{
+		"Month",
+		"Projected",
+		"Spend",
+		"Exposure",
+	}
+
+	file, err := exutils.NewXLSX(as.Config, sheet, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	axisHelp := exutils.NewAxisHelper(1)
+	setLicensesCostValues(file, sheet, axisHelp, nil, forecast.History, false)
+	setLicensesCostValues(file, sheet, axisHelp, nil, forecast.Forecast, true)
+
+	sheet = "License Types"
+	file.NewSheet(sheet)
+
+	axisHelp = exutils.NewAxisHelper(0)
+	axisHelp.NewRowAndFill(file, sheet,
+		"Part Number",
+		"Description",
+		"Metric",
+		"Cost",
+		"Month",
+		"Projected",
+		"Spend",
+		"Exposure",
+	)
+
+	for _, val := range forecast.LicenseTypes {
+		licenseType := []interface{}{val.LicenseTypeID, val.ItemDescription, val.Metric, val.Cost}
+
+		setLicensesCostValues(file, sheet, axisHelp, licenseType, val.History, false)
+		setLicensesCostValues(file, sheet, axisHelp, licenseType, val.Forecast, true)
+	}
+
+	return file, nil
+}
+
+func setLicensesCostValues(file *excelize.File, sheet string, axisHelp *exutils.AxisHelper,
+	prefix []interface{}, values []dto.LicensesCostValue, projected bool) {
+	for _, val := range values {
+		nextAxis := axisHelp.NewRow()
+
+		for _, p := range prefix {
+			file.SetCellValue(sheet, nextAxis(), p)
+		}
+
+		file.SetCellValue(sheet, nextAxis(), val.Date.Format("2006-01"))
+		file.SetCellValue(sheet, nextAxis(), projected)
+		file.SetCellValue(sheet, nextAxis(), val.Spend)
+		file.SetCellValue(sheet, nextAxis(), val.Exposure)
+	}
+}
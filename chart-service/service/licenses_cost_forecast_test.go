@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ercole-io/ercole/v2/chart-service/dto"
+)
+
+func TestMonthlyLicensesCost(t *testing.T) {
+	history := []dto.LicenseComplianceHistoricValue{
+		{Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Consumed: 10, Covered: 8, Purchased: 8},
+		{Date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Consumed: 12, Covered: 8, Purchased: 8},
+		{Date: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), Consumed: 12, Covered: 14, Purchased: 14},
+		{Date: time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), Consumed: 20, Covered: 10, Purchased: 10, Unlimited: true},
+	}
+
+	expected := []dto.LicensesCostValue{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Spend: 800, Exposure: 400},
+		{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Spend: 1400, Exposure: 0},
+		{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Spend: 1000, Exposure: 0},
+	}
+
+	assert.Equal(t, expected, monthlyLicensesCost(history, 100))
+}
+
+func TestSumLicensesCost(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	apr := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	histories := [][]dto.LicensesCostValue{
+		{
+			{Date: jan, Spend: 100, Exposure: 10},
+			{Date: mar, Spend: 300, Exposure: 30},
+		},
+		{
+			{Date: feb, Spend: 1000, Exposure: 0},
+			{Date: mar, Spend: 1000, Exposure: 50},
+			{Date: apr, Spend: 2000, Exposure: 60},
+		},
+		{},
+	}
+
+	expected := []dto.LicensesCostValue{
+		{Date: jan, Spend: 100, Exposure: 10},
+		{Date: feb, Spend: 1100, Exposure: 10},
+		{Date: mar, Spend: 1300, Exposure: 80},
+		{Date: apr, Spend: 2000, Exposure: 60},
+	}
+
+	assert.Equal(t, expected, sumLicensesCost(histories))
+}
+
+func TestForecastLicensesCost(t *testing.T) {
+	t.Run("Linear trend", func(t *testing.T) {
+		history := []dto.LicensesCostValue{
+			{Date: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), Spend: 1000, Exposure: 300},
+			{Date: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), Spend: 1100, Exposure: 200},
+			{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Spend: 1300, Exposure: 0},
+		}
+
+		expected := []dto.LicensesCostValue{
+			{Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Spend: 1400, Exposure: 0},
+			{Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Spend: 1500, Exposure: 0},
+		}
+
+		actual := forecastLicensesCost(history, 2)
+
+		assert.Len(t, actual, len(expected))
+
+		for i := range expected {
+			assert.Equal(t, expected[i].Date, actual[i].Date)
+			assert.InDelta(t, expected[i].Spend, actual[i].Spend, 0.000001)
+			assert.InDelta(t, expected[i].Exposure, actual[i].Exposure, 0.000001)
+		}
+	})
+
+	t.Run("Single month is flat", func(t *testing.T) {
+		history := []dto.LicensesCostValue{
+			{Date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), Spend: 1000, Exposure: 300},
+		}
+
+		expected := []dto.LicensesCostValue{
+			{Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Spend: 1000, Exposure: 300},
+		}
+
+		assert.Equal(t, expected, forecastLicensesCost(history, 1))
+	})
+
+	t.Run("Empty history", func(t *testing.T) {
+		assert.Equal(t, []dto.LicensesCostValue{}, forecastLicensesCost(nil, 12))
+	})
+}
@@ -30,6 +30,87 @@ func (as *ChartService) GetLicenseComplianceHistory(start, end time.Time) ([]dto
 		return nil, err
 	}
 
+	completeLicenses, err := as.getterCompleteLicensesComplianceHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	return completeLicenses(licenses), nil
+}
+
+// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location matching filter
+func (as *ChartService) GetLocationsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.LocationLicenseComplianceHistory, error) {
+	items, err := as.Database.GetLocationsLicenseComplianceHistory(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	completeLicenses, err := as.getterCompleteLicensesComplianceHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	licensesByLocation := make(map[string][]dto.LicenseComplianceHistory)
+	for _, item := range items {
+		licensesByLocation[item.Location] = append(licensesByLocation[item.Location], item.LicenseComplianceHistory)
+	}
+
+	locations := make([]string, 0, len(licensesByLocation))
+	for location := range licensesByLocation {
+		locations = append(locations, location)
+	}
+
+	sort.Strings(locations)
+
+	result := make([]dto.LocationLicenseComplianceHistory, 0, len(items))
+
+	for _, location := range locations {
+		licenses := completeLicenses(licensesByLocation[location])
+
+		sort.SliceStable(licenses, func(i, j int) bool {
+			return licenses[i].LicenseTypeID < licenses[j].LicenseTypeID
+		})
+
+		for _, license := range licenses {
+			license.History = aggregateLicenseComplianceHistory(license.History, filter.Aggregation)
+
+			result = append(result, dto.LocationLicenseComplianceHistory{
+				Location:                 location,
+				LicenseComplianceHistory: license,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// GetContractsLicenseComplianceHistory return the licenses compliance history of each contract matching filter
+func (as *ChartService) GetContractsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.ContractLicenseComplianceHistory, error) {
+	contracts, err := as.Database.GetContractsLicenseComplianceHistory(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range contracts {
+		contract := &contracts[i]
+
+		contract.History = aggregateLicenseComplianceHistory(sortAndKeepOnlyLastEntryOfEachDay(contract.History), filter.Aggregation)
+	}
+
+	sort.Slice(contracts, func(i, j int) bool {
+		if contracts[i].ContractID != contracts[j].ContractID {
+			return contracts[i].ContractID < contracts[j].ContractID
+		}
+
+		return contracts[i].LicenseTypeID < contracts[j].LicenseTypeID
+	})
+
+	return contracts, nil
+}
+
+// getterCompleteLicensesComplianceHistory return a function which fill the description and the metric of the licenses,
+// keep only the last value of each day and merge the MySQL and SQL Server licenses
+func (as *ChartService) getterCompleteLicensesComplianceHistory() (func([]dto.LicenseComplianceHistory) []dto.LicenseComplianceHistory, error) {
 	oracleTypes, err := as.getOracleDatabaseLicenseTypes()
 	if err != nil {
 		return nil, err
@@ -45,24 +126,50 @@ func (as *ChartService) GetLicenseComplianceHistory(start, end time.Time) ([]dto
 		return nil, err
 	}
 
-	for i := range licenses {
-		license := &licenses[i]
+	postgreSQLTypes, err := as.getPostgreSQLLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
 
-		if len(license.LicenseTypeID) > 0 {
-			if licenseType, ok := oracleTypes[license.LicenseTypeID]; ok {
-				license.ItemDescription = licenseType.ItemDescription
-				license.Metric = licenseType.Metric
-			}
-		}
+	mongoDBTypes, err := as.getMongoDBLicenseTypes()
+	if err != nil {
+		return nil, err
+	}
 
-		license.History = sortAndKeepOnlyLastEntryOfEachDay(license.History)
+	mariaDBTypes, err := as.getMariaDBLicenseTypes()
+	if err != nil {
+		return nil, err
 	}
 
-	licenses = mergeMySqlLicensesCompliance(licenses, mySqlTypes)
-	licenses = mergeSqlServerLicensesCompliance(licenses, sqlServerTypes)
-	licenses = removeEmptyLicensesCompliance(licenses)
+	return func(licenses []dto.LicenseComplianceHistory) []dto.LicenseComplianceHistory {
+		for i := range licenses {
+			license := &licenses[i]
+
+			if len(license.LicenseTypeID) > 0 {
+				if licenseType, ok := oracleTypes[license.LicenseTypeID]; ok {
+					license.ItemDescription = licenseType.ItemDescription
+					license.Metric = licenseType.Metric
+				} else if licenseType, ok := postgreSQLTypes[license.LicenseTypeID]; ok {
+					license.ItemDescription = licenseType.ItemDescription
+					license.Metric = licenseType.Metric
+				} else if licenseType, ok := mongoDBTypes[license.LicenseTypeID]; ok {
+					license.ItemDescription = licenseType.ItemDescription
+					license.Metric = licenseType.Metric
+				} else if licenseType, ok := mariaDBTypes[license.LicenseTypeID]; ok {
+					license.ItemDescription = licenseType.ItemDescription
+					license.Metric = licenseType.Metric
+				}
+			}
+
+			license.History = sortAndKeepOnlyLastEntryOfEachDay(license.History)
+		}
 
-	return licenses, nil
+		licenses = mergeMySqlLicensesCompliance(licenses, mySqlTypes)
+		licenses = mergeSqlServerLicensesCompliance(licenses, sqlServerTypes)
+		licenses = removeEmptyLicensesCompliance(licenses)
+
+		return licenses
+	}, nil
 }
 
 func sortAndKeepOnlyLastEntryOfEachDay(history []dto.LicenseComplianceHistoricValue) []dto.LicenseComplianceHistoricValue {
@@ -99,6 +206,36 @@ func sortAndKeepOnlyLastEntryOfEachDay(history []dto.LicenseComplianceHistoricVa
 	return newHistory
 }
 
+// aggregateLicenseComplianceHistory keep only the last value of each week or month of the daily history,
+// dated to the first day of the week or month. The daily history is returned as is
+func aggregateLicenseComplianceHistory(history []dto.LicenseComplianceHistoricValue, aggregation string) []dto.LicenseComplianceHistoricValue {
+	if aggregation != dto.LicenseComplianceHistoryAggregationWeekly &&
+		aggregation != dto.LicenseComplianceHistoryAggregationMonthly {
+		return history
+	}
+
+	aggregated := make([]dto.LicenseComplianceHistoricValue, 0, len(history))
+
+	for _, val := range history {
+		if aggregation == dto.LicenseComplianceHistoryAggregationWeekly {
+			// weeks start on monday
+			daysFromMonday := (int(val.Date.Weekday()) + 6) % 7
+			val.Date = time.Date(val.Date.Year(), val.Date.Month(), val.Date.Day()-daysFromMonday, 0, 0, 0, 0, val.Date.Location())
+		} else {
+			val.Date = time.Date(val.Date.Year(), val.Date.Month(), 1, 0, 0, 0, 0, val.Date.Location())
+		}
+
+		if last := len(aggregated) - 1; last >= 0 && aggregated[last].Date.Equal(val.Date) {
+			aggregated[last] = val
+			continue
+		}
+
+		aggregated = append(aggregated, val)
+	}
+
+	return aggregated
+}
+
 func mergeMySqlLicensesCompliance(licenses []dto.LicenseComplianceHistory, mySqlTypes map[string]model.MySqlLicenseType) []dto.LicenseComplianceHistory {
 	var mySql *dto.LicenseComplianceHistory
 
@@ -196,6 +333,7 @@ func mergeLicenseComplianceHistoricValues(a, b []dto.LicenseComplianceHistoricVa
 			Consumed:  valA.Consumed + valB.Consumed,
 			Covered:   valA.Covered + valB.Covered,
 			Purchased: valA.Purchased + valB.Purchased,
+			Unlimited: valA.Unlimited || valB.Unlimited,
 		}
 
 		merged = append(merged, newVal)
@@ -20,6 +20,8 @@ import (
 	time "time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
 
 	"github.com/ercole-io/ercole/v2/chart-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
@@ -542,3 +544,120 @@ func TestMergeLicenseComplianceHistoricValues(t *testing.T) {
 		assert.Equal(t, testCase.expected, actual)
 	}
 }
+
+func TestAggregateLicenseComplianceHistory(t *testing.T) {
+	history := []dto.LicenseComplianceHistoricValue{
+		{Date: time.Date(2021, 5, 30, 0, 0, 0, 0, time.UTC), Consumed: 1},
+		{Date: time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC), Consumed: 2},
+		{Date: time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), Consumed: 3},
+		{Date: time.Date(2021, 6, 6, 0, 0, 0, 0, time.UTC), Consumed: 4},
+		{Date: time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), Consumed: 5},
+	}
+
+	testCases := []struct {
+		aggregation string
+		expected    []dto.LicenseComplianceHistoricValue
+	}{
+		{
+			aggregation: dto.LicenseComplianceHistoryAggregationDaily,
+			expected:    history,
+		},
+		{
+			aggregation: dto.LicenseComplianceHistoryAggregationWeekly,
+			expected: []dto.LicenseComplianceHistoricValue{
+				{Date: time.Date(2021, 5, 24, 0, 0, 0, 0, time.UTC), Consumed: 1},
+				{Date: time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC), Consumed: 4},
+				{Date: time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), Consumed: 5},
+			},
+		},
+		{
+			aggregation: dto.LicenseComplianceHistoryAggregationMonthly,
+			expected: []dto.LicenseComplianceHistoricValue{
+				{Date: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), Consumed: 2},
+				{Date: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Consumed: 5},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.aggregation, func(t *testing.T) {
+			actual := aggregateLicenseComplianceHistory(history, tc.aggregation)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestGetContractsLicenseComplianceHistory(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	as := ChartService{
+		Database: db,
+	}
+
+	filter := dto.LicenseComplianceHistoryFilter{
+		Locations:   []string{"Italy"},
+		CSI:         "CSI001",
+		Start:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Aggregation: dto.LicenseComplianceHistoryAggregationMonthly,
+	}
+
+	db.EXPECT().GetContractsLicenseComplianceHistory(filter).Return([]dto.ContractLicenseComplianceHistory{
+		{
+			ContractID: "AID002",
+			CSI:        "CSI001",
+			Location:   "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "PID001",
+				History: []dto.LicenseComplianceHistoricValue{
+					{Date: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Consumed: 1},
+				},
+			},
+		},
+		{
+			ContractID: "AID001",
+			CSI:        "CSI001",
+			Location:   "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "PID001",
+				History: []dto.LicenseComplianceHistoricValue{
+					{Date: time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), Consumed: 3, Covered: 2},
+					{Date: time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), Consumed: 2, Covered: 2},
+					{Date: time.Date(2021, 7, 2, 0, 0, 0, 0, time.UTC), Consumed: 4, Covered: 4},
+				},
+			},
+		},
+	}, nil)
+
+	actual, err := as.GetContractsLicenseComplianceHistory(filter)
+	require.NoError(t, err)
+
+	expected := []dto.ContractLicenseComplianceHistory{
+		{
+			ContractID: "AID001",
+			CSI:        "CSI001",
+			Location:   "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "PID001",
+				History: []dto.LicenseComplianceHistoricValue{
+					{Date: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Consumed: 3, Covered: 2},
+					{Date: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), Consumed: 4, Covered: 4},
+				},
+			},
+		},
+		{
+			ContractID: "AID002",
+			CSI:        "CSI001",
+			Location:   "Italy",
+			LicenseComplianceHistory: dto.LicenseComplianceHistory{
+				LicenseTypeID: "PID001",
+				History: []dto.LicenseComplianceHistoricValue{
+					{Date: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), Consumed: 1},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, actual)
+}
@@ -20,6 +20,8 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/360EntSecGroup-Skylar/excelize"
+
 	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
 	"github.com/ercole-io/ercole/v2/chart-service/database"
 	"github.com/ercole-io/ercole/v2/chart-service/dto"
@@ -39,6 +41,13 @@ type ChartServiceInterface interface {
 	// GetOracleDatabaseChart return a chart associated to teh
 	GetOracleDatabaseChart(metric string, location string, environment string, olderThan time.Time) (dto.Chart, error)
 	GetLicenseComplianceHistory(start, end time.Time) ([]dto.LicenseComplianceHistory, error)
+	// GetLocationsLicenseComplianceHistory return the licenses compliance history of each location matching filter
+	GetLocationsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.LocationLicenseComplianceHistory, error)
+	// GetContractsLicenseComplianceHistory return the licenses compliance history of each contract matching filter
+	GetContractsLicenseComplianceHistory(filter dto.LicenseComplianceHistoryFilter) ([]dto.ContractLicenseComplianceHistory, error)
+	// GetLicensesCostForecast return the monthly history of the licenses cost and its projection
+	GetLicensesCostForecast(filter dto.LicensesCostForecastFilter) (*dto.LicensesCostForecast, error)
+	GetLicensesCostForecastAsXLSX(filter dto.LicensesCostForecastFilter) (*excelize.File, error)
 
 	// GetTechnologiesMetrics return metrics of all technologies
 	GetTechnologiesMetrics() (map[string]model.TechnologySupportedMetrics, error)
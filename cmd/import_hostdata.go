@@ -0,0 +1,339 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	alertservice_client "github.com/ercole-io/ercole/v2/alert-service/client"
+	apiservice_client "github.com/ercole-io/ercole/v2/api-service/client"
+	dataservice_database "github.com/ercole-io/ercole/v2/data-service/database"
+	dataservice_service "github.com/ercole-io/ercole/v2/data-service/service"
+	"github.com/ercole-io/ercole/v2/logger"
+)
+
+var importHostDataReport string
+
+// importHostDataCmd represents the import-hostdata command
+var importHostDataCmd = &cobra.Command{
+	Use:   "import-hostdata",
+	Short: "Import hostdata files",
+	Long: `Import the hostdata files contained in the directories or archives (.zip, .tar, .tar.gz, .tgz) in the args.
+The hostdata are validated and inserted in chronological order, keeping the time they were collected:
+the createdAt field of the hostdata if present, otherwise the modification time of the file`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		files := make([]hostdataFile, 0)
+
+		for _, arg := range args {
+			read, err := readHostdataFiles(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", arg, err)
+				os.Exit(1)
+			}
+
+			files = append(files, read...)
+		}
+
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].CollectedAt.Before(files[j].CollectedAt)
+		})
+
+		report := importHostdataFiles(files)
+
+		for _, result := range report.Results {
+			if !result.Accepted {
+				fmt.Fprintf(os.Stderr, "Rejected %s: %s\n", result.File, result.Error)
+			}
+		}
+
+		fmt.Printf("Hostdata accepted: %d, rejected: %d\n", report.Accepted, report.Rejected)
+
+		if importHostDataReport != "" {
+			raw, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to marshal the report: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := os.WriteFile(importHostDataReport, raw, 0o644); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write the report %s: %v\n", importHostDataReport, err)
+				os.Exit(1)
+			}
+		}
+
+		if report.Rejected > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importHostDataCmd)
+	importHostDataCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable the verbosity")
+	importHostDataCmd.Flags().StringVarP(&importHostDataReport, "report", "r", "", "Write the JSON report of the import in this file")
+}
+
+type hostdataFile struct {
+	Name        string
+	Hostname    string
+	CollectedAt time.Time
+	Raw         []byte
+}
+
+type hostdataImportReport struct {
+	Accepted int                    `json:"accepted"`
+	Rejected int                    `json:"rejected"`
+	Results  []hostdataImportResult `json:"results"`
+}
+
+type hostdataImportResult struct {
+	File        string    `json:"file"`
+	Hostname    string    `json:"hostname,omitempty"`
+	CollectedAt time.Time `json:"collectedAt"`
+	Accepted    bool      `json:"accepted"`
+	Error       string    `json:"error,omitempty"`
+}
+
+func importHostdataFiles(files []hostdataFile) hostdataImportReport {
+	log := logger.NewLogger("IMPO", logger.LogVerbosely(verbose))
+
+	db := &dataservice_database.MongoDatabase{
+		Config:  ercoleConfig,
+		TimeNow: time.Now,
+		Log:     log,
+	}
+	db.Init()
+
+	config := ercoleConfig
+	if configDB, err := db.ReadConfig(); err == nil && configDB != nil {
+		config = *configDB
+	}
+
+	service := &dataservice_service.HostDataService{
+		Config:         config,
+		ServerVersion:  config.Version,
+		Database:       db,
+		AlertSvcClient: alertservice_client.NewClient(config.AlertService),
+		ApiSvcClient:   apiservice_client.NewClient(config.APIService),
+		TimeNow:        time.Now,
+		Log:            log,
+	}
+
+	report := hostdataImportReport{
+		Results: make([]hostdataImportResult, 0, len(files)),
+	}
+
+	for _, file := range files {
+		result := hostdataImportResult{
+			File:        file.Name,
+			Hostname:    file.Hostname,
+			CollectedAt: file.CollectedAt,
+			Accepted:    true,
+		}
+
+		if err := service.ImportHostData(file.Raw, file.CollectedAt); err != nil {
+			result.Accepted = false
+			result.Error = err.Error()
+			report.Rejected++
+		} else {
+			report.Accepted++
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// readHostdataFiles return the hostdata files contained in the directory or in the archive at path
+func readHostdataFiles(path string) ([]hostdataFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := strings.ToLower(path)
+
+	switch {
+	case info.IsDir():
+		return readHostdataDir(path)
+	case strings.HasSuffix(lower, ".zip"):
+		return readHostdataZip(path)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return readHostdataTar(path)
+	default:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return []hostdataFile{newHostdataFile(path, raw, info.ModTime())}, nil
+	}
+}
+
+func readHostdataDir(dir string) ([]hostdataFile, error) {
+	files := make([]hostdataFile, 0)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !isHostdataFilename(path) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, newHostdataFile(path, raw, info.ModTime()))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func readHostdataZip(path string) ([]hostdataFile, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	files := make([]hostdataFile, 0)
+
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() || !isHostdataFilename(entry.Name) {
+			continue
+		}
+
+		reader, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := io.ReadAll(reader)
+		reader.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, newHostdataFile(path+":"+entry.Name, raw, entry.Modified))
+	}
+
+	return files, nil
+}
+
+func readHostdataTar(path string) ([]hostdataFile, error) {
+	archive, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	var reader io.Reader = archive
+
+	if lower := strings.ToLower(path); strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(archive)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+
+		reader = gz
+	}
+
+	tr := tar.NewReader(reader)
+	files := make([]hostdataFile, 0)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg || !isHostdataFilename(header.Name) {
+			continue
+		}
+
+		raw, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, newHostdataFile(path+":"+header.Name, raw, header.ModTime))
+	}
+
+	return files, nil
+}
+
+func isHostdataFilename(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".json")
+}
+
+// newHostdataFile return the hostdata file collected at the createdAt of the hostdata, if present, otherwise at modTime
+func newHostdataFile(name string, raw []byte, modTime time.Time) hostdataFile {
+	file := hostdataFile{
+		Name:        name,
+		CollectedAt: modTime.UTC(),
+		Raw:         raw,
+	}
+
+	var header struct {
+		Hostname  string    `json:"hostname"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	if err := json.Unmarshal(raw, &header); err == nil {
+		file.Hostname = header.Hostname
+
+		if !header.CreatedAt.IsZero() {
+			file.CollectedAt = header.CreatedAt.UTC()
+		}
+	}
+
+	return file
+}
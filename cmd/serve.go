@@ -190,6 +190,7 @@ func serveDataService(config config.Configuration, wg *sync.WaitGroup) {
 		Config:        config,
 		ServerVersion: config.Version,
 		Database:      db,
+		Service:       service,
 		TimeNow:       time.Now,
 		Log:           log,
 	}
@@ -236,23 +237,24 @@ func serveAlertService(config config.Configuration, wg *sync.WaitGroup) {
 		Config: config,
 	}
 
-	job := &alertservice_job.Job{
+	service := &alertservice_service.AlertService{
 		Config:   config,
 		Database: db,
+		TimeNow:  time.Now,
 		Log:      log,
 		Emailer:  emailer,
 	}
-	job.Init()
+	ctx, cancel := context.WithCancel(context.Background())
+	service.Init(ctx, wg)
 
-	service := &alertservice_service.AlertService{
+	job := &alertservice_job.Job{
 		Config:   config,
 		Database: db,
-		TimeNow:  time.Now,
 		Log:      log,
 		Emailer:  emailer,
+		Service:  service,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	service.Init(ctx, wg)
+	job.Init()
 
 	ctrl := &alertservice_controller.AlertQueueController{
 		Config:  config,
@@ -17,8 +17,10 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/OpenPeeDeeP/xdg"
@@ -46,6 +48,8 @@ type Configuration struct {
 	ChartService ChartService
 	// ThunderService contains configuration about the thunder service
 	ThunderService ThunderService
+	// HostIdentity contains the rules used to recognize the different names of the same host
+	HostIdentity HostIdentity
 	// Mongodb contains configuration about database connection, some data logic and migration
 	Mongodb Mongodb `bson:"-" json:"-"`
 	// Version contains the version of the server
@@ -66,7 +70,8 @@ type DataService struct {
 	LogHTTPRequest bool
 	// LogInsertingHostdata enable the logging of the inserting hostdata
 	LogInsertingHostdata bool
-	// AgentUsername contains the username of the agent
+	// AgentUsername contains the username shared by all the agents.
+	// Leave AgentUsername or AgentPassword empty to accept only per-agent credentials
 	AgentUsername string
 	// AgentPassword contains the password of the agent
 	AgentPassword string
@@ -76,6 +81,8 @@ type DataService struct {
 	ArchivedHostCleaningJob ArchivedHostCleaningJob
 	// FreshnessCheckJob contains the parameters of the freshness check
 	FreshnessCheckJob FreshnessCheckJob
+	// HostDataQueue contains the parameters of the queue of the received hostdata
+	HostDataQueue HostDataQueue
 	// LicenseTypeMetricsDefault default priority order of metric of licenseType when importing HostData
 	LicenseTypeMetricsDefault []string
 	// LicenseTypeMetricsByEnvironment custom priority order of metric of licenseType when importing HostData
@@ -106,9 +113,10 @@ type AlertService struct {
 	// Emailer contains the settings about the emailer
 	Emailer Emailer
 
-	AckAlertJob    AckAlertJob
-	RemoveAlertJob RemoveAlertJob
-	ReportAlertJob ReportAlertJob
+	AckAlertJob           AckAlertJob
+	RemoveAlertJob        RemoveAlertJob
+	ReportAlertJob        ReportAlertJob
+	ContractExpirationJob ContractExpirationJob
 }
 
 type AckAlertJob struct {
@@ -128,6 +136,13 @@ type ReportAlertJob struct {
 	RunAtStartup bool
 }
 
+type ContractExpirationJob struct {
+	Crontab      string
+	RunAtStartup bool
+	// LeadDays contains the days before the support expiration of a contract when a CONTRACT_EXPIRING alert is thrown
+	LeadDays []int
+}
+
 // APIService contains configuration about the api service
 type APIService struct {
 	// RemoteEndpoint contains the endpoint used to connect to the APIService
@@ -217,6 +232,24 @@ type Mongodb struct {
 	Migrate bool
 }
 
+// HostIdentity contains the rules used to recognize the different names of the same host,
+// like the ones received from the agents, the CMDB and the cloud providers
+type HostIdentity struct {
+	// CaseSensitive is true when hostnames differing only by case are different hosts
+	CaseSensitive bool
+	// KeepDomain is true when hostnames differing only by domain are different hosts
+	KeepDomain bool
+	// NormalizationRules contains the replacements applied, in order, to the hostnames after the case folding
+	NormalizationRules []HostnameNormalizationRule
+}
+
+// HostnameNormalizationRule replaces the parts of the hostname matching Pattern with Replacement,
+// that can reference the capturing groups of Pattern like $1 or ${name}
+type HostnameNormalizationRule struct {
+	Pattern     string
+	Replacement string
+}
+
 // FreshnessCheckJob contains parameters for the freshness check
 type FreshnessCheckJob struct {
 	// Crontab contains the crontab string used to schedule the freshness check
@@ -225,6 +258,20 @@ type FreshnessCheckJob struct {
 	RunAtStartup bool
 }
 
+// HostDataQueue contains parameters for the processing of the received hostdata
+type HostDataQueue struct {
+	// PollingInterval contains the number of seconds between two polls of the queue
+	PollingInterval int
+	// MaxAttempts contains the number of attempts before moving an hostdata to the dead letters
+	MaxAttempts int
+	// RetryBackoff contains the number of seconds to wait before the first retry, doubled at every attempt
+	RetryBackoff int
+	// MaxRetryBackoff contains the maximum number of seconds to wait before a retry
+	MaxRetryBackoff int
+	// LockTimeout contains the number of seconds after which an hostdata still in processing can be taken again
+	LockTimeout int
+}
+
 // CurrentHostCleaningJob contains parameters for the current host cleaning
 type CurrentHostCleaningJob struct {
 	// Crontab contains the crontab string used to schedule the cleaning
@@ -363,6 +410,9 @@ type AlertType struct {
 	MissingDatabase            Directive
 	AgentError                 Directive
 	NoData                     Directive
+	ContractExpiring           Directive
+	ContractExpired            Directive
+	LicenseNonCompliant        Directive
 }
 
 type AlertSeverity struct {
@@ -491,6 +541,12 @@ func checkConfiguration(log logger.Logger, config *Configuration) error {
 
 	checkOracleDatabaseLicenseTypeMetrics(log, config)
 
+	for _, rule := range config.HostIdentity.NormalizationRules {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("Check configuration: invalid HostIdentity normalization rule %q: %w", rule.Pattern, err)
+		}
+	}
+
 	return nil
 }
 
@@ -16,8 +16,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/utils"
 )
@@ -30,10 +33,64 @@ func (ctrl *DataController) CompareCmdbInfo(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if err := ctrl.Service.CompareCmdbInfo(cmdbInfo); err != nil {
+	snapshot, err := ctrl.Service.CompareCmdbInfo(cmdbInfo)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, snapshot)
+}
+
+// ImportCmdbCsv reconciles the hosts of the CMDB export in the request body, with a header naming its columns
+func (ctrl *DataController) ImportCmdbCsv(w http.ResponseWriter, r *http.Request) {
+	override, err := utils.Str2bool(r.URL.Query().Get("override"), false)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	records, err := ctrl.Service.ParseCmdbCsv(r.Body)
+	if errors.Is(err, utils.ErrInvalidCmdbCsv) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	snapshot, err := ctrl.Service.CompareCmdbInfo(dto.CmdbInfo{
+		Name:     mux.Vars(r)["name"],
+		Records:  records,
+		Override: override,
+	})
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, snapshot)
+}
+
+func (ctrl *DataController) ListCmdbSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := ctrl.Service.ListCmdbSnapshots()
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, snapshots)
+}
+
+func (ctrl *DataController) GetCmdbSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := ctrl.Service.GetCmdbSnapshot(mux.Vars(r)["name"])
+	if errors.Is(err, utils.ErrCmdbSnapshotNotFound) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	utils.WriteJSONResponse(w, http.StatusOK, snapshot)
 }
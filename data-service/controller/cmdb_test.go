@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
@@ -30,6 +31,7 @@ import (
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
@@ -45,7 +47,8 @@ func TestCompareCmdbsInfo_Success(t *testing.T) {
 	}
 
 	cmdbInfo := dto.CmdbInfo{}
-	as.EXPECT().CompareCmdbInfo(cmdbInfo).Return(nil)
+	snapshot := &model.CmdbSnapshot{Name: "thisCmdb", MissingHostnames: []string{"pippo"}}
+	as.EXPECT().CompareCmdbInfo(cmdbInfo).Return(snapshot, nil)
 
 	handler := http.HandlerFunc(ac.CompareCmdbInfo)
 
@@ -59,8 +62,8 @@ func TestCompareCmdbsInfo_Success(t *testing.T) {
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusNoContent, rr.Code)
-	assert.Empty(t, rr.Body.String())
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(snapshot), rr.Body.String())
 }
 
 func TestCompareCmdbsInfo_BadRequest(t *testing.T) {
@@ -102,7 +105,7 @@ func TestCompareCmdbsInfo_InternalServerError(t *testing.T) {
 	}
 
 	cmdbInfo := dto.CmdbInfo{}
-	as.EXPECT().CompareCmdbInfo(cmdbInfo).Return(aerrMock)
+	as.EXPECT().CompareCmdbInfo(cmdbInfo).Return(nil, aerrMock)
 
 	cmdbInfoBytes, err := json.Marshal(cmdbInfo)
 	require.NoError(t, err)
@@ -123,3 +126,87 @@ func TestCompareCmdbsInfo_InternalServerError(t *testing.T) {
 
 	assert.Equal(t, "mock", actual.Message)
 }
+
+func TestImportCmdbCsv(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	records := []model.CmdbRecord{{Hostname: "pippo", Location: "Italy"}}
+
+	t.Run("Success", func(t *testing.T) {
+		snapshot := &model.CmdbSnapshot{Name: "thisCmdb", Override: true, Records: records}
+
+		as.EXPECT().ParseCmdbCsv(gomock.Any()).Return(records, nil)
+		as.EXPECT().CompareCmdbInfo(dto.CmdbInfo{Name: "thisCmdb", Records: records, Override: true}).Return(snapshot, nil)
+
+		req, err := http.NewRequest("POST", "/cmdbs/thisCmdb/csv?override=true", strings.NewReader("hostname,location\npippo,Italy\n"))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"name": "thisCmdb"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ImportCmdbCsv).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(snapshot), rr.Body.String())
+	})
+
+	t.Run("Invalid csv", func(t *testing.T) {
+		as.EXPECT().ParseCmdbCsv(gomock.Any()).Return(nil, utils.ErrInvalidCmdbCsv)
+
+		req, err := http.NewRequest("POST", "/cmdbs/thisCmdb/csv", strings.NewReader("location\nItaly\n"))
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"name": "thisCmdb"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.ImportCmdbCsv).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetCmdbSnapshot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		snapshot := &model.CmdbSnapshot{Name: "thisCmdb"}
+		as.EXPECT().GetCmdbSnapshot("thisCmdb").Return(snapshot, nil)
+
+		req, err := http.NewRequest("GET", "/cmdbs/thisCmdb", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"name": "thisCmdb"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetCmdbSnapshot).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, utils.ToJSON(snapshot), rr.Body.String())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		as.EXPECT().GetCmdbSnapshot("otherCmdb").Return(nil, utils.ErrCmdbSnapshotNotFound)
+
+		req, err := http.NewRequest("GET", "/cmdbs/otherCmdb", nil)
+		require.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"name": "otherCmdb"})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ac.GetCmdbSnapshot).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -17,21 +17,40 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/ercole-io/ercole/v2/data-service/service"
 	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
 
 	"github.com/ercole-io/ercole/v2/config"
 )
 
 type DataControllerInterface interface {
 	InsertHostData(w http.ResponseWriter, r *http.Request)
+	InsertHostDataBatch(w http.ResponseWriter, r *http.Request)
+	GetHostDataSchemaVersions(w http.ResponseWriter, r *http.Request)
 	CompareCmdbInfo(w http.ResponseWriter, r *http.Request)
+	ImportCmdbCsv(w http.ResponseWriter, r *http.Request)
+	ListCmdbSnapshots(w http.ResponseWriter, r *http.Request)
+	GetCmdbSnapshot(w http.ResponseWriter, r *http.Request)
 
 	InsertExadata(w http.ResponseWriter, r *http.Request)
 
+	ReprocessOracleLicenses(w http.ResponseWriter, r *http.Request)
+
+	GetHostDataQueueStats(w http.ResponseWriter, r *http.Request)
+	ListHostDataDeadLetters(w http.ResponseWriter, r *http.Request)
+
+	ListQuarantinedHostData(w http.ResponseWriter, r *http.Request)
+	GetQuarantinedHostData(w http.ResponseWriter, r *http.Request)
+	UpdateQuarantinedHostData(w http.ResponseWriter, r *http.Request)
+	ReplayQuarantinedHostData(w http.ResponseWriter, r *http.Request)
+	ReplayQuarantinedHostDataBatch(w http.ResponseWriter, r *http.Request)
+
 	AuthenticateMiddleware(h http.Handler) http.Handler
 }
 
@@ -41,3 +60,25 @@ type DataController struct {
 	TimeNow func() time.Time
 	Log     logger.Logger
 }
+
+// maxBodyBytes is the maximum size of the decompressed body of a request with a single document
+const maxBodyBytes = 64 << 20
+
+// readBody reads the body of the request, up to limit bytes once decompressed.
+// If it fails the error is written in the response and false is returned
+func (ctrl *DataController) readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, bool) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusRequestEntityTooLarge, utils.NewError(err, http.StatusText(http.StatusRequestEntityTooLarge)))
+		return nil, false
+	} else if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+		return nil, false
+	}
+
+	return body, true
+}
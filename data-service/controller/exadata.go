@@ -18,7 +18,6 @@ package controller
 import (
 	"encoding/json"
 	"errors"
-	"io"
 	"net/http"
 
 	"github.com/ercole-io/ercole/v2/model"
@@ -27,15 +26,13 @@ import (
 )
 
 func (ctrl *DataController) InsertExadata(w http.ResponseWriter, r *http.Request) {
-	raw, err := io.ReadAll(r.Body)
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+	raw, ok := ctrl.readBody(w, r, maxBodyBytes)
+	if !ok {
 		return
 	}
 
-	defer r.Body.Close()
-
-	if raw, err = ctrl.sanitizeJson(raw); err != nil {
+	raw, err := ctrl.sanitizeJson(raw)
+	if err != nil {
 		if errors.Is(err, utils.ErrInvalidExadata) {
 			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
 
@@ -69,6 +66,11 @@ func (ctrl *DataController) InsertExadata(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err := checkAgentHostname(r, exadata.Hostname); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, err)
+		return
+	}
+
 	err = ctrl.Service.SaveExadata(&exadata)
 	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
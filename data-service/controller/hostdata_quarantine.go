@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (ctrl *DataController) ListQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	items, err := ctrl.Service.ListQuarantinedHostData(r.URL.Query().Get("hostname"), r.URL.Query().Get("status"))
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, items)
+}
+
+func (ctrl *DataController) GetQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	item, err := ctrl.Service.GetQuarantinedHostData(id)
+	if err != nil {
+		ctrl.writeQuarantineError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, item)
+}
+
+// UpdateQuarantinedHostData replaces the payload of a quarantined hostdata with the request body
+func (ctrl *DataController) UpdateQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	raw, ok := ctrl.readBody(w, r, maxBodyBytes)
+	if !ok {
+		return
+	}
+
+	item, err := ctrl.Service.UpdateQuarantinedHostData(id, raw)
+	if err != nil {
+		ctrl.writeQuarantineError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, item)
+}
+
+func (ctrl *DataController) ReplayQuarantinedHostData(w http.ResponseWriter, r *http.Request) {
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	force, err := utils.Str2bool(r.URL.Query().Get("force"), false)
+	if err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := ctrl.Service.ReplayQuarantinedHostData(id, force); err != nil {
+		ctrl.writeQuarantineError(w, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusAccepted, nil)
+}
+
+func (ctrl *DataController) ReplayQuarantinedHostDataBatch(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs   []primitive.ObjectID `json:"ids"`
+		Force bool                 `json:"force"`
+	}
+
+	if err := utils.Decode(r.Body, &req); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, ctrl.Service.ReplayQuarantinedHostDataBatch(req.IDs, req.Force))
+}
+
+func (ctrl *DataController) writeQuarantineError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusNotFound, err)
+	case errors.Is(err, utils.ErrHostDataAlreadyReplayed), errors.Is(err, utils.ErrHostDataBeingReplayed):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusConflict, err)
+	case errors.Is(err, utils.ErrInvalidHostdata), errors.Is(err, utils.ErrInvalidJSON):
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+	default:
+		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+	}
+}
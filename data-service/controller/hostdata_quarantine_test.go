@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestListQuarantinedHostData_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	items := []model.HostDataQuarantineItem{{Hostname: "foobar", Status: model.HostDataQuarantineItemStatusQuarantined}}
+	as.EXPECT().ListQuarantinedHostData("foobar", "").Return(items, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ListQuarantinedHostData)
+	req, err := http.NewRequest("GET", "/?hostname=foobar", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, utils.ToJSON(items), rr.Body.String())
+}
+
+func TestGetQuarantinedHostData_NotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := primitive.NewObjectID()
+	as.EXPECT().GetQuarantinedHostData(id).Return(nil, utils.ErrNotFound)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetQuarantinedHostData)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestReplayQuarantinedHostData_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := primitive.NewObjectID()
+	as.EXPECT().ReplayQuarantinedHostData(id, true).Return(nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReplayQuarantinedHostData)
+	req, err := http.NewRequest("POST", "/?force=true", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestReplayQuarantinedHostData_StillInvalid(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := primitive.NewObjectID()
+	as.EXPECT().ReplayQuarantinedHostData(id, false).Return(utils.ErrInvalidHostdata)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReplayQuarantinedHostData)
+	req, err := http.NewRequest("POST", "/", nil)
+	require.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"id": id.Hex()})
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestReplayQuarantinedHostDataBatch_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	id := utils.Str2oid("5dc3f534db7e81a98b726a52")
+	results := []dto.HostDataReplayResult{{ID: id, Replayed: true}}
+	as.EXPECT().ReplayQuarantinedHostDataBatch([]primitive.ObjectID{id}, false).Return(results)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReplayQuarantinedHostDataBatch)
+	req, err := http.NewRequest("POST", "/", strings.NewReader(`{"ids": ["5dc3f534db7e81a98b726a52"]}`))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, utils.ToJSON(results), rr.Body.String())
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestGetHostDataQueueStats_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	expected := &dto.HostDataQueueStats{Pending: 3, Processing: 1, DeadLetters: 2}
+	as.EXPECT().GetHostDataQueueStats().Return(expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetHostDataQueueStats)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var actual dto.HostDataQueueStats
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+	assert.Equal(t, *expected, actual)
+}
+
+func TestGetHostDataQueueStats_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().GetHostDataQueueStats().Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.GetHostDataQueueStats)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestListHostDataDeadLetters_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	deadLetters := []model.HostDataQueueItem{
+		{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusFailed,
+			Attempts:  5,
+			LastError: "DB ERROR",
+		},
+	}
+	as.EXPECT().ListHostDataDeadLetters().Return(deadLetters, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ListHostDataDeadLetters)
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, utils.ToJSON(deadLetters), rr.Body.String())
+}
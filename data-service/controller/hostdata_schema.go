@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/schema"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// GetHostDataSchemaVersions return the versions of the hostdata schema accepted by the data-service
+func (ctrl *DataController) GetHostDataSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	versions := dto.HostDataSchemaVersions{
+		Current:   model.SchemaVersion,
+		Supported: schema.SupportedHostdataSchemaVersions(),
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, versions)
+}
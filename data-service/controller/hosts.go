@@ -16,47 +16,119 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 
+	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/schema"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/ercole-io/ercole/v2/utils/sanitizer"
 )
 
-// InsertHostData update the informations about a host using the HostData in the request
+// InsertHostData saves the HostData in the request, it will be processed asynchronously
 func (ctrl *DataController) InsertHostData(w http.ResponseWriter, r *http.Request) {
-	raw, err := io.ReadAll(r.Body)
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+	raw, ok := ctrl.readBody(w, r, maxBodyBytes)
+	if !ok {
+		return
+	}
+
+	if _, status, err := ctrl.ingestHostData(r, raw); err != nil {
+		utils.WriteAndLogError(ctrl.Log, w, status, err)
+		return
+	}
+
+	utils.WriteJSONResponse(w, http.StatusAccepted, nil)
+}
+
+// maxHostDataBatchBytes is the maximum size of the decompressed body of a batch of hostdata
+const maxHostDataBatchBytes = 512 << 20
+
+// InsertHostDataBatch saves every hostdata in the request, one JSON document per line (NDJSON).
+// The whole body is read before enqueueing any hostdata, so a truncated or corrupted body is rejected as a whole.
+// Every hostdata is validated and enqueued independently and its outcome is reported in the response
+func (ctrl *DataController) InsertHostDataBatch(w http.ResponseWriter, r *http.Request) {
+	body, ok := ctrl.readBody(w, r, maxHostDataBatchBytes)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
 
-	if raw, err = ctrl.sanitizeJson(raw); err != nil {
+	report := dto.HostDataBatchReport{
+		Results: make([]dto.HostDataBatchResult, 0),
+	}
+
+	for i, doc := range bytes.Split(body, []byte("\n")) {
+		if doc = bytes.TrimSpace(doc); len(doc) == 0 {
+			continue
+		}
+
+		hostname, status, err := ctrl.ingestHostData(r, doc)
+
+		result := dto.HostDataBatchResult{
+			Line:     i + 1,
+			Hostname: hostname,
+			Status:   status,
+			Accepted: err == nil,
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+			report.Rejected++
+		} else {
+			report.Accepted++
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	utils.WriteJSONResponse(w, http.StatusOK, report)
+}
+
+// ingestHostData validates the raw hostdata and enqueues it. Invalid hostdata are quarantined
+// only if the credential of the request can send data of their hostname.
+// It returns the hostname of the hostdata, if known, and the http status describing the outcome
+func (ctrl *DataController) ingestHostData(r *http.Request, body []byte) (string, int, error) {
+	raw, err := ctrl.sanitizeJson(body)
+	if err != nil {
 		if errors.Is(err, utils.ErrInvalidJSON) {
-			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, err)
+			// the hostname of an invalid JSON is unknown
+			if checkAgentHostname(r, "") == nil {
+				ctrl.quarantineHostData(body)
+			}
+
 			ctrl.Service.AlertInvalidHostData(err, nil)
 
-			return
+			return "", http.StatusUnprocessableEntity, err
 		}
 
 		ctrl.Log.Error(err)
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
 
-		return
+		return "", http.StatusInternalServerError, err
+	}
+
+	hostname := peekHostname(raw)
+	if err := checkAgentHostname(r, hostname); err != nil {
+		return hostname, http.StatusForbidden, err
 	}
 
 	var hostdata model.HostDataBE
 
-	if validationErr := schema.ValidateHostdata(raw); validationErr != nil {
+	validationErr := schema.ValidateHostdata(raw)
+	if validationErr == nil {
+		if upgraded, err := schema.UpgradeHostdata(raw); err != nil {
+			validationErr = err
+		} else {
+			raw = upgraded
+		}
+	}
+
+	if validationErr != nil {
 		if errors.Is(validationErr, utils.ErrInvalidHostdata) {
 			ctrl.Log.Info(validationErr)
-			utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, validationErr)
+			ctrl.quarantineHostData(body)
 
 			if unmarshalErr := json.Unmarshal(raw, &hostdata); unmarshalErr != nil {
 				ctrl.Service.AlertInvalidHostData(validationErr, nil)
@@ -64,27 +136,45 @@ func (ctrl *DataController) InsertHostData(w http.ResponseWriter, r *http.Reques
 				ctrl.Service.AlertInvalidHostData(validationErr, &hostdata)
 			}
 
-			return
+			return hostdata.Hostname, http.StatusUnprocessableEntity, validationErr
 		}
 
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, validationErr)
+		return "", http.StatusInternalServerError, validationErr
+	}
 
-		return
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return "", http.StatusInternalServerError, err
 	}
 
-	err = json.Unmarshal(raw, &hostdata)
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
-		return
+	if err := checkAgentHostname(r, hostdata.Hostname); err != nil {
+		return hostdata.Hostname, http.StatusForbidden, err
 	}
 
-	err = ctrl.Service.InsertHostData(hostdata)
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
-		return
+	if err := ctrl.Service.EnqueueHostData(hostdata); err != nil {
+		return hostdata.Hostname, http.StatusInternalServerError, err
+	}
+
+	return hostdata.Hostname, http.StatusAccepted, nil
+}
+
+// peekHostname return the hostname of the raw hostdata, empty if it's missing or not a string
+func peekHostname(raw []byte) string {
+	var hostdata struct {
+		Hostname string `json:"hostname"`
 	}
 
-	utils.WriteJSONResponse(w, http.StatusOK, nil)
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return ""
+	}
+
+	return hostdata.Hostname
+}
+
+// quarantineHostData saves the rejected hostdata so that it can be fixed and replayed later
+func (ctrl *DataController) quarantineHostData(raw []byte) {
+	if err := ctrl.Service.QuarantineHostData(raw); err != nil {
+		ctrl.Log.Error(err)
+	}
 }
 
 func (ctrl *DataController) sanitizeJson(raw []byte) ([]byte, error) {
@@ -17,6 +17,9 @@ package controller
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -28,7 +31,9 @@ import (
 	gomock "go.uber.org/mock/gomock"
 
 	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 	"github.com/ercole-io/ercole/v2/utils/mongoutils"
 )
@@ -49,7 +54,7 @@ func TestUpdateHostInfo_Success(t *testing.T) {
 
 	expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
 
-	as.EXPECT().InsertHostData(expectedHostDataBE).Return(nil)
+	as.EXPECT().EnqueueHostData(expectedHostDataBE).Return(nil)
 
 	handler := http.HandlerFunc(ac.InsertHostData)
 	req, err := http.NewRequest("PUT", "/", bytes.NewReader(raw))
@@ -58,7 +63,34 @@ func TestUpdateHostInfo_Success(t *testing.T) {
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 
-	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestUpdateHostInfo_ForbiddenHostname(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	handler := http.HandlerFunc(ac.InsertHostData)
+	req, err := http.NewRequest("PUT", "/", bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	credential := &model.AgentCredential{Name: "other", HostnamePatterns: []string{"other-*"}}
+	req = req.WithContext(context.WithValue(req.Context(), agentCredentialContextKey{}, credential))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
 }
 
 func TestUpdateHostInfo_FailBadRequest(t *testing.T) {
@@ -82,6 +114,21 @@ func TestUpdateHostInfo_FailBadRequest(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
+func TestReadBody_TooLarge(t *testing.T) {
+	ac := DataController{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/", strings.NewReader(`{"hostname":"foobar"}`))
+	require.NoError(t, err)
+
+	_, ok := ac.readBody(rr, req, 4)
+
+	require.False(t, ok)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
 func TestUpdateHostInfo_UnprocessableEntity1(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -93,6 +140,7 @@ func TestUpdateHostInfo_UnprocessableEntity1(t *testing.T) {
 		Log:     logger.NewLogger("TEST"),
 	}
 
+	as.EXPECT().QuarantineHostData([]byte("{asasdsad")).Return(nil)
 	as.EXPECT().
 		AlertInvalidHostData(gomock.Any(), nil).
 		Do(func(err error, _ interface{}) {
@@ -120,6 +168,7 @@ func TestUpdateHostInfo_UnprocessableEntity2(t *testing.T) {
 		Log:     logger.NewLogger("TEST"),
 	}
 
+	as.EXPECT().QuarantineHostData([]byte("{}")).Return(nil)
 	as.EXPECT().
 		AlertInvalidHostData(gomock.Any(), gomock.Any()).
 		Do(func(err error, hd interface{}) {
@@ -137,6 +186,56 @@ func TestUpdateHostInfo_UnprocessableEntity2(t *testing.T) {
 	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
 }
 
+func TestUpdateHostInfo_InvalidOfForbiddenHostnameIsNotQuarantined(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	handler := http.HandlerFunc(ac.InsertHostData)
+	req, err := http.NewRequest("PUT", "/", strings.NewReader(`{"hostname":"foobar"}`))
+	require.NoError(t, err)
+
+	credential := &model.AgentCredential{Name: "other", HostnamePatterns: []string{"other-*"}}
+	req = req.WithContext(context.WithValue(req.Context(), agentCredentialContextKey{}, credential))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestUpdateHostInfo_InvalidJSONOfAgentCredentialIsNotQuarantined(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().AlertInvalidHostData(gomock.Any(), nil)
+
+	handler := http.HandlerFunc(ac.InsertHostData)
+	req, err := http.NewRequest("PUT", "/", strings.NewReader("{asasdsad"))
+	require.NoError(t, err)
+
+	credential := &model.AgentCredential{Name: "other", HostnamePatterns: []string{"other-*"}}
+	req = req.WithContext(context.WithValue(req.Context(), agentCredentialContextKey{}, credential))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
 func TestUpdateHostInfo_InternalServerError(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -153,7 +252,7 @@ func TestUpdateHostInfo_InternalServerError(t *testing.T) {
 
 	expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
 
-	as.EXPECT().InsertHostData(expectedHostDataBE).Return(aerrMock)
+	as.EXPECT().EnqueueHostData(expectedHostDataBE).Return(aerrMock)
 
 	rr := httptest.NewRecorder()
 	handler := http.HandlerFunc(ac.InsertHostData)
@@ -180,7 +279,7 @@ func TestUpdateHostInfo_SuccessAndSanitized(t *testing.T) {
 	require.NoError(t, err)
 
 	expected := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
-	as.EXPECT().InsertHostData(expected).Return(nil)
+	as.EXPECT().EnqueueHostData(expected).Return(nil)
 
 	handler := http.HandlerFunc(ac.InsertHostData)
 	req, err := http.NewRequest("PUT", "/", bytes.NewReader(actual))
@@ -189,5 +288,159 @@ func TestUpdateHostInfo_SuccessAndSanitized(t *testing.T) {
 	rr := httptest.NewRecorder()
 	handler.ServeHTTP(rr, req)
 
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestInsertHostDataBatch_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	var compacted bytes.Buffer
+	require.NoError(t, json.Compact(&compacted, raw))
+
+	body := compacted.String() + "\n\n" + "{\"hostname\": x}\n" + compacted.String()
+
+	expectedHostDataBE := mongoutils.LoadFixtureHostData(t, "../../fixture/test_dataservice_hostdata_v1_00.json")
+
+	as.EXPECT().EnqueueHostData(expectedHostDataBE).Return(nil).Times(2)
+	as.EXPECT().QuarantineHostData([]byte("{\"hostname\": x}")).Return(nil)
+	as.EXPECT().AlertInvalidHostData(gomock.Any(), nil)
+
+	handler := http.HandlerFunc(ac.InsertHostDataBatch)
+	req, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var actual dto.HostDataBatchReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+
+	assert.Equal(t, 2, actual.Accepted)
+	assert.Equal(t, 1, actual.Rejected)
+	require.Len(t, actual.Results, 3)
+
+	assert.Equal(t, dto.HostDataBatchResult{Line: 1, Hostname: "rac1_x", Status: http.StatusAccepted, Accepted: true}, actual.Results[0])
+	assert.Equal(t, 3, actual.Results[1].Line)
+	assert.Equal(t, http.StatusUnprocessableEntity, actual.Results[1].Status)
+	assert.False(t, actual.Results[1].Accepted)
+	assert.NotEmpty(t, actual.Results[1].Error)
+	assert.Equal(t, dto.HostDataBatchResult{Line: 4, Hostname: "rac1_x", Status: http.StatusAccepted, Accepted: true}, actual.Results[2])
+}
+
+func TestInsertHostDataBatch_ForbiddenHostname(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	var compacted bytes.Buffer
+	require.NoError(t, json.Compact(&compacted, raw))
+
+	handler := http.HandlerFunc(ac.InsertHostDataBatch)
+	req, err := http.NewRequest("POST", "/", &compacted)
+	require.NoError(t, err)
+
+	credential := &model.AgentCredential{Name: "other", HostnamePatterns: []string{"other-*"}}
+	req = req.WithContext(context.WithValue(req.Context(), agentCredentialContextKey{}, credential))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
 	require.Equal(t, http.StatusOK, rr.Code)
+
+	var actual dto.HostDataBatchReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+
+	assert.Equal(t, 0, actual.Accepted)
+	assert.Equal(t, 1, actual.Rejected)
+	require.Len(t, actual.Results, 1)
+	assert.Equal(t, http.StatusForbidden, actual.Results[0].Status)
+}
+
+func TestInsertHostDataBatch_CorruptedGzip(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	raw, err := ioutil.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	var compacted bytes.Buffer
+	require.NoError(t, json.Compact(&compacted, raw))
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err = gz.Write([]byte(compacted.String() + "\n" + compacted.String()))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	truncated := compressed.Bytes()[:compressed.Len()*2/3]
+
+	handler := ac.decompressMiddleware(http.HandlerFunc(ac.InsertHostDataBatch))
+	req, err := http.NewRequest("POST", "/", bytes.NewReader(truncated))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestUpdateHostInfo_UnsupportedSchemaVersion(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	body := `{"hostname":"foobar","schemaVersion":42}`
+
+	as.EXPECT().QuarantineHostData([]byte(body)).Return(nil)
+	as.EXPECT().
+		AlertInvalidHostData(gomock.Any(), gomock.Any()).
+		Do(func(err error, _ interface{}) {
+			assert.ErrorIs(t, err, utils.ErrUnsupportedHostdataSchemaVersion)
+		})
+
+	handler := http.HandlerFunc(ac.InsertHostData)
+	req, err := http.NewRequest("POST", "/", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), utils.ErrUnsupportedHostdataSchemaVersion.Error())
 }
@@ -16,24 +16,19 @@
 package controller
 
 import (
-	"io"
 	"net/http"
 
-	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
 func (ctrl *DataController) InsertOracleLicenseTypes(w http.ResponseWriter, r *http.Request) {
-	raw, err := io.ReadAll(r.Body)
-	if err != nil {
-		utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, http.StatusText(http.StatusBadRequest)))
+	raw, ok := ctrl.readBody(w, r, maxBodyBytes)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
-
-	var licenseTypes []model.OracleDatabaseLicenseType
 
-	if licenseTypes, err = ctrl.Service.SanitizeLicenseTypes(raw); err != nil {
+	licenseTypes, err := ctrl.Service.SanitizeLicenseTypes(raw)
+	if err != nil {
 		utils.WriteAndLogError(ctrl.Log, w, http.StatusUnprocessableEntity, utils.NewError(err, http.StatusText(http.StatusUnprocessableEntity)))
 
 		return
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestReprocessOracleLicenses_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	request := dto.OracleLicenseReprocessingRequest{Location: "Italy", DryRun: true}
+	expected := &dto.OracleLicenseReprocessingReport{
+		DryRun:         true,
+		HostsProcessed: 2,
+		HostsChanged:   1,
+		Changes: []dto.OracleLicenseReprocessingChange{
+			{
+				Hostname:         "foobar",
+				DatabaseName:     "ERCOLE",
+				LicenseName:      "Oracle ENT",
+				OldLicenseTypeID: "A90650",
+				NewLicenseTypeID: "A90611",
+				OldCount:         2,
+				NewCount:         2,
+			},
+		},
+	}
+	as.EXPECT().ReprocessOracleLicenses(request).Return(expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReprocessOracleLicenses)
+	req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte(utils.ToJSON(request))))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var actual dto.OracleLicenseReprocessingReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+	assert.Equal(t, *expected, actual)
+}
+
+func TestReprocessOracleLicenses_BadRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReprocessOracleLicenses)
+	req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte("{")))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestReprocessOracleLicenses_InternalServerError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	ac := DataController{
+		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{}).Return(nil, aerrMock)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(ac.ReprocessOracleLicenses)
+	req, err := http.NewRequest("POST", "/", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
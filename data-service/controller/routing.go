@@ -16,40 +16,193 @@
 package controller
 
 import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"strings"
 
-	"github.com/goji/httpauth"
 	"github.com/gorilla/mux"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
 // GetDataControllerHandler setup the routes of the router using the handler in the controller as http handler
 func (ctrl *DataController) GetDataControllerHandler() http.Handler {
 	router := mux.NewRouter()
+	router.StrictSlash(true)
+
+	ctrl.setupAdminRoutes(router.PathPrefix("/admin").Subrouter())
+
+	protected := router.NewRoute().Subrouter()
+	protected.Use(ctrl.AuthenticateMiddleware)
+	protected.Use(ctrl.decompressMiddleware)
 
-	router.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+	protected.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
 		if _, err := w.Write([]byte("Pong")); err != nil {
 			ctrl.Log.Error(err)
 			return
 		}
 	})
 
-	router.StrictSlash(true)
-	router.Use(ctrl.AuthenticateMiddleware)
-
-	ctrl.setupProtectedRoutes(router)
+	ctrl.setupProtectedRoutes(protected)
 
 	return router
 }
 
 func (ctrl *DataController) setupProtectedRoutes(router *mux.Router) {
 	router.HandleFunc("/hosts", ctrl.InsertHostData).Methods("POST")
-	router.HandleFunc("/cmdbs", ctrl.CompareCmdbInfo).Methods("POST")
-	router.HandleFunc("/oracle/license-types", ctrl.InsertOracleLicenseTypes).Methods("POST")
+	router.HandleFunc("/hosts/batch", ctrl.InsertHostDataBatch).Methods("POST")
+	router.HandleFunc("/hostdata-schema-versions", ctrl.GetHostDataSchemaVersions).Methods("GET")
+	// Deprecated: the CMDBs are reconciled with POST /admin/cmdbs, this route is kept for the existing CMDB feeds
+	router.HandleFunc("/cmdbs", ctrl.sharedCredentialOnly(ctrl.CompareCmdbInfo)).Methods("POST")
+	router.HandleFunc("/oracle/license-types", ctrl.sharedCredentialOnly(ctrl.InsertOracleLicenseTypes)).Methods("POST")
 	router.HandleFunc("/exadatas", ctrl.InsertExadata).Methods("POST")
 }
 
-// AuthenticateMiddleware return the middleware used to authenticate (request) users
+// setupAdminRoutes setup the routes used to administer the data-service.
+// Only the api-service can call them, it exposes them to its admin users
+func (ctrl *DataController) setupAdminRoutes(router *mux.Router) {
+	router.Use(ctrl.AdminAuthenticateMiddleware)
+
+	router.HandleFunc("/cmdbs", ctrl.CompareCmdbInfo).Methods("POST")
+	router.HandleFunc("/cmdbs", ctrl.ListCmdbSnapshots).Methods("GET")
+	router.HandleFunc("/cmdbs/{name}", ctrl.GetCmdbSnapshot).Methods("GET")
+	router.HandleFunc("/cmdbs/{name}/csv", ctrl.ImportCmdbCsv).Methods("POST")
+	router.HandleFunc("/oracle/license-reprocessing", ctrl.ReprocessOracleLicenses).Methods("POST")
+	router.HandleFunc("/hostdata-queue", ctrl.GetHostDataQueueStats).Methods("GET")
+	router.HandleFunc("/hostdata-queue/dead-letters", ctrl.ListHostDataDeadLetters).Methods("GET")
+	router.HandleFunc("/hostdata-quarantine", ctrl.ListQuarantinedHostData).Methods("GET")
+	router.HandleFunc("/hostdata-quarantine/replay", ctrl.ReplayQuarantinedHostDataBatch).Methods("POST")
+	router.HandleFunc("/hostdata-quarantine/{id}", ctrl.GetQuarantinedHostData).Methods("GET")
+	router.HandleFunc("/hostdata-quarantine/{id}", ctrl.UpdateQuarantinedHostData).Methods("PUT")
+	router.HandleFunc("/hostdata-quarantine/{id}/replay", ctrl.ReplayQuarantinedHostData).Methods("POST")
+}
+
+type agentCredentialContextKey struct{}
+
+// AuthenticateMiddleware return the middleware used to authenticate (request) users.
+// Agents can authenticate with the shared AgentUsername/AgentPassword or with their own agent credential
 func (ctrl *DataController) AuthenticateMiddleware(h http.Handler) http.Handler {
-	basicAuthHandler := httpauth.SimpleBasicAuth(ctrl.Config.DataService.AgentUsername, ctrl.Config.DataService.AgentPassword)
-	return basicAuthHandler(h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			unauthorized(w)
+			return
+		}
+
+		if ctrl.isSharedCredential(username, password) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		credential, err := ctrl.Service.AuthenticateAgent(username, password)
+		if errors.Is(err, utils.ErrInvalidAgentCredential) {
+			unauthorized(w)
+			return
+		} else if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), agentCredentialContextKey{}, credential)))
+	})
+}
+
+func (ctrl *DataController) isSharedCredential(username, password string) bool {
+	agentUsername, agentPassword := ctrl.Config.DataService.AgentUsername, ctrl.Config.DataService.AgentPassword
+	if agentUsername == "" || agentPassword == "" {
+		return false
+	}
+
+	usernameOk := subtle.ConstantTimeCompare([]byte(username), []byte(agentUsername)) == 1
+	passwordOk := subtle.ConstantTimeCompare([]byte(password), []byte(agentPassword)) == 1
+
+	return usernameOk && passwordOk
+}
+
+// AdminAuthenticateMiddleware return the middleware used to authenticate the requests of the api-service,
+// with the service username and password of its authentication provider.
+// The credentials of the agents, shared or not, are refused
+func (ctrl *DataController) AdminAuthenticateMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || !ctrl.isAPIServiceCredential(username, password) {
+			unauthorized(w)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (ctrl *DataController) isAPIServiceCredential(username, password string) bool {
+	provider := ctrl.Config.APIService.AuthenticationProvider
+	if provider.Username == "" || provider.Password == "" {
+		return false
+	}
+
+	usernameOk := subtle.ConstantTimeCompare([]byte(username), []byte(provider.Username)) == 1
+	passwordOk := subtle.ConstantTimeCompare([]byte(password), []byte(provider.Password)) == 1
+
+	return usernameOk && passwordOk
+}
+
+// decompressMiddleware decompress the body of the requests sent with Content-Encoding: gzip
+func (ctrl *DataController) decompressMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.EqualFold(strings.TrimSpace(r.Header.Get("Content-Encoding")), "gzip") {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusBadRequest, utils.NewError(err, "Invalid gzip body"))
+			return
+		}
+		defer gz.Close()
+
+		r.Header.Del("Content-Encoding")
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+		r.Body = gz
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// sharedCredentialOnly forbids the requests authenticated with an agent credential
+func (ctrl *DataController) sharedCredentialOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if getAgentCredential(r) != nil {
+			utils.WriteAndLogError(ctrl.Log, w, http.StatusForbidden, utils.ErrPermissionDenied)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// getAgentCredential return the agent credential used to authenticate the request,
+// nil if the request has been authenticated with the shared credential
+func getAgentCredential(r *http.Request) *model.AgentCredential {
+	credential, _ := r.Context().Value(agentCredentialContextKey{}).(*model.AgentCredential)
+	return credential
+}
+
+// checkAgentHostname verify that the agent credential used by the request can send data of hostname
+func checkAgentHostname(r *http.Request, hostname string) error {
+	if credential := getAgentCredential(r); credential != nil && !credential.CanSendHostname(hostname) {
+		return utils.NewErrorf("%w: %s", utils.ErrHostnameNotAllowed, hostname)
+	}
+
+	return nil
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 }
@@ -16,14 +16,22 @@
 package controller
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
 
 	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
@@ -58,7 +66,13 @@ func TestAuthenticateMiddleware_Success(t *testing.T) {
 func TestAuthenticateMiddleware_Unauthorized(t *testing.T) {
 	var err error
 
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+	as.EXPECT().AuthenticateAgent("agent", "T0poL1no").Return(nil, utils.ErrInvalidAgentCredential)
+
 	ac := DataController{
+		Service: as,
 		TimeNow: utils.Btc(utils.P("2019-11-05T14:02:03Z")),
 		Config: config.Configuration{
 			DataService: config.DataService{
@@ -81,3 +95,248 @@ func TestAuthenticateMiddleware_Unauthorized(t *testing.T) {
 
 	require.Equal(t, http.StatusUnauthorized, rr.Code)
 }
+
+func TestAuthenticateMiddleware_MissingCredentials(t *testing.T) {
+	ac := DataController{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				AgentUsername: "agent",
+				AgentPassword: "p4ssW0rd",
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := ac.AuthenticateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthenticateMiddleware_AgentCredential(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+
+	ac := DataController{
+		Service: as,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				AgentUsername: "agent",
+				AgentPassword: "p4ssW0rd",
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	credential := &model.AgentCredential{Name: "web", HostnamePatterns: []string{"web-*"}}
+	as.EXPECT().AuthenticateAgent("web", "s3cr3tKey").Return(credential, nil)
+
+	var actual *model.AgentCredential
+
+	rr := httptest.NewRecorder()
+	handler := ac.AuthenticateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual = getAgentCredential(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("web", "s3cr3tKey")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, credential, actual)
+}
+
+func TestAuthenticateMiddleware_EmptySharedCredential(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+
+	ac := DataController{
+		Service: as,
+		Config:  config.Configuration{},
+		Log:     logger.NewLogger("TEST"),
+	}
+
+	as.EXPECT().AuthenticateAgent("", "").Return(nil, utils.ErrInvalidAgentCredential)
+
+	rr := httptest.NewRecorder()
+	handler := ac.AuthenticateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("", "")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestSharedCredentialOnly_Forbidden(t *testing.T) {
+	ac := DataController{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := ac.sharedCredentialOnly(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	req, err := http.NewRequest("GET", "/", nil)
+	require.NoError(t, err)
+	req = req.WithContext(context.WithValue(req.Context(), agentCredentialContextKey{}, &model.AgentCredential{Name: "web"}))
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestAdminAuthenticateMiddleware(t *testing.T) {
+	ac := DataController{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				AgentUsername: "agent",
+				AgentPassword: "p4ssW0rd",
+			},
+			APIService: config.APIService{
+				AuthenticationProvider: config.AuthenticationProviderConfig{
+					Username: "ercole",
+					Password: "s3rv1ce",
+				},
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	handler := ac.AdminAuthenticateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	testCases := []struct {
+		name     string
+		username string
+		password string
+		expected int
+	}{
+		{"api-service credential", "ercole", "s3rv1ce", http.StatusNoContent},
+		{"shared agent credential", "agent", "p4ssW0rd", http.StatusUnauthorized},
+		{"wrong password", "ercole", "wrong", http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/", nil)
+			require.NoError(t, err)
+			req.SetBasicAuth(tc.username, tc.password)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.expected, rr.Code)
+		})
+	}
+
+	t.Run("Empty api-service credential", func(t *testing.T) {
+		ac := DataController{
+			Config: config.Configuration{},
+			Log:    logger.NewLogger("TEST"),
+		}
+
+		handler := ac.AdminAuthenticateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		req, err := http.NewRequest("GET", "/", nil)
+		require.NoError(t, err)
+		req.SetBasicAuth("", "")
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestDeprecatedCmdbsRoute(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	as := NewMockHostDataServiceInterface(mockCtrl)
+
+	ac := DataController{
+		Service: as,
+		Config: config.Configuration{
+			DataService: config.DataService{
+				AgentUsername: "agent",
+				AgentPassword: "p4ssW0rd",
+			},
+		},
+		Log: logger.NewLogger("TEST"),
+	}
+
+	cmdbInfo := dto.CmdbInfo{Name: "thisCmdb", Hostnames: []string{"foobar"}}
+	as.EXPECT().CompareCmdbInfo(cmdbInfo).Return(&model.CmdbSnapshot{Name: "thisCmdb"}, nil)
+
+	req, err := http.NewRequest("POST", "/cmdbs", strings.NewReader(`{"name":"thisCmdb","hostnames":["foobar"]}`))
+	require.NoError(t, err)
+	req.SetBasicAuth("agent", "p4ssW0rd")
+
+	rr := httptest.NewRecorder()
+	ac.GetDataControllerHandler().ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestDecompressMiddleware_Gzip(t *testing.T) {
+	ac := DataController{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte(`{"hostname":"foobar"}`))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	rr := httptest.NewRecorder()
+	handler := ac.decompressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"hostname":"foobar"}`, string(body))
+		require.Empty(t, r.Header.Get("Content-Encoding"))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req, err := http.NewRequest("POST", "/", &compressed)
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestDecompressMiddleware_InvalidGzip(t *testing.T) {
+	ac := DataController{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	rr := httptest.NewRecorder()
+	handler := ac.decompressMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req, err := http.NewRequest("POST", "/", strings.NewReader(`{"hostname":"foobar"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
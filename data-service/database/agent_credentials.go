@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const agentCredentialCollection = "agent_credentials"
+
+func (md *MongoDatabase) GetAgentCredentialByName(name string) (*model.AgentCredential, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		FindOne(context.TODO(), bson.M{"name": name})
+	if res.Err() == mongo.ErrNoDocuments {
+		return nil, utils.ErrAgentCredentialNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	result := &model.AgentCredential{}
+	if err := res.Decode(result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) UpdateAgentCredentialLastSeen(id primitive.ObjectID, lastSeenAt time.Time) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(agentCredentialCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": bson.M{"lastSeenAt": lastSeenAt}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
@@ -53,3 +54,39 @@ func (md *MongoDatabase) DeleteNoDataAlertByHost(hostname string) error {
 
 	return nil
 }
+
+// FindUnresolvedAlertsByCode return the alerts with the code, acknowledged or not, that haven't been resolved yet
+func (md *MongoDatabase) FindUnresolvedAlertsByCode(code string) ([]model.Alert, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection("alerts").
+		Find(ctx, bson.M{
+			"alertCode":          code,
+			"otherInfo.resolved": bson.M{"$ne": true},
+		})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	alerts := make([]model.Alert, 0)
+	if err := cur.All(ctx, &alerts); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return alerts, nil
+}
+
+// ResolveAlerts set the status of the alerts to ACK and mark them as resolved
+func (md *MongoDatabase) ResolveAlerts(ids []primitive.ObjectID) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).
+		Collection("alerts").
+		UpdateMany(context.TODO(),
+			bson.M{"_id": bson.M{"$in": ids}},
+			bson.M{"$set": bson.M{"alertStatus": model.AlertStatusAck, "otherInfo.resolved": true}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	cmdbSnapshotCollection = "cmdb_snapshots"
+	cmdbRecordCollection   = "cmdb_records"
+)
+
+// cmdbRecord is a record of a CMDB snapshot, saved as a document on its own
+// so that the size of a snapshot isn't limited by the size of a document
+type cmdbRecord struct {
+	SnapshotID       primitive.ObjectID `bson:"snapshotID"`
+	Cmdb             string             `bson:"cmdb"`
+	model.CmdbRecord `bson:",inline"`
+}
+
+// latestCmdbSnapshotsPipeline return the pipeline selecting the latest snapshot of every CMDB, without its records
+func latestCmdbSnapshotsPipeline(match bson.M) bson.A {
+	return bson.A{
+		bson.M{"$match": match},
+		bson.M{"$sort": bson.D{{Key: "name", Value: 1}, {Key: "receivedAt", Value: -1}, {Key: "_id", Value: -1}}},
+		bson.M{"$group": bson.M{"_id": "$name", "snapshot": bson.M{"$first": "$$ROOT"}}},
+		bson.M{"$replaceRoot": bson.M{"newRoot": "$snapshot"}},
+		bson.M{"$project": bson.M{"records": 0}},
+	}
+}
+
+// SaveCmdbSnapshot saves the snapshot with its records, then removes the previous snapshots of the same CMDB.
+// The snapshot replaces the previous ones as soon as it's saved, since only the latest snapshot of a CMDB is read
+func (md *MongoDatabase) SaveCmdbSnapshot(snapshot model.CmdbSnapshot) error {
+	ctx := context.TODO()
+	db := md.Client.Database(md.Config.Mongodb.DBName)
+
+	if len(snapshot.Records) > 0 {
+		records := make([]interface{}, 0, len(snapshot.Records))
+		for _, record := range snapshot.Records {
+			records = append(records, cmdbRecord{
+				SnapshotID: snapshot.ID,
+				Cmdb:       snapshot.Name,
+				CmdbRecord: record,
+			})
+		}
+
+		if _, err := db.Collection(cmdbRecordCollection).InsertMany(ctx, records); err != nil {
+			return utils.NewError(err, "DB ERROR")
+		}
+	}
+
+	if _, err := db.Collection(cmdbSnapshotCollection).InsertOne(ctx, bson.M{
+		"_id":                 snapshot.ID,
+		"name":                snapshot.Name,
+		"override":            snapshot.Override,
+		"receivedAt":          snapshot.ReceivedAt,
+		"unknownHostnames":    snapshot.UnknownHostnames,
+		"missingHostnames":    snapshot.MissingHostnames,
+		"attributeMismatches": snapshot.AttributeMismatches,
+	}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if _, err := db.Collection(cmdbSnapshotCollection).DeleteMany(ctx,
+		bson.M{"name": snapshot.Name, "_id": bson.M{"$ne": snapshot.ID}}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if _, err := db.Collection(cmdbRecordCollection).DeleteMany(ctx,
+		bson.M{"cmdb": snapshot.Name, "snapshotID": bson.M{"$ne": snapshot.ID}}); err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) ListCmdbSnapshots() ([]model.CmdbSnapshot, error) {
+	ctx := context.TODO()
+
+	pipeline := append(latestCmdbSnapshotsPipeline(bson.M{}), bson.M{"$sort": bson.M{"name": 1}})
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(cmdbSnapshotCollection).
+		Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.CmdbSnapshot, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) GetCmdbSnapshot(name string) (*model.CmdbSnapshot, error) {
+	ctx := context.TODO()
+	db := md.Client.Database(md.Config.Mongodb.DBName)
+
+	res := db.Collection(cmdbSnapshotCollection).
+		FindOne(ctx, bson.M{"name": name}, options.FindOne().
+			SetSort(bson.D{{Key: "receivedAt", Value: -1}, {Key: "_id", Value: -1}}).
+			SetProjection(bson.M{"records": 0}))
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, utils.ErrCmdbSnapshotNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var snapshot model.CmdbSnapshot
+	if err := res.Decode(&snapshot); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	cur, err := db.Collection(cmdbRecordCollection).Find(ctx, bson.M{"snapshotID": snapshot.ID})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	records := make([]cmdbRecord, 0)
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	snapshot.Records = make([]model.CmdbRecord, 0, len(records))
+	for _, record := range records {
+		snapshot.Records = append(snapshot.Records, record.CmdbRecord)
+	}
+
+	return &snapshot, nil
+}
+
+func (md *MongoDatabase) FindCmdbOverrideRecord(hostname string) (*model.CmdbRecord, error) {
+	ctx := context.TODO()
+	db := md.Client.Database(md.Config.Mongodb.DBName)
+
+	pipeline := append(latestCmdbSnapshotsPipeline(bson.M{}),
+		bson.M{"$match": bson.M{"override": true}},
+		bson.M{"$sort": bson.D{{Key: "receivedAt", Value: -1}, {Key: "_id", Value: -1}}},
+		bson.M{"$project": bson.M{"_id": 1}},
+	)
+
+	cur, err := db.Collection(cmdbSnapshotCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	var snapshots []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cur.All(ctx, &snapshots); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.ID)
+	}
+
+	cur, err = db.Collection(cmdbRecordCollection).
+		Find(ctx, bson.M{"snapshotID": bson.M{"$in": ids}, "ercoleHostname": hostname})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	records := make([]cmdbRecord, 0)
+	if err := cur.All(ctx, &records); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	for _, id := range ids {
+		for _, record := range records {
+			if record.SnapshotID == id {
+				return &record.CmdbRecord, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (md *MongoDatabase) FindCurrentHostsCmdbAttributes(hostnames []string) ([]model.HostDataBE, error) {
+	ctx := context.TODO()
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		Find(ctx,
+			bson.M{
+				"archived":    false,
+				"dismissedAt": nil,
+				"hostname":    bson.M{"$in": hostnames},
+			},
+			options.Find().SetProjection(bson.M{
+				"hostname":    1,
+				"location":    1,
+				"environment": 1,
+				"tags":        1,
+			}))
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.HostDataBE, 0)
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return result, nil
+}
+
+func (md *MongoDatabase) UpdateCurrentHostCmdbAttributes(hostname, location, environment string, tags []string) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		UpdateOne(context.TODO(),
+			bson.M{
+				"archived":    false,
+				"dismissedAt": nil,
+				"hostname":    hostname,
+			},
+			bson.M{"$set": bson.M{
+				"location":    location,
+				"environment": environment,
+				"tags":        tags,
+			}})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
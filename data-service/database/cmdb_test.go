@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestCmdbSnapshots() {
+	defer m.db.Client.Database(m.dbname).Collection(cmdbSnapshotCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(cmdbRecordCollection).DeleteMany(context.TODO(), bson.M{})
+
+	oldSnapshot := model.CmdbSnapshot{
+		ID:         utils.Str2oid("5fcb9a000000000000000001"),
+		Name:       "servicenow",
+		Override:   true,
+		ReceivedAt: utils.P("2020-12-01T10:00:00Z"),
+		Records: []model.CmdbRecord{
+			{Hostname: "db01", ErcoleHostname: "db01", Location: "Italy"},
+			{Hostname: "db02", ErcoleHostname: "db02", Location: "Italy"},
+		},
+		UnknownHostnames:    []string{},
+		MissingHostnames:    []string{},
+		AttributeMismatches: []model.CmdbMismatch{},
+	}
+	newSnapshot := model.CmdbSnapshot{
+		ID:         utils.Str2oid("5fcb9a000000000000000002"),
+		Name:       "servicenow",
+		Override:   true,
+		ReceivedAt: utils.P("2020-12-02T10:00:00Z"),
+		Records: []model.CmdbRecord{
+			{Hostname: "db01", ErcoleHostname: "db01", Location: "Germany"},
+		},
+		UnknownHostnames:    []string{},
+		MissingHostnames:    []string{"db02"},
+		AttributeMismatches: []model.CmdbMismatch{},
+	}
+	otherSnapshot := model.CmdbSnapshot{
+		ID:         utils.Str2oid("5fcb9a000000000000000003"),
+		Name:       "other",
+		Override:   false,
+		ReceivedAt: utils.P("2020-12-03T10:00:00Z"),
+		Records: []model.CmdbRecord{
+			{Hostname: "db01", ErcoleHostname: "db01", Location: "France"},
+		},
+		UnknownHostnames:    []string{},
+		MissingHostnames:    []string{},
+		AttributeMismatches: []model.CmdbMismatch{},
+	}
+
+	for _, snapshot := range []model.CmdbSnapshot{oldSnapshot, newSnapshot, otherSnapshot} {
+		require.NoError(m.T(), m.db.SaveCmdbSnapshot(snapshot))
+	}
+
+	m.T().Run("Previous snapshots are removed", func(t *testing.T) {
+		count, err := m.db.Client.Database(m.dbname).Collection(cmdbRecordCollection).
+			CountDocuments(context.TODO(), bson.M{"snapshotID": oldSnapshot.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		snapshots, err := m.db.ListCmdbSnapshots()
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, "other", snapshots[0].Name)
+		assert.Equal(t, newSnapshot.ID, snapshots[1].ID)
+		assert.Empty(t, snapshots[1].Records)
+	})
+
+	m.T().Run("Get with records", func(t *testing.T) {
+		snapshot, err := m.db.GetCmdbSnapshot("servicenow")
+		require.NoError(t, err)
+		require.NotNil(t, snapshot)
+		assert.Equal(t, newSnapshot.ID, snapshot.ID)
+		assert.Equal(t, newSnapshot.Records, snapshot.Records)
+		assert.Equal(t, newSnapshot.MissingHostnames, snapshot.MissingHostnames)
+
+		_, err = m.db.GetCmdbSnapshot("missing")
+		assert.ErrorIs(t, err, utils.ErrCmdbSnapshotNotFound)
+	})
+
+	m.T().Run("Override record of the latest snapshot only", func(t *testing.T) {
+		record, err := m.db.FindCmdbOverrideRecord("db01")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, "Germany", record.Location)
+
+		record, err = m.db.FindCmdbOverrideRecord("db02")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	m.T().Run("Stale snapshot of a CMDB is ignored", func(t *testing.T) {
+		_, err := m.db.Client.Database(m.dbname).Collection(cmdbSnapshotCollection).InsertOne(context.TODO(), bson.M{
+			"_id":        utils.Str2oid("5fcb9a000000000000000000"),
+			"name":       "servicenow",
+			"override":   true,
+			"receivedAt": utils.P("2020-11-30T10:00:00Z"),
+		})
+		require.NoError(t, err)
+		_, err = m.db.Client.Database(m.dbname).Collection(cmdbRecordCollection).InsertOne(context.TODO(), cmdbRecord{
+			SnapshotID: utils.Str2oid("5fcb9a000000000000000000"),
+			Cmdb:       "servicenow",
+			CmdbRecord: model.CmdbRecord{Hostname: "db02", ErcoleHostname: "db02", Location: "Italy"},
+		})
+		require.NoError(t, err)
+
+		record, err := m.db.FindCmdbOverrideRecord("db02")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+
+		snapshots, err := m.db.ListCmdbSnapshots()
+		require.NoError(t, err)
+		assert.Len(t, snapshots, 2)
+	})
+}
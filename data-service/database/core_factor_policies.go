@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const coreFactorPolicyCollection = "core_factor_policies"
+
+// FindActiveCoreFactorPolicy return the active core factor policy, nil if no policy has been saved
+func (md *MongoDatabase) FindActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(coreFactorPolicyCollection).
+		FindOne(context.TODO(), bson.M{"active": true})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var policy model.CoreFactorPolicy
+	if err := res.Decode(&policy); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	if err := policy.Compile(); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &policy, nil
+}
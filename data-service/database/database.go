@@ -37,18 +37,33 @@ type MongoDatabaseInterface interface {
 	DismissHost(hostname string) error
 	InsertHostData(hostData model.HostDataBE) error
 	ExistsDR(hostname string) bool
+	ListDisasterRecoveryPairs() ([]model.DisasterRecoveryPair, error)
 	GetCurrentHostnames() ([]string, error)
+	// GetCurrentHostsLocations return the list of the locations of the current hosts
+	GetCurrentHostsLocations() ([]string, error)
 	// FindOldCurrentHostnames return the list of current hosts names that haven't sent hostdata after time t
 	FindOldCurrentHostnames(t time.Time) ([]string, error)
 	FindOldCurrentHostdata(hostName string, t time.Time) (bool, error)
+	// ExistsCurrentHostDataNotOlderThan return true if the current hostdata of hostname has been created at or after t
+	ExistsCurrentHostDataNotOlderThan(hostname string, t time.Time) (bool, error)
 	// FindOldArchivedHosts return the list of archived hosts older than t
 	FindOldArchivedHosts(t time.Time) ([]primitive.ObjectID, error)
+	// FindHostData return the hostdata with the id, nil if it doesn't exist
+	FindHostData(id primitive.ObjectID) (*model.HostDataBE, error)
 	GetActiveHostdata() ([]model.HostDataBE, error)
 	DeleteHostData(id primitive.ObjectID) error
 	HistoricizeLicensesCompliance(licenses []dto.LicenseCompliance) error
+	// HistoricizeLocationLicensesCompliance save the licenses compliance of location in the history of today
+	HistoricizeLocationLicensesCompliance(location string, licenses []dto.LicenseCompliance) error
+	// HistoricizeOracleDatabaseContractsLicensesCompliance save the licenses compliance of each contract in the history of today
+	HistoricizeOracleDatabaseContractsLicensesCompliance(contracts []dto.OracleDatabaseContractLicenseCompliance) error
 
 	DeleteNoDataAlertByHost(hostname string) error
 	DeleteAllNoDataAlerts() error
+	// FindUnresolvedAlertsByCode return the alerts with the code, acknowledged or not, that haven't been resolved yet
+	FindUnresolvedAlertsByCode(code string) ([]model.Alert, error)
+	// ResolveAlerts set the status of the alerts to ACK and mark them as resolved
+	ResolveAlerts(ids []primitive.ObjectID) error
 	// FindMostRecentHostDataOlderThan return the most recest hostdata that is older than t
 	FindMostRecentHostDataOlderThan(hostname string, t time.Time) (*model.HostDataBE, error)
 	GetHostnames() ([]string, error)
@@ -61,6 +76,57 @@ type MongoDatabaseInterface interface {
 	PushComponentToExadataInstance(rackID string, component model.OracleExadataComponent) error
 	SetExadataComponent(rackID string, component model.OracleExadataComponent) error
 	UpdateExadataHidden(rackID string, hidden bool) error
+
+	EnqueueHostData(item model.HostDataQueueItem) error
+	// DequeueHostData lock and return the oldest hostdata ready to be processed, nil if there isn't any.
+	// The attempts of the returned hostdata include the current one
+	DequeueHostData(now, lockedUntil time.Time) (*model.HostDataQueueItem, error)
+	DeleteQueuedHostData(id primitive.ObjectID) error
+	// RescheduleQueuedHostData release the hostdata so it can be processed again after nextAttemptAt
+	RescheduleQueuedHostData(id primitive.ObjectID, attempts int, nextAttemptAt time.Time, lastError string) error
+	// MoveQueuedHostDataToDeadLetters remove the hostdata from the queue and save it in the dead letters
+	MoveQueuedHostDataToDeadLetters(item model.HostDataQueueItem) error
+	CountQueuedHostData(status string) (int64, error)
+	CountHostDataDeadLetters() (int64, error)
+	ListHostDataDeadLetters() ([]model.HostDataQueueItem, error)
+
+	InsertQuarantinedHostData(item model.HostDataQuarantineItem) error
+	// ListQuarantinedHostData return the quarantined hostdata without their payload, filtered by hostname and status if not empty
+	ListQuarantinedHostData(hostname, status string) ([]model.HostDataQuarantineItem, error)
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error)
+	UpdateQuarantinedHostDataPayload(id primitive.ObjectID, hostname, payload string, validationErrors []string, updatedAt time.Time) error
+	// ClaimQuarantinedHostData atomically marks a quarantined hostdata as being replayed and returns it
+	ClaimQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error)
+	// ReleaseQuarantinedHostData puts back in quarantine a hostdata whose replay failed
+	ReleaseQuarantinedHostData(id primitive.ObjectID) error
+	SetQuarantinedHostDataReplayed(id primitive.ObjectID, replayedAt time.Time) error
+
+	InsertHostChanges(changes []model.HostChange) error
+
+	ListHostAliases() ([]model.HostAlias, error)
+
+	// FindActiveCoreFactorPolicy return the active core factor policy, nil if no policy has been saved
+	FindActiveCoreFactorPolicy() (*model.CoreFactorPolicy, error)
+
+	// SaveCmdbSnapshot replaces the last snapshot of the CMDB with the same name, saving each record as its own document
+	SaveCmdbSnapshot(snapshot model.CmdbSnapshot) error
+	// ListCmdbSnapshots return the last snapshot of every CMDB, without its records
+	ListCmdbSnapshots() ([]model.CmdbSnapshot, error)
+	GetCmdbSnapshot(name string) (*model.CmdbSnapshot, error)
+	// FindCmdbOverrideRecord return the record of hostname in the most recent snapshot overriding the hosts attributes,
+	// nil if there isn't any. Only the last snapshot of every CMDB is searched
+	FindCmdbOverrideRecord(hostname string) (*model.CmdbRecord, error)
+	// FindCurrentHostsCmdbAttributes return the current hostdata of hostnames with only the attributes compared with the CMDB
+	FindCurrentHostsCmdbAttributes(hostnames []string) ([]model.HostDataBE, error)
+	UpdateCurrentHostCmdbAttributes(hostname, location, environment string, tags []string) error
+
+	// FindActiveHostdataOracleLicenses return the current hostdata with oracle databases, filtered by location and
+	// hostnames if not empty. Only the fields needed to assign the licenses are returned
+	FindActiveHostdataOracleLicenses(location string, hostnames []string) ([]model.HostDataBE, error)
+	UpdateHostdataOracleDatabaseLicenses(id primitive.ObjectID, dbName string, licenses []model.OracleDatabaseLicense) error
+
+	GetAgentCredentialByName(name string) (*model.AgentCredential, error)
+	UpdateAgentCredentialLastSeen(id primitive.ObjectID, lastSeenAt time.Time) error
 }
 
 type MongoDatabase struct {
@@ -20,8 +20,13 @@ import (
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
+const disasterRecoveryPairCollection = "disaster_recovery_pairs"
+
 func (md *MongoDatabase) ExistsDR(hostname string) bool {
 	filter := bson.M{"archived": false, "isDR": true, "hostname": hostname}
 
@@ -30,3 +35,24 @@ func (md *MongoDatabase) ExistsDR(hostname string) bool {
 
 	return res.Err() == nil
 }
+
+func (md *MongoDatabase) ListDisasterRecoveryPairs() ([]model.DisasterRecoveryPair, error) {
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(disasterRecoveryPairCollection).
+		Find(context.TODO(), bson.M{})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	result := make([]model.DisasterRecoveryPair, 0)
+	if err := cur.All(context.TODO(), &result); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	for i := range result {
+		if err := result[i].Compile(); err != nil {
+			return nil, utils.NewError(err, "DB ERROR")
+		}
+	}
+
+	return result, nil
+}
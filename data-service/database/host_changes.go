@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostChangesCollection = "host_changes"
+
+func (md *MongoDatabase) InsertHostChanges(changes []model.HostChange) error {
+	documents := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		documents = append(documents, change)
+	}
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostChangesCollection).
+		InsertMany(context.TODO(), documents)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const hostdataQuarantineCollection = "hostdata_quarantine"
+
+func (md *MongoDatabase) InsertQuarantinedHostData(item model.HostDataQuarantineItem) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		InsertOne(context.TODO(), item)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// ListQuarantinedHostData return the quarantined hostdata without their payload, filtered by hostname and status if not empty
+func (md *MongoDatabase) ListQuarantinedHostData(hostname, status string) ([]model.HostDataQuarantineItem, error) {
+	ctx := context.TODO()
+
+	filter := bson.M{}
+	if hostname != "" {
+		filter["hostname"] = hostname
+	}
+
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "receivedAt", Value: -1}}).
+		SetProjection(bson.M{"payload": 0})
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		Find(ctx, filter, opts)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]model.HostDataQuarantineItem, 0)
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return items, nil
+}
+
+func (md *MongoDatabase) GetQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		FindOne(context.TODO(), bson.M{"_id": id})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, utils.ErrNotFound
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var item model.HostDataQuarantineItem
+	if err := res.Decode(&item); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &item, nil
+}
+
+func (md *MongoDatabase) UpdateQuarantinedHostDataPayload(id primitive.ObjectID, hostname, payload string, validationErrors []string, updatedAt time.Time) error {
+	return md.updateQuarantinedHostData(id, bson.M{
+		"hostname":         hostname,
+		"payload":          payload,
+		"validationErrors": validationErrors,
+		"updatedAt":        updatedAt,
+	})
+}
+
+func (md *MongoDatabase) ClaimQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error) {
+	ctx := context.TODO()
+
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		FindOneAndUpdate(ctx,
+			bson.M{"_id": id, "status": model.HostDataQuarantineItemStatusQuarantined},
+			mu.UOSet(bson.M{"status": model.HostDataQuarantineItemStatusReplaying}),
+			options.FindOneAndUpdate().SetReturnDocument(options.After))
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		item, err := md.GetQuarantinedHostData(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Status == model.HostDataQuarantineItemStatusReplaying {
+			return nil, utils.ErrHostDataBeingReplayed
+		}
+
+		return nil, utils.ErrHostDataAlreadyReplayed
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var item model.HostDataQuarantineItem
+	if err := res.Decode(&item); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &item, nil
+}
+
+func (md *MongoDatabase) ReleaseQuarantinedHostData(id primitive.ObjectID) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		UpdateOne(context.TODO(),
+			bson.M{"_id": id, "status": model.HostDataQuarantineItemStatusReplaying},
+			mu.UOSet(bson.M{"status": model.HostDataQuarantineItemStatusQuarantined}))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) SetQuarantinedHostDataReplayed(id primitive.ObjectID, replayedAt time.Time) error {
+	return md.updateQuarantinedHostData(id, bson.M{
+		"status":     model.HostDataQuarantineItemStatusReplayed,
+		"replayedAt": replayedAt,
+	})
+}
+
+func (md *MongoDatabase) updateQuarantinedHostData(id primitive.ObjectID, set bson.M) error {
+	res, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQuarantineCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, mu.UOSet(set))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	if res.MatchedCount != 1 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amreo/mu"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+const (
+	hostdataQueueCollection            = "hostdata_queue"
+	hostdataQueueDeadLettersCollection = "hostdata_queue_dead_letters"
+)
+
+func (md *MongoDatabase) EnqueueHostData(item model.HostDataQueueItem) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueCollection).
+		InsertOne(context.TODO(), item)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// DequeueHostData lock and return the oldest hostdata ready to be processed, nil if there isn't any.
+// The attempts of the returned hostdata include the current one
+func (md *MongoDatabase) DequeueHostData(now, lockedUntil time.Time) (*model.HostDataQueueItem, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{
+				"status":        model.HostDataQueueItemStatusPending,
+				"nextAttemptAt": mu.QOLessThanOrEqual(now),
+			},
+			bson.M{
+				"status":      model.HostDataQueueItemStatusProcessing,
+				"lockedUntil": mu.QOLessThan(now),
+			},
+		},
+	}
+
+	// the attempt is counted before the processing, so an item that crashes the processing reaches the dead letters
+	update := bson.M{
+		"$set": bson.M{
+			"status":      model.HostDataQueueItemStatusProcessing,
+			"lockedUntil": lockedUntil,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueCollection).
+		FindOneAndUpdate(context.TODO(), filter, update, opts)
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var item model.HostDataQueueItem
+	if err := res.Decode(&item); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &item, nil
+}
+
+func (md *MongoDatabase) DeleteQueuedHostData(id primitive.ObjectID) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueCollection).
+		DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// RescheduleQueuedHostData release the hostdata so it can be processed again after nextAttemptAt
+func (md *MongoDatabase) RescheduleQueuedHostData(id primitive.ObjectID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	update := mu.UOSet(bson.M{
+		"status":        model.HostDataQueueItemStatusPending,
+		"attempts":      attempts,
+		"nextAttemptAt": nextAttemptAt,
+		"lastError":     lastError,
+	})
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueCollection).
+		UpdateOne(context.TODO(), bson.M{"_id": id}, update)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
+
+// MoveQueuedHostDataToDeadLetters remove the hostdata from the queue and save it in the dead letters
+func (md *MongoDatabase) MoveQueuedHostDataToDeadLetters(item model.HostDataQueueItem) error {
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueDeadLettersCollection).
+		InsertOne(context.TODO(), item)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return md.DeleteQueuedHostData(item.ID)
+}
+
+func (md *MongoDatabase) CountQueuedHostData(status string) (int64, error) {
+	count, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueCollection).
+		CountDocuments(context.TODO(), bson.M{"status": status})
+	if err != nil {
+		return 0, utils.NewError(err, "DB ERROR")
+	}
+
+	return count, nil
+}
+
+func (md *MongoDatabase) CountHostDataDeadLetters() (int64, error) {
+	count, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueDeadLettersCollection).
+		CountDocuments(context.TODO(), bson.M{})
+	if err != nil {
+		return 0, utils.NewError(err, "DB ERROR")
+	}
+
+	return count, nil
+}
+
+func (md *MongoDatabase) ListHostDataDeadLetters() ([]model.HostDataQueueItem, error) {
+	ctx := context.TODO()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failedAt", Value: -1}}).
+		SetProjection(bson.M{"hostdata": 0})
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(hostdataQueueDeadLettersCollection).
+		Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	items := make([]model.HostDataQueueItem, 0)
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return items, nil
+}
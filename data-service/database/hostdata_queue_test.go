@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func (m *MongodbSuite) TestHostDataQueue() {
+	defer m.db.Client.Database(m.dbname).Collection(hostdataQueueCollection).DeleteMany(context.TODO(), bson.M{})
+	defer m.db.Client.Database(m.dbname).Collection(hostdataQueueDeadLettersCollection).DeleteMany(context.TODO(), bson.M{})
+
+	now := utils.P("2020-12-05T14:02:03Z")
+
+	older := model.HostDataQueueItem{
+		ID:            utils.Str2oid("5fcb9a000000000000000001"),
+		Hostname:      "foobar",
+		Status:        model.HostDataQueueItemStatusPending,
+		CreatedAt:     utils.P("2020-12-05T14:00:00Z"),
+		NextAttemptAt: utils.P("2020-12-05T14:00:00Z"),
+		Hostdata:      model.HostDataBE{Hostname: "foobar"},
+	}
+	newer := model.HostDataQueueItem{
+		ID:            utils.Str2oid("5fcb9a000000000000000002"),
+		Hostname:      "foobar",
+		Status:        model.HostDataQueueItemStatusPending,
+		CreatedAt:     utils.P("2020-12-05T14:01:00Z"),
+		NextAttemptAt: utils.P("2020-12-05T14:01:00Z"),
+		Hostdata:      model.HostDataBE{Hostname: "foobar"},
+	}
+	notReady := model.HostDataQueueItem{
+		ID:            utils.Str2oid("5fcb9a000000000000000003"),
+		Hostname:      "barfoo",
+		Status:        model.HostDataQueueItemStatusPending,
+		CreatedAt:     utils.P("2020-12-05T13:00:00Z"),
+		NextAttemptAt: utils.P("2020-12-05T15:00:00Z"),
+		Hostdata:      model.HostDataBE{Hostname: "barfoo"},
+	}
+
+	for _, item := range []model.HostDataQueueItem{newer, older, notReady} {
+		require.NoError(m.T(), m.db.EnqueueHostData(item))
+	}
+
+	m.T().Run("Dequeue the oldest ready, counting the attempt", func(t *testing.T) {
+		item, err := m.db.DequeueHostData(now, now.Add(10*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, item)
+
+		assert.Equal(t, older.ID, item.ID)
+		assert.Equal(t, model.HostDataQueueItemStatusProcessing, item.Status)
+		assert.Equal(t, 1, item.Attempts)
+		assert.Equal(t, older.CreatedAt, item.CreatedAt.UTC())
+
+		item, err = m.db.DequeueHostData(now, now.Add(10*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, item)
+		assert.Equal(t, newer.ID, item.ID)
+
+		item, err = m.db.DequeueHostData(now, now.Add(10*time.Minute))
+		require.NoError(t, err)
+		assert.Nil(t, item)
+
+		count, err := m.db.CountQueuedHostData(model.HostDataQueueItemStatusProcessing)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	m.T().Run("Dequeue again an item whose lock is expired", func(t *testing.T) {
+		later := now.Add(20 * time.Minute)
+
+		item, err := m.db.DequeueHostData(later, later.Add(10*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, item)
+
+		assert.Equal(t, older.ID, item.ID)
+		assert.Equal(t, 2, item.Attempts)
+	})
+
+	m.T().Run("Reschedule", func(t *testing.T) {
+		err := m.db.RescheduleQueuedHostData(older.ID, 2, utils.P("2020-12-05T16:00:00Z"), "mock error")
+		require.NoError(t, err)
+
+		count, err := m.db.CountQueuedHostData(model.HostDataQueueItemStatusPending)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		item, err := m.db.DequeueHostData(now, now.Add(10*time.Minute))
+		require.NoError(t, err)
+		assert.Nil(t, item)
+	})
+
+	m.T().Run("Move to dead letters", func(t *testing.T) {
+		failedAt := utils.P("2020-12-05T14:30:00Z")
+		failed := newer
+		failed.Status = model.HostDataQueueItemStatusFailed
+		failed.Attempts = 5
+		failed.LastError = "mock error"
+		failed.FailedAt = &failedAt
+
+		require.NoError(t, m.db.MoveQueuedHostDataToDeadLetters(failed))
+
+		count, err := m.db.CountHostDataDeadLetters()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = m.db.CountQueuedHostData(model.HostDataQueueItemStatusProcessing)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		deadLetters, err := m.db.ListHostDataDeadLetters()
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, newer.ID, deadLetters[0].ID)
+		assert.Equal(t, "mock error", deadLetters[0].LastError)
+		assert.Equal(t, model.HostDataBE{}, deadLetters[0].Hostdata)
+	})
+
+	m.T().Run("Delete", func(t *testing.T) {
+		require.NoError(t, m.db.DeleteQueuedHostData(older.ID))
+
+		count, err := m.db.CountQueuedHostData(model.HostDataQueueItemStatusPending)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func (m *MongodbSuite) TestFindHostData() {
+	defer m.db.Client.Database(m.dbname).Collection("hosts").DeleteMany(context.TODO(), bson.M{})
+
+	hostdata := model.HostDataBE{
+		ID:        utils.Str2oid("5fcb9a000000000000000010"),
+		Hostname:  "foobar",
+		CreatedAt: utils.P("2020-12-05T14:00:00Z"),
+	}
+	require.NoError(m.T(), m.db.InsertHostData(hostdata))
+
+	actual, err := m.db.FindHostData(hostdata.ID)
+	require.NoError(m.T(), err)
+	require.NotNil(m.T(), actual)
+	assert.Equal(m.T(), "foobar", actual.Hostname)
+
+	actual, err = m.db.FindHostData(utils.Str2oid("5fcb9a000000000000000011"))
+	require.NoError(m.T(), err)
+	assert.Nil(m.T(), actual)
+}
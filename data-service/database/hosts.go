@@ -17,11 +17,13 @@ package database
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/amreo/mu"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
@@ -70,6 +72,29 @@ func (md *MongoDatabase) GetCurrentHostnames() ([]string, error) {
 	return hosts, nil
 }
 
+// GetCurrentHostsLocations return the list of the locations of the current hosts
+func (md *MongoDatabase) GetCurrentHostsLocations() ([]string, error) {
+	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Distinct(
+		context.TODO(),
+		"location",
+		bson.M{
+			"dismissedAt": nil,
+			"archived":    false,
+		})
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	var locations = make([]string, 0)
+	for _, val := range values {
+		if location, ok := val.(string); ok && location != "" {
+			locations = append(locations, location)
+		}
+	}
+
+	return locations, nil
+}
+
 // FindOldCurrentHostnames return the list of current hosts that haven't sent hostdata after time t
 func (md *MongoDatabase) FindOldCurrentHostnames(t time.Time) ([]string, error) {
 	values, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Distinct(
@@ -116,6 +141,42 @@ func (md *MongoDatabase) FindOldCurrentHostdata(hostName string, t time.Time) (b
 	return true, nil
 }
 
+// ExistsCurrentHostDataNotOlderThan return true if the current hostdata of hostname has been created at or after t
+func (md *MongoDatabase) ExistsCurrentHostDataNotOlderThan(hostname string, t time.Time) (bool, error) {
+	filter := bson.M{
+		"hostname":    hostname,
+		"dismissedAt": nil,
+		"archived":    false,
+		"createdAt":   bson.M{"$gte": t},
+	}
+
+	count, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return false, utils.NewError(err, "DB ERROR")
+	}
+
+	return count > 0, nil
+}
+
+// FindHostData return the hostdata with the id, nil if it doesn't exist
+func (md *MongoDatabase) FindHostData(id primitive.ObjectID) (*model.HostDataBE, error) {
+	res := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").
+		FindOne(context.TODO(), bson.M{"_id": id})
+	if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	} else if res.Err() != nil {
+		return nil, utils.NewError(res.Err(), "DB ERROR")
+	}
+
+	var hostdata model.HostDataBE
+	if err := res.Decode(&hostdata); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return &hostdata, nil
+}
+
 func (md *MongoDatabase) GetActiveHostdata() ([]model.HostDataBE, error) {
 	filter := bson.M{
 		"dismissedAt": nil,
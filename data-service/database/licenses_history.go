@@ -21,13 +21,18 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
-const licensesHistoryCollection = "database_licenses_history"
+const (
+	licensesHistoryCollection           = "database_licenses_history"
+	licensesHistoryByLocationCollection = "database_licenses_history_by_location"
+	licensesHistoryByContractCollection = "database_licenses_history_by_contract"
+)
 
 func (md *MongoDatabase) HistoricizeLicensesCompliance(licenses []dto.LicenseCompliance) error {
 	now := md.TimeNow()
@@ -67,6 +72,7 @@ func (md *MongoDatabase) updateLicenseComplianceHistoric(license dto.LicenseComp
 				{Key: "history.$.consumed", Value: license.Consumed},
 				{Key: "history.$.covered", Value: license.Covered},
 				{Key: "history.$.purchased", Value: license.Purchased},
+				{Key: "history.$.unlimited", Value: license.Unlimited},
 			},
 		}}
 
@@ -108,6 +114,7 @@ func (md *MongoDatabase) insertLicenseComplianceHistoric(license dto.LicenseComp
 						{Key: "consumed", Value: license.Consumed},
 						{Key: "covered", Value: license.Covered},
 						{Key: "purchased", Value: license.Purchased},
+						{Key: "unlimited", Value: license.Unlimited},
 					},
 				}}}}
 
@@ -123,3 +130,100 @@ func (md *MongoDatabase) insertLicenseComplianceHistoric(license dto.LicenseComp
 
 	return nil
 }
+
+// HistoricizeLocationLicensesCompliance save the licenses compliance of location in the history of today
+func (md *MongoDatabase) HistoricizeLocationLicensesCompliance(location string, licenses []dto.LicenseCompliance) error {
+	today := md.today()
+
+	for _, license := range licenses {
+		filter := bson.M{
+			"location":      location,
+			"licenseTypeID": license.LicenseTypeID,
+		}
+
+		if len(license.LicenseTypeID) == 0 {
+			filter["itemDescription"] = license.ItemDescription
+		}
+
+		info := bson.D{
+			{Key: "itemDescription", Value: license.ItemDescription},
+			{Key: "metric", Value: license.Metric},
+		}
+
+		if err := md.upsertLicenseComplianceHistoric(licensesHistoryByLocationCollection, filter, info,
+			license.Consumed, license.Covered, license.Purchased, license.Unlimited, today); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HistoricizeOracleDatabaseContractsLicensesCompliance save the licenses compliance of each contract in the history of today
+func (md *MongoDatabase) HistoricizeOracleDatabaseContractsLicensesCompliance(contracts []dto.OracleDatabaseContractLicenseCompliance) error {
+	today := md.today()
+
+	for _, contract := range contracts {
+		filter := bson.M{"_id": contract.ID}
+
+		info := bson.D{
+			{Key: "contractID", Value: contract.ContractID},
+			{Key: "csi", Value: contract.CSI},
+			{Key: "location", Value: contract.Location},
+			{Key: "licenseTypeID", Value: contract.LicenseTypeID},
+			{Key: "itemDescription", Value: contract.ItemDescription},
+			{Key: "metric", Value: contract.Metric},
+		}
+
+		if err := md.upsertLicenseComplianceHistoric(licensesHistoryByContractCollection, filter, info,
+			contract.Consumed, contract.Covered, contract.Purchased, contract.Unlimited, today); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (md *MongoDatabase) today() time.Time {
+	now := md.TimeNow()
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// upsertLicenseComplianceHistoric set the values of today in the history of the document matching filter,
+// adding the entry of today or the document if they don't exist yet.
+// It's a single update, so a concurrent historicization can't see or write a partial history
+func (md *MongoDatabase) upsertLicenseComplianceHistoric(collection string, filter bson.M, info bson.D,
+	consumed, covered, purchased float64, unlimited bool, today time.Time) error {
+	set := make(bson.D, 0, len(info)+1)
+	for _, e := range info {
+		set = append(set, bson.E{Key: e.Key, Value: bson.M{"$literal": e.Value}})
+	}
+
+	set = append(set, bson.E{
+		Key: "history",
+		Value: bson.M{
+			"$concatArrays": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+					"cond":  bson.M{"$ne": bson.A{"$$this.date", today}},
+				}},
+				bson.A{bson.D{
+					{Key: "date", Value: today},
+					{Key: "consumed", Value: consumed},
+					{Key: "covered", Value: covered},
+					{Key: "purchased", Value: purchased},
+					{Key: "unlimited", Value: unlimited},
+				}},
+			},
+		},
+	})
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection(collection).
+		UpdateOne(context.TODO(), filter, mongo.Pipeline{{{Key: "$set", Value: set}}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ package database
 import (
 	"context"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -106,10 +107,10 @@ func (m *MongodbSuite) TestHistoricizeLicensesCompliance() {
 		}
 
 		expected := []map[string]interface{}{
-			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "date": expectedDateDay1}}, "licenseTypeID": "L47247"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "date": expectedDateDay1}}, "licenseTypeID": "A90611"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "date": expectedDateDay1}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "L47247"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "A90611"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
 		}
 
 		assert.ElementsMatch(m.T(), expected, actual)
@@ -189,11 +190,11 @@ func (m *MongodbSuite) TestHistoricizeLicensesCompliance() {
 		expectedDateDay2 := utils.PDT("2020-12-06T00:00:00+02:00")
 
 		expected := []map[string]interface{}{
-			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "date": expectedDateDay1}, map[string]interface{}{"consumed": 0.5, "covered": 5.0, "purchased": 5.0, "date": expectedDateDay2}}, "licenseTypeID": "L47247"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "date": expectedDateDay1}, map[string]interface{}{"consumed": 4.5, "covered": 2.5, "purchased": 2.5, "date": expectedDateDay2}}, "licenseTypeID": "A90611"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "date": expectedDateDay2}}, "licenseTypeID": "PID001"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "date": expectedDateDay1}, map[string]interface{}{"consumed": 43.0, "covered": 86.0, "purchased": 86.0, "date": expectedDateDay2}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 0.5, "covered": 5.0, "purchased": 5.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "L47247"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 4.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "A90611"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "PID001"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 43.0, "covered": 86.0, "purchased": 86.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
 		}
 
 		assert.ElementsMatch(m.T(), expected, actual)
@@ -243,13 +244,167 @@ func (m *MongodbSuite) TestHistoricizeLicensesCompliance() {
 		expectedDateDay2 := utils.PDT("2020-12-06T00:00:00+02:00")
 
 		expected := []map[string]interface{}{
-			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "date": expectedDateDay1}, map[string]interface{}{"consumed": 0.5, "covered": 5.0, "purchased": 5.0, "date": expectedDateDay2}}, "licenseTypeID": "L47247"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "date": expectedDateDay1}, map[string]interface{}{"consumed": 42.52, "covered": 2.5, "purchased": 5.0, "date": expectedDateDay2}}, "licenseTypeID": "A90611"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "date": expectedDateDay2}}, "licenseTypeID": "PID001"},
-			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "date": expectedDateDay1}, map[string]interface{}{"consumed": 43.0, "covered": 86.0, "purchased": 86.0, "date": expectedDateDay2}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 0.0, "covered": 0.0, "purchased": 0.0, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 0.5, "covered": 5.0, "purchased": 5.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "L47247"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 42.52, "covered": 2.5, "purchased": 5.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "A90611"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "unlimited": false, "date": expectedDateDay1}}, "licenseTypeID": "A90620"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 3.0, "covered": 3.0, "purchased": 3.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "PID001"},
+			{"history": primitive.A{map[string]interface{}{"consumed": 42.0, "covered": 84.0, "purchased": 95.0, "unlimited": false, "date": expectedDateDay1}, map[string]interface{}{"consumed": 43.0, "covered": 86.0, "purchased": 86.0, "unlimited": false, "date": expectedDateDay2}}, "licenseTypeID": "", "itemDescription": "MySQL Enterprise per cluster"},
 		}
 
 		assert.ElementsMatch(m.T(), expected, actual)
 	})
 }
+
+func (m *MongodbSuite) TestHistoricizeLocationLicensesCompliance() {
+	defer m.db.Client.Database(m.dbname).Collection("database_licenses_history_by_location").DeleteMany(context.TODO(), bson.M{})
+
+	licenses := []dto.LicenseCompliance{
+		{
+			LicenseTypeID:   "A90611",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          "Processor Perpetual",
+			Consumed:        4.5,
+			Covered:         2.5,
+			Purchased:       2.5,
+		},
+	}
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-05T14:02:03+02:00") }
+	require.NoError(m.T(), m.db.HistoricizeLocationLicensesCompliance("Italy", licenses))
+	require.NoError(m.T(), m.db.HistoricizeLocationLicensesCompliance("Germany", licenses))
+
+	licenses[0].Consumed = 2.5
+	require.NoError(m.T(), m.db.HistoricizeLocationLicensesCompliance("Italy", licenses))
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-06T14:02:03+02:00") }
+	require.NoError(m.T(), m.db.HistoricizeLocationLicensesCompliance("Italy", licenses))
+
+	var actual []map[string]interface{}
+
+	cur, err := m.db.Client.Database(m.db.Config.Mongodb.DBName).
+		Collection("database_licenses_history_by_location").
+		Find(context.TODO(), bson.D{})
+	require.NoError(m.T(), err)
+	require.NoError(m.T(), cur.All(context.TODO(), &actual))
+
+	for _, doc := range actual {
+		delete(doc, "_id")
+	}
+
+	expectedDateDay1 := utils.PDT("2020-12-05T00:00:00+02:00")
+	expectedDateDay2 := utils.PDT("2020-12-06T00:00:00+02:00")
+
+	expected := []map[string]interface{}{
+		{
+			"location": "Italy", "licenseTypeID": "A90611", "itemDescription": "Oracle Database Enterprise Edition", "metric": "Processor Perpetual",
+			"history": primitive.A{
+				map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay1},
+				map[string]interface{}{"consumed": 2.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay2},
+			},
+		},
+		{
+			"location": "Germany", "licenseTypeID": "A90611", "itemDescription": "Oracle Database Enterprise Edition", "metric": "Processor Perpetual",
+			"history": primitive.A{
+				map[string]interface{}{"consumed": 4.5, "covered": 2.5, "purchased": 2.5, "unlimited": false, "date": expectedDateDay1},
+			},
+		},
+	}
+
+	assert.ElementsMatch(m.T(), expected, actual)
+}
+
+func (m *MongodbSuite) TestHistoricizeOracleDatabaseContractsLicensesCompliance() {
+	defer m.db.Client.Database(m.dbname).Collection("database_licenses_history_by_contract").DeleteMany(context.TODO(), bson.M{})
+
+	id := utils.Str2oid("aaaaaaaaaaaaaaaaaaaaaaaa")
+	contracts := []dto.OracleDatabaseContractLicenseCompliance{
+		{
+			ID:              id,
+			ContractID:      "AID001",
+			CSI:             "CSI001",
+			Location:        "Italy",
+			LicenseTypeID:   "A90611",
+			ItemDescription: "Oracle Database Enterprise Edition",
+			Metric:          "Processor Perpetual",
+			Unlimited:       true,
+			Consumed:        8,
+			Covered:         6,
+			Purchased:       6,
+		},
+	}
+
+	m.db.TimeNow = func() time.Time { return utils.P("2020-12-05T14:02:03+02:00") }
+	require.NoError(m.T(), m.db.HistoricizeOracleDatabaseContractsLicensesCompliance(contracts))
+
+	contracts[0].CSI = "CSI002"
+	contracts[0].Covered = 8
+	require.NoError(m.T(), m.db.HistoricizeOracleDatabaseContractsLicensesCompliance(contracts))
+
+	var actual []map[string]interface{}
+
+	cur, err := m.db.Client.Database(m.db.Config.Mongodb.DBName).
+		Collection("database_licenses_history_by_contract").
+		Find(context.TODO(), bson.D{})
+	require.NoError(m.T(), err)
+	require.NoError(m.T(), cur.All(context.TODO(), &actual))
+
+	expected := []map[string]interface{}{
+		{
+			"_id": id, "contractID": "AID001", "csi": "CSI002", "location": "Italy",
+			"licenseTypeID": "A90611", "itemDescription": "Oracle Database Enterprise Edition", "metric": "Processor Perpetual",
+			"history": primitive.A{
+				map[string]interface{}{"consumed": 8.0, "covered": 8.0, "purchased": 6.0, "unlimited": true, "date": utils.PDT("2020-12-05T00:00:00+02:00")},
+			},
+		},
+	}
+
+	assert.Equal(m.T(), expected, actual)
+}
+
+func (m *MongodbSuite) TestUpsertLicenseComplianceHistoric() {
+	collection := "database_licenses_history_by_location"
+	defer m.db.Client.Database(m.dbname).Collection(collection).DeleteMany(context.TODO(), bson.M{})
+
+	filter := bson.M{"location": "Italy", "licenseTypeID": "A90611"}
+	day1 := utils.P("2020-12-05T00:00:00+02:00")
+	day2 := utils.P("2020-12-06T00:00:00+02:00")
+
+	require.NoError(m.T(), m.db.upsertLicenseComplianceHistoric(collection, filter,
+		bson.D{{Key: "itemDescription", Value: "$Oracle Database Enterprise Edition"}}, 1, 1, 1, false, day1))
+
+	m.T().Run("Concurrent upserts of the same day keep a single entry", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 1; i <= 10; i++ {
+			wg.Add(1)
+
+			go func(consumed float64) {
+				defer wg.Done()
+				assert.NoError(t, m.db.upsertLicenseComplianceHistoric(collection, filter,
+					bson.D{{Key: "itemDescription", Value: "$Oracle Database Enterprise Edition"}}, consumed, 1, 1, false, day2))
+			}(float64(i))
+		}
+
+		wg.Wait()
+
+		var actual []struct {
+			ItemDescription string `bson:"itemDescription"`
+			History         []struct {
+				Date     time.Time `bson:"date"`
+				Consumed float64   `bson:"consumed"`
+			} `bson:"history"`
+		}
+
+		cur, err := m.db.Client.Database(m.dbname).Collection(collection).Find(context.TODO(), bson.M{})
+		require.NoError(t, err)
+		require.NoError(t, cur.All(context.TODO(), &actual))
+
+		require.Len(t, actual, 1)
+		assert.Equal(t, "$Oracle Database Enterprise Edition", actual[0].ItemDescription)
+		require.Len(t, actual[0].History, 2)
+		assert.True(t, day1.Equal(actual[0].History[0].Date))
+		assert.Equal(t, 1.0, actual[0].History[0].Consumed)
+		assert.True(t, day2.Equal(actual[0].History[1].Date))
+		assert.True(t, actual[0].History[1].Consumed >= 1 && actual[0].History[1].Consumed <= 10)
+	})
+}
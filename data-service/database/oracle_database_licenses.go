@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// FindActiveHostdataOracleLicenses return the current hostdata with oracle databases, filtered by location and
+// hostnames if not empty. Only the fields needed to assign the licenses are returned
+func (md *MongoDatabase) FindActiveHostdataOracleLicenses(location string, hostnames []string) ([]model.HostDataBE, error) {
+	ctx := context.TODO()
+
+	filter := bson.M{
+		"dismissedAt":                        nil,
+		"archived":                           false,
+		"features.oracle.database.databases": bson.M{"$exists": true},
+	}
+
+	if location != "" {
+		filter["location"] = location
+	}
+
+	if len(hostnames) > 0 {
+		filter["hostname"] = bson.M{"$in": hostnames}
+	}
+
+	opts := options.Find().SetProjection(bson.M{
+		"hostname":    1,
+		"location":    1,
+		"environment": 1,
+		"info":        1,
+		"cloud":       1,
+		"features.oracle.database.databases.name":     1,
+		"features.oracle.database.databases.version":  1,
+		"features.oracle.database.databases.status":   1,
+		"features.oracle.database.databases.role":     1,
+		"features.oracle.database.databases.dbID":     1,
+		"features.oracle.database.databases.isRAC":    1,
+		"features.oracle.database.databases.licenses": 1,
+	})
+
+	cur, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	hosts := make([]model.HostDataBE, 0)
+	if err := cur.All(ctx, &hosts); err != nil {
+		return nil, utils.NewError(err, "DB ERROR")
+	}
+
+	return hosts, nil
+}
+
+func (md *MongoDatabase) UpdateHostdataOracleDatabaseLicenses(id primitive.ObjectID, dbName string, licenses []model.OracleDatabaseLicense) error {
+	filter := bson.M{
+		"_id": id,
+		"features.oracle.database.databases.name": dbName,
+	}
+
+	update := bson.M{"$set": bson.M{"features.oracle.database.databases.$.licenses": licenses}}
+
+	_, err := md.Client.Database(md.Config.Mongodb.DBName).Collection("hosts").UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return utils.NewError(err, "DB ERROR")
+	}
+
+	return nil
+}
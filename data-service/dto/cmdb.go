@@ -15,7 +15,24 @@
 
 package dto
 
+import "github.com/ercole-io/ercole/v2/model"
+
+// CmdbInfo contains the hosts of a CMDB, as a list of hostnames and as records with their attributes.
+// If Override is true the CMDB attributes replace the ones received from the agents
 type CmdbInfo struct {
-	Name      string   `json:"name"`
-	Hostnames []string `json:"hostnames"`
+	Name      string             `json:"name"`
+	Hostnames []string           `json:"hostnames"`
+	Records   []model.CmdbRecord `json:"records"`
+	Override  bool               `json:"override"`
+}
+
+// CmdbRecords return the records of the CMDB, including the hostnames without attributes
+func (c CmdbInfo) CmdbRecords() []model.CmdbRecord {
+	records := make([]model.CmdbRecord, 0, len(c.Hostnames)+len(c.Records))
+
+	for _, hostname := range c.Hostnames {
+		records = append(records, model.CmdbRecord{Hostname: hostname})
+	}
+
+	return append(records, c.Records...)
 }
@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+// HostDataBatchReport contains the outcome of every hostdata received in a batch
+type HostDataBatchReport struct {
+	Accepted int                   `json:"accepted"`
+	Rejected int                   `json:"rejected"`
+	Results  []HostDataBatchResult `json:"results"`
+}
+
+// HostDataBatchResult contains the outcome of a single hostdata received in a batch
+type HostDataBatchResult struct {
+	Line     int    `json:"line"`
+	Hostname string `json:"hostname,omitempty"`
+	Status   int    `json:"status"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
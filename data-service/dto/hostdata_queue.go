@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type HostDataQueueStats struct {
+	Pending     int64 `json:"pending"`
+	Processing  int64 `json:"processing"`
+	DeadLetters int64 `json:"deadLetters"`
+}
+
+type HostDataReplayResult struct {
+	ID       primitive.ObjectID `json:"id"`
+	Replayed bool               `json:"replayed"`
+	Error    string             `json:"error,omitempty"`
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dto
+
+// OracleLicenseReprocessingRequest contains the scope of a reprocessing of the oracle licenses.
+// Empty Location and Hostnames mean all the hosts
+type OracleLicenseReprocessingRequest struct {
+	Location  string   `json:"location"`
+	Hostnames []string `json:"hostnames"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+type OracleLicenseReprocessingReport struct {
+	DryRun         bool                              `json:"dryRun"`
+	HostsProcessed int                               `json:"hostsProcessed"`
+	HostsChanged   int                               `json:"hostsChanged"`
+	Changes        []OracleLicenseReprocessingChange `json:"changes"`
+}
+
+// OracleLicenseReprocessingChange is a license whose assignment has changed.
+// If the license has been removed NewLicenseTypeID is empty and NewCount is zero
+type OracleLicenseReprocessingChange struct {
+	Hostname         string  `json:"hostname"`
+	DatabaseName     string  `json:"databaseName"`
+	LicenseName      string  `json:"licenseName"`
+	OldLicenseTypeID string  `json:"oldLicenseTypeID"`
+	NewLicenseTypeID string  `json:"newLicenseTypeID"`
+	OldCount         float64 `json:"oldCount"`
+	NewCount         float64 `json:"newCount"`
+}
@@ -25,6 +25,7 @@ import (
 	alert_service_client "github.com/ercole-io/ercole/v2/alert-service/client"
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/data-service/database"
+	"github.com/ercole-io/ercole/v2/data-service/service"
 	"github.com/ercole-io/ercole/v2/logger"
 )
 
@@ -36,6 +37,7 @@ type Job struct {
 	Config        config.Configuration
 	ServerVersion string
 	Database      database.MongoDatabaseInterface
+	Service       service.HostDataServiceInterface
 	TimeNow       func() time.Time
 	Log           logger.Logger
 }
@@ -80,10 +82,23 @@ func (j *Job) Init() {
 	}
 
 	historicizeLicensesComplianceJob := &HistoricizeLicensesComplianceJob{
-		Database: j.Database,
-		TimeNow:  j.TimeNow,
-		Config:   j.Config,
-		Log:      j.Log,
+		Database:       j.Database,
+		AlertSvcClient: alert_service_client.NewClient(j.Config.AlertService),
+		TimeNow:        j.TimeNow,
+		Config:         j.Config,
+		Log:            j.Log,
+		NewObjectID: func() primitive.ObjectID {
+			return primitive.NewObjectIDFromTimestamp(j.TimeNow())
+		},
 	}
 	jobrunner.Every(5*time.Minute, historicizeLicensesComplianceJob)
+
+	hostdataQueueJob := &HostDataQueueJob{Service: j.Service}
+
+	pollingInterval := time.Duration(j.Config.DataService.HostDataQueue.PollingInterval) * time.Second
+	if pollingInterval <= 0 {
+		pollingInterval = 5 * time.Second
+	}
+
+	jobrunner.Every(pollingInterval, hostdataQueueJob)
 }
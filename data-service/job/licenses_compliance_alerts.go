@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+type licenseComplianceKey struct {
+	licenseTypeID string
+	location      string
+}
+
+// checkLicensesCompliance throws a LICENSE_NON_COMPLIANT alert for each license type and location
+// that isn't compliant and resolves the alerts of the ones that are compliant again.
+// An alert isn't thrown again, even if it has been acknowledged, until the compliance recovers
+func (job *HistoricizeLicensesComplianceJob) checkLicensesCompliance(licensesByLocation map[string][]dto.LicenseCompliance) {
+	alerts, err := job.Database.FindUnresolvedAlertsByCode(model.AlertCodeLicenseNonCompliant)
+	if err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	job.updateLicenseNonCompliantAlerts(licensesByLocation, alerts)
+}
+
+func (job *HistoricizeLicensesComplianceJob) updateLicenseNonCompliantAlerts(licensesByLocation map[string][]dto.LicenseCompliance, alerts []model.Alert) {
+	openAlerts := make(map[licenseComplianceKey]primitive.ObjectID, len(alerts))
+
+	for _, alert := range alerts {
+		licenseTypeID, _ := alert.OtherInfo["licenseTypeID"].(string)
+		location, _ := alert.OtherInfo["location"].(string)
+		openAlerts[licenseComplianceKey{licenseTypeID, location}] = alert.ID
+	}
+
+	locations := make([]string, 0, len(licensesByLocation))
+	for location := range licensesByLocation {
+		locations = append(locations, location)
+	}
+
+	sort.Strings(locations)
+
+	nonCompliant := make(map[licenseComplianceKey]bool)
+
+	for _, location := range locations {
+		for _, license := range licensesByLocation[location] {
+			if license.Unlimited || license.Compliance >= 1 {
+				continue
+			}
+
+			key := licenseComplianceKey{license.LicenseTypeID, location}
+			nonCompliant[key] = true
+
+			if _, ok := openAlerts[key]; ok {
+				continue
+			}
+
+			delta := license.Consumed - license.Covered
+
+			alert := model.Alert{
+				ID:                      job.NewObjectID(),
+				AlertAffectedTechnology: technologyPtr(license.Technology),
+				AlertCategory:           model.AlertCategoryLicense,
+				AlertCode:               model.AlertCodeLicenseNonCompliant,
+				AlertSeverity:           model.AlertSeverityCritical,
+				AlertStatus:             model.AlertStatusNew,
+				Date:                    job.TimeNow(),
+				Description: fmt.Sprintf("The license %s (%s) in %s is not compliant: %g licenses consumed, %g covered",
+					license.LicenseTypeID, license.ItemDescription, location, license.Consumed, license.Covered),
+				OtherInfo: map[string]interface{}{
+					"licenseTypeID":   license.LicenseTypeID,
+					"itemDescription": license.ItemDescription,
+					"metric":          license.Metric,
+					"location":        location,
+					"consumed":        license.Consumed,
+					"covered":         license.Covered,
+					"purchased":       license.Purchased,
+					"compliance":      license.Compliance,
+					"delta":           delta,
+					"costImpact":      delta * license.Cost,
+				},
+			}
+
+			if err := job.AlertSvcClient.ThrowNewAlert(alert); err != nil {
+				job.Log.Error(err)
+				continue
+			}
+		}
+	}
+
+	resolved := make([]primitive.ObjectID, 0)
+
+	for key, id := range openAlerts {
+		if !nonCompliant[key] {
+			resolved = append(resolved, id)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return
+	}
+
+	sort.Slice(resolved, func(i, j int) bool {
+		return resolved[i].Hex() < resolved[j].Hex()
+	})
+
+	if err := job.Database.ResolveAlerts(resolved); err != nil {
+		job.Log.Error(err)
+	}
+}
+
+func technologyPtr(technology string) *string {
+	if technology == "" {
+		return nil
+	}
+
+	return &technology
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package job
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/api-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestUpdateLicenseNonCompliantAlerts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	now := utils.Btc(utils.P("2019-11-05T14:02:03Z"))
+
+	job := HistoricizeLicensesComplianceJob{
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        now,
+		Log:            logger.NewLogger("TEST"),
+		NewObjectID:    utils.NewObjectIDForTests(),
+	}
+
+	licensesByLocation := map[string][]dto.LicenseCompliance{
+		"Italy": {
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Metric: "Processor Perpetual", Cost: 100, Technology: model.TechnologyOracleDatabase, Consumed: 10, Covered: 6, Purchased: 6, Compliance: 0.6},
+			{LicenseTypeID: "A90649", ItemDescription: "Diagnostics Pack", Metric: "Processor Perpetual", Cost: 100, Consumed: 4, Covered: 4, Purchased: 4, Compliance: 1},
+			{LicenseTypeID: "A90650", ItemDescription: "Tuning Pack", Metric: "Processor Perpetual", Cost: 100, Consumed: 4, Covered: 0, Compliance: 0, Unlimited: true},
+		},
+		"Germany": {
+			{LicenseTypeID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Metric: "Processor Perpetual", Cost: 100, Consumed: 2, Covered: 1, Purchased: 1, Compliance: 0.5},
+		},
+	}
+
+	alerts := []model.Alert{
+		{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a51"),
+			AlertCode: model.AlertCodeLicenseNonCompliant,
+			OtherInfo: map[string]interface{}{"licenseTypeID": "A90611", "location": "Germany"},
+		},
+		{
+			ID:          utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			AlertCode:   model.AlertCodeLicenseNonCompliant,
+			AlertStatus: model.AlertStatusAck,
+			OtherInfo:   map[string]interface{}{"licenseTypeID": "A90649", "location": "Italy"},
+		},
+	}
+
+	expectedAlert := model.Alert{
+		ID:                      utils.Str2oid("000000000000000000000001"),
+		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeLicenseNonCompliant,
+		AlertSeverity:           model.AlertSeverityCritical,
+		AlertStatus:             model.AlertStatusNew,
+		Date:                    now(),
+		Description:             "The license A90611 (Oracle Database Enterprise Edition) in Italy is not compliant: 10 licenses consumed, 6 covered",
+		OtherInfo: map[string]interface{}{
+			"licenseTypeID":   "A90611",
+			"itemDescription": "Oracle Database Enterprise Edition",
+			"metric":          "Processor Perpetual",
+			"location":        "Italy",
+			"consumed":        float64(10),
+			"covered":         float64(6),
+			"purchased":       float64(6),
+			"compliance":      0.6,
+			"delta":           float64(4),
+			"costImpact":      float64(400),
+		},
+	}
+
+	gomock.InOrder(
+		asc.EXPECT().ThrowNewAlert(expectedAlert).Return(nil),
+		db.EXPECT().ResolveAlerts([]primitive.ObjectID{utils.Str2oid("5dc3f534db7e81a98b726a52")}).Return(nil),
+	)
+
+	job.updateLicenseNonCompliantAlerts(licensesByLocation, alerts)
+}
+
+func TestUpdateLicenseNonCompliantAlerts_AllCompliant(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+
+	job := HistoricizeLicensesComplianceJob{
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+		NewObjectID:    utils.NewObjectIDForTests(),
+	}
+
+	licensesByLocation := map[string][]dto.LicenseCompliance{
+		"Italy": {
+			{LicenseTypeID: "A90611", Cost: 100, Consumed: 10, Covered: 10, Purchased: 10, Compliance: 1},
+		},
+	}
+
+	job.updateLicenseNonCompliantAlerts(licensesByLocation, []model.Alert{})
+}
+
+func TestUpdateLicenseNonCompliantAlerts_AcknowledgedNotThrownAgain(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+
+	job := HistoricizeLicensesComplianceJob{
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+		NewObjectID:    utils.NewObjectIDForTests(),
+	}
+
+	licensesByLocation := map[string][]dto.LicenseCompliance{
+		"Italy": {
+			{LicenseTypeID: "A90611", Cost: 100, Consumed: 10, Covered: 6, Purchased: 6, Compliance: 0.6},
+		},
+	}
+
+	alerts := []model.Alert{
+		{
+			ID:          utils.Str2oid("5dc3f534db7e81a98b726a51"),
+			AlertCode:   model.AlertCodeLicenseNonCompliant,
+			AlertStatus: model.AlertStatusAck,
+			OtherInfo:   map[string]interface{}{"licenseTypeID": "A90611", "location": "Italy"},
+		},
+	}
+
+	job.updateLicenseNonCompliantAlerts(licensesByLocation, alerts)
+}
@@ -20,8 +20,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	alert_service_client "github.com/ercole-io/ercole/v2/alert-service/client"
 	"github.com/ercole-io/ercole/v2/api-service/dto"
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/data-service/database"
@@ -30,33 +34,99 @@ import (
 )
 
 type HistoricizeLicensesComplianceJob struct {
-	Database database.MongoDatabaseInterface
-	TimeNow  func() time.Time
-	Config   config.Configuration
-	Log      logger.Logger
+	Database       database.MongoDatabaseInterface
+	AlertSvcClient alert_service_client.AlertSvcClientInterface
+	TimeNow        func() time.Time
+	Config         config.Configuration
+	Log            logger.Logger
+	NewObjectID    func() primitive.ObjectID
 }
 
 func (job *HistoricizeLicensesComplianceJob) Run() {
-	url := utils.NewAPIUrlNoParams(
+	licenses, err := job.getLicensesCompliance("")
+	if err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	err = job.Database.HistoricizeLicensesCompliance(licenses)
+	if err != nil {
+		job.Log.Error("Can't historicize database licenses")
+		return
+	}
+
+	licensesByLocation, err := job.getLicensesComplianceByLocation()
+	if err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	for location, licenses := range licensesByLocation {
+		if err := job.Database.HistoricizeLocationLicensesCompliance(location, licenses); err != nil {
+			job.Log.Errorf("Can't historicize database licenses of location %s: %s", location, err)
+		}
+	}
+
+	job.historicizeContractsLicensesCompliance()
+
+	job.checkLicensesCompliance(licensesByLocation)
+}
+
+// getLicensesComplianceByLocation return the licenses compliance of each location of the current hosts
+func (job *HistoricizeLicensesComplianceJob) getLicensesComplianceByLocation() (map[string][]dto.LicenseCompliance, error) {
+	locations, err := job.Database.GetCurrentHostsLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	licensesByLocation := make(map[string][]dto.LicenseCompliance, len(locations))
+
+	for _, location := range locations {
+		licenses, err := job.getLicensesCompliance(location)
+		if err != nil {
+			return nil, err
+		}
+
+		licensesByLocation[location] = licenses
+	}
+
+	return licensesByLocation, nil
+}
+
+func (job *HistoricizeLicensesComplianceJob) historicizeContractsLicensesCompliance() {
+	contracts, err := job.getOracleDatabaseContractsLicensesCompliance()
+	if err != nil {
+		job.Log.Error(err)
+		return
+	}
+
+	if err := job.Database.HistoricizeOracleDatabaseContractsLicensesCompliance(contracts); err != nil {
+		job.Log.Errorf("Can't historicize the licenses of the contracts: %s", err)
+	}
+}
+
+func (job *HistoricizeLicensesComplianceJob) getLicensesCompliance(location string) ([]dto.LicenseCompliance, error) {
+	params := url.Values{}
+	if location != "" {
+		params.Set("location", location)
+	}
+
+	endpoint := utils.NewAPIUrl(
 		job.Config.APIService.RemoteEndpoint,
 		job.Config.APIService.AuthenticationProvider.Username,
 		job.Config.APIService.AuthenticationProvider.Password,
-		"/hosts/technologies/all/databases/licenses-compliance").String()
+		"/hosts/technologies/all/databases/licenses-compliance",
+		params).String()
 
 	client := http.Client{Timeout: 1 * time.Minute}
 
-	resp, err := client.Get(url)
+	resp, err := client.Get(endpoint)
 	if err != nil || resp == nil {
-		err = fmt.Errorf("Error while retrieving licenses compliance: [%w], response: [%v]", err, resp)
-		job.Log.Error(err)
-
-		return
+		return nil, fmt.Errorf("Error while retrieving licenses compliance: [%w], response: [%v]", err, resp)
 	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		err = fmt.Errorf("Error while retrieving licenses compliance: response status code: response: [%+v]", resp)
-		job.Log.Error(err)
-
-		return
+		return nil, fmt.Errorf("Error while retrieving licenses compliance: response status code: response: [%+v]", resp)
 	}
+	defer resp.Body.Close()
 
 	response := map[string][]dto.LicenseCompliance{}
 
@@ -64,15 +134,35 @@ func (job *HistoricizeLicensesComplianceJob) Run() {
 	decoder.DisallowUnknownFields()
 
 	if err := decoder.Decode(&response); err != nil {
-		job.Log.Error(err)
-		return
+		return nil, err
 	}
 
-	licenses := response["licensesCompliance"]
+	return response["licensesCompliance"], nil
+}
 
-	err = job.Database.HistoricizeLicensesCompliance(licenses)
-	if err != nil {
-		job.Log.Error("Can't historicize database licenses")
-		return
+func (job *HistoricizeLicensesComplianceJob) getOracleDatabaseContractsLicensesCompliance() ([]dto.OracleDatabaseContractLicenseCompliance, error) {
+	endpoint := utils.NewAPIUrl(
+		job.Config.APIService.RemoteEndpoint,
+		job.Config.APIService.AuthenticationProvider.Username,
+		job.Config.APIService.AuthenticationProvider.Password,
+		"/contracts/oracle/database/licenses-compliance",
+		url.Values{}).String()
+
+	client := http.Client{Timeout: 1 * time.Minute}
+
+	resp, err := client.Get(endpoint)
+	if err != nil || resp == nil {
+		return nil, fmt.Errorf("Error while retrieving contracts licenses compliance: [%w], response: [%v]", err, resp)
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("Error while retrieving contracts licenses compliance: response status code: response: [%+v]", resp)
 	}
+	defer resp.Body.Close()
+
+	response := map[string][]dto.OracleDatabaseContractLicenseCompliance{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return response["licensesCompliance"], nil
 }
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	cr "github.com/ercole-io/ercole/v2/utils/crypto"
+)
+
+// agentCredentialLastSeenInterval is the minimum interval between two updates of the last seen date of a credential
+const agentCredentialLastSeenInterval = 5 * time.Minute
+
+// unknownAgentKeyHash is compared with the keys of unknown credentials,
+// so that the response time doesn't reveal which credential names exist
+var unknownAgentKeyHash = cr.HashAPIKey("")
+
+func (hds *HostDataService) AuthenticateAgent(name, key string) (*model.AgentCredential, error) {
+	credential, err := hds.Database.GetAgentCredentialByName(name)
+	if errors.Is(err, utils.ErrAgentCredentialNotFound) {
+		cr.MatchAPIKey(key, unknownAgentKeyHash)
+		return nil, utils.ErrInvalidAgentCredential
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !cr.MatchAPIKey(key, credential.KeyHash) || credential.IsRevoked() {
+		return nil, utils.ErrInvalidAgentCredential
+	}
+
+	now := hds.TimeNow()
+	if credential.LastSeenAt != nil && now.Sub(*credential.LastSeenAt) < agentCredentialLastSeenInterval {
+		return credential, nil
+	}
+
+	if err := hds.Database.UpdateAgentCredentialLastSeen(credential.ID, now); err != nil {
+		hds.Log.Error(err)
+	} else {
+		credential.LastSeenAt = &now
+	}
+
+	return credential, nil
+}
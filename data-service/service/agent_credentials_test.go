@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	cr "github.com/ercole-io/ercole/v2/utils/crypto"
+)
+
+func TestAuthenticateAgent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	hash := cr.HashAPIKey("s3cr3tKey")
+	id := primitive.NewObjectID()
+
+	newCredential := func() *model.AgentCredential {
+		return &model.AgentCredential{ID: id, Name: "web", KeyHash: hash}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("web").Return(newCredential(), nil)
+		db.EXPECT().UpdateAgentCredentialLastSeen(id, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+		actual, err := hds.AuthenticateAgent("web", "s3cr3tKey")
+		require.NoError(t, err)
+
+		assert.Equal(t, "web", actual.Name)
+		assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), *actual.LastSeenAt)
+	})
+
+	t.Run("Last seen recently is not updated", func(t *testing.T) {
+		credential := newCredential()
+		lastSeenAt := utils.P("2019-11-05T14:00:00Z")
+		credential.LastSeenAt = &lastSeenAt
+
+		db.EXPECT().GetAgentCredentialByName("web").Return(credential, nil)
+
+		actual, err := hds.AuthenticateAgent("web", "s3cr3tKey")
+		require.NoError(t, err)
+
+		assert.Equal(t, lastSeenAt, *actual.LastSeenAt)
+	})
+
+	t.Run("Last seen long ago is updated", func(t *testing.T) {
+		credential := newCredential()
+		lastSeenAt := utils.P("2019-11-05T13:00:00Z")
+		credential.LastSeenAt = &lastSeenAt
+
+		db.EXPECT().GetAgentCredentialByName("web").Return(credential, nil)
+		db.EXPECT().UpdateAgentCredentialLastSeen(id, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+		actual, err := hds.AuthenticateAgent("web", "s3cr3tKey")
+		require.NoError(t, err)
+
+		assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), *actual.LastSeenAt)
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("web").Return(newCredential(), nil)
+
+		_, err := hds.AuthenticateAgent("web", "wrong")
+		require.ErrorIs(t, err, utils.ErrInvalidAgentCredential)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		credential := newCredential()
+		revokedAt := utils.P("2019-11-01T00:00:00Z")
+		credential.RevokedAt = &revokedAt
+
+		db.EXPECT().GetAgentCredentialByName("web").Return(credential, nil)
+
+		_, err := hds.AuthenticateAgent("web", "s3cr3tKey")
+		require.ErrorIs(t, err, utils.ErrInvalidAgentCredential)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("foo").Return(nil, utils.ErrAgentCredentialNotFound)
+
+		_, err := hds.AuthenticateAgent("foo", "s3cr3tKey")
+		require.ErrorIs(t, err, utils.ErrInvalidAgentCredential)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		db.EXPECT().GetAgentCredentialByName("web").Return(nil, aerrMock)
+
+		_, err := hds.AuthenticateAgent("web", "s3cr3tKey")
+		require.Equal(t, aerrMock, err)
+	})
+}
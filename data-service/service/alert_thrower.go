@@ -26,10 +26,10 @@ import (
 )
 
 // ThrowNewDatabaseAlert create and insert in the database a new NEW_DATABASE alert
-func (hds *HostDataService) throwNewDatabaseAlert(dbname string, hostname string) error {
+func (hds *HostDataService) throwNewDatabaseAlert(technology *string, dbname string, hostname string) error {
 	alr := model.Alert{
 		ID:                      primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
-		AlertAffectedTechnology: model.TechnologyOracleDatabasePtr,
+		AlertAffectedTechnology: technology,
 		AlertCategory:           model.AlertCategoryLicense,
 		AlertCode:               model.AlertCodeNewDatabase,
 		AlertSeverity:           model.AlertSeverityInfo,
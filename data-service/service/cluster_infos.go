@@ -16,8 +16,6 @@
 package service
 
 import (
-	"strings"
-
 	"github.com/ercole-io/ercole/v2/model"
 	"github.com/ercole-io/ercole/v2/utils"
 )
@@ -27,42 +25,26 @@ func (hds *HostDataService) clusterInfoChecks(clusters []model.ClusterInfo) {
 }
 
 func (hds *HostDataService) assignKnownHostnames(clusters []model.ClusterInfo) {
-	var hostnames map[string]string
-	{
-		knownHostnames, err := hds.Database.GetHostnames()
-		if err != nil {
-			hds.Log.Error(utils.NewError(err, "Can't retrieve hostnames"))
-			return
-		}
-
-		hostnames = make(map[string]string, len(knownHostnames))
-		for _, knownHostname := range knownHostnames {
-			alternatives := []string{
-				strings.ToLower(knownHostname),
-				strings.ToLower(strings.Split(knownHostname, ".")[0]),
-			}
+	knownHostnames, err := hds.Database.GetHostnames()
+	if err != nil {
+		hds.Log.Error(utils.NewError(err, "Can't retrieve hostnames"))
+		return
+	}
 
-			for _, a := range alternatives {
-				hostnames[a] = knownHostname
-			}
-		}
+	resolver, err := hds.hostIdentityResolver(knownHostnames)
+	if err != nil {
+		hds.Log.Error(utils.NewError(err, "Can't resolve hostnames"))
+		return
 	}
 
 	for i := range clusters {
 		cluster := &clusters[i]
-	vms:
+
 		for j := range cluster.VMs {
 			vm := &cluster.VMs[j]
 
-			alternatives := []string{
-				strings.ToLower(vm.Hostname),
-				strings.ToLower(strings.Split(vm.Hostname, ".")[0]),
-			}
-			for _, a := range alternatives {
-				if knownHostname, ok := hostnames[a]; ok {
-					vm.Hostname = knownHostname
-					continue vms
-				}
+			if knownHostname, ok := resolver.Resolve(vm.Hostname); ok {
+				vm.Hostname = knownHostname
 			}
 		}
 	}
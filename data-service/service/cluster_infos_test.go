@@ -321,6 +321,7 @@ func TestCheckClusterInfos(t *testing.T) {
 		tc := &testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
 			db.EXPECT().GetHostnames().Return(tc.hostnames, nil)
+			db.EXPECT().ListHostAliases().Return(nil, nil)
 
 			hds.clusterInfoChecks(tc.actual)
 
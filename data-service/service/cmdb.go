@@ -17,23 +17,52 @@ package service
 
 import (
 	"fmt"
-	"strings"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/ercole-io/ercole/v2/data-service/dto"
 	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils/hostidentity"
 )
 
-func (hds *HostDataService) CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error {
+// CompareCmdbInfo reconciles the hosts of the CMDB with the current hosts, throwing an alert for the unknown hostnames,
+// the missing hostnames and the attributes mismatches, then saves the snapshot of the CMDB.
+// If the CMDB overrides the hosts attributes, the mismatching location, environment and tags are replaced
+func (hds *HostDataService) CompareCmdbInfo(cmdbInfo dto.CmdbInfo) (*model.CmdbSnapshot, error) {
 	hostnames, err := hds.Database.GetCurrentHostnames()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	resolver, err := hds.hostIdentityResolver(hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	records := cmdbInfo.CmdbRecords()
+	cmdbHostnames := make([]string, 0, len(records))
+
+	for i := range records {
+		cmdbHostnames = append(cmdbHostnames, records[i].Hostname)
+
+		if hostname, found := resolver.Resolve(records[i].Hostname); found {
+			records[i].ErcoleHostname = hostname
+		}
+	}
+
+	unknownHostnames, missingHostnames := differenceHostnames(resolver, cmdbHostnames, hostnames)
+
+	mismatches, err := hds.reconcileCmdbRecords(records, cmdbInfo.Override)
+	if err != nil {
+		return nil, err
 	}
 
 	missingAlerts := make([]model.Alert, 0, 2)
 
 	descriptionErcole := ""
 
-	for _, h := range differenceHostnames(cmdbInfo.Hostnames, hostnames) {
+	for _, h := range unknownHostnames {
 		descriptionErcole += fmt.Sprintf("Received unknown hostname %s from CMDB %s\n", h, cmdbInfo.Name)
 	}
 
@@ -50,7 +79,7 @@ func (hds *HostDataService) CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error {
 
 	descriptionCmdb := ""
 
-	for _, h := range differenceHostnames(hostnames, cmdbInfo.Hostnames) {
+	for _, h := range missingHostnames {
 		descriptionCmdb += fmt.Sprintf("Missing hostname %s in CMDB %s\n", h, cmdbInfo.Name)
 	}
 
@@ -69,42 +98,132 @@ func (hds *HostDataService) CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error {
 		hds.Log.Errorf("Can't create a new alert: %s", err)
 	}
 
-	return nil
+	descriptionMismatches := ""
+
+	for _, m := range mismatches {
+		descriptionMismatches += fmt.Sprintf("Host %s has %s %q but it is %q in CMDB %s\n",
+			m.Hostname, m.Attribute, m.ErcoleValue, m.CmdbValue, cmdbInfo.Name)
+	}
+
+	if descriptionMismatches != "" {
+		if err := hds.AlertSvcClient.ThrowNewAlert(model.Alert{
+			ID:            primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+			AlertCategory: model.AlertCategoryEngine,
+			AlertCode:     model.AlertCodeCmdbAttributeMismatch,
+			AlertSeverity: model.AlertSeverityWarning,
+			AlertStatus:   model.AlertStatusNew,
+			Date:          hds.TimeNow(),
+			Description:   descriptionMismatches,
+		}); err != nil {
+			hds.Log.Errorf("Can't create a new alert: %s", err)
+		}
+	}
+
+	snapshot := model.CmdbSnapshot{
+		ID:                  primitive.NewObjectIDFromTimestamp(hds.TimeNow()),
+		Name:                cmdbInfo.Name,
+		Override:            cmdbInfo.Override,
+		ReceivedAt:          hds.TimeNow(),
+		Records:             records,
+		UnknownHostnames:    unknownHostnames,
+		MissingHostnames:    missingHostnames,
+		AttributeMismatches: mismatches,
+	}
+
+	if err := hds.Database.SaveCmdbSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
 }
 
-// differenceHostnames returns hostnames in `a` that aren't in `b`
-// If a has multiple times on item, which is in b even only once, no occurrences will be returned
-func differenceHostnames(a, b []string) []string {
-	mb := make(map[string]struct{}, len(b))
+// reconcileCmdbRecords return the mismatches between the records and the current hosts they match.
+// If override is true the attributes of the mismatching hosts are replaced by the ones of their record
+func (hds *HostDataService) reconcileCmdbRecords(records []model.CmdbRecord, override bool) ([]model.CmdbMismatch, error) {
+	mismatches := make([]model.CmdbMismatch, 0)
 
-	for _, x := range b {
-		lcx := strings.ToLower(x)
-		mb[lcx] = struct{}{}
+	recordsByHostname := make(map[string]model.CmdbRecord)
 
-		withoutDomain := hostnameWithoutDomain(lcx)
-		if lcx != withoutDomain {
-			mb[withoutDomain] = struct{}{}
+	for _, record := range records {
+		if record.ErcoleHostname != "" && len(record.Mismatches(model.HostDataBE{})) > 0 {
+			recordsByHostname[record.ErcoleHostname] = record
 		}
 	}
 
-	var diff []string
+	if len(recordsByHostname) == 0 {
+		return mismatches, nil
+	}
 
-	for _, x := range a {
-		lcx := strings.ToLower(x)
-		if _, found := mb[lcx]; found {
-			continue
+	hostnames := make([]string, 0, len(recordsByHostname))
+	for hostname := range recordsByHostname {
+		hostnames = append(hostnames, hostname)
+	}
+
+	hosts, err := hds.Database.FindCurrentHostsCmdbAttributes(hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Hostname < hosts[j].Hostname
+	})
+
+	for _, host := range hosts {
+		record := recordsByHostname[host.Hostname]
+
+		hostMismatches := record.Mismatches(host)
+
+		if override && record.Apply(&host) {
+			if err := hds.Database.UpdateCurrentHostCmdbAttributes(host.Hostname, host.Location, host.Environment, host.Tags); err != nil {
+				return nil, err
+			}
+
+			for i := range hostMismatches {
+				hostMismatches[i].Overridden = hostMismatches[i].IsOverridable()
+			}
 		}
 
-		if _, found := mb[hostnameWithoutDomain(lcx)]; found {
+		mismatches = append(mismatches, hostMismatches...)
+	}
+
+	return mismatches, nil
+}
+
+// applyCmdbOverride replaces the attributes of the hostdata with the ones of its record
+// in the most recent snapshot of a CMDB overriding the hosts attributes
+func (hds *HostDataService) applyCmdbOverride(hostdata *model.HostDataBE) error {
+	record, err := hds.Database.FindCmdbOverrideRecord(hostdata.Hostname)
+	if err != nil {
+		return err
+	}
+
+	if record != nil {
+		record.Apply(hostdata)
+	}
+
+	return nil
+}
+
+// differenceHostnames returns the cmdb hostnames that don't match any ercole hostname
+// and the ercole hostnames that aren't matched by any cmdb hostname
+func differenceHostnames(resolver *hostidentity.Resolver, cmdbHostnames, ercoleHostnames []string) (unknown, missing []string) {
+	matched := make(map[string]bool, len(cmdbHostnames))
+
+	for _, h := range cmdbHostnames {
+		hostname, found := resolver.Resolve(h)
+		if !found {
+			unknown = append(unknown, h)
 			continue
 		}
 
-		diff = append(diff, x)
+		matched[hostname] = true
 	}
 
-	return diff
-}
+	for _, h := range ercoleHostnames {
+		if !matched[h] {
+			missing = append(missing, h)
+		}
+	}
 
-func hostnameWithoutDomain(hostname string) string {
-	return strings.Split(hostname, ".")[0]
+	return unknown, missing
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ParseCmdbCsv return the records of a CMDB export. The header names the columns, in any order and case:
+// hostname, that is required, location, environment, owner, business service and decommissioned.
+// The other columns are ignored
+func (hds *HostDataService) ParseCmdbCsv(reader io.Reader) ([]model.CmdbRecord, error) {
+	r := csv.NewReader(reader)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, utils.NewErrorf("%w: missing header", utils.ErrInvalidCmdbCsv)
+	} else if err != nil {
+		return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidCmdbCsv, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[cmdbCsvColumnName(name)] = i
+	}
+
+	if _, ok := columns["hostname"]; !ok {
+		return nil, utils.NewErrorf("%w: missing hostname column", utils.ErrInvalidCmdbCsv)
+	}
+
+	records := make([]model.CmdbRecord, 0)
+
+	for {
+		line, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, utils.NewErrorf("%w: %s", utils.ErrInvalidCmdbCsv, err)
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(line) {
+				return ""
+			}
+
+			return strings.TrimSpace(line[i])
+		}
+
+		record := model.CmdbRecord{
+			Hostname:        value("hostname"),
+			Location:        value("location"),
+			Environment:     value("environment"),
+			Owner:           value("owner"),
+			BusinessService: value("businessservice"),
+		}
+
+		if record.Hostname == "" {
+			row, _ := r.FieldPos(0)
+			return nil, utils.NewErrorf("%w: missing hostname at line %d", utils.ErrInvalidCmdbCsv, row)
+		}
+
+		switch strings.ToLower(value("decommissioned")) {
+		case "", "false", "no", "n", "0":
+		case "true", "yes", "y", "1":
+			record.Decommissioned = true
+		default:
+			row, _ := r.FieldPos(0)
+			return nil, utils.NewErrorf("%w: invalid decommissioned value %q at line %d",
+				utils.ErrInvalidCmdbCsv, value("decommissioned"), row)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func cmdbCsvColumnName(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func (hds *HostDataService) ListCmdbSnapshots() ([]model.CmdbSnapshot, error) {
+	return hds.Database.ListCmdbSnapshots()
+}
+
+func (hds *HostDataService) GetCmdbSnapshot(name string) (*model.CmdbSnapshot, error) {
+	return hds.Database.GetCmdbSnapshot(name)
+}
@@ -17,9 +17,11 @@ package service
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 
 	"github.com/ercole-io/ercole/v2/config"
@@ -47,7 +49,7 @@ func TestCompareCmdbInfo_DbError(t *testing.T) {
 	db.EXPECT().GetCurrentHostnames().
 		Return(nil, aerrMock)
 
-	actualErr := hds.CompareCmdbInfo(dto.CmdbInfo{})
+	_, actualErr := hds.CompareCmdbInfo(dto.CmdbInfo{})
 	assert.Equal(t, aerrMock, actualErr)
 }
 
@@ -68,12 +70,14 @@ func TestCompareCmdbInfo_NoAlerts(t *testing.T) {
 
 	db.EXPECT().GetCurrentHostnames().
 		Return([]string{"pippo", "topolino", "pluto"}, nil)
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().SaveCmdbSnapshot(gomock.Any()).Return(nil)
 
 	cmdbInfo := dto.CmdbInfo{
 		Name:      "thisCmdb",
 		Hostnames: []string{"pippo", "topolino", "pluto"},
 	}
-	actualErr := hds.CompareCmdbInfo(cmdbInfo)
+	_, actualErr := hds.CompareCmdbInfo(cmdbInfo)
 	assert.Nil(t, actualErr)
 }
 
@@ -94,6 +98,8 @@ func TestCompareCmdbInfo_MissingHostInErcole(t *testing.T) {
 
 	db.EXPECT().GetCurrentHostnames().
 		Return([]string{"pippo", "topolino.topolinia.top", "pluto"}, nil)
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().SaveCmdbSnapshot(gomock.Any()).Return(nil)
 
 	alert := model.Alert{
 		AlertCategory: model.AlertCategoryEngine,
@@ -110,7 +116,7 @@ func TestCompareCmdbInfo_MissingHostInErcole(t *testing.T) {
 		Name:      "thisCmdb",
 		Hostnames: []string{"pippo", "topolino.topolinia.top", "pluto"},
 	}
-	actualErr := hds.CompareCmdbInfo(cmdbInfo)
+	_, actualErr := hds.CompareCmdbInfo(cmdbInfo)
 	assert.Nil(t, actualErr)
 }
 
@@ -131,6 +137,8 @@ func TestCompareCmdbInfo_MissingHostInCmdb(t *testing.T) {
 
 	db.EXPECT().GetCurrentHostnames().
 		Return([]string{"pippo.topolinia.top", "TOPOLINO", "pluto"}, nil)
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().SaveCmdbSnapshot(gomock.Any()).Return(nil)
 
 	alert := model.Alert{
 		AlertCategory: model.AlertCategoryEngine,
@@ -146,6 +154,138 @@ func TestCompareCmdbInfo_MissingHostInCmdb(t *testing.T) {
 		Name:      "thisCmdb",
 		Hostnames: []string{"pippo.topolinia.top", "TOPOLINO", "pluto"},
 	}
-	actualErr := hds.CompareCmdbInfo(cmdbInfo)
+	_, actualErr := hds.CompareCmdbInfo(cmdbInfo)
 	assert.Nil(t, actualErr)
 }
+
+func TestCompareCmdbInfo_Aliases(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+
+	hds := HostDataService{
+		Config: config.Configuration{
+			HostIdentity: config.HostIdentity{
+				NormalizationRules: []config.HostnameNormalizationRule{
+					{Pattern: "^srv-", Replacement: ""},
+				},
+			},
+		},
+		ServerVersion:  "1.6.6",
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetCurrentHostnames().
+		Return([]string{"pippo", "topolino"}, nil)
+	db.EXPECT().ListHostAliases().
+		Return([]model.HostAlias{{Alias: "mickey.disney.com", Hostname: "topolino"}}, nil)
+	db.EXPECT().SaveCmdbSnapshot(gomock.Any()).Return(nil)
+
+	cmdbInfo := dto.CmdbInfo{
+		Name:      "thisCmdb",
+		Hostnames: []string{"SRV-PIPPO.topolinia.top", "mickey"},
+	}
+	_, actualErr := hds.CompareCmdbInfo(cmdbInfo)
+	assert.Nil(t, actualErr)
+}
+
+func TestCompareCmdbInfo_AttributeMismatches(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+
+	hds := HostDataService{
+		Config:         config.Configuration{},
+		ServerVersion:  "1.6.6",
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().GetCurrentHostnames().
+		Return([]string{"pippo", "topolino"}, nil)
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().FindCurrentHostsCmdbAttributes(gomock.Any()).
+		Do(func(hostnames []string) {
+			assert.ElementsMatch(t, []string{"pippo", "topolino"}, hostnames)
+		}).
+		Return([]model.HostDataBE{
+			{Hostname: "topolino", Location: "Italy", Environment: "PROD"},
+			{Hostname: "pippo", Location: "Italy", Environment: "TST", Tags: []string{"dba"}},
+		}, nil)
+	db.EXPECT().UpdateCurrentHostCmdbAttributes("pippo", "Italy", "PROD", []string{"dba", "billing"}).Return(nil)
+	asc.EXPECT().ThrowNewAlert(gomock.Any()).
+		Do(func(alert model.Alert) {
+			assert.Equal(t, model.AlertCodeCmdbAttributeMismatch, alert.AlertCode)
+			assert.Equal(t, "Host pippo has environment \"TST\" but it is \"PROD\" in CMDB thisCmdb\n"+
+				"Host pippo has businessService \"dba\" but it is \"billing\" in CMDB thisCmdb\n"+
+				"Host topolino has decommissioned \"false\" but it is \"true\" in CMDB thisCmdb\n", alert.Description)
+		}).Return(nil)
+	db.EXPECT().SaveCmdbSnapshot(gomock.Any()).
+		Do(func(snapshot model.CmdbSnapshot) {
+			assert.Equal(t, "thisCmdb", snapshot.Name)
+			assert.Equal(t, "pippo", snapshot.Records[0].ErcoleHostname)
+		}).Return(nil)
+
+	cmdbInfo := dto.CmdbInfo{
+		Name: "thisCmdb",
+		Records: []model.CmdbRecord{
+			{Hostname: "PIPPO.topolinia.top", Location: "italy", Environment: "PROD", BusinessService: "billing"},
+			{Hostname: "topolino", Decommissioned: true},
+		},
+		Override: true,
+	}
+	actual, err := hds.CompareCmdbInfo(cmdbInfo)
+	require.NoError(t, err)
+
+	expected := []model.CmdbMismatch{
+		{Hostname: "pippo", Attribute: model.CmdbAttributeEnvironment, CmdbValue: "PROD", ErcoleValue: "TST", Overridden: true},
+		{Hostname: "pippo", Attribute: model.CmdbAttributeBusinessService, CmdbValue: "billing", ErcoleValue: "dba", Overridden: true},
+		{Hostname: "topolino", Attribute: model.CmdbAttributeDecommissioned, CmdbValue: "true", ErcoleValue: "false"},
+	}
+	assert.Equal(t, expected, actual.AttributeMismatches)
+	assert.Empty(t, actual.UnknownHostnames)
+	assert.Empty(t, actual.MissingHostnames)
+}
+
+func TestParseCmdbCsv(t *testing.T) {
+	hds := HostDataService{
+		Log: logger.NewLogger("TEST"),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		csv := "Hostname,Location,Environment,Owner,Business Service,Decommissioned,Notes\n" +
+			"pippo,Italy,PROD,dba,billing,no,foo\n" +
+			"topolino,,,,,yes\n"
+
+		actual, err := hds.ParseCmdbCsv(strings.NewReader(csv))
+		require.NoError(t, err)
+
+		expected := []model.CmdbRecord{
+			{Hostname: "pippo", Location: "Italy", Environment: "PROD", Owner: "dba", BusinessService: "billing"},
+			{Hostname: "topolino", Decommissioned: true},
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Missing hostname column", func(t *testing.T) {
+		_, err := hds.ParseCmdbCsv(strings.NewReader("location\nItaly\n"))
+		assert.ErrorIs(t, err, utils.ErrInvalidCmdbCsv)
+	})
+
+	t.Run("Invalid decommissioned", func(t *testing.T) {
+		_, err := hds.ParseCmdbCsv(strings.NewReader("hostname,decommissioned\npippo,maybe\n"))
+		assert.ErrorIs(t, err, utils.ErrInvalidCmdbCsv)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := hds.ParseCmdbCsv(strings.NewReader(""))
+		assert.ErrorIs(t, err, utils.ErrInvalidCmdbCsv)
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import "github.com/ercole-io/ercole/v2/model"
+
+// coreFactorPolicy return the active core factor policy, or the default one if no policy has been saved
+func (hds *HostDataService) coreFactorPolicy() (model.CoreFactorPolicy, error) {
+	policy, err := hds.Database.FindActiveCoreFactorPolicy()
+	if err != nil {
+		return model.CoreFactorPolicy{}, err
+	}
+
+	if policy == nil {
+		return model.DefaultCoreFactorPolicy(), nil
+	}
+
+	return *policy, nil
+}
+
+// applyCoreFactorPolicy sets the count of the licenses consumed by the Enterprise or Extreme Edition db,
+// the ones with a count, to the cores of the host multiplied by its core factor.
+// The licenses of the other editions don't depend on the core factor
+func applyCoreFactorPolicy(host model.Host, hostCoreFactor float64, db *model.OracleDatabase) {
+	if edition := db.Edition(); edition != model.OracleDatabaseEditionEnterprise && edition != model.OracleDatabaseEditionExtreme {
+		return
+	}
+
+	for i := range db.Licenses {
+		if db.Licenses[i].Count > 0 {
+			db.Licenses[i].Count = float64(host.CPUCores) * hostCoreFactor
+		}
+	}
+}
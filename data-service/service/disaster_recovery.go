@@ -17,19 +17,32 @@
 package service
 
 import (
-	"fmt"
-
 	"github.com/ercole-io/ercole/v2/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func (hds *HostDataService) createDR(hostdata model.HostDataBE) error {
-	drname := fmt.Sprintf("%s_DR", hostdata.Hostname)
+	pairs, err := hds.Database.ListDisasterRecoveryPairs()
+	if err != nil {
+		return err
+	}
+
+	drname := model.DRHostname(pairs, hostdata.Hostname)
 
 	if !hds.Database.ExistsDR(drname) {
 		return nil
 	}
 
+	// the DR of this hostdata was already created by a previous attempt
+	created, err := hds.Database.ExistsCurrentHostDataNotOlderThan(drname, hostdata.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		return nil
+	}
+
 	if err := hds.Database.DismissHost(drname); err != nil {
 		return err
 	}
@@ -40,7 +53,7 @@ func (hds *HostDataService) createDR(hostdata model.HostDataBE) error {
 
 	if hostdata.ClusterMembershipStatus.VeritasClusterServer {
 		for i := 0; i < len(hostdata.ClusterMembershipStatus.VeritasClusterHostnames); i++ {
-			hostdata.ClusterMembershipStatus.VeritasClusterHostnames[i] = fmt.Sprintf("%s_DR", hostdata.ClusterMembershipStatus.VeritasClusterHostnames[i])
+			hostdata.ClusterMembershipStatus.VeritasClusterHostnames[i] = model.DRHostname(pairs, hostdata.ClusterMembershipStatus.VeritasClusterHostnames[i])
 		}
 	}
 
@@ -18,8 +18,11 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -34,7 +37,9 @@ func TestCreateDR(t *testing.T) {
 
 	drname := "test_DR"
 
+	db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil).Times(1)
 	db.EXPECT().ExistsDR(drname).Return(true).Times(1)
+	db.EXPECT().ExistsCurrentHostDataNotOlderThan(drname, time.Time{}).Return(false, nil).Times(1)
 	db.EXPECT().DismissHost(drname).Return(nil).Times(1)
 	db.EXPECT().InsertHostData(gomock.Any()).Return(nil).Times(1)
 
@@ -46,3 +51,59 @@ func TestCreateDR(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestCreateDR_AlreadyCreated(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+	}
+
+	createdAt := utils.P("2019-11-05T14:02:03Z")
+
+	db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil).Times(1)
+	db.EXPECT().ExistsDR("test_DR").Return(true).Times(1)
+	db.EXPECT().ExistsCurrentHostDataNotOlderThan("test_DR", createdAt).Return(true, nil).Times(1)
+
+	err := hds.createDR(model.HostDataBE{
+		Hostname:  "test",
+		CreatedAt: createdAt,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCreateDR_Pairs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+	}
+
+	pairs := []model.DisasterRecoveryPair{
+		{Primary: "db01.mi", DR: "db01.rm"},
+		{Primary: `(.+)\.mi`, DR: "$1.rm", Regex: true},
+	}
+
+	db.EXPECT().ListDisasterRecoveryPairs().Return(pairs, nil).Times(1)
+	db.EXPECT().ExistsDR("db01.rm").Return(true).Times(1)
+	db.EXPECT().ExistsCurrentHostDataNotOlderThan("db01.rm", time.Time{}).Return(false, nil).Times(1)
+	db.EXPECT().DismissHost("db01.rm").Return(nil).Times(1)
+	db.EXPECT().InsertHostData(gomock.Any()).Do(func(hostdata model.HostDataBE) {
+		assert.Equal(t, "db01.rm", hostdata.Hostname)
+		assert.True(t, hostdata.IsDR)
+		assert.Equal(t, []string{"db01.rm", "db02.rm"}, hostdata.ClusterMembershipStatus.VeritasClusterHostnames)
+	}).Return(nil).Times(1)
+
+	err := hds.createDR(model.HostDataBE{
+		Hostname: "db01.mi",
+		ClusterMembershipStatus: model.ClusterMembershipStatus{
+			VeritasClusterServer:    true,
+			VeritasClusterHostnames: []string{"db01.mi", "db02.mi"},
+		},
+	})
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// saveHostChanges stores the differences between the previous hostdata and the new one
+func (hds *HostDataService) saveHostChanges(previous *model.HostDataBE, hostdata model.HostDataBE) {
+	if previous == nil {
+		return
+	}
+
+	changes := diffHostData(*previous, hostdata)
+	if len(changes) == 0 {
+		return
+	}
+
+	for i := range changes {
+		changes[i].ID = primitive.NewObjectIDFromTimestamp(hostdata.CreatedAt)
+		changes[i].HostDataID = hostdata.ID
+		changes[i].Hostname = hostdata.Hostname
+		changes[i].Location = hostdata.Location
+		changes[i].Date = hostdata.CreatedAt
+	}
+
+	if err := hds.Database.InsertHostChanges(changes); err != nil {
+		hds.Log.Error(err)
+	}
+}
+
+// diffHostData return the changes between two hostdata of the same host.
+// Only the fields that identify the change are set
+func diffHostData(previous, current model.HostDataBE) []model.HostChange {
+	changes := make([]model.HostChange, 0)
+
+	changes = append(changes, diffHostInfo(previous.Info, current.Info)...)
+
+	if oldValue, newValue := clusterMembershipString(previous.ClusterMembershipStatus), clusterMembershipString(current.ClusterMembershipStatus); oldValue != newValue {
+		changes = append(changes, model.HostChange{
+			Kind:     model.HostChangeKindClusterMembershipChanged,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+
+	changes = append(changes, diffOracleDatabases(oracleDatabases(previous), oracleDatabases(current))...)
+	changes = append(changes, diffDatabaseVersions(model.TechnologyMicrosoftSQLServer, sqlServerVersions(previous), sqlServerVersions(current))...)
+	changes = append(changes, diffDatabaseVersions(model.TechnologyOracleMySQL, mySQLVersions(previous), mySQLVersions(current))...)
+	changes = append(changes, diffDatabaseVersions(model.TechnologyPostgreSQLPostgreSQL, postgreSQLVersions(previous), postgreSQLVersions(current))...)
+	changes = append(changes, diffDatabaseVersions(model.TechnologyMongoDBMongoDB, mongoDBVersions(previous), mongoDBVersions(current))...)
+	changes = append(changes, diffDatabaseVersions(model.TechnologyMariaDBFoundationMariaDB, mariaDBVersions(previous), mariaDBVersions(current))...)
+
+	return changes
+}
+
+type fieldChange struct {
+	object   string
+	oldValue string
+	newValue string
+}
+
+func diffFields(kind string, fields []fieldChange) []model.HostChange {
+	changes := make([]model.HostChange, 0)
+
+	for _, f := range fields {
+		if f.oldValue != f.newValue {
+			changes = append(changes, model.HostChange{
+				Kind:     kind,
+				Object:   f.object,
+				OldValue: f.oldValue,
+				NewValue: f.newValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffHostInfo(previous, current model.Host) []model.HostChange {
+	changes := make([]model.HostChange, 0)
+
+	changes = append(changes, diffFields(model.HostChangeKindMemoryChanged, []fieldChange{
+		{"memoryTotal", formatFloat(previous.MemoryTotal), formatFloat(current.MemoryTotal)},
+	})...)
+
+	changes = append(changes, diffFields(model.HostChangeKindCPUChanged, []fieldChange{
+		{"cpuModel", previous.CPUModel, current.CPUModel},
+		{"cpuSockets", strconv.Itoa(previous.CPUSockets), strconv.Itoa(current.CPUSockets)},
+		{"cpuCores", strconv.Itoa(previous.CPUCores), strconv.Itoa(current.CPUCores)},
+		{"cpuThreads", strconv.Itoa(previous.CPUThreads), strconv.Itoa(current.CPUThreads)},
+	})...)
+
+	changes = append(changes, diffFields(model.HostChangeKindOSChanged, []fieldChange{
+		{"os", previous.OS, current.OS},
+		{"osVersion", previous.OSVersion, current.OSVersion},
+		{"kernel", previous.Kernel, current.Kernel},
+		{"kernelVersion", previous.KernelVersion, current.KernelVersion},
+	})...)
+
+	return changes
+}
+
+// diffDatabaseVersions compares the databases of a technology, identified by name and mapped to their version
+func diffDatabaseVersions(technology string, previous, current map[string]string) []model.HostChange {
+	changes := make([]model.HostChange, 0)
+
+	for _, name := range sortedKeys(current) {
+		oldVersion, found := previous[name]
+
+		switch {
+		case !found:
+			changes = append(changes, model.HostChange{
+				Kind:       model.HostChangeKindDatabaseAdded,
+				Technology: technology,
+				Database:   name,
+				NewValue:   current[name],
+			})
+		case oldVersion != current[name]:
+			changes = append(changes, model.HostChange{
+				Kind:       model.HostChangeKindDatabaseVersionChanged,
+				Technology: technology,
+				Database:   name,
+				OldValue:   oldVersion,
+				NewValue:   current[name],
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(previous) {
+		if _, found := current[name]; !found {
+			changes = append(changes, model.HostChange{
+				Kind:       model.HostChangeKindDatabaseRemoved,
+				Technology: technology,
+				Database:   name,
+				OldValue:   previous[name],
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffOracleDatabases(previous, current []model.OracleDatabase) []model.HostChange {
+	previousVersions := make(map[string]string, len(previous))
+	previousByName := make(map[string]model.OracleDatabase, len(previous))
+
+	for _, db := range previous {
+		previousVersions[db.Name] = db.Version
+		previousByName[db.Name] = db
+	}
+
+	currentVersions := make(map[string]string, len(current))
+	for _, db := range current {
+		currentVersions[db.Name] = db.Version
+	}
+
+	changes := diffDatabaseVersions(model.TechnologyOracleDatabase, previousVersions, currentVersions)
+
+	for _, db := range current {
+		previousDB, found := previousByName[db.Name]
+		if !found {
+			continue
+		}
+
+		newChange := func(kind, object, oldValue, newValue string) model.HostChange {
+			return model.HostChange{
+				Kind:       kind,
+				Technology: model.TechnologyOracleDatabase,
+				Database:   db.Name,
+				Object:     object,
+				OldValue:   oldValue,
+				NewValue:   newValue,
+			}
+		}
+
+		previousPatches := make(map[int]bool, len(previousDB.Patches))
+		for _, patch := range previousDB.Patches {
+			previousPatches[patch.PatchID] = true
+		}
+
+		currentPatches := make(map[int]bool, len(db.Patches))
+		for _, patch := range db.Patches {
+			currentPatches[patch.PatchID] = true
+
+			if !previousPatches[patch.PatchID] {
+				changes = append(changes, newChange(model.HostChangeKindPatchApplied, strconv.Itoa(patch.PatchID), "", patch.Description))
+			}
+		}
+
+		for _, patch := range previousDB.Patches {
+			if !currentPatches[patch.PatchID] {
+				changes = append(changes, newChange(model.HostChangeKindPatchRemoved, strconv.Itoa(patch.PatchID), patch.Description, ""))
+			}
+		}
+
+		previousTablespaces := make(map[string]bool, len(previousDB.Tablespaces))
+		for _, tablespace := range previousDB.Tablespaces {
+			previousTablespaces[tablespace.Name] = true
+		}
+
+		for _, tablespace := range db.Tablespaces {
+			if !previousTablespaces[tablespace.Name] {
+				changes = append(changes, newChange(model.HostChangeKindTablespaceAdded, tablespace.Name, "", ""))
+			}
+		}
+
+		previousSchemas := make(map[string]bool, len(previousDB.Schemas))
+		for _, schema := range previousDB.Schemas {
+			previousSchemas[schema.User] = true
+		}
+
+		for _, schema := range db.Schemas {
+			if !previousSchemas[schema.User] {
+				changes = append(changes, newChange(model.HostChangeKindSchemaAdded, schema.User, "", ""))
+			}
+		}
+	}
+
+	return changes
+}
+
+func clusterMembershipString(cms model.ClusterMembershipStatus) string {
+	memberships := make([]string, 0)
+
+	if cms.OracleClusterware {
+		memberships = append(memberships, "oracleClusterware")
+	}
+
+	if cms.SunCluster {
+		memberships = append(memberships, "sunCluster")
+	}
+
+	if cms.HACMP {
+		memberships = append(memberships, "hacmp")
+	}
+
+	if cms.VeritasClusterServer {
+		hostnames := append([]string{}, cms.VeritasClusterHostnames...)
+		sort.Strings(hostnames)
+
+		memberships = append(memberships, fmt.Sprintf("veritasClusterServer(%s)", strings.Join(hostnames, ",")))
+	}
+
+	return strings.Join(memberships, ", ")
+}
+
+func oracleDatabases(hostdata model.HostDataBE) []model.OracleDatabase {
+	if hostdata.Features.Oracle == nil || hostdata.Features.Oracle.Database == nil {
+		return nil
+	}
+
+	return hostdata.Features.Oracle.Database.Databases
+}
+
+func sqlServerVersions(hostdata model.HostDataBE) map[string]string {
+	versions := make(map[string]string)
+
+	if hostdata.Features.Microsoft != nil && hostdata.Features.Microsoft.SQLServer != nil {
+		for _, instance := range hostdata.Features.Microsoft.SQLServer.Instances {
+			versions[instance.Name] = instance.Version
+		}
+	}
+
+	return versions
+}
+
+func mySQLVersions(hostdata model.HostDataBE) map[string]string {
+	versions := make(map[string]string)
+
+	if hostdata.Features.MySQL != nil {
+		for _, instance := range hostdata.Features.MySQL.Instances {
+			versions[instance.Name] = instance.Version
+		}
+	}
+
+	return versions
+}
+
+func postgreSQLVersions(hostdata model.HostDataBE) map[string]string {
+	versions := make(map[string]string)
+
+	if hostdata.Features.PostgreSQL != nil {
+		for _, instance := range hostdata.Features.PostgreSQL.Instances {
+			version := ""
+			if instance.Setting != nil {
+				version = instance.Setting.DbVersion
+			}
+
+			versions[instance.Name] = version
+		}
+	}
+
+	return versions
+}
+
+func mongoDBVersions(hostdata model.HostDataBE) map[string]string {
+	versions := make(map[string]string)
+
+	if hostdata.Features.MongoDB != nil {
+		for _, instance := range hostdata.Features.MongoDB.Instances {
+			versions[instance.Name] = instance.Version
+		}
+	}
+
+	return versions
+}
+
+func mariaDBVersions(hostdata model.HostDataBE) map[string]string {
+	versions := make(map[string]string)
+
+	if hostdata.Features.MariaDB != nil {
+		for _, instance := range hostdata.Features.MariaDB.Instances {
+			versions[instance.Name] = instance.Version
+		}
+	}
+
+	return versions
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func TestDiffHostData(t *testing.T) {
+	previous := model.HostDataBE{
+		Info: model.Host{MemoryTotal: 16, CPUCores: 4, OS: "Red Hat Enterprise Linux", OSVersion: "7.4"},
+		ClusterMembershipStatus: model.ClusterMembershipStatus{
+			VeritasClusterServer:    true,
+			VeritasClusterHostnames: []string{"b", "a"},
+		},
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:        "ERCOLE",
+							Version:     "19.0.0.0",
+							Patches:     []model.OracleDatabasePatch{{PatchID: 1, Description: "RU 1"}},
+							Tablespaces: []model.OracleDatabaseTablespace{{Name: "SYSTEM"}},
+							Schemas:     []model.OracleDatabaseSchema{{User: "SYS"}},
+						},
+						{Name: "OLD", Version: "12.2.0.1"},
+					},
+				},
+			},
+			MySQL: &model.MySQLFeature{
+				Instances: []model.MySQLInstance{{Name: "mysql", Version: "8.0.1"}},
+			},
+		},
+	}
+
+	current := model.HostDataBE{
+		Info: model.Host{MemoryTotal: 32, CPUCores: 4, OS: "Red Hat Enterprise Linux", OSVersion: "8.2"},
+		ClusterMembershipStatus: model.ClusterMembershipStatus{
+			VeritasClusterServer:    true,
+			VeritasClusterHostnames: []string{"a", "b"},
+		},
+		Features: model.Features{
+			Oracle: &model.OracleFeature{
+				Database: &model.OracleDatabaseFeature{
+					Databases: []model.OracleDatabase{
+						{
+							Name:        "ERCOLE",
+							Version:     "19.3.0.0",
+							Patches:     []model.OracleDatabasePatch{{PatchID: 2, Description: "RU 2"}},
+							Tablespaces: []model.OracleDatabaseTablespace{{Name: "SYSTEM"}, {Name: "USERS"}},
+							Schemas:     []model.OracleDatabaseSchema{{User: "SYS"}, {User: "APP"}},
+						},
+						{Name: "NEW", Version: "19.3.0.0"},
+					},
+				},
+			},
+			MySQL: &model.MySQLFeature{
+				Instances: []model.MySQLInstance{{Name: "mysql", Version: "8.0.1"}},
+			},
+		},
+	}
+
+	expected := []model.HostChange{
+		{Kind: model.HostChangeKindMemoryChanged, Object: "memoryTotal", OldValue: "16", NewValue: "32"},
+		{Kind: model.HostChangeKindOSChanged, Object: "osVersion", OldValue: "7.4", NewValue: "8.2"},
+		{Kind: model.HostChangeKindDatabaseVersionChanged, Technology: model.TechnologyOracleDatabase, Database: "ERCOLE", OldValue: "19.0.0.0", NewValue: "19.3.0.0"},
+		{Kind: model.HostChangeKindDatabaseAdded, Technology: model.TechnologyOracleDatabase, Database: "NEW", NewValue: "19.3.0.0"},
+		{Kind: model.HostChangeKindDatabaseRemoved, Technology: model.TechnologyOracleDatabase, Database: "OLD", OldValue: "12.2.0.1"},
+		{Kind: model.HostChangeKindPatchApplied, Technology: model.TechnologyOracleDatabase, Database: "ERCOLE", Object: "2", NewValue: "RU 2"},
+		{Kind: model.HostChangeKindPatchRemoved, Technology: model.TechnologyOracleDatabase, Database: "ERCOLE", Object: "1", OldValue: "RU 1"},
+		{Kind: model.HostChangeKindTablespaceAdded, Technology: model.TechnologyOracleDatabase, Database: "ERCOLE", Object: "USERS"},
+		{Kind: model.HostChangeKindSchemaAdded, Technology: model.TechnologyOracleDatabase, Database: "ERCOLE", Object: "APP"},
+	}
+
+	assert.Equal(t, expected, diffHostData(previous, current))
+}
+
+func TestDiffHostData_ClusterMembership(t *testing.T) {
+	previous := model.HostDataBE{}
+	current := model.HostDataBE{
+		ClusterMembershipStatus: model.ClusterMembershipStatus{OracleClusterware: true},
+	}
+
+	expected := []model.HostChange{
+		{Kind: model.HostChangeKindClusterMembershipChanged, OldValue: "", NewValue: "oracleClusterware"},
+	}
+
+	assert.Equal(t, expected, diffHostData(previous, current))
+}
+
+func TestDiffHostData_NoChanges(t *testing.T) {
+	hostdata := model.HostDataBE{Info: model.Host{MemoryTotal: 8}}
+
+	assert.Empty(t, diffHostData(hostdata, hostdata))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils/hostidentity"
+)
+
+// hostIdentityResolver return a resolver of the names of hostnames and of the host aliases
+func (hds *HostDataService) hostIdentityResolver(hostnames []string) (*hostidentity.Resolver, error) {
+	aliases, err := hds.Database.ListHostAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	return hostidentity.NewResolver(hds.Config.HostIdentity, hostnames, aliases)
+}
+
+// resolveHostDataHostname renames the hostdata received with an alias, like the name of a renamed host,
+// to the hostname of the alias. The normalization rules alone never rename an hostdata,
+// because two different hosts could have the same normalized name
+func (hds *HostDataService) resolveHostDataHostname(hostdata *model.HostDataBE) error {
+	resolver, err := hds.hostIdentityResolver(nil)
+	if err != nil {
+		return err
+	}
+
+	if hostname, ok := resolver.ResolveAlias(hostdata.Hostname); ok && hostname != hostdata.Hostname {
+		hds.Log.Infof("Received hostdata of %s, alias of %s", hostdata.Hostname, hostname)
+		hostdata.Hostname = hostname
+	}
+
+	return nil
+}
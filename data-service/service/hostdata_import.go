@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"time"
+
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ImportHostData validates and inserts the raw hostdata as if it was received at collectedAt.
+// It refuses the hostdata older than the current one of the same host, so the imports can't replace more recent data
+func (hds *HostDataService) ImportHostData(raw []byte, collectedAt time.Time) error {
+	hostdata, err := hds.parseHostDataPayload(raw, false)
+	if err != nil {
+		return err
+	}
+
+	if err := hds.resolveHostDataHostname(hostdata); err != nil {
+		return err
+	}
+
+	if err := hds.applyCmdbOverride(hostdata); err != nil {
+		return err
+	}
+
+	outdated, err := hds.Database.ExistsCurrentHostDataNotOlderThan(hostdata.Hostname, collectedAt)
+	if err != nil {
+		return err
+	}
+
+	if outdated {
+		return utils.NewErrorf("%w: %s", utils.ErrHostDataOutdated, hostdata.Hostname)
+	}
+
+	importer := *hds
+	importer.TimeNow = func() time.Time { return collectedAt }
+
+	return importer.InsertHostData(*hostdata)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestImportHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			AlertService: config.AlertService{
+				Emailer: config.Emailer{
+					AlertType: config.AlertType{
+						NewHost: config.Directive{Enable: true},
+					}}},
+		},
+		ServerVersion:  "1.6.6",
+		Database:       db,
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	raw, err := os.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	collectedAt := utils.P("2019-10-01T08:00:00Z")
+
+	t.Run("Success", func(t *testing.T) {
+		gomock.InOrder(
+			db.EXPECT().ListHostAliases().Return(nil, nil),
+			db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil),
+			db.EXPECT().ExistsCurrentHostDataNotOlderThan("rac1_x", collectedAt).Return(false, nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("rac1_x", collectedAt).Return(nil, nil),
+			asc.EXPECT().ThrowNewAlert(gomock.Any()).Do(func(a model.Alert) {
+				assert.Equal(t, collectedAt, a.Date)
+			}).Return(nil),
+			db.EXPECT().DismissHost("rac1_x").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).
+				Do(func(newHD model.HostDataBE) {
+					assert.Equal(t, collectedAt, newHD.ID.Timestamp())
+					assert.Equal(t, collectedAt, newHD.CreatedAt)
+					assert.Equal(t, "rac1_x", newHD.Hostname)
+				}).
+				Return(nil),
+			db.EXPECT().DeleteNoDataAlertByHost("rac1_x").Return(nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
+			db.EXPECT().ExistsDR("rac1_x_DR").Return(false),
+		)
+
+		require.NoError(t, hds.ImportHostData(raw, collectedAt))
+		assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), hds.TimeNow())
+	})
+
+	t.Run("Outdated", func(t *testing.T) {
+		db.EXPECT().ListHostAliases().Return(nil, nil)
+		db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil)
+		db.EXPECT().ExistsCurrentHostDataNotOlderThan("rac1_x", collectedAt).Return(true, nil)
+
+		err := hds.ImportHostData(raw, collectedAt)
+		assert.ErrorIs(t, err, utils.ErrHostDataOutdated)
+	})
+
+	t.Run("Invalid hostdata", func(t *testing.T) {
+		err := hds.ImportHostData([]byte(`{"hostname": "foobar"}`), collectedAt)
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		err := hds.ImportHostData([]byte(`{"hostname": x}`), collectedAt)
+		assert.ErrorIs(t, err, utils.ErrInvalidJSON)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/schema"
+	"github.com/ercole-io/ercole/v2/utils"
+	"github.com/ercole-io/ercole/v2/utils/sanitizer"
+)
+
+// QuarantineHostData saves a rejected hostdata payload with its validation errors
+func (hds *HostDataService) QuarantineHostData(raw []byte) error {
+	now := hds.TimeNow()
+
+	item := model.HostDataQuarantineItem{
+		ID:               primitive.NewObjectIDFromTimestamp(now),
+		Hostname:         payloadHostname(raw),
+		Status:           model.HostDataQuarantineItemStatusQuarantined,
+		ValidationErrors: payloadValidationErrors(raw),
+		ReceivedAt:       now,
+		Payload:          string(raw),
+	}
+
+	return hds.Database.InsertQuarantinedHostData(item)
+}
+
+func (hds *HostDataService) ListQuarantinedHostData(hostname, status string) ([]model.HostDataQuarantineItem, error) {
+	return hds.Database.ListQuarantinedHostData(hostname, status)
+}
+
+func (hds *HostDataService) GetQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error) {
+	return hds.Database.GetQuarantinedHostData(id)
+}
+
+// UpdateQuarantinedHostData replaces the payload of a quarantined hostdata, e.g. to fix it before replaying
+func (hds *HostDataService) UpdateQuarantinedHostData(id primitive.ObjectID, raw []byte) (*model.HostDataQuarantineItem, error) {
+	item, err := hds.Database.GetQuarantinedHostData(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch item.Status {
+	case model.HostDataQuarantineItemStatusReplaying:
+		return nil, utils.ErrHostDataBeingReplayed
+	case model.HostDataQuarantineItemStatusReplayed:
+		return nil, utils.ErrHostDataAlreadyReplayed
+	}
+
+	now := hds.TimeNow()
+	item.Hostname = payloadHostname(raw)
+	item.ValidationErrors = payloadValidationErrors(raw)
+	item.Payload = string(raw)
+	item.UpdatedAt = &now
+
+	if err := hds.Database.UpdateQuarantinedHostDataPayload(id, item.Hostname, item.Payload, item.ValidationErrors, now); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ReplayQuarantinedHostData submits again a quarantined hostdata.
+// If force is true the hostdata is submitted even if it doesn't respect the schema, as long as it has its required fields.
+// The item is claimed before being enqueued, so that concurrent replays of the same item can't enqueue it twice
+func (hds *HostDataService) ReplayQuarantinedHostData(id primitive.ObjectID, force bool) error {
+	item, err := hds.Database.ClaimQuarantinedHostData(id)
+	if err != nil {
+		return err
+	}
+
+	hostdata, err := hds.parseHostDataPayload([]byte(item.Payload), force)
+	if err == nil {
+		err = hds.EnqueueHostData(*hostdata)
+	}
+
+	if err != nil {
+		if errRelease := hds.Database.ReleaseQuarantinedHostData(id); errRelease != nil {
+			hds.Log.Errorf("Can't put back in quarantine hostdata %s: %s", id.Hex(), errRelease)
+		}
+
+		return err
+	}
+
+	return hds.Database.SetQuarantinedHostDataReplayed(id, hds.TimeNow())
+}
+
+// ReplayQuarantinedHostDataBatch submits again many quarantined hostdata, returning the outcome of each one
+func (hds *HostDataService) ReplayQuarantinedHostDataBatch(ids []primitive.ObjectID, force bool) []dto.HostDataReplayResult {
+	results := make([]dto.HostDataReplayResult, 0, len(ids))
+
+	for _, id := range ids {
+		result := dto.HostDataReplayResult{ID: id, Replayed: true}
+
+		if err := hds.ReplayQuarantinedHostData(id, force); err != nil {
+			result.Replayed = false
+			result.Error = err.Error()
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (hds *HostDataService) parseHostDataPayload(raw []byte, force bool) (*model.HostDataBE, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, utils.ErrInvalidJSON
+	}
+
+	sanitized, err := sanitizer.NewSanitizer(hds.Log).Sanitize(m)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to sanitize: %w", err)
+	}
+
+	if raw, err = json.Marshal(sanitized); err != nil {
+		return nil, fmt.Errorf("Unable to marshal: %w", err)
+	}
+
+	if force {
+		err = schema.ValidateHostdataRequiredFields(raw)
+	} else {
+		err = schema.ValidateHostdata(raw)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if raw, err = schema.UpgradeHostdata(raw); err != nil {
+		return nil, err
+	}
+
+	var hostdata model.HostDataBE
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	}
+
+	return &hostdata, nil
+}
+
+func payloadHostname(raw []byte) string {
+	var payload struct {
+		Hostname interface{} `json:"hostname"`
+	}
+
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return ""
+	}
+
+	if hostname, ok := payload.Hostname.(string); ok {
+		return hostname
+	}
+
+	return ""
+}
+
+func payloadValidationErrors(raw []byte) []string {
+	if !json.Valid(raw) {
+		return []string{utils.ErrInvalidJSON.Error()}
+	}
+
+	errs, err := schema.HostdataValidationErrors(raw)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	return errs
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestQuarantineHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	t.Run("Invalid hostdata", func(t *testing.T) {
+		db.EXPECT().InsertQuarantinedHostData(gomock.Any()).
+			Do(func(item model.HostDataQuarantineItem) {
+				assert.Equal(t, "foobar", item.Hostname)
+				assert.Equal(t, model.HostDataQuarantineItemStatusQuarantined, item.Status)
+				assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), item.ReceivedAt)
+				assert.Equal(t, `{"hostname":"foobar"}`, item.Payload)
+				assert.NotEmpty(t, item.ValidationErrors)
+			}).Return(nil)
+
+		require.NoError(t, hds.QuarantineHostData([]byte(`{"hostname":"foobar"}`)))
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		db.EXPECT().InsertQuarantinedHostData(gomock.Any()).
+			Do(func(item model.HostDataQuarantineItem) {
+				assert.Equal(t, "", item.Hostname)
+				assert.Equal(t, []string{utils.ErrInvalidJSON.Error()}, item.ValidationErrors)
+			}).Return(nil)
+
+		require.NoError(t, hds.QuarantineHostData([]byte(`{"hostname":`)))
+	})
+}
+
+func TestReplayQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	valid, err := os.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	id := primitive.NewObjectID()
+
+	t.Run("Success", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).
+			Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusReplaying, Payload: string(valid)}, nil)
+		db.EXPECT().ListHostAliases().Return(nil, nil)
+		db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil)
+		db.EXPECT().EnqueueHostData(gomock.Any()).
+			Do(func(item model.HostDataQueueItem) {
+				assert.Equal(t, "rac1_x", item.Hostname)
+			}).Return(nil)
+		db.EXPECT().SetQuarantinedHostDataReplayed(id, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+		require.NoError(t, hds.ReplayQuarantinedHostData(id, false))
+	})
+
+	t.Run("Still invalid", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).
+			Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusReplaying, Payload: `{"hostname":"foobar"}`}, nil)
+		db.EXPECT().ReleaseQuarantinedHostData(id).Return(nil)
+
+		err := hds.ReplayQuarantinedHostData(id, false)
+		require.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	})
+
+	t.Run("Forced", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).
+			Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusReplaying, Payload: forcedPayload(t, valid)}, nil)
+		db.EXPECT().ListHostAliases().Return(nil, nil)
+		db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil)
+		db.EXPECT().EnqueueHostData(gomock.Any()).
+			Do(func(item model.HostDataQueueItem) {
+				assert.Equal(t, "rac1_x", item.Hostname)
+				assert.Equal(t, "ENVIRONMENT_TOO_LONG", item.Hostdata.Environment)
+			}).Return(nil)
+		db.EXPECT().SetQuarantinedHostDataReplayed(id, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+
+		require.NoError(t, hds.ReplayQuarantinedHostData(id, true))
+	})
+
+	t.Run("Forced without required fields", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).
+			Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusReplaying, Payload: `{"hostname":"foobar"}`}, nil)
+		db.EXPECT().ReleaseQuarantinedHostData(id).Return(nil)
+
+		err := hds.ReplayQuarantinedHostData(id, true)
+		require.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	})
+
+	t.Run("Enqueue error", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).
+			Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusReplaying, Payload: string(valid)}, nil)
+		db.EXPECT().ListHostAliases().Return(nil, nil)
+		db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil)
+		db.EXPECT().EnqueueHostData(gomock.Any()).Return(aerrMock)
+		db.EXPECT().ReleaseQuarantinedHostData(id).Return(nil)
+
+		err := hds.ReplayQuarantinedHostData(id, false)
+		require.ErrorIs(t, err, aerrMock)
+	})
+
+	t.Run("Already replayed", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).Return(nil, utils.ErrHostDataAlreadyReplayed)
+
+		err := hds.ReplayQuarantinedHostData(id, true)
+		require.ErrorIs(t, err, utils.ErrHostDataAlreadyReplayed)
+	})
+
+	t.Run("Being replayed", func(t *testing.T) {
+		db.EXPECT().ClaimQuarantinedHostData(id).Return(nil, utils.ErrHostDataBeingReplayed)
+
+		err := hds.ReplayQuarantinedHostData(id, true)
+		require.ErrorIs(t, err, utils.ErrHostDataBeingReplayed)
+	})
+}
+
+func TestReplayQuarantinedHostDataBatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	valid, err := os.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	replayable, missing := primitive.NewObjectID(), primitive.NewObjectID()
+
+	db.EXPECT().ClaimQuarantinedHostData(replayable).
+		Return(&model.HostDataQuarantineItem{ID: replayable, Status: model.HostDataQuarantineItemStatusReplaying, Payload: forcedPayload(t, valid)}, nil)
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().FindCmdbOverrideRecord("rac1_x").Return(nil, nil)
+	db.EXPECT().EnqueueHostData(gomock.Any()).Return(nil)
+	db.EXPECT().SetQuarantinedHostDataReplayed(replayable, utils.P("2019-11-05T14:02:03Z")).Return(nil)
+	db.EXPECT().ClaimQuarantinedHostData(missing).Return(nil, utils.ErrNotFound)
+
+	expected := []dto.HostDataReplayResult{
+		{ID: replayable, Replayed: true},
+		{ID: missing, Replayed: false, Error: utils.ErrNotFound.Error()},
+	}
+
+	assert.Equal(t, expected, hds.ReplayQuarantinedHostDataBatch([]primitive.ObjectID{replayable, missing}, true))
+}
+
+// forcedPayload return the valid hostdata with an environment longer than allowed by the schema
+func forcedPayload(t *testing.T, valid []byte) string {
+	var hostdata map[string]interface{}
+	require.NoError(t, json.Unmarshal(valid, &hostdata))
+
+	hostdata["environment"] = "ENVIRONMENT_TOO_LONG"
+
+	raw, err := json.Marshal(hostdata)
+	require.NoError(t, err)
+
+	return string(raw)
+}
+
+func TestUpdateQuarantinedHostData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	valid, err := os.ReadFile("../../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	id := primitive.NewObjectID()
+
+	db.EXPECT().GetQuarantinedHostData(id).
+		Return(&model.HostDataQuarantineItem{ID: id, Status: model.HostDataQuarantineItemStatusQuarantined, Payload: "{}"}, nil)
+	db.EXPECT().UpdateQuarantinedHostDataPayload(id, "rac1_x", string(valid), []string{}, utils.P("2019-11-05T14:02:03Z")).
+		Return(nil)
+
+	actual, err := hds.UpdateQuarantinedHostData(id, valid)
+	require.NoError(t, err)
+
+	assert.Empty(t, actual.ValidationErrors)
+	assert.Equal(t, string(valid), actual.Payload)
+}
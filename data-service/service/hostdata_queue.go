@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+const (
+	defaultHostDataQueueMaxAttempts     = 5
+	defaultHostDataQueueRetryBackoff    = 30 * time.Second
+	defaultHostDataQueueMaxRetryBackoff = time.Hour
+	defaultHostDataQueueLockTimeout     = 10 * time.Minute
+)
+
+// EnqueueHostData saves the hostdata in the queue, it will be processed asynchronously
+func (hds *HostDataService) EnqueueHostData(hostdata model.HostDataBE) error {
+	if err := hds.resolveHostDataHostname(&hostdata); err != nil {
+		return err
+	}
+
+	if err := hds.applyCmdbOverride(&hostdata); err != nil {
+		return err
+	}
+
+	now := hds.TimeNow()
+
+	item := model.HostDataQueueItem{
+		ID:            primitive.NewObjectIDFromTimestamp(now),
+		Hostname:      hostdata.Hostname,
+		Status:        model.HostDataQueueItemStatusPending,
+		Attempts:      0,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		Hostdata:      hostdata,
+	}
+
+	return hds.Database.EnqueueHostData(item)
+}
+
+// ProcessHostDataQueue inserts all the queued hostdata ready to be processed
+func (hds *HostDataService) ProcessHostDataQueue() {
+	for {
+		now := hds.TimeNow()
+
+		item, err := hds.Database.DequeueHostData(now, now.Add(hds.hostDataQueueLockTimeout()))
+		if err != nil {
+			hds.Log.Error(err)
+			return
+		}
+
+		if item == nil {
+			return
+		}
+
+		hds.processQueuedHostData(*item)
+	}
+}
+
+func (hds *HostDataService) processQueuedHostData(item model.HostDataQueueItem) {
+	if item.Attempts > hds.hostDataQueueMaxAttempts() {
+		// a previous attempt has been interrupted without releasing the item
+		hds.moveQueuedHostDataToDeadLetters(item, errors.New("The processing was interrupted too many times"))
+		return
+	}
+
+	insertErr := hds.insertQueuedHostData(item)
+	if insertErr == nil {
+		if err := hds.Database.DeleteQueuedHostData(item.ID); err != nil {
+			hds.Log.Error(err)
+		}
+
+		return
+	}
+
+	item.LastError = insertErr.Error()
+
+	if item.Attempts >= hds.hostDataQueueMaxAttempts() {
+		hds.moveQueuedHostDataToDeadLetters(item, insertErr)
+		return
+	}
+
+	hds.Log.Warnf("Can't insert hostdata of %s (attempt %d): %s", item.Hostname, item.Attempts, insertErr)
+
+	nextAttemptAt := hds.TimeNow().Add(hds.hostDataQueueRetryBackoff(item.Attempts))
+	if err := hds.Database.RescheduleQueuedHostData(item.ID, item.Attempts, nextAttemptAt, item.LastError); err != nil {
+		hds.Log.Error(err)
+	}
+}
+
+// insertQueuedHostData saves the hostdata of the item with the item ID and its receive date.
+// An item older than the current hostdata of the host is dropped,
+// and an item already saved by a previous attempt only completes the creation of its DR
+func (hds *HostDataService) insertQueuedHostData(item model.HostDataQueueItem) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Panic while inserting hostdata: %v", r)
+		}
+	}()
+
+	saved, err := hds.Database.FindHostData(item.ID)
+	if err != nil {
+		return err
+	}
+
+	if saved != nil {
+		if saved.Archived {
+			return nil
+		}
+
+		return hds.createDR(*saved)
+	}
+
+	outdated, err := hds.Database.ExistsCurrentHostDataNotOlderThan(item.Hostname, item.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if outdated {
+		hds.Log.Warnf("Dropping hostdata of %s received at %s, the current one is more recent", item.Hostname, item.CreatedAt)
+		return nil
+	}
+
+	hostdata := item.Hostdata
+	hostdata.ID = item.ID
+	hostdata.CreatedAt = item.CreatedAt
+
+	return hds.insertHostData(hostdata)
+}
+
+func (hds *HostDataService) moveQueuedHostDataToDeadLetters(item model.HostDataQueueItem, cause error) {
+	hds.Log.Errorf("Can't insert hostdata of %s after %d attempts, moving it to dead letters: %s",
+		item.Hostname, item.Attempts, cause)
+
+	failedAt := hds.TimeNow()
+	item.Status = model.HostDataQueueItemStatusFailed
+	item.FailedAt = &failedAt
+	item.LastError = cause.Error()
+
+	if err := hds.Database.MoveQueuedHostDataToDeadLetters(item); err != nil {
+		hds.Log.Error(err)
+	}
+}
+
+func (hds *HostDataService) GetHostDataQueueStats() (*dto.HostDataQueueStats, error) {
+	pending, err := hds.Database.CountQueuedHostData(model.HostDataQueueItemStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	processing, err := hds.Database.CountQueuedHostData(model.HostDataQueueItemStatusProcessing)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters, err := hds.Database.CountHostDataDeadLetters()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.HostDataQueueStats{
+		Pending:     pending,
+		Processing:  processing,
+		DeadLetters: deadLetters,
+	}, nil
+}
+
+func (hds *HostDataService) ListHostDataDeadLetters() ([]model.HostDataQueueItem, error) {
+	return hds.Database.ListHostDataDeadLetters()
+}
+
+func (hds *HostDataService) hostDataQueueMaxAttempts() int {
+	if hds.Config.DataService.HostDataQueue.MaxAttempts <= 0 {
+		return defaultHostDataQueueMaxAttempts
+	}
+
+	return hds.Config.DataService.HostDataQueue.MaxAttempts
+}
+
+func (hds *HostDataService) hostDataQueueLockTimeout() time.Duration {
+	if hds.Config.DataService.HostDataQueue.LockTimeout <= 0 {
+		return defaultHostDataQueueLockTimeout
+	}
+
+	return time.Duration(hds.Config.DataService.HostDataQueue.LockTimeout) * time.Second
+}
+
+// hostDataQueueRetryBackoff return the time to wait before the next attempt, doubled at every attempt
+func (hds *HostDataService) hostDataQueueRetryBackoff(attempts int) time.Duration {
+	backoff := defaultHostDataQueueRetryBackoff
+	if hds.Config.DataService.HostDataQueue.RetryBackoff > 0 {
+		backoff = time.Duration(hds.Config.DataService.HostDataQueue.RetryBackoff) * time.Second
+	}
+
+	maxBackoff := defaultHostDataQueueMaxRetryBackoff
+	if hds.Config.DataService.HostDataQueue.MaxRetryBackoff > 0 {
+		maxBackoff = time.Duration(hds.Config.DataService.HostDataQueue.MaxRetryBackoff) * time.Second
+	}
+
+	backoff = time.Duration(float64(backoff) * math.Pow(2, float64(attempts-1)))
+	if backoff > maxBackoff || backoff <= 0 {
+		return maxBackoff
+	}
+
+	return backoff
+}
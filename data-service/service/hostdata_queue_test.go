@@ -0,0 +1,358 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestEnqueueHostData_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	hostdata := model.HostDataBE{Hostname: "foobar"}
+
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().FindCmdbOverrideRecord("foobar").Return(nil, nil)
+	db.EXPECT().EnqueueHostData(gomock.Any()).
+		Do(func(item model.HostDataQueueItem) {
+			assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), item.ID.Timestamp())
+			assert.Equal(t, "foobar", item.Hostname)
+			assert.Equal(t, model.HostDataQueueItemStatusPending, item.Status)
+			assert.Equal(t, 0, item.Attempts)
+			assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), item.NextAttemptAt)
+			assert.Equal(t, hostdata, item.Hostdata)
+		}).Return(nil)
+
+	err := hds.EnqueueHostData(hostdata)
+	require.NoError(t, err)
+}
+
+func TestEnqueueHostData_Alias(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().ListHostAliases().
+		Return([]model.HostAlias{{Alias: "oldname", Hostname: "newname", Source: model.HostAliasSourceMerge}}, nil)
+	db.EXPECT().FindCmdbOverrideRecord("newname").Return(nil, nil)
+	db.EXPECT().EnqueueHostData(gomock.Any()).
+		Do(func(item model.HostDataQueueItem) {
+			assert.Equal(t, "newname", item.Hostname)
+			assert.Equal(t, "newname", item.Hostdata.Hostname)
+		}).Return(nil)
+
+	err := hds.EnqueueHostData(model.HostDataBE{Hostname: "OLDNAME"})
+	require.NoError(t, err)
+}
+
+func TestEnqueueHostData_CmdbOverride(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().ListHostAliases().Return(nil, nil)
+	db.EXPECT().FindCmdbOverrideRecord("foobar").
+		Return(&model.CmdbRecord{Hostname: "foobar.example.com", ErcoleHostname: "foobar", Environment: "PROD", Owner: "team-dba"}, nil)
+	db.EXPECT().EnqueueHostData(gomock.Any()).
+		Do(func(item model.HostDataQueueItem) {
+			assert.Equal(t, "Italy", item.Hostdata.Location)
+			assert.Equal(t, "PROD", item.Hostdata.Environment)
+			assert.Equal(t, []string{"team-dba"}, item.Hostdata.Tags)
+		}).Return(nil)
+
+	err := hds.EnqueueHostData(model.HostDataBE{Hostname: "foobar", Location: "Italy", Environment: "TST"})
+	require.NoError(t, err)
+}
+
+func TestProcessHostDataQueue(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{
+					MaxAttempts:     3,
+					RetryBackoff:    60,
+					MaxRetryBackoff: 3600,
+					LockTimeout:     300,
+				},
+			},
+		},
+		ServerVersion: "1.6.6",
+		Database:      db,
+		TimeNow:       utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:           logger.NewLogger("TEST"),
+	}
+
+	now := utils.P("2019-11-05T14:02:03Z")
+	lockedUntil := now.Add(5 * time.Minute)
+	receivedAt := utils.P("2019-11-05T13:00:00Z")
+
+	t.Run("Success", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  1,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).Return(nil, nil),
+			db.EXPECT().ExistsCurrentHostDataNotOlderThan("foobar", receivedAt).Return(false, nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", receivedAt).Return(&model.HostDataBE{}, nil),
+			db.EXPECT().DismissHost("foobar").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).
+				Do(func(hostdata model.HostDataBE) {
+					assert.Equal(t, item.ID, hostdata.ID)
+					assert.Equal(t, receivedAt, hostdata.CreatedAt)
+				}).Return(nil),
+			db.EXPECT().DeleteNoDataAlertByHost("foobar").Return(nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
+			db.EXPECT().ExistsDR("foobar_DR").Return(false),
+			db.EXPECT().DeleteQueuedHostData(item.ID).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Older than the current hostdata, dropped", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  2,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).Return(nil, nil),
+			db.EXPECT().ExistsCurrentHostDataNotOlderThan("foobar", receivedAt).Return(true, nil),
+			db.EXPECT().DeleteQueuedHostData(item.ID).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Already saved, completes only the DR", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  2,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+		saved := &model.HostDataBE{ID: item.ID, Hostname: "foobar", CreatedAt: receivedAt}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).Return(saved, nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
+			db.EXPECT().ExistsDR("foobar_DR").Return(true),
+			db.EXPECT().ExistsCurrentHostDataNotOlderThan("foobar_DR", receivedAt).Return(false, nil),
+			db.EXPECT().DismissHost("foobar_DR").Return(nil),
+			db.EXPECT().InsertHostData(gomock.Any()).
+				Do(func(hostdata model.HostDataBE) {
+					assert.Equal(t, "foobar_DR", hostdata.Hostname)
+					assert.True(t, hostdata.IsDR)
+				}).Return(nil),
+			db.EXPECT().DeleteQueuedHostData(item.ID).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Failed, will retry", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  2,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).Return(nil, nil),
+			db.EXPECT().ExistsCurrentHostDataNotOlderThan("foobar", receivedAt).Return(false, nil),
+			db.EXPECT().FindMostRecentHostDataOlderThan("foobar", receivedAt).Return(nil, aerrMock),
+			db.EXPECT().RescheduleQueuedHostData(item.ID, 2, now.Add(2*time.Minute), aerrMock.Error()).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Failed too many times", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  3,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).Return(nil, aerrMock),
+			db.EXPECT().MoveQueuedHostDataToDeadLetters(gomock.Any()).
+				Do(func(deadLetter model.HostDataQueueItem) {
+					assert.Equal(t, item.ID, deadLetter.ID)
+					assert.Equal(t, 3, deadLetter.Attempts)
+					assert.Equal(t, model.HostDataQueueItemStatusFailed, deadLetter.Status)
+					assert.Equal(t, aerrMock.Error(), deadLetter.LastError)
+					require.NotNil(t, deadLetter.FailedAt)
+					assert.Equal(t, now, *deadLetter.FailedAt)
+				}).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Panic, will retry", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  1,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().FindHostData(item.ID).
+				DoAndReturn(func(id interface{}) (*model.HostDataBE, error) {
+					panic("boom")
+				}),
+			db.EXPECT().RescheduleQueuedHostData(item.ID, 1, now.Add(time.Minute), "Panic while inserting hostdata: boom").Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Interrupted too many times", func(t *testing.T) {
+		item := &model.HostDataQueueItem{
+			ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
+			Hostname:  "foobar",
+			Status:    model.HostDataQueueItemStatusProcessing,
+			Attempts:  4,
+			CreatedAt: receivedAt,
+			Hostdata:  model.HostDataBE{Hostname: "foobar"},
+		}
+
+		gomock.InOrder(
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(item, nil),
+			db.EXPECT().MoveQueuedHostDataToDeadLetters(gomock.Any()).
+				Do(func(deadLetter model.HostDataQueueItem) {
+					assert.Equal(t, 4, deadLetter.Attempts)
+					assert.Equal(t, model.HostDataQueueItemStatusFailed, deadLetter.Status)
+				}).Return(nil),
+			db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, nil),
+		)
+
+		hds.ProcessHostDataQueue()
+	})
+
+	t.Run("Dequeue error", func(t *testing.T) {
+		db.EXPECT().DequeueHostData(now, lockedUntil).Return(nil, aerrMock)
+
+		hds.ProcessHostDataQueue()
+	})
+}
+
+func TestHostDataQueueRetryBackoff(t *testing.T) {
+	hds := HostDataService{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				HostDataQueue: config.HostDataQueue{
+					RetryBackoff:    30,
+					MaxRetryBackoff: 300,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 30*time.Second, hds.hostDataQueueRetryBackoff(1))
+	assert.Equal(t, 60*time.Second, hds.hostDataQueueRetryBackoff(2))
+	assert.Equal(t, 240*time.Second, hds.hostDataQueueRetryBackoff(4))
+	assert.Equal(t, 300*time.Second, hds.hostDataQueueRetryBackoff(5))
+	assert.Equal(t, 300*time.Second, hds.hostDataQueueRetryBackoff(100))
+
+	hds.Config.DataService.HostDataQueue = config.HostDataQueue{}
+	assert.Equal(t, defaultHostDataQueueRetryBackoff, hds.hostDataQueueRetryBackoff(1))
+	assert.Equal(t, defaultHostDataQueueMaxRetryBackoff, hds.hostDataQueueRetryBackoff(1000))
+}
+
+func TestGetHostDataQueueStats_Success(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	db.EXPECT().CountQueuedHostData(model.HostDataQueueItemStatusPending).Return(int64(4), nil)
+	db.EXPECT().CountQueuedHostData(model.HostDataQueueItemStatusProcessing).Return(int64(1), nil)
+	db.EXPECT().CountHostDataDeadLetters().Return(int64(2), nil)
+
+	actual, err := hds.GetHostDataQueueStats()
+	require.NoError(t, err)
+	assert.Equal(t, &dto.HostDataQueueStats{Pending: 4, Processing: 1, DeadLetters: 2}, actual)
+}
@@ -24,13 +24,21 @@ import (
 
 // InsertHostData saves the hostdata
 func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
+	now := hds.TimeNow()
+
+	hostdata.CreatedAt = now
+	hostdata.ID = primitive.NewObjectIDFromTimestamp(now)
+
+	return hds.insertHostData(hostdata)
+}
+
+// insertHostData saves the hostdata as the current one of the host, with the ID and the creation date it already has
+func (hds *HostDataService) insertHostData(hostdata model.HostDataBE) error {
 	var err error
 
 	hostdata.ServerVersion = hds.ServerVersion
 	hostdata.Archived = false
-	hostdata.CreatedAt = hds.TimeNow()
 	hostdata.ServerSchemaVersion = model.SchemaVersion
-	hostdata.ID = primitive.NewObjectIDFromTimestamp(hds.TimeNow())
 
 	previousHostdata, err := hds.Database.FindMostRecentHostDataOlderThan(hostdata.Hostname, hostdata.CreatedAt)
 	if err != nil {
@@ -62,6 +70,10 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 		hds.mySqlDatabasesChecks(previousHostdata, &hostdata)
 	}
 
+	if hostdata.Features.MariaDB != nil {
+		hds.mariaDBDatabasesChecks(previousHostdata, &hostdata)
+	}
+
 	if hostdata.Clusters != nil {
 		hds.clusterInfoChecks(hostdata.Clusters)
 	}
@@ -80,6 +92,8 @@ func (hds *HostDataService) InsertHostData(hostdata model.HostDataBE) error {
 		return err
 	}
 
+	hds.saveHostChanges(previousHostdata, hostdata)
+
 	if err := hds.Database.DeleteNoDataAlertByHost(hostdata.Hostname); err != nil {
 		hds.Log.Error(err)
 	}
@@ -90,6 +90,7 @@ func TestInsertHostData_Success(t *testing.T) {
 				}).
 				Return(nil),
 			db.EXPECT().DeleteNoDataAlertByHost(hd.Hostname).Return(nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
 			db.EXPECT().ExistsDR("rac1_x_DR").Return(false),
 		)
 
@@ -120,6 +121,7 @@ func TestInsertHostData_Success(t *testing.T) {
 				}).
 				Return(nil),
 			db.EXPECT().DeleteNoDataAlertByHost(hd.Hostname).Return(nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
 			db.EXPECT().ExistsDR("rac1_x_DR").Return(false),
 		)
 
@@ -145,7 +147,17 @@ func TestInsertHostData_Success(t *testing.T) {
 					//I assume that other fields are correct
 				}).
 				Return(nil),
+			db.EXPECT().InsertHostChanges(gomock.Any()).
+				Do(func(changes []model.HostChange) {
+					assert.NotEmpty(t, changes)
+					for _, change := range changes {
+						assert.Equal(t, hd.Hostname, change.Hostname)
+						assert.Equal(t, utils.P("2019-11-05T14:02:03Z"), change.Date)
+					}
+				}).
+				Return(nil),
 			db.EXPECT().DeleteNoDataAlertByHost(hd.Hostname).Return(nil),
+			db.EXPECT().ListDisasterRecoveryPairs().Return(nil, nil),
 			db.EXPECT().ExistsDR("rac1_x_DR").Return(false),
 		)
 
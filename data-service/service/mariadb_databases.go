@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func (hds *HostDataService) mariaDBDatabasesChecks(previousHostdata, hostdata *model.HostDataBE) {
+	if hostdata.Features.MariaDB.Instances == nil {
+		return
+	}
+
+	hds.alertMariaDBNewDatabases(previousHostdata, hostdata)
+}
+
+// alertMariaDBNewDatabases throws a NEW_DATABASE alert for every database
+// that wasn't in the same instance of the previous hostdata
+func (hds *HostDataService) alertMariaDBNewDatabases(previous, new *model.HostDataBE) {
+	previousDbs := make(map[string]map[string]bool)
+
+	if previous != nil && previous.Features.MariaDB != nil {
+		for _, instance := range previous.Features.MariaDB.Instances {
+			dbs := make(map[string]bool, len(instance.Databases))
+			for _, db := range instance.Databases {
+				dbs[db.Name] = true
+			}
+
+			previousDbs[instance.Name] = dbs
+		}
+	}
+
+	for _, instance := range new.Features.MariaDB.Instances {
+		for _, db := range instance.Databases {
+			if previousDbs[instance.Name][db.Name] {
+				continue
+			}
+
+			if err := hds.throwNewDatabaseAlert(model.TechnologyMariaDBFoundationMariaDBPrt, db.Name, new.Hostname); err != nil {
+				hds.Log.Error(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestMariaDBDatabasesChecks_NewDatabase(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T16:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	previous := model.HostDataBE{
+		Hostname: "superhost1",
+		Features: model.Features{
+			MariaDB: &model.MariaDBFeature{
+				Instances: []model.MariaDBInstance{
+					{
+						Name:      "mariadb:3306",
+						Databases: []model.MariaDBDatabase{{Name: "sales"}},
+					},
+				},
+			},
+		},
+	}
+
+	hostdata := model.HostDataBE{
+		Hostname: "superhost1",
+		Features: model.Features{
+			MariaDB: &model.MariaDBFeature{
+				Instances: []model.MariaDBInstance{
+					{
+						Name:      "mariadb:3306",
+						Databases: []model.MariaDBDatabase{{Name: "sales"}, {Name: "orders"}},
+					},
+				},
+			},
+		},
+	}
+
+	asc.EXPECT().ThrowNewAlert(&alertSimilarTo{al: model.Alert{
+		AlertAffectedTechnology: model.TechnologyMariaDBFoundationMariaDBPrt,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeNewDatabase,
+		OtherInfo: map[string]interface{}{
+			"hostname": "superhost1",
+			"dbname":   "orders",
+		},
+	}}).Return(nil).Times(1)
+
+	hds.mariaDBDatabasesChecks(&previous, &hostdata)
+}
+
+func TestMariaDBDatabasesChecks_NoPreviousHostdata(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	asc := NewMockAlertSvcClientInterface(mockCtrl)
+	hds := HostDataService{
+		AlertSvcClient: asc,
+		TimeNow:        utils.Btc(utils.P("2019-11-05T16:02:03Z")),
+		Log:            logger.NewLogger("TEST"),
+	}
+
+	hostdata := model.HostDataBE{
+		Hostname: "superhost1",
+		Features: model.Features{
+			MariaDB: &model.MariaDBFeature{
+				Instances: []model.MariaDBInstance{
+					{
+						Name:      "mariadb:3306",
+						Databases: []model.MariaDBDatabase{{Name: "sales"}},
+					},
+				},
+			},
+		},
+	}
+
+	asc.EXPECT().ThrowNewAlert(&alertSimilarTo{al: model.Alert{
+		AlertAffectedTechnology: model.TechnologyMariaDBFoundationMariaDBPrt,
+		AlertCategory:           model.AlertCategoryLicense,
+		AlertCode:               model.AlertCodeNewDatabase,
+		OtherInfo: map[string]interface{}{
+			"hostname": "superhost1",
+			"dbname":   "sales",
+		},
+	}}).Return(aerrMock).Times(1)
+
+	hds.mariaDBDatabasesChecks(nil, &hostdata)
+}
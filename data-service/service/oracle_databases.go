@@ -33,7 +33,7 @@ func (hds *HostDataService) oracleDatabasesChecks(previousHostdata, hostdata *mo
 		return
 	}
 
-	hds.checkSecondaryDbs(hostdata)
+	hds.applyCoreFactorPolicyToDbs(hostdata)
 
 	licenseTypes, err := hds.getOracleDatabaseLicenseTypes(hostdata.Environment)
 	if err != nil {
@@ -78,14 +78,35 @@ func (hds *HostDataService) ackOldUnlistedRunningDatabasesAlerts(hostname, dbnam
 	return hds.ApiSvcClient.AckAlerts(f)
 }
 
-func (hds *HostDataService) checkSecondaryDbs(hostdata *model.HostDataBE) {
+// applyCoreFactorPolicyToDbs counts with the active core factor policy the licenses of the secondary databases of hostdata,
+// that take the licenses of their primary one, and of the primary databases of a cloud host.
+// The licenses of the primary databases of the other hosts are the ones counted by the agent
+func (hds *HostDataService) applyCoreFactorPolicyToDbs(hostdata *model.HostDataBE) {
+	policy, err := hds.coreFactorPolicy()
+	if err != nil {
+		hds.Log.Errorf("Can't get core factor policy, the default one is used: %s", err)
+
+		policy = model.DefaultCoreFactorPolicy()
+	}
+
+	hostCoreFactor := hostdata.CoreFactor(policy)
+
 	for i := range hostdata.Features.Oracle.Database.Databases {
-		db := &hostdata.Features.Oracle.Database.Databases[i]
+		hds.countOracleDatabaseLicenses(hostdata, hostCoreFactor, &hostdata.Features.Oracle.Database.Databases[i])
+	}
+}
 
-		if utils.Contains(model.OracleDatabaseStatusMounted, db.Status) &&
-			db.Role != model.OracleDatabaseRolePrimary {
-			hds.addLicensesToSecondaryDb(hostdata.Info, hostdata.CoreFactor(), db)
-		}
+// countOracleDatabaseLicenses counts the licenses of db of hostdata with the host core factor:
+// a secondary db takes the licenses of its primary one, a primary db of a cloud host is counted with the core factor
+func (hds *HostDataService) countOracleDatabaseLicenses(hostdata *model.HostDataBE, hostCoreFactor float64, db *model.OracleDatabase) {
+	if utils.Contains(model.OracleDatabaseStatusMounted, db.Status) &&
+		db.Role != model.OracleDatabaseRolePrimary {
+		hds.addLicensesToSecondaryDb(hostdata.Info, hostCoreFactor, db)
+		return
+	}
+
+	if hostdata.Cloud.Membership != model.CloudMembershipUnknown && hostdata.Cloud.Membership != model.CloudMembershipNone {
+		applyCoreFactorPolicy(hostdata.Info, hostCoreFactor, db)
 	}
 }
 
@@ -258,9 +279,17 @@ func (hds *HostDataService) getOracleDatabaseLicenseTypes(environment string) ([
 		return nil, utils.NewError(err, "Can't retrieve licenseTypes")
 	}
 
-	sort.Slice(licenseTypes, licenseTypesSorter(hds.Config.DataService, environment, licenseTypes))
+	return hds.sortLicenseTypes(environment, licenseTypes), nil
+}
+
+// sortLicenseTypes return a copy of licenseTypes sorted by the priority of their metric in the environment
+func (hds *HostDataService) sortLicenseTypes(environment string, licenseTypes []model.OracleDatabaseLicenseType) []model.OracleDatabaseLicenseType {
+	sorted := make([]model.OracleDatabaseLicenseType, len(licenseTypes))
+	copy(sorted, licenseTypes)
+
+	sort.Slice(sorted, licenseTypesSorter(hds.Config.DataService, environment, sorted))
 
-	return licenseTypes, nil
+	return sorted
 }
 
 func licenseTypesSorter(config config.DataService, environment string, licenseTypes []model.OracleDatabaseLicenseType,
@@ -328,7 +357,7 @@ func (hds *HostDataService) checkNewLicenses(previous, new *model.HostDataBE, li
 				Licenses: []model.OracleDatabaseLicense{},
 			}
 
-			if err := hds.throwNewDatabaseAlert(newDb.Name, new.Hostname); err != nil {
+			if err := hds.throwNewDatabaseAlert(model.TechnologyOracleDatabasePtr, newDb.Name, new.Hostname); err != nil {
 				hds.Log.Error(err)
 			}
 		}
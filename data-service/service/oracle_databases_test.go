@@ -126,6 +126,116 @@ func TestAddLicensesToSecondaryDbs(t *testing.T) {
 	hds.addLicensesToSecondaryDb(hdPrimary.Info, 2, &primaryDB)
 }
 
+func TestApplyCoreFactorPolicyToDbs(t *testing.T) {
+	newHostdata := func(cloud string) model.HostDataBE {
+		return model.HostDataBE{
+			Hostname: "foobar",
+			Info: model.Host{
+				CPUCores:                      4,
+				CPUThreads:                    8,
+				ThreadsPerCore:                2,
+				HardwareAbstractionTechnology: model.HardwareAbstractionTechnologyVmware,
+			},
+			Cloud: model.Cloud{Membership: cloud},
+			Features: model.Features{
+				Oracle: &model.OracleFeature{
+					Database: &model.OracleDatabaseFeature{
+						Databases: []model.OracleDatabase{
+							{
+								Name:    "ENT",
+								Status:  "OPEN",
+								Role:    model.OracleDatabaseRolePrimary,
+								Version: "19.0.0.0.0 Enterprise Edition",
+								Licenses: []model.OracleDatabaseLicense{
+									{Name: "Oracle ENT", Count: 2},
+									{Name: "Partitioning", Count: 0},
+								},
+							},
+							{
+								Name:    "STD",
+								Status:  "OPEN",
+								Role:    model.OracleDatabaseRolePrimary,
+								Version: "19.0.0.0.0 Standard Edition",
+								Licenses: []model.OracleDatabaseLicense{
+									{Name: "Oracle STD", Count: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	policy := model.DefaultCoreFactorPolicy()
+	policy.CloudRules[0].VCPUsPerLicenseHT = 8
+
+	t.Run("Cloud host", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		db := NewMockMongoDatabaseInterface(mockCtrl)
+		hds := HostDataService{
+			Database: db,
+			Log:      logger.NewLogger("TEST"),
+		}
+
+		hostdata := newHostdata(model.CloudMembershipAws)
+
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(&policy, nil)
+
+		hds.applyCoreFactorPolicyToDbs(&hostdata)
+
+		dbs := hostdata.Features.Oracle.Database.Databases
+		assert.Equal(t, []model.OracleDatabaseLicense{
+			{Name: "Oracle ENT", Count: 1},
+			{Name: "Partitioning", Count: 0},
+		}, dbs[0].Licenses)
+		assert.Equal(t, []model.OracleDatabaseLicense{{Name: "Oracle STD", Count: 1}}, dbs[1].Licenses)
+	})
+
+	t.Run("Cloud host without policy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		db := NewMockMongoDatabaseInterface(mockCtrl)
+		hds := HostDataService{
+			Database: db,
+			Log:      logger.NewLogger("TEST"),
+		}
+
+		hostdata := newHostdata(model.CloudMembershipAws)
+
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(nil, aerrMock)
+
+		hds.applyCoreFactorPolicyToDbs(&hostdata)
+
+		assert.Equal(t, []model.OracleDatabaseLicense{
+			{Name: "Oracle ENT", Count: 4},
+			{Name: "Partitioning", Count: 0},
+		}, hostdata.Features.Oracle.Database.Databases[0].Licenses)
+	})
+
+	t.Run("On-premise host keeps the licenses counted by the agent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		db := NewMockMongoDatabaseInterface(mockCtrl)
+		hds := HostDataService{
+			Database: db,
+			Log:      logger.NewLogger("TEST"),
+		}
+
+		hostdata := newHostdata(model.CloudMembershipNone)
+
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(&policy, nil)
+
+		hds.applyCoreFactorPolicyToDbs(&hostdata)
+
+		assert.Equal(t, []model.OracleDatabaseLicense{
+			{Name: "Oracle ENT", Count: 2},
+			{Name: "Partitioning", Count: 0},
+		}, hostdata.Features.Oracle.Database.Databases[0].Licenses)
+	})
+}
+
 var hostData1 model.HostDataBE = model.HostDataBE{
 	ID:        utils.Str2oid("5dc3f534db7e81a98b726a52"),
 	Hostname:  "superhost1",
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// ReprocessOracleLicenses assigns again the license types to the licenses of the current hosts in the scope of req,
+// using the current license types and metric priorities, and counts them again with the active core factor policy
+// as the ingestion of the hostdata does. If req.DryRun is true the hosts aren't updated
+func (hds *HostDataService) ReprocessOracleLicenses(req dto.OracleLicenseReprocessingRequest) (*dto.OracleLicenseReprocessingReport, error) {
+	hosts, err := hds.Database.FindActiveHostdataOracleLicenses(req.Location, req.Hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	licenseTypes, err := hds.Database.GetOracleDatabaseLicenseTypes()
+	if err != nil {
+		return nil, utils.NewError(err, "Can't retrieve licenseTypes")
+	}
+
+	policy, err := hds.coreFactorPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.OracleLicenseReprocessingReport{
+		DryRun:  req.DryRun,
+		Changes: make([]dto.OracleLicenseReprocessingChange, 0),
+	}
+
+	for _, host := range hosts {
+		if host.Features.Oracle == nil || host.Features.Oracle.Database == nil {
+			continue
+		}
+
+		report.HostsProcessed++
+		hostChanged := false
+
+		sortedLicenseTypes := hds.sortLicenseTypes(host.Environment, licenseTypes)
+		hostCoreFactor := host.CoreFactor(policy)
+
+		for _, db := range host.Features.Oracle.Database.Databases {
+			reprocessed := db
+			reprocessed.Licenses = make([]model.OracleDatabaseLicense, len(db.Licenses))
+			copy(reprocessed.Licenses, db.Licenses)
+
+			hds.countOracleDatabaseLicenses(&host, hostCoreFactor, &reprocessed)
+
+			for i := range reprocessed.Licenses {
+				reprocessed.Licenses[i].LicenseTypeID = ""
+			}
+
+			setLicenseTypeIDs(sortedLicenseTypes, &reprocessed)
+
+			changes := diffLicenseAssignments(host.Hostname, db.Name, db.Licenses, reprocessed.Licenses)
+			if len(changes) == 0 {
+				continue
+			}
+
+			hostChanged = true
+			report.Changes = append(report.Changes, changes...)
+
+			if req.DryRun {
+				continue
+			}
+
+			if err := hds.Database.UpdateHostdataOracleDatabaseLicenses(host.ID, db.Name, reprocessed.Licenses); err != nil {
+				return nil, err
+			}
+		}
+
+		if hostChanged {
+			report.HostsChanged++
+		}
+	}
+
+	if !req.DryRun {
+		hds.Log.Infof("Oracle licenses reprocessed: %d hosts processed, %d hosts changed, %d licenses changed",
+			report.HostsProcessed, report.HostsChanged, len(report.Changes))
+	}
+
+	return report, nil
+}
+
+func diffLicenseAssignments(hostname, dbName string, previous, current []model.OracleDatabaseLicense) []dto.OracleLicenseReprocessingChange {
+	changes := make([]dto.OracleLicenseReprocessingChange, 0)
+
+	currentByName := make(map[string]model.OracleDatabaseLicense, len(current))
+	for _, license := range current {
+		currentByName[license.Name] = license
+	}
+
+	for _, old := range previous {
+		reprocessed, found := currentByName[old.Name]
+		if found && reprocessed.LicenseTypeID == old.LicenseTypeID && reprocessed.Count == old.Count {
+			continue
+		}
+
+		changes = append(changes, dto.OracleLicenseReprocessingChange{
+			Hostname:         hostname,
+			DatabaseName:     dbName,
+			LicenseName:      old.Name,
+			OldLicenseTypeID: old.LicenseTypeID,
+			NewLicenseTypeID: reprocessed.LicenseTypeID,
+			OldCount:         old.Count,
+			NewCount:         reprocessed.Count,
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/data-service/dto"
+	"github.com/ercole-io/ercole/v2/logger"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestReprocessOracleLicenses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	db := NewMockMongoDatabaseInterface(mockCtrl)
+	hds := HostDataService{
+		Config: config.Configuration{
+			DataService: config.DataService{
+				LicenseTypeMetricsDefault: []string{"Named User Plus Perpetual", "Processor Perpetual"},
+				LicenseTypeMetricsByEnvironment: map[string][]string{
+					"PRD": {"Processor Perpetual", "Named User Plus Perpetual"},
+				},
+			},
+		},
+		Database: db,
+		TimeNow:  utils.Btc(utils.P("2019-11-05T14:02:03Z")),
+		Log:      logger.NewLogger("TEST"),
+	}
+
+	licenseTypes := []model.OracleDatabaseLicenseType{
+		{ID: "A90611", ItemDescription: "Oracle Database Enterprise Edition", Metric: "Processor Perpetual", Aliases: []string{"Oracle ENT"}},
+		{ID: "A90650", ItemDescription: "Oracle Database Enterprise Edition", Metric: "Named User Plus Perpetual", Aliases: []string{"Oracle ENT"}},
+	}
+
+	hostID := primitive.NewObjectID()
+	hosts := []model.HostDataBE{
+		{
+			ID:          hostID,
+			Hostname:    "foobar",
+			Environment: "PRD",
+			Info: model.Host{
+				CPUCores:                      4,
+				HardwareAbstractionTechnology: model.HardwareAbstractionTechnologyPhysical,
+			},
+			Features: model.Features{
+				Oracle: &model.OracleFeature{
+					Database: &model.OracleDatabaseFeature{
+						Databases: []model.OracleDatabase{
+							{
+								Name:    "ERCOLE",
+								Version: "19.0.0.0.0 Enterprise Edition",
+								Licenses: []model.OracleDatabaseLicense{
+									{LicenseTypeID: "A90650", Name: "Oracle ENT", Count: 2, Ignored: true},
+								},
+							},
+							{
+								Name:    "UNCHANGED",
+								Version: "19.0.0.0.0 Enterprise Edition",
+								Licenses: []model.OracleDatabaseLicense{
+									{LicenseTypeID: "A90611", Name: "Oracle ENT", Count: 2},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedChanges := []dto.OracleLicenseReprocessingChange{
+		{
+			Hostname:         "foobar",
+			DatabaseName:     "ERCOLE",
+			LicenseName:      "Oracle ENT",
+			OldLicenseTypeID: "A90650",
+			NewLicenseTypeID: "A90611",
+			OldCount:         2,
+			NewCount:         2,
+		},
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		db.EXPECT().FindActiveHostdataOracleLicenses("Italy", nil).Return(hosts, nil)
+		db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(nil, nil)
+
+		actual, err := hds.ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{Location: "Italy", DryRun: true})
+		require.NoError(t, err)
+
+		expected := &dto.OracleLicenseReprocessingReport{
+			DryRun:         true,
+			HostsProcessed: 1,
+			HostsChanged:   1,
+			Changes:        expectedChanges,
+		}
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		db.EXPECT().FindActiveHostdataOracleLicenses("", []string{"foobar"}).Return(hosts, nil)
+		db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(nil, nil)
+		db.EXPECT().UpdateHostdataOracleDatabaseLicenses(hostID, "ERCOLE", []model.OracleDatabaseLicense{
+			{LicenseTypeID: "A90611", Name: "Oracle ENT", Count: 2, Ignored: true},
+		}).Return(nil)
+
+		actual, err := hds.ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{Hostnames: []string{"foobar"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedChanges, actual.Changes)
+		assert.Equal(t, "A90650", hosts[0].Features.Oracle.Database.Databases[0].Licenses[0].LicenseTypeID)
+	})
+
+	t.Run("Active core factor policy on a cloud host", func(t *testing.T) {
+		policy := model.DefaultCoreFactorPolicy()
+		policy.DefaultCoreFactor = 1
+		policy.CloudRules = nil
+
+		cloudHosts := []model.HostDataBE{hosts[0]}
+		cloudHosts[0].Cloud.Membership = model.CloudMembershipOci
+
+		db.EXPECT().FindActiveHostdataOracleLicenses("", nil).Return(cloudHosts, nil)
+		db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(&policy, nil)
+
+		actual, err := hds.ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{DryRun: true})
+		require.NoError(t, err)
+
+		expected := []dto.OracleLicenseReprocessingChange{
+			{
+				Hostname:         "foobar",
+				DatabaseName:     "ERCOLE",
+				LicenseName:      "Oracle ENT",
+				OldLicenseTypeID: "A90650",
+				NewLicenseTypeID: "A90611",
+				OldCount:         2,
+				NewCount:         4,
+			},
+			{
+				Hostname:         "foobar",
+				DatabaseName:     "UNCHANGED",
+				LicenseName:      "Oracle ENT",
+				OldLicenseTypeID: "A90611",
+				NewLicenseTypeID: "A90611",
+				OldCount:         2,
+				NewCount:         4,
+			},
+		}
+		assert.Equal(t, expected, actual.Changes)
+	})
+
+	t.Run("Secondary database takes the licenses of its primary", func(t *testing.T) {
+		apisc := NewMockApiSvcClientInterface(mockCtrl)
+		hds.ApiSvcClient = apisc
+		defer func() { hds.ApiSvcClient = nil }()
+
+		standbyHosts := []model.HostDataBE{
+			{
+				ID:          hostID,
+				Hostname:    "foobar",
+				Environment: "PRD",
+				Info:        hosts[0].Info,
+				Features: model.Features{
+					Oracle: &model.OracleFeature{
+						Database: &model.OracleDatabaseFeature{
+							Databases: []model.OracleDatabase{
+								{
+									Name:    "ERCOLE",
+									DbID:    42,
+									Version: "19.0.0.0.0 Enterprise Edition",
+									Status:  "MOUNTED",
+									Role:    model.OracleDatabaseRolePhysicalStandby,
+									Licenses: []model.OracleDatabaseLicense{
+										{LicenseTypeID: "A90611", Name: "Oracle ENT", Count: 0},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		db.EXPECT().FindActiveHostdataOracleLicenses("", nil).Return(standbyHosts, nil)
+		db.EXPECT().GetOracleDatabaseLicenseTypes().Return(licenseTypes, nil)
+		db.EXPECT().FindActiveCoreFactorPolicy().Return(nil, nil)
+		apisc.EXPECT().GetOracleDatabases().Return([]model.OracleDatabase{
+			{
+				Name:    "ERCOLE",
+				DbID:    42,
+				Version: "19.0.0.0.0 Enterprise Edition",
+				Status:  "OPEN",
+				Role:    model.OracleDatabaseRolePrimary,
+				Licenses: []model.OracleDatabaseLicense{
+					{LicenseTypeID: "A90611", Name: "Oracle ENT", Count: 4},
+				},
+			},
+		}, nil)
+
+		actual, err := hds.ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{DryRun: true})
+		require.NoError(t, err)
+
+		expected := []dto.OracleLicenseReprocessingChange{
+			{
+				Hostname:         "foobar",
+				DatabaseName:     "ERCOLE",
+				LicenseName:      "Oracle ENT",
+				OldLicenseTypeID: "A90611",
+				NewLicenseTypeID: "A90611",
+				OldCount:         0,
+				NewCount:         2,
+			},
+		}
+		assert.Equal(t, expected, actual.Changes)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		db.EXPECT().FindActiveHostdataOracleLicenses("", nil).Return(nil, aerrMock)
+
+		_, err := hds.ReprocessOracleLicenses(dto.OracleLicenseReprocessingRequest{})
+		require.Equal(t, aerrMock, err)
+	})
+}
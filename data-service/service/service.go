@@ -17,8 +17,11 @@
 package service
 
 import (
+	"io"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/ercole-io/ercole/v2/config"
 	"github.com/ercole-io/ercole/v2/logger"
 	"github.com/ercole-io/ercole/v2/model"
@@ -31,11 +34,38 @@ import (
 
 type HostDataServiceInterface interface {
 	InsertHostData(hostdata model.HostDataBE) error
+	// EnqueueHostData saves the hostdata in the queue, it will be processed asynchronously
+	EnqueueHostData(hostdata model.HostDataBE) error
+	// ProcessHostDataQueue inserts all the queued hostdata ready to be processed
+	ProcessHostDataQueue()
+	GetHostDataQueueStats() (*dto.HostDataQueueStats, error)
+	ListHostDataDeadLetters() ([]model.HostDataQueueItem, error)
+	// QuarantineHostData saves a rejected hostdata payload with its validation errors
+	QuarantineHostData(raw []byte) error
+	ListQuarantinedHostData(hostname, status string) ([]model.HostDataQuarantineItem, error)
+	GetQuarantinedHostData(id primitive.ObjectID) (*model.HostDataQuarantineItem, error)
+	UpdateQuarantinedHostData(id primitive.ObjectID, raw []byte) (*model.HostDataQuarantineItem, error)
+	// ReplayQuarantinedHostData submits again a quarantined hostdata.
+	// If force is true the hostdata is submitted even if it doesn't respect the schema
+	ReplayQuarantinedHostData(id primitive.ObjectID, force bool) error
+	ReplayQuarantinedHostDataBatch(ids []primitive.ObjectID, force bool) []dto.HostDataReplayResult
+	// ImportHostData validates and inserts the raw hostdata as if it was received at collectedAt
+	ImportHostData(raw []byte, collectedAt time.Time) error
 	AlertInvalidHostData(validationErr error, hostdata *model.HostDataBE)
-	CompareCmdbInfo(cmdbInfo dto.CmdbInfo) error
+	// CompareCmdbInfo reconciles the hosts of the CMDB with the current hosts and saves its snapshot
+	CompareCmdbInfo(cmdbInfo dto.CmdbInfo) (*model.CmdbSnapshot, error)
+	// ParseCmdbCsv return the records of a CMDB export, with a header naming its columns
+	ParseCmdbCsv(reader io.Reader) ([]model.CmdbRecord, error)
+	ListCmdbSnapshots() ([]model.CmdbSnapshot, error)
+	GetCmdbSnapshot(name string) (*model.CmdbSnapshot, error)
 	InsertOracleLicenseTypes(licenseTypes []model.OracleDatabaseLicenseType) error
 	SanitizeLicenseTypes(raw []byte) ([]model.OracleDatabaseLicenseType, error)
+	// ReprocessOracleLicenses assigns again the license types to the licenses of the current hosts in the scope of req,
+	// using the current license types and metric priorities. If req.DryRun is true the hosts aren't updated
+	ReprocessOracleLicenses(req dto.OracleLicenseReprocessingRequest) (*dto.OracleLicenseReprocessingReport, error)
 	SaveExadata(exadata *model.OracleExadataInstance) error
+	// AuthenticateAgent return the agent credential identified by name if key is valid
+	AuthenticateAgent(name, key string) (*model.AgentCredential, error)
 }
 
 type HostDataService struct {
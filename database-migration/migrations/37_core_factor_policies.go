@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func init() {
+	err := migrate.Register(add_default_core_factor_policy, nil)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+func add_default_core_factor_policy(db *mongo.Database) error {
+	ctx := context.TODO()
+	collection := db.Collection("core_factor_policies")
+
+	if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "active", Value: 1}},
+	}); err != nil {
+		return err
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	policy := model.DefaultCoreFactorPolicy()
+	policy.ID = primitive.NewObjectID()
+	policy.Version = 1
+	policy.CreatedAt = time.Now()
+
+	if _, err := collection.InsertOne(ctx, policy); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package migrations
+
+import (
+	"context"
+
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func init() {
+	err := migrate.Register(add_contract_changes, nil)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+// add_contract_changes saves the current contracts as their first version,
+// dated at the creation of the contract
+func add_contract_changes(db *mongo.Database) error {
+	ctx := context.TODO()
+	collection := db.Collection("contract_changes")
+	versions := db.Collection("contract_change_versions")
+
+	if err := db.CreateCollection(ctx, "contract_change_versions"); err != nil {
+		return err
+	}
+
+	if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "contractObjectID", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "date", Value: 1}},
+	}); err != nil {
+		return err
+	}
+
+	contractCollections := map[string]string{
+		model.TechnologyOracleDatabase:     "oracle_database_contracts",
+		model.TechnologyMicrosoftSQLServer: "ms_sqlserver_database_contracts",
+		model.TechnologyOracleMySQL:        "mysql_contracts",
+	}
+
+	for technology, contractCollection := range contractCollections {
+		cur, err := db.Collection(contractCollection).Find(ctx, bson.M{})
+		if err != nil {
+			return err
+		}
+
+		contracts := make([]map[string]interface{}, 0)
+		if err := cur.All(ctx, &contracts); err != nil {
+			return err
+		}
+
+		for _, contract := range contracts {
+			id, ok := contract["_id"].(primitive.ObjectID)
+			if !ok {
+				continue
+			}
+
+			count, err := collection.CountDocuments(ctx, bson.M{"contractObjectID": id})
+			if err != nil {
+				return err
+			}
+
+			if count > 0 {
+				continue
+			}
+
+			change := model.ContractChange{
+				ID:               primitive.NewObjectID(),
+				Technology:       technology,
+				ContractObjectID: id,
+				Version:          1,
+				Operation:        model.ContractChangeOperationAdd,
+				User:             model.ContractChangeSystemUser,
+				Date:             id.Timestamp(),
+				After:            contract,
+			}
+
+			if _, err := collection.InsertOne(ctx, change); err != nil {
+				return err
+			}
+
+			if _, err := versions.UpdateOne(ctx,
+				bson.M{"_id": id},
+				bson.M{"$max": bson.M{"version": change.Version}},
+				options.Update().SetUpsert(true)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package migrations
+
+import (
+	"context"
+
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func init() {
+	err := migrate.Register(add_postgresql_license_types, nil)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+// add_postgresql_license_types creates the collections of the commercial PostgreSQL
+// subscriptions and loads the most common EDB and Crunchy Data license types
+func add_postgresql_license_types(db *mongo.Database) error {
+	ctx := context.TODO()
+
+	for _, collectionName := range []string{"postgresql_license_types", "postgresql_contracts"} {
+		collectionNames, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: collectionName}})
+		if err != nil {
+			return err
+		}
+
+		if len(collectionNames) == 0 {
+			if err := db.CreateCollection(ctx, collectionName); err != nil {
+				return err
+			}
+		}
+	}
+
+	licenseTypes := []model.PostgreSQLLicenseType{
+		{
+			ID:              "EDB-STD-CORE",
+			ItemDescription: "EDB Postgres Standard",
+			Vendor:          model.PostgreSQLVendorEDB,
+			Metric:          model.PostgreSQLLicenseTypeMetricCore,
+		},
+		{
+			ID:              "EDB-ENT-CORE",
+			ItemDescription: "EDB Postgres Enterprise",
+			Vendor:          model.PostgreSQLVendorEDB,
+			Metric:          model.PostgreSQLLicenseTypeMetricCore,
+		},
+		{
+			ID:              "CRUNCHY-CORE",
+			ItemDescription: "Crunchy Certified PostgreSQL",
+			Vendor:          model.PostgreSQLVendorCrunchy,
+			Metric:          model.PostgreSQLLicenseTypeMetricCore,
+		},
+	}
+
+	collection := db.Collection("postgresql_license_types")
+
+	for _, licenseType := range licenseTypes {
+		count, err := collection.CountDocuments(ctx, bson.M{"_id": licenseType.ID})
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			continue
+		}
+
+		if _, err := collection.InsertOne(ctx, licenseType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
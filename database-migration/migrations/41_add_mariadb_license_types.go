@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package migrations
+
+import (
+	"context"
+
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+func init() {
+	err := migrate.Register(add_mariadb_license_types, nil)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+// add_mariadb_license_types creates the collections of the MariaDB
+// subscriptions and loads the Enterprise Server license type
+func add_mariadb_license_types(db *mongo.Database) error {
+	ctx := context.TODO()
+
+	for _, collectionName := range []string{"mariadb_license_types", "mariadb_contracts"} {
+		collectionNames, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: collectionName}})
+		if err != nil {
+			return err
+		}
+
+		if len(collectionNames) == 0 {
+			if err := db.CreateCollection(ctx, collectionName); err != nil {
+				return err
+			}
+		}
+	}
+
+	licenseType := model.MariaDBLicenseType{
+		ID:              "MARIADB-ES-SERVER",
+		ItemDescription: "MariaDB Enterprise Server",
+		Metric:          model.MariaDBLicenseTypeMetricServer,
+		Edition:         model.MariaDBEditionEnterprise,
+	}
+
+	collection := db.Collection("mariadb_license_types")
+
+	count, err := collection.CountDocuments(ctx, bson.M{"_id": licenseType.ID})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	_, err = collection.InsertOne(ctx, licenseType)
+
+	return err
+}
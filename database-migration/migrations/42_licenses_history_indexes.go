@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func init() {
+	err := migrate.Register(create_index_licenses_history, nil)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+// create_index_licenses_history indexes the licenses compliance histories by location and license type,
+// that are the keys of their daily upserts and of the filters of the charts
+func create_index_licenses_history(db *mongo.Database) error {
+	for _, collection := range []string{"database_licenses_history_by_location", "database_licenses_history_by_contract"} {
+		if _, err := db.Collection(collection).Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "location", Value: 1},
+				{Key: "licenseTypeID", Value: 1},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AgentCredential is the identity used by an agent to authenticate to the data-service
+type AgentCredential struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id"`
+	Name             string             `json:"name" bson:"name"`
+	HostnamePatterns []string           `json:"hostnamePatterns" bson:"hostnamePatterns"`
+	KeyHash          string             `json:"-" bson:"keyHash"`
+	CreatedAt        time.Time          `json:"createdAt" bson:"createdAt"`
+	RotatedAt        *time.Time         `json:"rotatedAt,omitempty" bson:"rotatedAt,omitempty"`
+	RevokedAt        *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+	LastSeenAt       *time.Time         `json:"lastSeenAt,omitempty" bson:"lastSeenAt,omitempty"`
+}
+
+// IsRevoked return true if the credential has been revoked
+func (c AgentCredential) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
+
+// CanSendHostname return true if the hostname matches one of the patterns bound to the credential
+func (c AgentCredential) CanSendHostname(hostname string) bool {
+	hostname = strings.ToLower(hostname)
+
+	for _, pattern := range c.HostnamePatterns {
+		if matched, err := path.Match(strings.ToLower(pattern), hostname); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentCredential_CanSendHostname(t *testing.T) {
+	cred := AgentCredential{HostnamePatterns: []string{"web-??.example.com", "DB01"}}
+
+	assert.True(t, cred.CanSendHostname("web-01.example.com"))
+	assert.True(t, cred.CanSendHostname("WEB-02.example.com"))
+	assert.True(t, cred.CanSendHostname("db01"))
+	assert.False(t, cred.CanSendHostname("web-001.example.com"))
+	assert.False(t, cred.CanSendHostname("db02"))
+	assert.False(t, AgentCredential{}.CanSendHostname("db01"))
+}
+
+func TestAgentCredential_IsRevoked(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, AgentCredential{}.IsRevoked())
+	assert.True(t, AgentCredential{RevokedAt: &now}.IsRevoked())
+}
@@ -52,6 +52,7 @@ const (
 	AlertCodeMissingPrimaryDatabase  string = "MISSING_PRIMARY_DATABASE"
 	AlertCodeMissingHostInErcole     string = "MISSING_HOST_IN_ERCOLE"
 	AlertCodeMissingHostInCmdb       string = "MISSING_HOST_IN_CMDB"
+	AlertCodeCmdbAttributeMismatch   string = "CMDB_ATTRIBUTE_MISMATCH"
 	AlertCodeAgentError              string = "AGENT_ERROR"
 	AlertCodeDismissHost             string = "DISMISSED_HOST"
 
@@ -61,18 +62,22 @@ const (
 
 	// LICENSE
 
-	AlertCodeNewDatabase       string = "NEW_DATABASE"
-	AlertCodeNewLicense        string = "NEW_LICENSE"
-	AlertCodeNewOption         string = "NEW_OPTION"
-	AlertCodeIncreasedCPUCores string = "INCREASED_CPU_CORES"
-	AlertCodeMissingDatabase   string = "MISSING_DATABASE"
+	AlertCodeNewDatabase         string = "NEW_DATABASE"
+	AlertCodeNewLicense          string = "NEW_LICENSE"
+	AlertCodeNewOption           string = "NEW_OPTION"
+	AlertCodeIncreasedCPUCores   string = "INCREASED_CPU_CORES"
+	AlertCodeMissingDatabase     string = "MISSING_DATABASE"
+	AlertCodeContractExpiring    string = "CONTRACT_EXPIRING"
+	AlertCodeContractExpired     string = "CONTRACT_EXPIRED"
+	AlertCodeLicenseNonCompliant string = "LICENSE_NON_COMPLIANT"
 )
 
 func getAlertCodes() []string {
 	return []string{
-		AlertCodeNewServer, AlertCodeUnlistedRunningDatabase, AlertCodeMissingPrimaryDatabase, AlertCodeMissingHostInErcole, AlertCodeMissingHostInCmdb, AlertCodeAgentError,
+		AlertCodeNewServer, AlertCodeUnlistedRunningDatabase, AlertCodeMissingPrimaryDatabase, AlertCodeMissingHostInErcole, AlertCodeMissingHostInCmdb, AlertCodeCmdbAttributeMismatch, AlertCodeAgentError,
 		AlertCodeNoData,
 		AlertCodeNewDatabase, AlertCodeNewLicense, AlertCodeNewOption, AlertCodeIncreasedCPUCores, AlertCodeMissingDatabase, AlertCodeDismissHost,
+		AlertCodeContractExpiring, AlertCodeContractExpired, AlertCodeLicenseNonCompliant,
 	}
 }
 
@@ -23,4 +23,7 @@ const (
 	CloudMembershipUnknown string = ""     // It's unknown if host is in a cloud
 	CloudMembershipNone    string = "None" // Host isn't in a known cloud
 	CloudMembershipAws     string = "AWS"
+	CloudMembershipAzure   string = "Azure"
+	CloudMembershipOci     string = "OCI"
+	CloudMembershipGcp     string = "GCP"
 )
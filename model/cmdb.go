@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Attributes of a CmdbRecord compared with the hostdata
+const (
+	CmdbAttributeLocation        = "location"
+	CmdbAttributeEnvironment     = "environment"
+	CmdbAttributeOwner           = "owner"
+	CmdbAttributeBusinessService = "businessService"
+	CmdbAttributeDecommissioned  = "decommissioned"
+)
+
+// CmdbRecord contains the attributes of a host in a CMDB.
+// ErcoleHostname is the hostname known by ercole matching Hostname, empty if there isn't any
+type CmdbRecord struct {
+	Hostname        string `json:"hostname" bson:"hostname"`
+	ErcoleHostname  string `json:"ercoleHostname,omitempty" bson:"ercoleHostname,omitempty"`
+	Location        string `json:"location,omitempty" bson:"location,omitempty"`
+	Environment     string `json:"environment,omitempty" bson:"environment,omitempty"`
+	Owner           string `json:"owner,omitempty" bson:"owner,omitempty"`
+	BusinessService string `json:"businessService,omitempty" bson:"businessService,omitempty"`
+	Decommissioned  bool   `json:"decommissioned" bson:"decommissioned"`
+}
+
+// CmdbMismatch is an attribute of a host with a different value in ercole and in the CMDB
+type CmdbMismatch struct {
+	Hostname    string `json:"hostname" bson:"hostname"`
+	Attribute   string `json:"attribute" bson:"attribute"`
+	CmdbValue   string `json:"cmdbValue" bson:"cmdbValue"`
+	ErcoleValue string `json:"ercoleValue" bson:"ercoleValue"`
+	Overridden  bool   `json:"overridden" bson:"overridden"`
+}
+
+// CmdbSnapshot is the last content received from a CMDB with the result of its reconciliation.
+// If Override is true the location, the environment and the tags of the hosts are taken from the CMDB
+type CmdbSnapshot struct {
+	ID                  primitive.ObjectID `json:"id" bson:"_id"`
+	Name                string             `json:"name" bson:"name"`
+	Override            bool               `json:"override" bson:"override"`
+	ReceivedAt          time.Time          `json:"receivedAt" bson:"receivedAt"`
+	Records             []CmdbRecord       `json:"records" bson:"records"`
+	UnknownHostnames    []string           `json:"unknownHostnames" bson:"unknownHostnames"`
+	MissingHostnames    []string           `json:"missingHostnames" bson:"missingHostnames"`
+	AttributeMismatches []CmdbMismatch     `json:"attributeMismatches" bson:"attributeMismatches"`
+}
+
+// Mismatches return the attributes of host with a value different from the one in the record.
+// The empty attributes of the record are ignored, the owner and the business service must be tags of host
+func (r CmdbRecord) Mismatches(host HostDataBE) []CmdbMismatch {
+	mismatches := make([]CmdbMismatch, 0)
+
+	mismatch := func(attribute, cmdbValue, ercoleValue string) {
+		mismatches = append(mismatches, CmdbMismatch{
+			Hostname:    host.Hostname,
+			Attribute:   attribute,
+			CmdbValue:   cmdbValue,
+			ErcoleValue: ercoleValue,
+		})
+	}
+
+	if r.Location != "" && !strings.EqualFold(r.Location, host.Location) {
+		mismatch(CmdbAttributeLocation, r.Location, host.Location)
+	}
+
+	if r.Environment != "" && !strings.EqualFold(r.Environment, host.Environment) {
+		mismatch(CmdbAttributeEnvironment, r.Environment, host.Environment)
+	}
+
+	if r.Owner != "" && !containsFold(host.Tags, r.Owner) {
+		mismatch(CmdbAttributeOwner, r.Owner, strings.Join(host.Tags, ","))
+	}
+
+	if r.BusinessService != "" && !containsFold(host.Tags, r.BusinessService) {
+		mismatch(CmdbAttributeBusinessService, r.BusinessService, strings.Join(host.Tags, ","))
+	}
+
+	if r.Decommissioned {
+		mismatch(CmdbAttributeDecommissioned, "true", "false")
+	}
+
+	return mismatches
+}
+
+// Apply sets the location and the environment of host to the ones of the record
+// and adds the owner and the business service to its tags. It return true if host is changed
+func (r CmdbRecord) Apply(host *HostDataBE) bool {
+	changed := false
+
+	if r.Location != "" && !strings.EqualFold(r.Location, host.Location) {
+		host.Location = r.Location
+		changed = true
+	}
+
+	if r.Environment != "" && !strings.EqualFold(r.Environment, host.Environment) {
+		host.Environment = r.Environment
+		changed = true
+	}
+
+	for _, tag := range []string{r.Owner, r.BusinessService} {
+		if tag != "" && !containsFold(host.Tags, tag) {
+			host.Tags = append(host.Tags, tag)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// IsOverridable return true if the attribute can be taken from the CMDB
+func (m CmdbMismatch) IsOverridable() bool {
+	return m.Attribute != CmdbAttributeDecommissioned
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
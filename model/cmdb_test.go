@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdbRecordMismatches(t *testing.T) {
+	host := HostDataBE{
+		Hostname:    "db01",
+		Location:    "Italy",
+		Environment: "PROD",
+		Tags:        []string{"team-dba"},
+	}
+
+	record := CmdbRecord{
+		Hostname:        "db01.example.com",
+		Location:        "italy",
+		Environment:     "TEST",
+		Owner:           "TEAM-DBA",
+		BusinessService: "billing",
+		Decommissioned:  true,
+	}
+
+	expected := []CmdbMismatch{
+		{Hostname: "db01", Attribute: CmdbAttributeEnvironment, CmdbValue: "TEST", ErcoleValue: "PROD"},
+		{Hostname: "db01", Attribute: CmdbAttributeBusinessService, CmdbValue: "billing", ErcoleValue: "team-dba"},
+		{Hostname: "db01", Attribute: CmdbAttributeDecommissioned, CmdbValue: "true", ErcoleValue: "false"},
+	}
+
+	assert.Equal(t, expected, record.Mismatches(host))
+	assert.Empty(t, CmdbRecord{Hostname: "db01"}.Mismatches(host))
+}
+
+func TestCmdbRecordApply(t *testing.T) {
+	host := HostDataBE{
+		Hostname:    "db01",
+		Location:    "Italy",
+		Environment: "PROD",
+		Tags:        []string{"team-dba"},
+	}
+
+	record := CmdbRecord{
+		Hostname:        "db01",
+		Location:        "Germany",
+		Owner:           "team-dba",
+		BusinessService: "billing",
+	}
+
+	assert.True(t, record.Apply(&host))
+	assert.Equal(t, "Germany", host.Location)
+	assert.Equal(t, "PROD", host.Environment)
+	assert.Equal(t, []string{"team-dba", "billing"}, host.Tags)
+
+	assert.False(t, record.Apply(&host))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ContractChange contains a version of a contract of any technology,
+// with the values of the contract before and after the change
+type ContractChange struct {
+	ID               primitive.ObjectID     `json:"id" bson:"_id"`
+	Technology       string                 `json:"technology" bson:"technology"`
+	ContractObjectID primitive.ObjectID     `json:"contractObjectID" bson:"contractObjectID"`
+	Version          int                    `json:"version" bson:"version"`
+	Operation        string                 `json:"operation" bson:"operation"`
+	User             string                 `json:"user" bson:"user"`
+	Date             time.Time              `json:"date" bson:"date"`
+	Before           map[string]interface{} `json:"before" bson:"before"`
+	After            map[string]interface{} `json:"after" bson:"after"`
+}
+
+// Contract change operations
+const (
+	ContractChangeOperationAdd        = "ADD"
+	ContractChangeOperationUpdate     = "UPDATE"
+	ContractChangeOperationDelete     = "DELETE"
+	ContractChangeOperationAddHost    = "ADD_HOST"
+	ContractChangeOperationDeleteHost = "DELETE_HOST"
+	ContractChangeOperationMergeHost  = "MERGE_HOST"
+)
+
+// ContractChangeSystemUser is the user of the changes made by ercole itself, like the ones made dismissing a host
+const ContractChangeSystemUser = "ercole"
+
+// DecodeAfter decodes the values of the contract after the change into contract
+func (change ContractChange) DecodeAfter(contract interface{}) error {
+	raw, err := bson.Marshal(change.After)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(raw, contract)
+}
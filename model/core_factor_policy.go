@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CoreFactorPolicy is a version of the rules used to convert the cores, or the vCPUs, of a host
+// in the Oracle processor licenses it needs
+type CoreFactorPolicy struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id"`
+	Version           int                `json:"version" bson:"version"`
+	Description       string             `json:"description" bson:"description"`
+	Active            bool               `json:"active" bson:"active"`
+	DefaultCoreFactor float64            `json:"defaultCoreFactor" bson:"defaultCoreFactor"`
+	CoreFactorRules   []CoreFactorRule   `json:"coreFactorRules" bson:"coreFactorRules"`
+	CloudRules        []CloudVCPURule    `json:"cloudRules" bson:"cloudRules"`
+	CreatedAt         time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// CoreFactorRule assigns CoreFactor to the hosts with a CPU model and an operating system
+// matching the case insensitive regular expressions. An empty expression matches every host
+type CoreFactorRule struct {
+	CPUModel   string  `json:"cpuModel" bson:"cpuModel"`
+	OS         string  `json:"os" bson:"os"`
+	CoreFactor float64 `json:"coreFactor" bson:"coreFactor"`
+
+	cpuModelRegexp *regexp.Regexp
+	osRegexp       *regexp.Regexp
+}
+
+// CloudVCPURule counts the processor licenses of the hosts of an authorized cloud from their vCPUs:
+// every VCPUsPerLicenseHT vCPUs need a license if hyperthreading is enabled, every VCPUsPerLicense otherwise
+type CloudVCPURule struct {
+	Membership        string  `json:"membership" bson:"membership"`
+	VCPUsPerLicense   float64 `json:"vcpusPerLicense" bson:"vcpusPerLicense"`
+	VCPUsPerLicenseHT float64 `json:"vcpusPerLicenseHT" bson:"vcpusPerLicenseHT"`
+}
+
+// DefaultCoreFactorPolicy return the policy used when none has been saved,
+// with the main entries of the Oracle processor core factor table and the rules of the authorized clouds
+func DefaultCoreFactorPolicy() CoreFactorPolicy {
+	policy := CoreFactorPolicy{
+		Version:           0,
+		Description:       "Default policy",
+		Active:            true,
+		DefaultCoreFactor: 0.5,
+		CoreFactorRules: []CoreFactorRule{
+			{CPUModel: `ultrasparc[ -]?t1\b`, CoreFactor: 0.25},
+			{CPUModel: `sparc64[ -]?vi\b`, CoreFactor: 0.75},
+			{CPUModel: `itanium.*9[3-9]\d\d`, CoreFactor: 1},
+			{CPUModel: `power`, CoreFactor: 1},
+			{OS: `aix`, CoreFactor: 1},
+		},
+		CloudRules: []CloudVCPURule{
+			{Membership: CloudMembershipAws, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+			{Membership: CloudMembershipAzure, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+			{Membership: CloudMembershipGcp, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+			{Membership: CloudMembershipOci, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+		},
+	}
+
+	if err := policy.Compile(); err != nil {
+		panic(err)
+	}
+
+	return policy
+}
+
+// Validate return an error if a core factor or a vCPU ratio isn't positive, an expression is invalid
+// or a cloud has more than a rule. The expressions of the rules are compiled
+func (p CoreFactorPolicy) Validate() error {
+	if p.DefaultCoreFactor <= 0 {
+		return errors.New("defaultCoreFactor must be positive")
+	}
+
+	for i, rule := range p.CoreFactorRules {
+		if rule.CoreFactor <= 0 {
+			return fmt.Errorf("coreFactorRules[%d]: coreFactor must be positive", i)
+		}
+	}
+
+	if err := p.Compile(); err != nil {
+		return err
+	}
+
+	memberships := make(map[string]bool, len(p.CloudRules))
+
+	for i, rule := range p.CloudRules {
+		if rule.Membership == CloudMembershipUnknown || rule.Membership == CloudMembershipNone {
+			return fmt.Errorf("cloudRules[%d]: membership %q isn't a cloud", i, rule.Membership)
+		}
+
+		if memberships[rule.Membership] {
+			return fmt.Errorf("cloudRules[%d]: duplicated membership %q", i, rule.Membership)
+		}
+
+		memberships[rule.Membership] = true
+
+		if rule.VCPUsPerLicense <= 0 || rule.VCPUsPerLicenseHT <= 0 {
+			return fmt.Errorf("cloudRules[%d]: vcpusPerLicense and vcpusPerLicenseHT must be positive", i)
+		}
+	}
+
+	return nil
+}
+
+// Compile compiles the expressions of the rules, so that they aren't compiled again on every match.
+// It must be called on the policies loaded from the database
+func (p *CoreFactorPolicy) Compile() error {
+	for i := range p.CoreFactorRules {
+		rule := &p.CoreFactorRules[i]
+
+		cpuModel, err := compileFold(rule.CPUModel)
+		if err != nil {
+			return fmt.Errorf("coreFactorRules[%d]: invalid cpuModel regex: %w", i, err)
+		}
+
+		os, err := compileFold(rule.OS)
+		if err != nil {
+			return fmt.Errorf("coreFactorRules[%d]: invalid os regex: %w", i, err)
+		}
+
+		rule.cpuModelRegexp, rule.osRegexp = cpuModel, os
+	}
+
+	return nil
+}
+
+// HostCoreFactor return the factor to multiply the cores of host by to get its processor licenses.
+// The hosts of a cloud with a rule are licensed by vCPU, the others by the first rule matching them
+// or by the default core factor
+func (p CoreFactorPolicy) HostCoreFactor(host Host, cloud Cloud) float64 {
+	for _, rule := range p.CloudRules {
+		if rule.Membership == cloud.Membership {
+			return rule.coreFactor(host)
+		}
+	}
+
+	for _, rule := range p.CoreFactorRules {
+		if rule.Match(host) {
+			return rule.CoreFactor
+		}
+	}
+
+	return p.DefaultCoreFactor
+}
+
+// Match return true if the CPU model and the operating system of host match the rule.
+// The expressions of a rule not compiled by its policy are compiled on every call
+func (r CoreFactorRule) Match(host Host) bool {
+	cpuModel, os := r.cpuModelRegexp, r.osRegexp
+
+	if cpuModel == nil || os == nil {
+		var err error
+
+		if cpuModel, err = compileFold(r.CPUModel); err != nil {
+			return false
+		}
+
+		if os, err = compileFold(r.OS); err != nil {
+			return false
+		}
+	}
+
+	return cpuModel.MatchString(host.CPUModel) && os.MatchString(host.OS)
+}
+
+func (r CloudVCPURule) coreFactor(host Host) float64 {
+	vcpus := host.CPUThreads
+	if vcpus == 0 {
+		vcpus = host.CPUCores
+	}
+
+	if host.CPUCores == 0 || vcpus == 0 {
+		return 1
+	}
+
+	vcpusPerLicense := r.VCPUsPerLicense
+	if host.ThreadsPerCore > 1 {
+		vcpusPerLicense = r.VCPUsPerLicenseHT
+	}
+
+	return float64(vcpus) / vcpusPerLicense / float64(host.CPUCores)
+}
+
+func compileFold(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + expr)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoreFactorPolicy_Validate(t *testing.T) {
+	assert.NoError(t, DefaultCoreFactorPolicy().Validate())
+
+	assert.Error(t, CoreFactorPolicy{}.Validate())
+	assert.Error(t, CoreFactorPolicy{
+		DefaultCoreFactor: 0.5,
+		CoreFactorRules:   []CoreFactorRule{{CPUModel: "power(", CoreFactor: 1}},
+	}.Validate())
+	assert.Error(t, CoreFactorPolicy{
+		DefaultCoreFactor: 0.5,
+		CloudRules:        []CloudVCPURule{{Membership: CloudMembershipNone, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2}},
+	}.Validate())
+	assert.Error(t, CoreFactorPolicy{
+		DefaultCoreFactor: 0.5,
+		CloudRules: []CloudVCPURule{
+			{Membership: CloudMembershipAws, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+			{Membership: CloudMembershipAws, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 2},
+		},
+	}.Validate())
+}
+
+func TestCoreFactorPolicy_HostCoreFactor(t *testing.T) {
+	policy := DefaultCoreFactorPolicy()
+
+	xeon := Host{CPUModel: "Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz", OS: "Red Hat Enterprise Linux", CPUCores: 4, CPUThreads: 8, ThreadsPerCore: 2}
+	assert.Equal(t, 0.5, policy.HostCoreFactor(xeon, Cloud{Membership: CloudMembershipNone}))
+	assert.Equal(t, 1.0, policy.HostCoreFactor(xeon, Cloud{Membership: CloudMembershipAws}))
+	assert.Equal(t, 1.0, policy.HostCoreFactor(xeon, Cloud{Membership: CloudMembershipOci}))
+
+	noHT := Host{CPUModel: "Intel(R) Xeon(R) Platinum 8272CL", CPUCores: 4, CPUThreads: 4, ThreadsPerCore: 1}
+	assert.Equal(t, 1.0, policy.HostCoreFactor(noHT, Cloud{Membership: CloudMembershipAzure}))
+
+	power := Host{CPUModel: "PowerPC_POWER9", OS: "AIX", CPUCores: 8}
+	assert.Equal(t, 1.0, policy.HostCoreFactor(power, Cloud{}))
+
+	t1 := Host{CPUModel: "UltraSPARC-T1", OS: "SunOS", CPUCores: 8}
+	assert.Equal(t, 0.25, policy.HostCoreFactor(t1, Cloud{}))
+
+	policy.CloudRules = []CloudVCPURule{{Membership: CloudMembershipGcp, VCPUsPerLicense: 1, VCPUsPerLicenseHT: 1}}
+	assert.Equal(t, 2.0, policy.HostCoreFactor(xeon, Cloud{Membership: CloudMembershipGcp}))
+	assert.Equal(t, 0.5, policy.HostCoreFactor(xeon, Cloud{Membership: CloudMembershipAws}))
+}
+
+func TestCoreFactorPolicy_Compile(t *testing.T) {
+	policy := CoreFactorPolicy{
+		DefaultCoreFactor: 0.5,
+		CoreFactorRules:   []CoreFactorRule{{CPUModel: "power", CoreFactor: 1}},
+	}
+
+	assert.NoError(t, policy.Compile())
+	assert.NotNil(t, policy.CoreFactorRules[0].cpuModelRegexp)
+	assert.NotNil(t, policy.CoreFactorRules[0].osRegexp)
+	assert.True(t, policy.CoreFactorRules[0].Match(Host{CPUModel: "PowerPC_POWER9"}))
+
+	policy.CoreFactorRules = []CoreFactorRule{{OS: "aix(", CoreFactor: 1}}
+	assert.Error(t, policy.Compile())
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultDRSuffix is appended to the hostname to name its DR counterpart when no pair matches it
+const DefaultDRSuffix = "_DR"
+
+// DisasterRecoveryPair maps a primary host to its disaster recovery counterpart.
+// If Regex is true Primary is a regular expression matching the whole hostname
+// and DR can reference its capturing groups, like $1 or ${name}
+type DisasterRecoveryPair struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	Primary   string             `json:"primary" bson:"primary"`
+	DR        string             `json:"dr" bson:"dr"`
+	Regex     bool               `json:"regex" bson:"regex"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+
+	primaryRegexp *regexp.Regexp
+}
+
+// Validate return an error if the pair is incomplete or its regular expression is invalid
+func (p DisasterRecoveryPair) Validate() error {
+	if p.Primary == "" || p.DR == "" {
+		return errors.New("primary and dr are required")
+	}
+
+	if !p.Regex {
+		if p.Primary == p.DR {
+			return errors.New("primary and dr must be different")
+		}
+
+		return nil
+	}
+
+	if err := p.Compile(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Compile compiles the regular expression of the pair, so that it isn't compiled again on every match.
+// It must be called on the pairs loaded from the database
+func (p *DisasterRecoveryPair) Compile() error {
+	if !p.Regex {
+		return nil
+	}
+
+	re, err := regexp.Compile("^(?:" + p.Primary + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid primary regex: %w", err)
+	}
+
+	p.primaryRegexp = re
+
+	return nil
+}
+
+// Match return the DR hostname of hostname and true if the pair matches hostname.
+// The expression of a pair not compiled is compiled on every call
+func (p DisasterRecoveryPair) Match(hostname string) (string, bool) {
+	if !p.Regex {
+		return p.DR, p.Primary == hostname
+	}
+
+	re := p.primaryRegexp
+	if re == nil {
+		if err := p.Compile(); err != nil {
+			return "", false
+		}
+
+		re = p.primaryRegexp
+	}
+
+	submatches := re.FindStringSubmatchIndex(hostname)
+	if submatches == nil {
+		return "", false
+	}
+
+	return string(re.ExpandString(nil, p.DR, hostname, submatches)), true
+}
+
+// DRHostname return the name of the DR counterpart of hostname.
+// Pairs matching hostname by name take precedence over the ones matching it by regex,
+// if no pair matches it the name is hostname followed by DefaultDRSuffix
+func DRHostname(pairs []DisasterRecoveryPair, hostname string) string {
+	for _, p := range pairs {
+		if dr, ok := p.Match(hostname); ok && !p.Regex {
+			return dr
+		}
+	}
+
+	for _, p := range pairs {
+		if dr, ok := p.Match(hostname); ok && p.Regex {
+			return dr
+		}
+	}
+
+	return hostname + DefaultDRSuffix
+}
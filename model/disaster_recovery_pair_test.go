@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisasterRecoveryPair_Validate(t *testing.T) {
+	assert.NoError(t, DisasterRecoveryPair{Primary: "db01", DR: "drdb01"}.Validate())
+	assert.NoError(t, DisasterRecoveryPair{Primary: `db(\d+)\.mi`, DR: "db$1.rm", Regex: true}.Validate())
+
+	assert.Error(t, DisasterRecoveryPair{Primary: "db01"}.Validate())
+	assert.Error(t, DisasterRecoveryPair{Primary: "db01", DR: "db01"}.Validate())
+	assert.Error(t, DisasterRecoveryPair{Primary: "db(", DR: "dr", Regex: true}.Validate())
+}
+
+func TestDRHostname(t *testing.T) {
+	pairs := []DisasterRecoveryPair{
+		{Primary: `db(\d+)\.mi\.example\.com`, DR: "db$1.rm.example.com", Regex: true},
+		{Primary: "db01.mi.example.com", DR: "standby01.example.com"},
+		{Primary: `(?P<name>.+)-prd`, DR: "${name}-dr", Regex: true},
+	}
+
+	assert.Equal(t, "standby01.example.com", DRHostname(pairs, "db01.mi.example.com"))
+	assert.Equal(t, "db02.rm.example.com", DRHostname(pairs, "db02.mi.example.com"))
+	assert.Equal(t, "erp-dr", DRHostname(pairs, "erp-prd"))
+	assert.Equal(t, "db02.mi.example.com.old_DR", DRHostname(pairs, "db02.mi.example.com.old"))
+	assert.Equal(t, "foobar_DR", DRHostname(nil, "foobar"))
+}
+
+func TestDisasterRecoveryPair_Compile(t *testing.T) {
+	pair := DisasterRecoveryPair{Primary: `db(\d+)\.mi`, DR: "db$1.rm", Regex: true}
+	assert.NoError(t, pair.Compile())
+	assert.NotNil(t, pair.primaryRegexp)
+
+	dr, ok := pair.Match("db07.mi")
+	assert.True(t, ok)
+	assert.Equal(t, "db07.rm", dr)
+
+	_, ok = pair.Match("db07.mi.old")
+	assert.False(t, ok)
+
+	invalid := DisasterRecoveryPair{Primary: "db(", DR: "dr", Regex: true}
+	assert.Error(t, invalid.Compile())
+}
@@ -22,4 +22,5 @@ type Features struct {
 	MySQL      *MySQLFeature      `json:"mysql,omitempty" bson:"mysql,omitempty"`
 	PostgreSQL *PostgreSQLFeature `json:"postgresql,omitempty" bson:"postgresql,omitempty"`
 	MongoDB    *MongoDBFeature    `json:"mongodb,omitempty" bson:"mongodb,omitempty"`
+	MariaDB    *MariaDBFeature    `json:"mariadb,omitempty" bson:"mariadb,omitempty"`
 }
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Sources of an HostAlias
+const (
+	HostAliasSourceManual = "manual"
+	HostAliasSourceMerge  = "merge"
+)
+
+// HostAlias maps another name of a host, like the one used by the CMDB, the cloud provider
+// or the name the host had before being renamed, to the hostname known by ercole
+type HostAlias struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	Alias     string             `json:"alias" bson:"alias"`
+	Hostname  string             `json:"hostname" bson:"hostname"`
+	Source    string             `json:"source" bson:"source"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
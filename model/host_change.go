@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HostChange is a difference found between two consecutive hostdata of the same host
+type HostChange struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	HostDataID primitive.ObjectID `json:"hostDataID" bson:"hostDataID"`
+	Hostname   string             `json:"hostname" bson:"hostname"`
+	Location   string             `json:"location" bson:"location"`
+	Date       time.Time          `json:"date" bson:"date"`
+	Kind       string             `json:"kind" bson:"kind"`
+	Technology string             `json:"technology,omitempty" bson:"technology,omitempty"`
+	Database   string             `json:"database,omitempty" bson:"database,omitempty"`
+	Object     string             `json:"object,omitempty" bson:"object,omitempty"`
+	OldValue   string             `json:"oldValue,omitempty" bson:"oldValue,omitempty"`
+	NewValue   string             `json:"newValue,omitempty" bson:"newValue,omitempty"`
+}
+
+// HostChange kinds
+const (
+	HostChangeKindDatabaseAdded            string = "DATABASE_ADDED"
+	HostChangeKindDatabaseRemoved          string = "DATABASE_REMOVED"
+	HostChangeKindDatabaseVersionChanged   string = "DATABASE_VERSION_CHANGED"
+	HostChangeKindPatchApplied             string = "PATCH_APPLIED"
+	HostChangeKindPatchRemoved             string = "PATCH_REMOVED"
+	HostChangeKindTablespaceAdded          string = "TABLESPACE_ADDED"
+	HostChangeKindSchemaAdded              string = "SCHEMA_ADDED"
+	HostChangeKindMemoryChanged            string = "MEMORY_CHANGED"
+	HostChangeKindCPUChanged               string = "CPU_CHANGED"
+	HostChangeKindOSChanged                string = "OS_CHANGED"
+	HostChangeKindClusterMembershipChanged string = "CLUSTER_MEMBERSHIP_CHANGED"
+)
@@ -74,10 +74,7 @@ func (v *HostDataBE) GetClusterCores(hostdatasPerHostname map[string]*HostDataBE
 	return sumClusterCores, nil
 }
 
-func (v *HostDataBE) CoreFactor() float64 {
-	if v.Cloud.Membership == CloudMembershipAws {
-		return 1
-	}
-
-	return 0.5
+// CoreFactor return the core factor of the host according to policy
+func (v *HostDataBE) CoreFactor(policy CoreFactorPolicy) float64 {
+	return policy.HostCoreFactor(v.Info, v.Cloud)
 }
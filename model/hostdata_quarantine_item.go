@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HostDataQuarantineItem holds an hostdata rejected because it isn't valid, so that it can be fixed and replayed
+type HostDataQuarantineItem struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id"`
+	Hostname         string             `json:"hostname" bson:"hostname"`
+	Status           string             `json:"status" bson:"status"`
+	ValidationErrors []string           `json:"validationErrors" bson:"validationErrors"`
+	ReceivedAt       time.Time          `json:"receivedAt" bson:"receivedAt"`
+	UpdatedAt        *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	ReplayedAt       *time.Time         `json:"replayedAt,omitempty" bson:"replayedAt,omitempty"`
+	Payload          string             `json:"payload,omitempty" bson:"payload,omitempty"`
+}
+
+// HostDataQuarantineItem status
+const (
+	HostDataQuarantineItemStatusQuarantined string = "QUARANTINED"
+	HostDataQuarantineItemStatusReplaying   string = "REPLAYING"
+	HostDataQuarantineItemStatusReplayed    string = "REPLAYED"
+)
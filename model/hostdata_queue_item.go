@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// HostDataQueueItem holds an hostdata received from an agent and waiting to be processed.
+// CreatedAt is the date the hostdata was received and becomes the creation date of the saved hostdata
+type HostDataQueueItem struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Hostname      string             `json:"hostname" bson:"hostname"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	NextAttemptAt time.Time          `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	LockedUntil   time.Time          `json:"lockedUntil" bson:"lockedUntil"`
+	FailedAt      *time.Time         `json:"failedAt,omitempty" bson:"failedAt,omitempty"`
+	Hostdata      HostDataBE         `json:"hostdata" bson:"hostdata"`
+}
+
+// HostDataQueueItem status
+const (
+	HostDataQueueItemStatusPending    string = "PENDING"
+	HostDataQueueItemStatusProcessing string = "PROCESSING"
+	HostDataQueueItemStatusFailed     string = "FAILED"
+)
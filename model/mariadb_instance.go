@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// MariaDBInstance holds informations about a single MariaDB server instance
+type MariaDBInstance struct {
+	Name               string  `json:"name" bson:"name"`
+	Version            string  `json:"version" bson:"version"`
+	Edition            string  `json:"edition" bson:"edition"`
+	Platform           string  `json:"platform" bson:"platform"`
+	Architecture       string  `json:"architecture" bson:"architecture"`
+	Engine             string  `json:"engine" bson:"engine"`
+	CharsetServer      string  `json:"charsetServer" bson:"charsetServer"`
+	CharsetSystem      string  `json:"charsetSystem" bson:"charsetSystem"`
+	ThreadsConcurrency int     `json:"threadsConcurrency" bson:"threadsConcurrency"`
+	BufferPoolSize     float64 `json:"bufferPoolSize" bson:"bufferPoolSize"` // in MB
+	ReadOnly           bool    `json:"readOnly" bson:"readOnly"`
+	LogBin             bool    `json:"logBin" bson:"logBin"`
+	HighAvailability   bool    `json:"highAvailability" bson:"highAvailability"`
+	GaleraClusterName  string  `json:"galeraClusterName" bson:"galeraClusterName"`
+
+	Databases []MariaDBDatabase `json:"databases" bson:"databases"`
+}
+
+const (
+	MariaDBEditionCommunity  = "COMMUNITY"
+	MariaDBEditionEnterprise = "ENTERPRISE"
+)
+
+// MariaDBDatabase holds informations about a database of a MariaDB instance
+type MariaDBDatabase struct {
+	Name      string  `json:"name" bson:"name"`
+	Charset   string  `json:"charset" bson:"charset"`
+	Collation string  `json:"collation" bson:"collation"`
+	Encrypted bool    `json:"encrypted" bson:"encrypted"`
+	Allocated float64 `json:"allocated" bson:"allocated"` // in MB
+}
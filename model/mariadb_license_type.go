@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// MariaDBLicenseType holds informations about a single MariaDB subscription
+type MariaDBLicenseType struct {
+	ID              string  `json:"id" bson:"_id"`
+	ItemDescription string  `json:"itemDescription" bson:"itemDescription"`
+	Metric          string  `json:"metric" bson:"metric"`
+	Edition         string  `json:"edition" bson:"edition"`
+	Cost            float64 `json:"cost" bson:"cost"`
+}
+
+// MariaDBLicenseTypeMetricServer is the metric of the subscriptions sold per server
+const MariaDBLicenseTypeMetricServer = "Server"
@@ -18,9 +18,15 @@ package model
 type MongoDBInstance struct {
 	Name             string                 `json:"name" bson:"name"`
 	Version          string                 `json:"version" bson:"version"`
+	Edition          string                 `json:"edition,omitempty" bson:"edition,omitempty"`
 	Dbs              int                    `json:"dbs" bson:"dbs"`
 	ReplicaSet       HelloResult            `json:"replicaSet" bson:"replicaSet"`
 	ShardList        ShardStatus            `json:"shardList" bson:"shardList"`
 	StatusConnection ServerStatusConnection `json:"statusConnection" bson:"statusConnection"`
 	Stats            []DBStats              `json:"dbStats" bson:"dbStats"`
 }
+
+const (
+	MongoDBEditionCommunity  = "COMMUNITY"
+	MongoDBEditionEnterprise = "ENTERPRISE"
+)
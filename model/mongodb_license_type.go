@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "math"
+
+// MongoDBLicenseType holds informations about a single MongoDB Enterprise subscription
+type MongoDBLicenseType struct {
+	ID              string  `json:"id" bson:"_id"`
+	ItemDescription string  `json:"itemDescription" bson:"itemDescription"`
+	Metric          string  `json:"metric" bson:"metric"`
+	RAMTier         float64 `json:"ramTier" bson:"ramTier"`
+	Cost            float64 `json:"cost" bson:"cost"`
+}
+
+// MongoDBLicenseTypeMetricServer is the metric of the subscriptions sold per server
+const MongoDBLicenseTypeMetricServer = "Server"
+
+// MongoDBDefaultRAMTier is the RAM in GB covered by a single server subscription
+const MongoDBDefaultRAMTier float64 = 256
+
+// ServersByMemory return the number of server subscriptions needed by a host with memory GB of RAM.
+// Every host needs at least a subscription, bigger hosts need one more for every RAM tier
+func (lt MongoDBLicenseType) ServersByMemory(memory float64) float64 {
+	tier := lt.RAMTier
+	if tier <= 0 {
+		tier = MongoDBDefaultRAMTier
+	}
+
+	return math.Max(1, math.Ceil(memory/tier))
+}
@@ -17,7 +17,6 @@ package model
 
 import (
 	"math"
-	"regexp"
 	"strings"
 
 	"github.com/ercole-io/ercole/v2/utils"
@@ -137,13 +136,7 @@ func (od OracleDatabase) CoreFactor(host Host, hostCoreFactor float64) (float64,
 
 	if host.HardwareAbstractionTechnology == HardwareAbstractionTechnologyPhysical {
 		if dbEdition == OracleDatabaseEditionExtreme || dbEdition == OracleDatabaseEditionEnterprise {
-			re := regexp.MustCompile(`(?i)aix`)
-
-			if re.MatchString(host.OS) {
-				return 1, nil
-			}
-
-			return 0.5, nil
+			return hostCoreFactor, nil
 		} else if dbEdition == OracleDatabaseEditionStandard {
 			return float64(host.CPUSockets), nil
 		}
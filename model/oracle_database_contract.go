@@ -51,16 +51,19 @@ func (contract OracleDatabaseContract) Check() error {
 	return nil
 }
 
+// ContractCSVDateFormat is the format of the dates in the contracts csv
+const ContractCSVDateFormat = "02/01/2006"
+
 type dateTime struct {
 	time.Time
 }
 
 func (d *dateTime) MarshalCSV() (string, error) {
-	return d.Time.Format("02/01/2006"), nil
+	return d.Time.Format(ContractCSVDateFormat), nil
 }
 
 func (d *dateTime) UnmarshalCSV(csv string) (err error) {
-	d.Time, err = time.Parse("02/01/2006", csv)
+	d.Time, err = time.Parse(ContractCSVDateFormat, csv)
 	return err
 }
 
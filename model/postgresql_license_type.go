@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "strings"
+
+// PostgreSQLLicenseType holds informations about a single commercial PostgreSQL subscription
+type PostgreSQLLicenseType struct {
+	ID              string  `json:"id" bson:"_id"`
+	ItemDescription string  `json:"itemDescription" bson:"itemDescription"`
+	Vendor          string  `json:"vendor" bson:"vendor"`
+	Metric          string  `json:"metric" bson:"metric"`
+	Cost            float64 `json:"cost" bson:"cost"`
+}
+
+// PostgreSQL subscription vendors
+const (
+	PostgreSQLVendorEDB     string = "EDB"
+	PostgreSQLVendorCrunchy string = "Crunchy Data"
+)
+
+// PostgreSQLVendorOfVersion return the vendor of the subscription needed by an instance with the version reported by version(),
+// or an empty string for the community PostgreSQL that doesn't need a subscription
+func PostgreSQLVendorOfVersion(version string) string {
+	version = strings.ToLower(version)
+
+	switch {
+	case strings.Contains(version, "enterprisedb") || strings.Contains(version, "edb"):
+		return PostgreSQLVendorEDB
+	case strings.Contains(version, "crunchy"):
+		return PostgreSQLVendorCrunchy
+	default:
+		return ""
+	}
+}
+
+// PostgreSQLLicenseTypeMetricCore is the metric of the subscriptions sold per core
+const PostgreSQLLicenseTypeMetricCore = "Core"
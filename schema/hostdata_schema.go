@@ -21,14 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/ercole-io/ercole/v2/utils"
 )
 
-//go:embed hostdata.json
-var hostdataSchema string
+//go:embed hostdata_v1.json
+var hostdataSchemaV1 string
 
 //go:embed oracle.json
 var oracleSchema string
@@ -45,22 +46,17 @@ var mysqlSchema string
 //go:embed mongodb.json
 var mongodbSchema string
 
-var schema *gojsonschema.Schema
+//go:embed mariadb.json
+var mariadbSchema string
 
-func ValidateHostdata(raw []byte) error {
-	if schema == nil {
-		if err := loadSchema(); err != nil {
-			return err
-		}
-	}
-
-	documentLoader := gojsonschema.NewBytesLoader(raw)
-	result, err := schema.Validate(documentLoader)
+var (
+	schemas   = make(map[int]*gojsonschema.Schema)
+	schemasMu sync.Mutex
+)
 
-	syntaxErr := &json.SyntaxError{}
-	if errors.As(err, &syntaxErr) {
-		return fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
-	} else if err != nil {
+func ValidateHostdata(raw []byte) error {
+	result, err := validateHostdata(raw)
+	if err != nil {
 		return err
 	}
 
@@ -82,25 +78,151 @@ func ValidateHostdata(raw []byte) error {
 	return nil
 }
 
-func loadSchema() error {
+// ValidateHostdataRequiredFields validates only that raw has the required fields, and the required fields of the
+// required objects, of the schema of its schemaVersion. It's the minimum needed to save raw as a host
+func ValidateHostdataRequiredFields(raw []byte) error {
+	version, err := hostdataVersion(raw)
+	if err != nil {
+		return err
+	}
+
+	v, err := getHostdataSchemaVersion(version)
+	if err != nil {
+		return err
+	}
+
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Required []string `json:"required"`
+		} `json:"properties"`
+	}
+
+	if err := json.Unmarshal([]byte(v.schema), &schema); err != nil {
+		return utils.NewError(err, fmt.Sprintf("Wrong hostdata schema v%d", version))
+	}
+
+	var hostdata map[string]interface{}
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	}
+
+	missing := make([]string, 0)
+
+	for _, field := range schema.Required {
+		value, ok := hostdata[field]
+		if !ok || value == nil || value == "" {
+			missing = append(missing, field)
+			continue
+		}
+
+		required := schema.Properties[field].Required
+		if len(required) == 0 {
+			continue
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			missing = append(missing, field)
+			continue
+		}
+
+		for _, nested := range required {
+			if object[nested] == nil {
+				missing = append(missing, field+"."+nested)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing required fields: %s", utils.ErrInvalidHostdata, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// HostdataValidationErrors return the violations of the hostdata schema, it's empty if raw is a valid hostdata
+func HostdataValidationErrors(raw []byte) ([]string, error) {
+	result, err := validateHostdata(raw)
+	if errors.Is(err, utils.ErrInvalidHostdata) {
+		return []string{err.Error()}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	errs := make([]string, 0, len(result.Errors()))
+	for _, err := range result.Errors() {
+		errs = append(errs, err.String())
+	}
+
+	return errs, nil
+}
+
+// validateHostdata validates raw against the schema of its schemaVersion.
+// If the schemaVersion is missing or isn't an integer, raw is validated against the current schema
+func validateHostdata(raw []byte) (*gojsonschema.Result, error) {
+	version, err := hostdataVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := getSchema(version)
+	if err != nil {
+		return nil, err
+	}
+
+	documentLoader := gojsonschema.NewBytesLoader(raw)
+	result, err := schema.Validate(documentLoader)
+
+	syntaxErr := &json.SyntaxError{}
+	if errors.As(err, &syntaxErr) {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func getSchema(version int) (*gojsonschema.Schema, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if schema, ok := schemas[version]; ok {
+		return schema, nil
+	}
+
+	schema, err := loadSchema(version)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas[version] = schema
+
+	return schema, nil
+}
+
+func loadSchema(version int) (*gojsonschema.Schema, error) {
+	v, err := getHostdataSchemaVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
 	sl := gojsonschema.NewSchemaLoader()
 
-	schemas := []string{oracleSchema, postgresqlSchema, microsoftSchema, mysqlSchema, mongodbSchema}
-	for i := range schemas {
-		jl := gojsonschema.NewStringLoader(schemas[i])
+	for i := range v.refs {
+		jl := gojsonschema.NewStringLoader(v.refs[i])
 		if err := sl.AddSchemas(jl); err != nil {
-			return utils.NewError(err, "Wrong hostdata schema: [%s]", schemas[i])
+			return nil, utils.NewError(err, "Wrong hostdata schema: [%s]", v.refs[i])
 		}
 	}
 
-	hostdata := gojsonschema.NewStringLoader(hostdataSchema)
+	hostdata := gojsonschema.NewStringLoader(v.schema)
 
-	var err error
-
-	schema, err = sl.Compile(hostdata)
+	schema, err := sl.Compile(hostdata)
 	if err != nil {
-		return utils.NewError(err, "Wrong hostdata schema: can't load or compile it")
+		return nil, utils.NewError(err, fmt.Sprintf("Wrong hostdata schema v%d: can't load or compile it", version))
 	}
 
-	return nil
+	return schema, nil
 }
@@ -16,12 +16,48 @@
 package schema
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/utils"
 )
 
 func TestLoadSchema(t *testing.T) {
-	err := loadSchema()
-	assert.Nil(t, err)
+	for _, version := range SupportedHostdataSchemaVersions() {
+		_, err := loadSchema(version)
+		assert.Nil(t, err)
+	}
+}
+
+func TestHostdataValidationErrors(t *testing.T) {
+	errs, err := HostdataValidationErrors([]byte(`{"hostname": 42}`))
+	require.NoError(t, err)
+	assert.NotEmpty(t, errs)
+
+	errs, err = HostdataValidationErrors([]byte(`{"hostname": x}`))
+	require.NoError(t, err)
+	assert.Len(t, errs, 1)
+}
+
+func TestValidateHostdataRequiredFields(t *testing.T) {
+	raw, err := os.ReadFile("../fixture/test_dataservice_hostdata_v1_00.json")
+	require.NoError(t, err)
+
+	assert.NoError(t, ValidateHostdataRequiredFields(raw))
+
+	err = ValidateHostdataRequiredFields([]byte(`{"hostname":"foobar"}`))
+	require.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	assert.Contains(t, err.Error(), "location")
+
+	err = ValidateHostdataRequiredFields([]byte(`{"hostname":"foobar","location":"Italy","environment":"PROD","tags":[],` +
+		`"agentVersion":"1.0","schemaVersion":1,"info":{"hostname":"foobar"},"clusterMembershipStatus":{},"features":{},"filesystems":[]}`))
+	require.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	assert.Contains(t, err.Error(), "info.cpuModel")
+	assert.NotContains(t, err.Error(), "info.hostname")
+
+	err = ValidateHostdataRequiredFields([]byte(`{"hostname": x}`))
+	assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
 }
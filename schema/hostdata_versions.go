@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+// hostdataSchemaVersion is a version of the hostdata schema accepted by the data-service
+type hostdataSchemaVersion struct {
+	version int
+	// schema is the JSON schema of the hostdata, refs are the schemas referenced by it
+	schema string
+	refs   []string
+	// upgrade converts a hostdata of this version to the shape of the next one, it's nil for the current version
+	upgrade func(hostdata map[string]interface{}) error
+}
+
+// hostdataSchemaVersions contains the supported versions of the hostdata schema, from the oldest to the current one.
+// To add a version add its hostdata_vN.json schema and set the upgrade function of the previous version.
+// Until then every hostdata of a version other than 1 is rejected with ErrUnsupportedHostdataSchemaVersion
+var hostdataSchemaVersions = []hostdataSchemaVersion{
+	{
+		version: 1,
+		schema:  hostdataSchemaV1,
+		refs:    []string{oracleSchema, postgresqlSchema, microsoftSchema, mysqlSchema, mongodbSchema, mariadbSchema},
+	},
+}
+
+// SupportedHostdataSchemaVersions return the versions of the hostdata schema accepted by the data-service
+func SupportedHostdataSchemaVersions() []int {
+	versions := make([]int, 0, len(hostdataSchemaVersions))
+	for _, v := range hostdataSchemaVersions {
+		versions = append(versions, v.version)
+	}
+
+	return versions
+}
+
+// UpgradeHostdata converts raw, a hostdata of a supported schema version, to the current schema version.
+// If the schemaVersion is missing raw is returned as is
+func UpgradeHostdata(raw []byte) ([]byte, error) {
+	version, err := hostdataVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == model.SchemaVersion {
+		return raw, nil
+	}
+
+	var hostdata map[string]interface{}
+	if err := json.Unmarshal(raw, &hostdata); err != nil {
+		return nil, fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	}
+
+	for i := range hostdataSchemaVersions {
+		v := hostdataSchemaVersions[i]
+		if v.version < version || v.upgrade == nil {
+			continue
+		}
+
+		if err := v.upgrade(hostdata); err != nil {
+			return nil, fmt.Errorf("%w: can't upgrade from schema version %d: %s", utils.ErrInvalidHostdata, v.version, err)
+		}
+
+		hostdata["schemaVersion"] = hostdataSchemaVersions[i+1].version
+	}
+
+	return json.Marshal(hostdata)
+}
+
+// hostdataVersion return the schemaVersion of raw, or the current version if it's missing.
+// It returns ErrInvalidHostdata if raw isn't a valid JSON or its schemaVersion isn't an integer,
+// and ErrUnsupportedHostdataSchemaVersion too if its schemaVersion isn't supported
+func hostdataVersion(raw []byte) (int, error) {
+	var header struct {
+		SchemaVersion json.RawMessage `json:"schemaVersion"`
+	}
+
+	err := json.Unmarshal(raw, &header)
+
+	syntaxErr := &json.SyntaxError{}
+	if errors.As(err, &syntaxErr) {
+		return 0, fmt.Errorf("%w: %s", utils.ErrInvalidHostdata, err)
+	}
+
+	if err != nil || header.SchemaVersion == nil || string(header.SchemaVersion) == "null" {
+		return model.SchemaVersion, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(header.SchemaVersion, &version); err != nil {
+		return 0, fmt.Errorf("%w: schemaVersion %s isn't an integer", utils.ErrInvalidHostdata, header.SchemaVersion)
+	}
+
+	if _, err := getHostdataSchemaVersion(version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func getHostdataSchemaVersion(version int) (*hostdataSchemaVersion, error) {
+	for i := range hostdataSchemaVersions {
+		if hostdataSchemaVersions[i].version == version {
+			return &hostdataSchemaVersions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %w %d, supported versions are %v",
+		utils.ErrInvalidHostdata, utils.ErrUnsupportedHostdataSchemaVersion, version, SupportedHostdataSchemaVersions())
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/ercole-io/ercole/v2/utils"
+)
+
+func TestHostdataSchemaVersions_Current(t *testing.T) {
+	versions := SupportedHostdataSchemaVersions()
+	require.NotEmpty(t, versions)
+
+	assert.Equal(t, model.SchemaVersion, versions[len(versions)-1])
+	assert.Nil(t, hostdataSchemaVersions[len(hostdataSchemaVersions)-1].upgrade)
+
+	for i := range hostdataSchemaVersions[:len(hostdataSchemaVersions)-1] {
+		assert.NotNil(t, hostdataSchemaVersions[i].upgrade, "missing upgrade of version %d", hostdataSchemaVersions[i].version)
+	}
+}
+
+func TestUpgradeHostdata(t *testing.T) {
+	actualVersions := hostdataSchemaVersions
+	defer func() { hostdataSchemaVersions = actualVersions }()
+
+	hostdataSchemaVersions = []hostdataSchemaVersion{
+		{
+			version: -1,
+			upgrade: func(hostdata map[string]interface{}) error {
+				hostdata["location"] = hostdata["site"]
+				delete(hostdata, "site")
+
+				return nil
+			},
+		},
+		{
+			version: 0,
+			upgrade: func(hostdata map[string]interface{}) error {
+				if _, ok := hostdata["environment"]; !ok {
+					return errors.New("missing environment")
+				}
+
+				hostdata["tags"] = []string{}
+
+				return nil
+			},
+		},
+		{
+			version: model.SchemaVersion,
+		},
+	}
+
+	t.Run("Current version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar","schemaVersion":1}`)
+
+		actual, err := UpgradeHostdata(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, actual)
+	})
+
+	t.Run("Missing version", func(t *testing.T) {
+		raw := []byte(`{"hostname":"foobar"}`)
+
+		actual, err := UpgradeHostdata(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, actual)
+	})
+
+	t.Run("Oldest version", func(t *testing.T) {
+		actual, err := UpgradeHostdata([]byte(`{"hostname":"foobar","site":"Italy","environment":"PRD","schemaVersion":-1}`))
+		require.NoError(t, err)
+
+		var hostdata map[string]interface{}
+		require.NoError(t, json.Unmarshal(actual, &hostdata))
+
+		expected := map[string]interface{}{
+			"hostname":      "foobar",
+			"location":      "Italy",
+			"environment":   "PRD",
+			"tags":          []interface{}{},
+			"schemaVersion": float64(model.SchemaVersion),
+		}
+		assert.Equal(t, expected, hostdata)
+	})
+
+	t.Run("Upgrade error", func(t *testing.T) {
+		_, err := UpgradeHostdata([]byte(`{"hostname":"foobar","schemaVersion":0}`))
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		_, err := UpgradeHostdata([]byte(`{"hostname":"foobar","schemaVersion":42}`))
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+		assert.ErrorIs(t, err, utils.ErrUnsupportedHostdataSchemaVersion)
+	})
+
+	t.Run("Version isn't an integer", func(t *testing.T) {
+		_, err := UpgradeHostdata([]byte(`{"hostname":"foobar","schemaVersion":"2"}`))
+		assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+		assert.NotErrorIs(t, err, utils.ErrUnsupportedHostdataSchemaVersion)
+	})
+}
+
+func TestValidateHostdata_UnsupportedVersion(t *testing.T) {
+	err := ValidateHostdata([]byte(`{"hostname":"foobar","schemaVersion":42}`))
+	assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+	assert.ErrorIs(t, err, utils.ErrUnsupportedHostdataSchemaVersion)
+	assert.Contains(t, err.Error(), "supported versions are [1]")
+
+	err = ValidateHostdata([]byte(`{"hostname":"foobar","schemaVersion":1.5}`))
+	assert.ErrorIs(t, err, utils.ErrInvalidHostdata)
+
+	errs, err := HostdataValidationErrors([]byte(`{"hostname":"foobar","schemaVersion":42}`))
+	require.NoError(t, err)
+	assert.Len(t, errs, 1)
+}
@@ -17,7 +17,10 @@ package crypto
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"strings"
 	"time"
@@ -39,6 +42,7 @@ const (
 	minUpperCase   = 2
 	passwordLength = 16
 	usernameLength = 16
+	apiKeyLength   = 32
 )
 
 var sample = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -81,6 +85,30 @@ func GenerateHashAndSalt(password string, salt []byte) (string, string) {
 	return encodedHash, b64Salt
 }
 
+// HashAPIKey return the hex encoded SHA-256 hash of key.
+// The keys are random and long enough that a slow and salted hash isn't needed
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// MatchAPIKey return true if the hash of key equals hash, in constant time
+func MatchAPIKey(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hash)) == 1
+}
+
+// GenerateAPIKey return a new random key suitable to authenticate a client
+func GenerateAPIKey() (string, error) {
+	b := make([]byte, apiKeyLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 func SuggestPassword() string {
 	r := mathRand.New(mathRand.NewSource(time.Now().UnixNano()))
 
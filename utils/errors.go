@@ -50,6 +50,9 @@ var ErrNotInClusterHostNotFound = errors.New("Not in cluster host not found")
 
 var ErrInvalidHostdata = errors.New("Invalid hostdata")
 
+// ErrUnsupportedHostdataSchemaVersion is returned for hostdata of a schema version the data-service doesn't know
+var ErrUnsupportedHostdataSchemaVersion = errors.New("Unsupported hostdata schema version")
+
 var ErrInvalidJSON = errors.New("invalid JSON")
 
 var ErrInvalidLocation = errors.New("Invalid location")
@@ -95,3 +98,55 @@ var ErrInvalidExadata = errors.New("invalid exadata")
 var ErrInvalidOracleContract = errors.New("invalid oracle contract")
 
 var ErrMissingDatabaseNotFound = errors.New("Missing database not found")
+
+var ErrAgentCredentialNotFound = errors.New("Agent credential not found")
+
+var ErrAgentCredentialAlreadyExists = errors.New("Agent credential already exists")
+
+var ErrInvalidAgentCredential = errors.New("Invalid agent credential")
+
+var ErrHostDataAlreadyReplayed = errors.New("Hostdata already replayed")
+
+var ErrHostDataBeingReplayed = errors.New("Hostdata is being replayed")
+
+var ErrHostDataOutdated = errors.New("A more recent hostdata of the host has already been received")
+
+var ErrDisasterRecoveryPairNotFound = errors.New("Disaster recovery pair not found")
+
+var ErrDisasterRecoveryPairAlreadyExists = errors.New("Disaster recovery pair already exists")
+
+var ErrInvalidDisasterRecoveryPair = errors.New("Invalid disaster recovery pair")
+
+var ErrHostnameNotAllowed = errors.New("Hostname not allowed for this agent credential")
+
+var ErrHostAliasNotFound = errors.New("Host alias not found")
+
+var ErrHostAliasAlreadyExists = errors.New("Host alias already exists")
+
+var ErrInvalidHostAlias = errors.New("Invalid host alias")
+
+var ErrInvalidHostMerge = errors.New("Invalid host merge")
+
+var ErrCmdbSnapshotNotFound = errors.New("CMDB snapshot not found")
+
+var ErrInvalidCmdbCsv = errors.New("Invalid CMDB csv")
+
+var ErrCoreFactorPolicyNotFound = errors.New("Core factor policy not found")
+
+var ErrInvalidCoreFactorPolicy = errors.New("Invalid core factor policy")
+
+var ErrInvalidLicenseSimulation = errors.New("Invalid license simulation")
+
+var ErrInvalidContractChange = errors.New("Invalid contract change")
+
+var ErrInvalidContractImport = errors.New("Invalid contracts import")
+
+var ErrContractImportPreviewChanged = errors.New("Contracts import changed since the preview")
+
+var ErrPostgreSQLLicenseTypeIDNotFound = errors.New("PostgreSQL LicenseTypeID not found")
+
+var ErrMongoDBLicenseTypeIDNotFound = errors.New("MongoDB LicenseTypeID not found")
+
+var ErrMariaDBLicenseTypeIDNotFound = errors.New("MariaDB LicenseTypeID not found")
+
+var ErrTransactionsNotSupported = errors.New("The database doesn't support transactions, MongoDB must be a replica set or a sharded cluster")
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exutils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+// excelEpoch is the day zero of the serial dates of Excel, that counts the nonexistent 29/02/1900
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// dateLayouts are tried in order, so days come before months when it's ambiguous
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	"02/01/2006",
+	"2/1/2006",
+	"02.01.2006",
+	"2.1.2006",
+	"02-01-2006",
+	"2-1-2006",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+// dateNumFmtIDs are the builtin number formats of dates and times
+var dateNumFmtIDs = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 28: true, 29: true, 30: true, 31: true, 32: true, 33: true, 34: true, 35: true, 36: true,
+	45: true, 46: true, 47: true, 50: true, 51: true, 52: true, 53: true, 54: true, 55: true, 56: true, 57: true, 58: true,
+}
+
+// numFmtLiterals matches the parts of a number format that aren't placeholders: strings, escaped chars and colors
+var numFmtLiterals = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+// thousandsGrouping matches an integer with a non-zero first group and the other groups of three digits
+var thousandsGrouping = regexp.MustCompile(`^[1-9][0-9]{0,2}([.,][0-9]{3})+$`)
+
+// Cell is a cell of a sheet
+type Cell struct {
+	// Value is the text of the cell, or the raw value when the cell is a number
+	Value string
+	// Numeric is true when the cell holds a number, written without any locale formatting
+	Numeric bool
+	// Date is true when the number of the cell is formatted as a date, so it's an Excel serial date
+	Date bool
+}
+
+// ReadFirstSheet return all the rows of the first sheet of the workbook
+func ReadFirstSheet(r io.Reader) ([][]Cell, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := file.GetSheetMap()
+	if len(sheets) == 0 {
+		return nil, errors.New("the workbook has no sheets")
+	}
+
+	indexes := make([]int, 0, len(sheets))
+	for i := range sheets {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	values := file.GetRows(sheets[indexes[0]])
+
+	rows := make([][]Cell, len(values))
+	for i := range values {
+		rows[i] = make([]Cell, len(values[i]))
+		for j := range values[i] {
+			rows[i][j] = Cell{Value: values[i][j]}
+		}
+	}
+
+	// GetRows applies the builtin formats to the numbers, rounding them,
+	// so they are replaced by the raw values stored in the sheet.
+	// The sheet was loaded by GetRows with the path used by excelize
+	sheet := file.Sheet["xl/worksheets/sheet"+strconv.Itoa(indexes[0])+".xml"]
+	if sheet == nil {
+		return rows, nil
+	}
+
+	for _, row := range sheet.SheetData.Row {
+		for _, c := range row.C {
+			if (c.T != "" && c.T != "n") || c.V == "" {
+				continue
+			}
+
+			i := row.R - 1
+			j := excelize.TitleToNumber(strings.Map(func(r rune) rune {
+				if r >= 'A' && r <= 'Z' {
+					return r
+				}
+
+				return -1
+			}, c.R))
+
+			if i < 0 || i >= len(rows) || j < 0 || j >= len(rows[i]) {
+				continue
+			}
+
+			rows[i][j] = Cell{Value: c.V, Numeric: true, Date: isDateStyle(file, c.S)}
+		}
+	}
+
+	return rows, nil
+}
+
+// isDateStyle return true if the number format of the style is a date or a time
+func isDateStyle(file *excelize.File, style int) bool {
+	if style == 0 || file.Styles == nil || file.Styles.CellXfs == nil || style >= len(file.Styles.CellXfs.Xf) {
+		return false
+	}
+
+	numFmtID := file.Styles.CellXfs.Xf[style].NumFmtID
+	if dateNumFmtIDs[numFmtID] {
+		return true
+	}
+
+	if file.Styles.NumFmts == nil {
+		return false
+	}
+
+	for _, numFmt := range file.Styles.NumFmts.NumFmt {
+		if numFmt.NumFmtID == numFmtID {
+			code := strings.ToLower(numFmtLiterals.ReplaceAllString(numFmt.FormatCode, ""))
+			return strings.ContainsAny(code, "ymdhs")
+		}
+	}
+
+	return false
+}
+
+// ParseDateCell parses the date of the cell, that is an Excel serial date when the cell is a date
+func ParseDateCell(cell Cell) (time.Time, error) {
+	if !cell.Date {
+		return ParseDate(cell.Value)
+	}
+
+	serial, err := strconv.ParseFloat(cell.Value, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a valid date", cell.Value)
+	}
+
+	days, fraction := math.Modf(serial)
+
+	return excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(fraction * float64(24*time.Hour))), nil
+}
+
+// ParseDate parses a date written in one of the common formats
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a valid date", value)
+}
+
+// ParseNumberCell parses the number of the cell, that has no locale formatting when the cell is a number
+func ParseNumberCell(cell Cell) (float64, error) {
+	if !cell.Numeric {
+		return ParseNumber(cell.Value)
+	}
+
+	number, err := strconv.ParseFloat(cell.Value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid number", cell.Value)
+	}
+
+	return number, nil
+}
+
+// ParseNumber parses a number using either the dot or the comma as decimal separator.
+// The other one is a thousands separator only when the grouping is unambiguous:
+// a separator that occurs once is always the decimal one, so "1.500" is 1.5 and "1.500.000" is 1500000
+func ParseNumber(value string) (float64, error) {
+	cleaned := strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(strings.TrimSpace(value))
+
+	sign := ""
+	if strings.HasPrefix(cleaned, "-") || strings.HasPrefix(cleaned, "+") {
+		sign, cleaned = cleaned[:1], cleaned[1:]
+	}
+
+	integer, decimals := cleaned, ""
+	hasDecimals := false
+
+	if last := strings.LastIndexAny(cleaned, ".,"); last >= 0 && strings.Count(cleaned, cleaned[last:last+1]) == 1 {
+		integer, decimals, hasDecimals = cleaned[:last], cleaned[last+1:], true
+	}
+
+	if strings.ContainsAny(integer, ".,") {
+		if !thousandsGrouping.MatchString(integer) || strings.Contains(integer, ".") && strings.Contains(integer, ",") {
+			return 0, fmt.Errorf("%q is not a valid number", value)
+		}
+
+		integer = strings.NewReplacer(".", "", ",", "").Replace(integer)
+	}
+
+	if hasDecimals {
+		integer += "." + decimals
+	}
+
+	number, err := strconv.ParseFloat(sign+integer, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid number", value)
+	}
+
+	return number, nil
+}
+
+// ParseBool parses a boolean written as true/false, yes/no, sì/no, y/n, x or 1/0
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "y", "si", "sì", "x", "1", "vero":
+		return true, nil
+	case "false", "no", "n", "0", "falso":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("%q is not a valid boolean", value)
+}
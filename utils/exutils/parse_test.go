@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"2024-03-01", "2024-03-01T00:00:00Z", "01/03/2024", "1/3/2024", "01.03.2024", "01-03-2024"} {
+		actual, err := ParseDate(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, actual, value)
+	}
+
+	actual, err := ParseDate("03/31/2024")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), actual)
+
+	_, err = ParseDate("yesterday")
+	assert.Error(t, err)
+
+	_, err = ParseDate("45352")
+	assert.Error(t, err)
+}
+
+func TestParseDateCell(t *testing.T) {
+	actual, err := ParseDateCell(Cell{Value: "45352.5", Numeric: true, Date: true})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), actual)
+
+	actual, err = ParseDateCell(Cell{Value: "01/03/2024"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), actual)
+
+	_, err = ParseDateCell(Cell{Value: "45352", Numeric: true})
+	assert.Error(t, err)
+}
+
+func TestParseNumber(t *testing.T) {
+	testCases := map[string]float64{
+		"10":           10,
+		"1.5":          1.5,
+		"1,5":          1.5,
+		"-1,5":         -1.5,
+		"0.125":        0.125,
+		"0,125":        0.125,
+		"1.500":        1.5,
+		"1,000":        1,
+		"1.234,56":     1234.56,
+		"1,234.56":     1234.56,
+		"1.234.567,89": 1234567.89,
+		"1 234 567":    1234567,
+		"1'234":        1234,
+		"2.000.000":    2000000,
+	}
+
+	for value, expected := range testCases {
+		actual, err := ParseNumber(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, actual, value)
+	}
+
+	for _, value := range []string{"ten", "0.125.000", "1.23.456", "1.234,567.000", "1,234,56.7"} {
+		_, err := ParseNumber(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseNumberCell(t *testing.T) {
+	actual, err := ParseNumberCell(Cell{Value: "1.5E3", Numeric: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, actual)
+
+	actual, err = ParseNumberCell(Cell{Value: "1.500.000"})
+	require.NoError(t, err)
+	assert.Equal(t, 1500000.0, actual)
+}
+
+func TestParseBool(t *testing.T) {
+	for _, value := range []string{"true", "YES", "Sì", "x", "1"} {
+		actual, err := ParseBool(value)
+		require.NoError(t, err, value)
+		assert.True(t, actual, value)
+	}
+
+	for _, value := range []string{"false", "No", "0"} {
+		actual, err := ParseBool(value)
+		require.NoError(t, err, value)
+		assert.False(t, actual, value)
+	}
+
+	_, err := ParseBool("maybe")
+	assert.Error(t, err)
+}
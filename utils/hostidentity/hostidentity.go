@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package hostidentity recognizes the different names of the same host
+package hostidentity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+)
+
+// Resolver resolves the names of the hosts, like the ones received from the CMDB or the cloud providers,
+// to the hostnames known by ercole using the aliases and the configured normalization rules
+type Resolver struct {
+	conf      config.HostIdentity
+	rules     []normalizationRule
+	known     map[string]bool
+	hostnames map[string]string
+	aliases   map[string]string
+}
+
+type normalizationRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// NewResolver return a Resolver of the names of hostnames and aliases
+func NewResolver(conf config.HostIdentity, hostnames []string, aliases []model.HostAlias) (*Resolver, error) {
+	r := &Resolver{
+		conf:      conf,
+		rules:     make([]normalizationRule, 0, len(conf.NormalizationRules)),
+		known:     make(map[string]bool, len(hostnames)),
+		hostnames: make(map[string]string, len(hostnames)),
+		aliases:   make(map[string]string, len(aliases)),
+	}
+
+	for _, rule := range conf.NormalizationRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid normalization rule %q: %w", rule.Pattern, err)
+		}
+
+		r.rules = append(r.rules, normalizationRule{re: re, replacement: rule.Replacement})
+	}
+
+	for _, hostname := range hostnames {
+		r.known[hostname] = true
+	}
+
+	r.index(r.hostnames, hostnames, hostnames)
+
+	names := make([]string, 0, len(aliases))
+	resolved := make([]string, 0, len(aliases))
+
+	for _, alias := range aliases {
+		names = append(names, alias.Alias)
+		resolved = append(resolved, alias.Hostname)
+	}
+
+	r.index(r.aliases, names, resolved)
+
+	return r, nil
+}
+
+// index maps the keys of every name to its resolved hostname.
+// The keys with the domain take precedence over the ones without it
+func (r *Resolver) index(m map[string]string, names, resolved []string) {
+	shorts := make(map[string]string)
+
+	for i, name := range names {
+		keys := r.keys(name)
+		m[keys[0]] = resolved[i]
+
+		if len(keys) > 1 {
+			if _, ok := shorts[keys[1]]; !ok {
+				shorts[keys[1]] = resolved[i]
+			}
+		}
+	}
+
+	for k, v := range shorts {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+}
+
+// Resolve return the known hostname of name and true, or name and false if it doesn't match any host.
+// The exact hostnames take precedence over the aliases, which take precedence over the normalized hostnames
+func (r *Resolver) Resolve(name string) (string, bool) {
+	if r.known[name] {
+		return name, true
+	}
+
+	for _, key := range r.keys(name) {
+		if hostname, ok := r.aliases[key]; ok {
+			return hostname, true
+		}
+
+		if hostname, ok := r.hostnames[key]; ok {
+			return hostname, true
+		}
+	}
+
+	return name, false
+}
+
+// ResolveAlias return the hostname of the alias matching name and true, or name and false if there isn't any.
+// Unlike Resolve, the domain of name is never ignored, so it can't match the alias of a host in another domain
+func (r *Resolver) ResolveAlias(name string) (string, bool) {
+	if hostname, ok := r.aliases[r.keys(name)[0]]; ok {
+		return hostname, true
+	}
+
+	return name, false
+}
+
+// Normalize return name as compared by the Resolver
+func (r *Resolver) Normalize(name string) string {
+	keys := r.keys(name)
+
+	return keys[len(keys)-1]
+}
+
+// keys return the normalized name, followed by the normalized name without domain
+// if the domains are ignored and name has one
+func (r *Resolver) keys(name string) []string {
+	key := strings.TrimSpace(name)
+
+	if !r.conf.CaseSensitive {
+		key = strings.ToLower(key)
+	}
+
+	for _, rule := range r.rules {
+		key = rule.re.ReplaceAllString(key, rule.replacement)
+	}
+
+	if r.conf.KeepDomain {
+		return []string{key}
+	}
+
+	short := strings.Split(key, ".")[0]
+	if short == key {
+		return []string{key}
+	}
+
+	return []string{key, short}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Sorint.lab S.p.A.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hostidentity
+
+import (
+	"testing"
+
+	"github.com/ercole-io/ercole/v2/config"
+	"github.com/ercole-io/ercole/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve_Default(t *testing.T) {
+	r, err := NewResolver(config.HostIdentity{},
+		[]string{"db01.example.com", "DB02", "app01.a.com", "app01.b.com"},
+		[]model.HostAlias{{Alias: "oldname.example.com", Hostname: "DB02"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		expected string
+		found    bool
+	}{
+		{"db01.example.com", "db01.example.com", true},
+		{"DB01", "db01.example.com", true},
+		{"db01.other.com", "db01.example.com", true},
+		{"db02.example.com", "DB02", true},
+		{"OLDNAME", "DB02", true},
+		{"app01.b.com", "app01.b.com", true},
+		{"APP01.B.COM", "app01.b.com", true},
+		{"unknown", "unknown", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, found := r.Resolve(tc.name)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.found, found)
+		})
+	}
+}
+
+func TestResolve_Configured(t *testing.T) {
+	conf := config.HostIdentity{
+		CaseSensitive: true,
+		KeepDomain:    true,
+		NormalizationRules: []config.HostnameNormalizationRule{
+			{Pattern: `-(bkp|mgmt)$`, Replacement: ""},
+		},
+	}
+
+	r, err := NewResolver(conf, []string{"db01.example.com"}, nil)
+	require.NoError(t, err)
+
+	actual, found := r.Resolve("db01-mgmt.example.com")
+	assert.False(t, found)
+	assert.Equal(t, "db01-mgmt.example.com", actual)
+
+	actual, found = r.Resolve("DB01.example.com")
+	assert.False(t, found)
+	assert.Equal(t, "DB01.example.com", actual)
+
+	r, err = NewResolver(config.HostIdentity{
+		NormalizationRules: []config.HostnameNormalizationRule{
+			{Pattern: `-(bkp|mgmt)(\.|$)`, Replacement: "$2"},
+		},
+	}, []string{"db01.example.com"}, nil)
+	require.NoError(t, err)
+
+	actual, found = r.Resolve("DB01-BKP.example.com")
+	assert.True(t, found)
+	assert.Equal(t, "db01.example.com", actual)
+}
+
+func TestResolveAlias(t *testing.T) {
+	r, err := NewResolver(config.HostIdentity{}, []string{"db01", "db02"},
+		[]model.HostAlias{{Alias: "legacy-db", Hostname: "db01"}})
+	require.NoError(t, err)
+
+	actual, found := r.ResolveAlias("LEGACY-DB")
+	assert.True(t, found)
+	assert.Equal(t, "db01", actual)
+
+	actual, found = r.ResolveAlias("legacy-db.example.com")
+	assert.False(t, found)
+	assert.Equal(t, "legacy-db.example.com", actual)
+
+	actual, found = r.ResolveAlias("db02.example.com")
+	assert.False(t, found)
+	assert.Equal(t, "db02.example.com", actual)
+}
+
+func TestNewResolver_InvalidRule(t *testing.T) {
+	_, err := NewResolver(config.HostIdentity{
+		NormalizationRules: []config.HostnameNormalizationRule{{Pattern: "("}},
+	}, nil, nil)
+	assert.Error(t, err)
+}